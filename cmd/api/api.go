@@ -1,16 +1,23 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/finkabaj/hyde-bot/internals/backend/controllers"
+	apiMiddleware "github.com/finkabaj/hyde-bot/internals/backend/middleware"
 	"github.com/finkabaj/hyde-bot/internals/backend/services"
 	"github.com/finkabaj/hyde-bot/internals/db"
+	"github.com/finkabaj/hyde-bot/internals/db/memory"
 	"github.com/finkabaj/hyde-bot/internals/db/postgresql"
+	"github.com/finkabaj/hyde-bot/internals/db/sqlite"
 	"github.com/finkabaj/hyde-bot/internals/logger"
+	"github.com/finkabaj/hyde-bot/internals/oauth2"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/joho/godotenv"
@@ -41,17 +48,31 @@ func main() {
 		r.Use(middleware.Recoverer)
 	}
 
-	var database db.Database = postgresql.NewPostgresql(logger)
-	defer database.Close()
-
-	credentials := db.DatabaseCredentials{
-		Host:     os.Getenv("POSTGRES_HOST"),
-		Port:     os.Getenv("POSTGRES_PORT"),
-		User:     os.Getenv("POSTGRES_USER"),
-		Password: os.Getenv("POSTGRES_PASSWORD"),
-		Database: os.Getenv("POSTGRES_DB"),
+	var database db.Database
+	var credentials db.DatabaseCredentials
+
+	switch driver := os.Getenv("DB_DRIVER"); driver {
+	case "", "postgres":
+		database = postgresql.NewPostgresql(logger)
+		credentials = db.DatabaseCredentials{
+			Host:     os.Getenv("POSTGRES_HOST"),
+			Port:     os.Getenv("POSTGRES_PORT"),
+			User:     os.Getenv("POSTGRES_USER"),
+			Password: os.Getenv("POSTGRES_PASSWORD"),
+			Database: os.Getenv("POSTGRES_DB"),
+		}
+	case "sqlite":
+		database = sqlite.NewSqlite(logger)
+		credentials = db.DatabaseCredentials{Database: os.Getenv("SQLITE_PATH")}
+	case "memory":
+		logger.Info("Using in-memory database, data is lost on shutdown")
+		database = memory.NewMemory()
+	default:
+		logger.Fatal(errors.New("unknown DB_DRIVER: " + driver))
 	}
 
+	defer database.Close()
+
 	if err = database.Connect(credentials); err != nil {
 		logger.Fatal(err)
 	}
@@ -60,17 +81,89 @@ func main() {
 		logger.Fatal(err)
 	}
 
+	migrator, canMigrate := database.(db.Migrator)
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if !canMigrate {
+			fmt.Println("Error: database doesn't support migrations")
+			database.Close()
+			os.Exit(1)
+		}
+
+		if err = migrate(migrator, os.Args[2:]); err != nil {
+			fmt.Printf("Error: %s\n", err.Error())
+			database.Close()
+			os.Exit(1)
+		}
+
+		return
+	}
+
+	if canMigrate {
+		if err = migrator.MigrateUp(); err != nil {
+			logger.Fatal(err, map[string]any{"details": "error while migrating database"})
+		}
+	}
+
+	sessionSecret := os.Getenv("SESSION_SECRET")
+
+	if len(sessionSecret) < 32 {
+		logger.Fatal(errors.New("SESSION_SECRET must be at least 32 characters"))
+	}
+
+	// invalid or empty ttls fall back to services.DefaultSessionTTL and services.DefaultRefreshTokenTTL
+	sessionTTL, _ := time.ParseDuration(os.Getenv("SESSION_TTL"))
+	refreshTokenTTL, _ := time.ParseDuration(os.Getenv("REFRESH_TOKEN_TTL"))
+
+	oauthClient := oauth2.NewClient(oauth2.Config{
+		ClientId:     os.Getenv("DISCORD_CLIENT_ID"),
+		ClientSecret: os.Getenv("DISCORD_CLIENT_SECRET"),
+		RedirectURL:  os.Getenv("OAUTH_REDIRECT_URL"),
+	}, &http.Client{Timeout: 10 * time.Second})
+
+	authService := services.NewAuthService(logger, database, oauthClient, services.AuthConfig{
+		Secret:          []byte(sessionSecret),
+		SessionTTL:      sessionTTL,
+		RefreshTokenTTL: refreshTokenTTL,
+	})
+	authController := controllers.NewAuthController(authService, logger)
+	authController.RegisterRoutes(r)
+
+	serviceToken := os.Getenv("API_SERVICE_TOKEN")
+
+	if len(serviceToken) < 32 {
+		logger.Fatal(errors.New("API_SERVICE_TOKEN must be at least 32 characters"))
+	}
+
+	auth := apiMiddleware.NewAuth(authService, authService, serviceToken)
+
 	commandsController := controllers.NewCommandsController(&database)
 	commandsController.RegisterRoutes(r)
 
-	guildService := services.NewGuildService(database)
-	guildController := controllers.NewGuildController(guildService, logger)
+	ruleEvents := services.NewRuleEventBroker(logger)
+
+	// invalid or empty retention falls back to services.DefaultGuildRetention
+	guildRetention, _ := time.ParseDuration(os.Getenv("GUILD_RETENTION"))
+
+	guildService := services.NewGuildService(logger, database, ruleEvents, guildRetention)
+	go guildService.PurgeGuilds(context.Background(), services.GuildPurgeInterval)
+	guildController := controllers.NewGuildController(guildService, auth, logger)
 	guildController.RegisterRoutes(r)
 
-	reactionService := services.NewReactionService(logger, database, guildService)
-	rulesController := controllers.NewRulesController(reactionService, logger)
+	reactionService := services.NewReactionService(logger, database, guildService, ruleEvents)
+	reactionRoleService := services.NewReactionRoleService(logger, database, guildService, ruleEvents)
+	reactionFloodService := services.NewReactionFloodService(logger, database, guildService, ruleEvents)
+	rulesController := controllers.NewRulesController(reactionService, reactionRoleService, reactionFloodService, ruleEvents, auth, logger)
 	rulesController.RegisterRoutes(r)
 
+	infractionService := services.NewInfractionService(logger, database, guildService)
+	infractionsController := controllers.NewInfractionsController(infractionService, auth, logger)
+	infractionsController.RegisterRoutes(r)
+
+	escalationService := services.NewEscalationService(logger, database, guildService)
+	escalationController := controllers.NewEscalationController(escalationService, auth, logger)
+	escalationController.RegisterRoutes(r)
+
 	host := os.Getenv("API_HOST")
 	port := os.Getenv("API_PORT")
 
@@ -90,3 +183,52 @@ func main() {
 
 	logger.Info("API is up and running!")
 }
+
+const migrateUsage = "usage: api migrate up | down [steps] | status"
+
+// migrate runs the migrate subcommand: up applies all pending migrations,
+// down reverts the last steps migrations (1 by default), status prints every migration.
+func migrate(m db.Migrator, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	switch args[0] {
+	case "up":
+		return m.MigrateUp()
+	case "down":
+		steps := 1
+
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+
+			if err != nil || n < 1 {
+				return fmt.Errorf("invalid steps: %s", args[1])
+			}
+
+			steps = n
+		}
+
+		return m.MigrateDown(steps)
+	case "status":
+		status, err := m.MigrationStatus()
+
+		if err != nil {
+			return err
+		}
+
+		for _, s := range status {
+			appliedAt := "pending"
+
+			if s.Applied {
+				appliedAt = s.AppliedAt.Format(time.RFC3339)
+			}
+
+			fmt.Printf("%04d_%s\t%s\n", s.Version, s.Name, appliedAt)
+		}
+
+		return nil
+	}
+
+	return errors.New(migrateUsage)
+}
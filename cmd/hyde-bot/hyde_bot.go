@@ -1,19 +1,29 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/finkabaj/hyde-bot/internals/actions"
+	"github.com/finkabaj/hyde-bot/internals/apiclient"
 	"github.com/finkabaj/hyde-bot/internals/commands"
+	"github.com/finkabaj/hyde-bot/internals/escalation"
 	"github.com/finkabaj/hyde-bot/internals/events"
+	"github.com/finkabaj/hyde-bot/internals/flood"
 	"github.com/finkabaj/hyde-bot/internals/logger"
+	"github.com/finkabaj/hyde-bot/internals/members"
+	"github.com/finkabaj/hyde-bot/internals/modlog"
 	"github.com/finkabaj/hyde-bot/internals/rules"
+	"github.com/finkabaj/hyde-bot/internals/threshold"
 	commandUtils "github.com/finkabaj/hyde-bot/internals/utils/command"
+	"github.com/finkabaj/hyde-bot/internals/utils/common"
 	"github.com/joho/godotenv"
 )
 
@@ -36,35 +46,78 @@ func main() {
 		log.Fatal(err)
 	}
 
-	client := &http.Client{
-		Timeout: 10 * time.Second,
+	fs, err := os.OpenFile("log/logs.log", os.O_APPEND|os.O_CREATE|os.O_RDWR, 0644)
+
+	if err != nil {
+		log.Fatal("Error creating new log file", err)
 	}
 
-	messageInteractions := commandUtils.NewMessageInteractions()
-	rm := rules.NewRuleManager(client)
+	l := logger.NewLogger(fs)
 
-	cmdManager := commands.NewCommandManager(rm, messageInteractions)
-	cmdManager.RegisterDefaultCommandsToManager()
+	if err != nil {
+		logger.Fatal(err, map[string]any{"details": "Error creating a new log file"})
+	}
 
-	evtManager := events.NewEventManager(rm, cmdManager, client, messageInteractions)
-	evtManager.RegisterDefaultEvents()
+	// every api request of the bot carries the service credential
+	apiTransport := &common.ServiceAuthTransport{Token: os.Getenv("API_SERVICE_TOKEN")}
 
-	s.AddHandler(func(s *discordgo.Session, event interface{}) {
-		evtManager.HandleEvent(s, event)
-	})
+	apiUrl := common.GetApiUrl(os.Getenv("API_HOST"), os.Getenv("API_PORT"), "")
 
-	fs, err := os.OpenFile("log/logs.log", os.O_APPEND|os.O_CREATE|os.O_RDWR, 0644)
+	writeQueue := rules.NewWriteQueue(l)
 
-	if err != nil {
-		log.Fatal("Error creating new log file", err)
+	// writes made while the api is unavailable survive restarts, empty path falls back to rules.DefaultWriteQueuePath
+	if err := writeQueue.Open(os.Getenv("WRITE_QUEUE_PATH")); err != nil {
+		logger.Fatal(err, map[string]any{"details": "Error opening the write queue"})
 	}
 
-	logger.NewLogger(fs)
+	defer writeQueue.Close()
+
+	// invalid or empty values fall back to apiclient.DefaultBreakerThreshold and apiclient.DefaultBreakerCooldown
+	breakerThreshold, _ := strconv.Atoi(os.Getenv("API_BREAKER_THRESHOLD"))
+	breakerCooldown, _ := time.ParseDuration(os.Getenv("API_BREAKER_COOLDOWN"))
+	breaker := apiclient.NewBreaker(l, breakerThreshold, breakerCooldown)
+
+	api := apiclient.NewClient(l, apiUrl, &http.Client{
+		Timeout:   10 * time.Second,
+		Transport: apiTransport,
+	}, apiclient.DefaultRetryPolicy, breaker)
+
+	messageInteractions := commandUtils.NewMessageInteractions()
+	pendingRules := commandUtils.NewPendingReactionRules()
+	rm := rules.NewRuleManager(api, writeQueue)
+
+	// invalid or empty interval falls back to rules.DefaultReconcileInterval
+	resyncInterval, _ := time.ParseDuration(os.Getenv("RULES_RESYNC_INTERVAL"))
+	reconciler := rules.NewReconciler(rm, resyncInterval)
+
+	banDeleteDays, err := strconv.Atoi(os.Getenv("BAN_DELETE_DAYS"))
 
 	if err != nil {
-		logger.Fatal(err, map[string]any{"details": "Error creating a new log file"})
+		banDeleteDays = 0
 	}
 
+	// rule changes are posted to mod log channels along with enforcement and commands
+	modLog := modlog.NewPublisher(rm)
+	rm.ObserveWrites(events.ModLogWrites(modLog))
+	rm.ObserveRuleEvents(events.ModLogRuleEvents(modLog))
+
+	executor := actions.NewExecutor(banDeleteDays)
+	evaluator := escalation.NewEvaluator(rm, executor, modLog)
+
+	cmdManager := commands.NewCommandManager(rm, messageInteractions, executor, evaluator)
+	cmdManager.RegisterDefaultCommandsToManager()
+
+	// invalid or empty ttl falls back to members.DefaultTTL
+	memberCacheTTL, _ := time.ParseDuration(os.Getenv("MEMBER_CACHE_TTL"))
+	memberCache := members.NewCache(memberCacheTTL)
+
+	evtManager := events.NewEventManager(rm, reconciler, cmdManager, executor, evaluator, memberCache, threshold.NewCounter(), flood.NewLimiter(), modLog, messageInteractions, pendingRules)
+	evtManager.RegisterDefaultEvents()
+
+	s.AddHandler(func(s *discordgo.Session, event interface{}) {
+		evtManager.HandleEvent(s, event)
+	})
+
 	s.AddHandler(func(s *discordgo.Session, m *discordgo.Ready) {
 		logger.Info("Bot is up and running!")
 	})
@@ -90,6 +143,16 @@ func main() {
 	defer s.Close()
 	defer fs.Close()
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// rule events stream is long lived, so it can't share the client with timeout
+	go rm.ListenRuleEvents(ctx, apiclient.NewClient(l, apiUrl, &http.Client{Transport: apiTransport}, apiclient.DefaultRetryPolicy, breaker))
+	// writes made while the api was unavailable
+	go rm.ReplayWrites(ctx, events.NotifyWriteOutcome(s))
+	go reconciler.Run(ctx)
+	go modLog.Run(ctx, s)
+
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt)
 	<-stop
@@ -0,0 +1,301 @@
+package actions
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/finkabaj/hyde-bot/internals/utils/common"
+	"github.com/finkabaj/hyde-bot/internals/utils/escalation"
+	"github.com/finkabaj/hyde-bot/internals/utils/infraction"
+	"github.com/finkabaj/hyde-bot/internals/utils/rule"
+)
+
+var (
+	ErrMissingPermission = errors.New("bot is missing permission to perform the action")
+	ErrTargetAboveBot    = errors.New("target member is not below the bot in role hierarchy")
+	ErrTargetIsOwner     = errors.New("target member is the guild owner")
+	ErrRoleAboveBot      = errors.New("role is not below the bot in role hierarchy")
+	ErrUnknownAction     = errors.New("unknown action")
+)
+
+// MaxBanDeleteDays is the maximum number of days of messages discord allows to delete on ban.
+const MaxBanDeleteDays = 7
+
+// Target describes the reaction that triggered a rule.
+type Target struct {
+	GuildID   string
+	ChannelID string
+	MessageID string
+	UserID    string
+	Emoji     discordgo.Emoji
+	Member    *discordgo.Member // Member is the reacting member if discord sent it with the event. Can be nil.
+}
+
+// Result is the outcome of a single action. Err is nil if the action succeeded.
+type Result struct {
+	Action rule.Action
+	Err    error
+}
+
+type Executor struct {
+	banDeleteDays int
+}
+
+var executor *Executor
+
+// NewExecutor creates an action executor. banDeleteDays is clamped to [0, MaxBanDeleteDays].
+func NewExecutor(banDeleteDays int) *Executor {
+	if executor == nil {
+		executor = &Executor{
+			banDeleteDays: min(max(banDeleteDays, 0), MaxBanDeleteDays),
+		}
+	}
+	return executor
+}
+
+// Execute applies the actions of the rule in order to the user who reacted.
+// A failed action doesn't abort the rest, every action gets its own Result.
+func (e *Executor) Execute(s *discordgo.Session, r rule.ReactionRule, t Target) []Result {
+	results := make([]Result, 0, len(r.Actions))
+
+	for _, a := range r.Actions {
+		results = append(results, Result{
+			Action: a,
+			Err:    e.execute(s, a, t),
+		})
+	}
+
+	return results
+}
+
+func (e *Executor) execute(s *discordgo.Session, a rule.Action, t Target) error {
+	switch a.Type {
+	case rule.Delete:
+		return e.delete(s, t)
+	case rule.Warn:
+		return e.warn(s, t,
+			fmt.Sprintf("You have been warned in **%s** for reacting with %s", guildName(s, t.GuildID), EmojiMention(t.Emoji)),
+			fmt.Sprintf("<@%s>, reacting with %s is not allowed in this server", t.UserID, EmojiMention(t.Emoji)))
+	case rule.Ban:
+		return e.ban(s, t, reason(t))
+	case rule.Kick:
+		return e.kick(s, t, reason(t))
+	case rule.Timeout:
+		return e.timeout(s, t, a.Duration(), reason(t))
+	}
+
+	return fmt.Errorf("%w: %d", ErrUnknownAction, a.Type)
+}
+
+// ExecuteOnMessage applies the actions of a threshold rule in order to the reacted message, UserID of t is its author.
+// Delete removes the message, the other actions are taken on the author.
+func (e *Executor) ExecuteOnMessage(s *discordgo.Session, r rule.ReactionRule, t Target) []Result {
+	results := make([]Result, 0, len(r.Actions))
+
+	for _, a := range r.Actions {
+		results = append(results, Result{
+			Action: a,
+			Err:    e.executeOnMessage(s, a, r.Threshold, t),
+		})
+	}
+
+	return results
+}
+
+func (e *Executor) executeOnMessage(s *discordgo.Session, a rule.Action, threshold *rule.ReactionThreshold, t Target) error {
+	reported := fmt.Sprintf("reported with %s by %d members", EmojiMention(t.Emoji), threshold.Count)
+	reason := fmt.Sprintf("Message %s (reaction rule)", reported)
+
+	switch a.Type {
+	case rule.Delete:
+		return mapRestError(s.ChannelMessageDelete(t.ChannelID, t.MessageID, discordgo.WithAuditLogReason(reason)))
+	case rule.Warn:
+		return e.warn(s, t,
+			fmt.Sprintf("You have been warned in **%s**, your message was %s", guildName(s, t.GuildID), reported),
+			fmt.Sprintf("<@%s>, your message was %s", t.UserID, reported))
+	case rule.Ban:
+		return e.ban(s, t, reason)
+	case rule.Kick:
+		return e.kick(s, t, reason)
+	case rule.Timeout:
+		return e.timeout(s, t, a.Duration(), reason)
+	}
+
+	return fmt.Errorf("%w: %d", ErrUnknownAction, a.Type)
+}
+
+// ExecuteFlood applies the actions of the flood rule in order to the member of t who exceeded it.
+// Delete removes reactions of the flood, the other actions are taken on the member.
+func (e *Executor) ExecuteFlood(s *discordgo.Session, f rule.ReactionFloodRule, t Target, reactions []Target) []Result {
+	results := make([]Result, 0, len(f.Actions))
+
+	for _, a := range f.Actions {
+		results = append(results, Result{
+			Action: a,
+			Err:    e.executeFlood(s, a, f, t, reactions),
+		})
+	}
+
+	return results
+}
+
+func (e *Executor) executeFlood(s *discordgo.Session, a rule.Action, f rule.ReactionFloodRule, t Target, reactions []Target) error {
+	flooded := fmt.Sprintf("reacting more than %d times in %s", f.MaxReactions, common.FormatDuration(f.Window()))
+	reason := fmt.Sprintf("Reacted more than %d times in %s (reaction flood rule)", f.MaxReactions, common.FormatDuration(f.Window()))
+
+	switch a.Type {
+	case rule.Delete:
+		var errs []error
+
+		for _, r := range reactions {
+			if err := s.MessageReactionRemove(r.ChannelID, r.MessageID, EmojiAPIName(r.Emoji), t.UserID); err != nil {
+				errs = append(errs, mapRestError(err))
+			}
+		}
+
+		return errors.Join(errs...)
+	case rule.Warn:
+		return e.warn(s, t,
+			fmt.Sprintf("You have been warned in **%s** for %s", guildName(s, t.GuildID), flooded),
+			fmt.Sprintf("<@%s>, %s is not allowed in this server", t.UserID, flooded))
+	case rule.Ban:
+		return e.ban(s, t, reason)
+	case rule.Kick:
+		return e.kick(s, t, reason)
+	case rule.Timeout:
+		return e.timeout(s, t, a.Duration(), reason)
+	}
+
+	return fmt.Errorf("%w: %d", ErrUnknownAction, a.Type)
+}
+
+// Warn sends a warning issued by a moderator to the member of t.
+func (e *Executor) Warn(s *discordgo.Session, t Target, reason string) error {
+	return e.warn(s, t,
+		fmt.Sprintf("You have been warned in **%s**: %s", guildName(s, t.GuildID), reason),
+		fmt.Sprintf("<@%s>, you have been warned: %s", t.UserID, reason))
+}
+
+// Escalate takes the action of the escalation step on the member of t.
+func (e *Executor) Escalate(s *discordgo.Session, t Target, step escalation.Step) error {
+	switch step.Action {
+	case infraction.Timeout:
+		return e.timeout(s, t, step.Timeout(), step.Reason())
+	case infraction.Kick:
+		return e.kick(s, t, step.Reason())
+	case infraction.Ban:
+		return e.ban(s, t, step.Reason())
+	}
+
+	return fmt.Errorf("%w: %s", ErrUnknownAction, step.Action)
+}
+
+func (e *Executor) delete(s *discordgo.Session, t Target) error {
+	err := s.MessageReactionsRemoveEmoji(t.ChannelID, t.MessageID, EmojiAPIName(t.Emoji))
+
+	return mapRestError(err)
+}
+
+// warn sends dm to the user as a direct message. If the user doesn't accept direct messages,
+// channelMessage is sent to the channel of t.
+func (e *Executor) warn(s *discordgo.Session, t Target, dm, channelMessage string) error {
+	dmErr := func() error {
+		channel, err := s.UserChannelCreate(t.UserID)
+
+		if err != nil {
+			return err
+		}
+
+		_, err = s.ChannelMessageSend(channel.ID, dm)
+
+		return err
+	}()
+
+	if dmErr == nil {
+		return nil
+	}
+
+	_, err := s.ChannelMessageSend(t.ChannelID, channelMessage)
+
+	if err != nil {
+		return fmt.Errorf("failed to warn user: %w", errors.Join(dmErr, mapRestError(err)))
+	}
+
+	return nil
+}
+
+func (e *Executor) ban(s *discordgo.Session, t Target, reason string) error {
+	if err := checkPermission(s, t.ChannelID, discordgo.PermissionBanMembers); err != nil {
+		return err
+	}
+
+	if err := checkHierarchy(s, t); err != nil {
+		return err
+	}
+
+	err := s.GuildBanCreateWithReason(t.GuildID, t.UserID, reason, e.banDeleteDays)
+
+	return mapRestError(err)
+}
+
+func (e *Executor) kick(s *discordgo.Session, t Target, reason string) error {
+	if err := checkPermission(s, t.ChannelID, discordgo.PermissionKickMembers); err != nil {
+		return err
+	}
+
+	if err := checkHierarchy(s, t); err != nil {
+		return err
+	}
+
+	err := s.GuildMemberDeleteWithReason(t.GuildID, t.UserID, reason)
+
+	return mapRestError(err)
+}
+
+func (e *Executor) timeout(s *discordgo.Session, t Target, d time.Duration, reason string) error {
+	if err := checkPermission(s, t.ChannelID, discordgo.PermissionModerateMembers); err != nil {
+		return err
+	}
+
+	if err := checkHierarchy(s, t); err != nil {
+		return err
+	}
+
+	until := time.Now().Add(min(d, rule.MaxTimeout))
+	err := s.GuildMemberTimeout(t.GuildID, t.UserID, &until, discordgo.WithAuditLogReason(reason))
+
+	return mapRestError(err)
+}
+
+// guildName returns name of the guild from the state, or its id if the guild isn't cached.
+func guildName(s *discordgo.Session, guildID string) string {
+	if g, err := s.State.Guild(guildID); err == nil {
+		return g.Name
+	}
+
+	return guildID
+}
+
+func reason(t Target) string {
+	return fmt.Sprintf("Reacted with %s (reaction rule)", EmojiMention(t.Emoji))
+}
+
+// EmojiAPIName returns emoji in the format discord expects in reaction endpoints.
+func EmojiAPIName(e discordgo.Emoji) string {
+	if e.ID != "" {
+		return e.Name + ":" + e.ID
+	}
+
+	return e.Name
+}
+
+// EmojiMention returns emoji in the format that renders in a message.
+func EmojiMention(e discordgo.Emoji) string {
+	if e.ID != "" {
+		return e.MessageFormat()
+	}
+
+	return e.Name
+}
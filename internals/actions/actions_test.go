@@ -0,0 +1,310 @@
+package actions
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/finkabaj/hyde-bot/internals/utils/rule"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const moderatePermissions = discordgo.PermissionBanMembers | discordgo.PermissionKickMembers | discordgo.PermissionModerateMembers
+
+// fakeResponse is the answer of fakeDiscord to a route.
+type fakeResponse struct {
+	status int
+	body   any
+}
+
+// fakeDiscord is a local REST api for the session of newTestSession. Routes are keyed by method and
+// path without the api prefix, unknown routes return 404. Requests are recorded in order.
+type fakeDiscord struct {
+	mu       sync.Mutex
+	routes   map[string]fakeResponse
+	requests []string
+	bodies   map[string]string
+	queries  map[string]url.Values
+}
+
+func (f *fakeDiscord) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	route := r.Method + " " + strings.TrimPrefix(r.URL.Path, "/api/v"+discordgo.APIVersion)
+	body, _ := io.ReadAll(r.Body)
+
+	f.mu.Lock()
+	f.requests = append(f.requests, route)
+	f.bodies[route] = string(body)
+	f.queries[route] = r.URL.Query()
+	res, ok := f.routes[route]
+	f.mu.Unlock()
+
+	if !ok {
+		res = fakeResponse{status: http.StatusNotFound, body: discordgo.APIErrorMessage{Code: discordgo.ErrCodeUnknownChannel, Message: "Unknown"}}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(res.status)
+
+	if res.body != nil {
+		json.NewEncoder(w).Encode(res.body)
+	}
+}
+
+// Requests returns the recorded routes in the order they were requested.
+func (f *fakeDiscord) Requests() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]string(nil), f.requests...)
+}
+
+// Query returns the query of the last request to route.
+func (f *fakeDiscord) Query(route string) url.Values {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.queries[route]
+}
+
+// Body returns the body of the last request to route.
+func (f *fakeDiscord) Body(route string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.bodies[route]
+}
+
+// rewriteTransport sends every request to the fake api instead of discord.
+type rewriteTransport struct {
+	target *url.URL
+}
+
+func (rt rewriteTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	r.URL.Scheme = rt.target.Scheme
+	r.URL.Host = rt.target.Host
+
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+// newTestSession returns a session of the bot user "bot" whose REST calls are answered by routes.
+// Guilds are added to the state.
+func newTestSession(t *testing.T, routes map[string]fakeResponse, guilds ...*discordgo.Guild) (*discordgo.Session, *fakeDiscord) {
+	fake := &fakeDiscord{routes: routes, bodies: make(map[string]string), queries: make(map[string]url.Values)}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	target, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	s, err := discordgo.New("Bot token")
+	require.NoError(t, err)
+
+	s.Client = &http.Client{Transport: rewriteTransport{target: target}}
+	s.MaxRestRetries = 0
+	s.State.User = &discordgo.User{ID: "bot"}
+
+	for _, g := range guilds {
+		require.NoError(t, s.State.GuildAdd(g))
+	}
+
+	return s, fake
+}
+
+// testGuild returns the guild "guild" with the channel "channel". The bot has botPermissions and
+// the role at position 2, the member "target" has the role at position targetPosition.
+// The role at position 0 is @everyone.
+func testGuild(botPermissions int64, targetPosition int) *discordgo.Guild {
+	roles := []*discordgo.Role{
+		{ID: "guild", Position: 0},
+		{ID: "low", Position: 1},
+		{ID: "bot", Position: 2, Permissions: botPermissions},
+		{ID: "high", Position: 3},
+	}
+
+	return &discordgo.Guild{
+		ID:       "guild",
+		Name:     "Guild",
+		OwnerID:  "owner",
+		Roles:    roles,
+		Channels: []*discordgo.Channel{{ID: "channel", GuildID: "guild", Type: discordgo.ChannelTypeGuildText}},
+		Members: []*discordgo.Member{
+			{GuildID: "guild", User: &discordgo.User{ID: "bot"}, Roles: []string{"bot"}},
+			{GuildID: "guild", User: &discordgo.User{ID: "target"}, Roles: []string{roles[targetPosition].ID}},
+		},
+	}
+}
+
+func testTarget() Target {
+	return Target{
+		GuildID:   "guild",
+		ChannelID: "channel",
+		MessageID: "message",
+		UserID:    "target",
+		Emoji:     discordgo.Emoji{ID: "1", Name: "bad"},
+	}
+}
+
+// moderationRoutes answers every request the actions of a reaction rule make on "target".
+func moderationRoutes() map[string]fakeResponse {
+	return map[string]fakeResponse{
+		"DELETE /channels/channel/messages/message/reactions/bad:1": {status: http.StatusNoContent},
+		"POST /users/@me/channels":                                  {status: http.StatusOK, body: discordgo.Channel{ID: "dm"}},
+		"POST /channels/dm/messages":                                {status: http.StatusOK, body: discordgo.Message{ID: "warning"}},
+		"PUT /guilds/guild/bans/target":                             {status: http.StatusNoContent},
+		"DELETE /guilds/guild/members/target":                       {status: http.StatusNoContent},
+		"PATCH /guilds/guild/members/target":                        {status: http.StatusOK, body: discordgo.Member{}},
+	}
+}
+
+func TestNewExecutor(t *testing.T) {
+	tests := []struct {
+		name          string
+		banDeleteDays int
+		expected      int
+	}{
+		{"Negative", -1, 0},
+		{"InRange", 3, 3},
+		{"AboveMax", 30, MaxBanDeleteDays},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor = nil
+			t.Cleanup(func() { executor = nil })
+
+			e := NewExecutor(tt.banDeleteDays)
+
+			assert.Equal(t, tt.expected, e.banDeleteDays)
+		})
+	}
+}
+
+func TestExecute(t *testing.T) {
+	t.Run("Order", testExecuteOrder)
+	t.Run("FailedActionDoesNotAbort", testExecuteFailedActionDoesNotAbort)
+	t.Run("UnknownAction", testExecuteUnknownAction)
+}
+
+func TestBan(t *testing.T) {
+	t.Run("DeleteDays", testBanDeleteDays)
+	t.Run("NoDeleteDays", testBanNoDeleteDays)
+}
+
+func TestTimeout(t *testing.T) {
+	t.Run("Duration", testTimeoutDuration)
+	t.Run("ClampedToMaxTimeout", testTimeoutClampedToMaxTimeout)
+}
+
+func testExecuteOrder(t *testing.T) {
+	s, fake := newTestSession(t, moderationRoutes(), testGuild(moderatePermissions, 1))
+	r := rule.ReactionRule{Actions: rule.Actions{{Type: rule.Delete}, {Type: rule.Warn}, {Type: rule.Kick}}}
+
+	results := (&Executor{}).Execute(s, r, testTarget())
+
+	require.Len(t, results, 3)
+
+	for i, res := range results {
+		assert.Equal(t, r.Actions[i], res.Action)
+		assert.NoError(t, res.Err)
+	}
+
+	assert.Equal(t, []string{
+		"DELETE /channels/channel/messages/message/reactions/bad:1",
+		"POST /users/@me/channels",
+		"POST /channels/dm/messages",
+		"DELETE /guilds/guild/members/target",
+	}, fake.Requests())
+}
+
+func testExecuteFailedActionDoesNotAbort(t *testing.T) {
+	routes := moderationRoutes()
+	routes["DELETE /channels/channel/messages/message/reactions/bad:1"] = fakeResponse{
+		status: http.StatusForbidden,
+		body:   discordgo.APIErrorMessage{Code: discordgo.ErrCodeMissingPermissions, Message: "Missing Permissions"},
+	}
+
+	// the bot can't kick, the ban after it still runs
+	s, fake := newTestSession(t, routes, testGuild(discordgo.PermissionBanMembers, 1))
+	r := rule.ReactionRule{Actions: rule.Actions{{Type: rule.Delete}, {Type: rule.Kick}, {Type: rule.Ban}}}
+
+	results := (&Executor{}).Execute(s, r, testTarget())
+
+	require.Len(t, results, 3)
+	assert.True(t, errors.Is(results[0].Err, ErrMissingPermission), "delete rejected by discord")
+	assert.Equal(t, ErrMissingPermission, results[1].Err, "kick without permission")
+	assert.NoError(t, results[2].Err)
+	assert.Contains(t, fake.Requests(), "PUT /guilds/guild/bans/target")
+	assert.NotContains(t, fake.Requests(), "DELETE /guilds/guild/members/target")
+}
+
+func testExecuteUnknownAction(t *testing.T) {
+	s, fake := newTestSession(t, moderationRoutes(), testGuild(moderatePermissions, 1))
+	r := rule.ReactionRule{Actions: rule.Actions{{Type: 0}, {Type: rule.Delete}}}
+
+	results := (&Executor{}).Execute(s, r, testTarget())
+
+	require.Len(t, results, 2)
+	assert.True(t, errors.Is(results[0].Err, ErrUnknownAction))
+	assert.NoError(t, results[1].Err)
+	assert.Len(t, fake.Requests(), 1)
+}
+
+func testBanDeleteDays(t *testing.T) {
+	s, fake := newTestSession(t, moderationRoutes(), testGuild(moderatePermissions, 1))
+
+	err := (&Executor{banDeleteDays: 3}).ban(s, testTarget(), "reason")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "3", fake.Query("PUT /guilds/guild/bans/target").Get("delete_message_days"))
+	assert.Equal(t, "reason", fake.Query("PUT /guilds/guild/bans/target").Get("reason"))
+}
+
+func testBanNoDeleteDays(t *testing.T) {
+	s, fake := newTestSession(t, moderationRoutes(), testGuild(moderatePermissions, 1))
+
+	err := (&Executor{}).ban(s, testTarget(), "reason")
+
+	assert.NoError(t, err)
+	assert.False(t, fake.Query("PUT /guilds/guild/bans/target").Has("delete_message_days"))
+}
+
+// timedOutUntil returns the end of the timeout the fake api received.
+func timedOutUntil(t *testing.T, fake *fakeDiscord) time.Time {
+	var body struct {
+		CommunicationDisabledUntil time.Time `json:"communication_disabled_until"`
+	}
+
+	require.NoError(t, json.Unmarshal([]byte(fake.Body("PATCH /guilds/guild/members/target")), &body))
+
+	return body.CommunicationDisabledUntil
+}
+
+func testTimeoutDuration(t *testing.T) {
+	s, fake := newTestSession(t, moderationRoutes(), testGuild(moderatePermissions, 1))
+
+	before := time.Now()
+	err := (&Executor{}).timeout(s, testTarget(), time.Hour, "reason")
+
+	assert.NoError(t, err)
+	assert.WithinRange(t, timedOutUntil(t, fake), before.Add(time.Hour).Truncate(time.Second), time.Now().Add(time.Hour))
+}
+
+func testTimeoutClampedToMaxTimeout(t *testing.T) {
+	s, fake := newTestSession(t, moderationRoutes(), testGuild(moderatePermissions, 1))
+
+	before := time.Now()
+	err := (&Executor{}).timeout(s, testTarget(), 2*rule.MaxTimeout, "reason")
+
+	assert.NoError(t, err)
+	assert.WithinRange(t, timedOutUntil(t, fake), before.Add(rule.MaxTimeout).Truncate(time.Second), time.Now().Add(rule.MaxTimeout))
+}
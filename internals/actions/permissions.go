@@ -0,0 +1,178 @@
+package actions
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// checkPermission checks that the bot has perm in the channel. State is used first, REST api is a fallback.
+func checkPermission(s *discordgo.Session, channelID string, perm int64) error {
+	perms, err := s.State.UserChannelPermissions(s.State.User.ID, channelID)
+
+	if err != nil {
+		perms, err = s.UserChannelPermissions(s.State.User.ID, channelID)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to get bot permissions: %w", mapRestError(err))
+	}
+
+	// discordgo expands administrator to a permission set without the newer permissions, like moderate members
+	if perms&discordgo.PermissionAdministrator == 0 && perms&perm != perm {
+		return ErrMissingPermission
+	}
+
+	return nil
+}
+
+// checkHierarchy checks that the bot is able to moderate the target member.
+func checkHierarchy(s *discordgo.Session, t Target) error {
+	g, err := s.State.Guild(t.GuildID)
+
+	if err != nil {
+		g, err = s.Guild(t.GuildID)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to get guild: %w", mapRestError(err))
+	}
+
+	if g.OwnerID == t.UserID {
+		return ErrTargetIsOwner
+	}
+
+	target := t.Member
+
+	if target == nil {
+		target, err = getMember(s, t.GuildID, t.UserID)
+
+		if err != nil {
+			return fmt.Errorf("failed to get target member: %w", err)
+		}
+	}
+
+	bot, err := getMember(s, t.GuildID, s.State.User.ID)
+
+	if err != nil {
+		return fmt.Errorf("failed to get bot member: %w", err)
+	}
+
+	if highestRolePosition(g.Roles, target.Roles) >= highestRolePosition(g.Roles, bot.Roles) {
+		return ErrTargetAboveBot
+	}
+
+	return nil
+}
+
+func getMember(s *discordgo.Session, guildID, userID string) (*discordgo.Member, error) {
+	m, err := s.State.Member(guildID, userID)
+
+	if err == nil {
+		return m, nil
+	}
+
+	m, err = s.GuildMember(guildID, userID)
+
+	return m, mapRestError(err)
+}
+
+// highestRolePosition returns position of the highest role of the member. Member without roles has position 0 (@everyone).
+func highestRolePosition(guildRoles []*discordgo.Role, memberRoles []string) int {
+	highest := 0
+
+	for _, r := range guildRoles {
+		for _, id := range memberRoles {
+			if r.ID == id && r.Position > highest {
+				highest = r.Position
+			}
+		}
+	}
+
+	return highest
+}
+
+// mapRestError converts discord permission errors to ErrMissingPermission. Other errors are returned as is.
+func mapRestError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var restErr *discordgo.RESTError
+
+	if errors.As(err, &restErr) && restErr.Message != nil {
+		switch restErr.Message.Code {
+		case discordgo.ErrCodeMissingPermissions, discordgo.ErrCodeMissingAccess:
+			return fmt.Errorf("%w: %s", ErrMissingPermission, restErr.Message.Message)
+		}
+	}
+
+	return err
+}
+
+// CanManageRole checks that the bot has the manage roles permission and the role is below the highest role of the bot.
+func CanManageRole(s *discordgo.Session, guildID, roleID string) error {
+	g, err := s.State.Guild(guildID)
+
+	if err != nil {
+		g, err = s.Guild(guildID)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to get guild: %w", mapRestError(err))
+	}
+
+	bot, err := getMember(s, guildID, s.State.User.ID)
+
+	if err != nil {
+		return fmt.Errorf("failed to get bot member: %w", err)
+	}
+
+	var perms int64
+
+	for _, r := range g.Roles {
+		if r.ID == guildID || slices.Contains(bot.Roles, r.ID) {
+			perms |= r.Permissions
+		}
+	}
+
+	if perms&(discordgo.PermissionManageRoles|discordgo.PermissionAdministrator) == 0 {
+		return ErrMissingPermission
+	}
+
+	if highestRolePosition(g.Roles, []string{roleID}) >= highestRolePosition(g.Roles, bot.Roles) {
+		return ErrRoleAboveBot
+	}
+
+	return nil
+}
+
+// IsPrivileged reports whether the member is the guild owner or has the administrator permission.
+func IsPrivileged(s *discordgo.Session, guildID, userID string, roles []string) (bool, error) {
+	g, err := s.State.Guild(guildID)
+
+	if err != nil {
+		g, err = s.Guild(guildID)
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("failed to get guild: %w", mapRestError(err))
+	}
+
+	if g.OwnerID == userID {
+		return true, nil
+	}
+
+	var perms int64
+
+	for _, r := range g.Roles {
+		// @everyone role has the same id as the guild
+		if r.ID == guildID || slices.Contains(roles, r.ID) {
+			perms |= r.Permissions
+		}
+	}
+
+	return perms&discordgo.PermissionAdministrator != 0, nil
+}
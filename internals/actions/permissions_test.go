@@ -0,0 +1,192 @@
+package actions
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+)
+
+// restGuildRoutes answers the lookups of the guild from testGuild, the channel and the bot member over REST.
+func restGuildRoutes(g *discordgo.Guild) map[string]fakeResponse {
+	return map[string]fakeResponse{
+		"GET /channels/channel":            {status: http.StatusOK, body: g.Channels[0]},
+		"GET /guilds/guild":                {status: http.StatusOK, body: discordgo.Guild{ID: g.ID, OwnerID: g.OwnerID, Roles: g.Roles}},
+		"GET /guilds/guild/members/bot":    {status: http.StatusOK, body: g.Members[0]},
+		"GET /guilds/guild/members/target": {status: http.StatusOK, body: g.Members[1]},
+	}
+}
+
+func TestCheckPermission(t *testing.T) {
+	missingAccess := fakeResponse{
+		status: http.StatusForbidden,
+		body:   discordgo.APIErrorMessage{Code: discordgo.ErrCodeMissingAccess, Message: "Missing Access"},
+	}
+
+	tests := []struct {
+		name     string
+		perms    int64
+		inState  bool
+		routes   func(g *discordgo.Guild) map[string]fakeResponse
+		perm     int64
+		expected error
+		requests []string
+	}{
+		{
+			name:    "StateGranted",
+			perms:   discordgo.PermissionBanMembers,
+			inState: true,
+			perm:    discordgo.PermissionBanMembers,
+		},
+		{
+			name:     "StateMissing",
+			perms:    discordgo.PermissionKickMembers,
+			inState:  true,
+			perm:     discordgo.PermissionBanMembers,
+			expected: ErrMissingPermission,
+		},
+		{
+			name:    "StateAdministrator",
+			perms:   discordgo.PermissionAdministrator,
+			inState: true,
+			perm:    discordgo.PermissionModerateMembers,
+		},
+		{
+			name:     "RESTGranted",
+			perms:    discordgo.PermissionBanMembers,
+			routes:   restGuildRoutes,
+			perm:     discordgo.PermissionBanMembers,
+			requests: []string{"GET /channels/channel", "GET /guilds/guild", "GET /guilds/guild/members/bot"},
+		},
+		{
+			name:     "RESTMissing",
+			perms:    discordgo.PermissionKickMembers,
+			routes:   restGuildRoutes,
+			perm:     discordgo.PermissionBanMembers,
+			expected: ErrMissingPermission,
+			requests: []string{"GET /channels/channel", "GET /guilds/guild", "GET /guilds/guild/members/bot"},
+		},
+		{
+			name:  "RESTMissingAccess",
+			perms: discordgo.PermissionBanMembers,
+			routes: func(g *discordgo.Guild) map[string]fakeResponse {
+				return map[string]fakeResponse{"GET /channels/channel": missingAccess}
+			},
+			perm:     discordgo.PermissionBanMembers,
+			expected: ErrMissingPermission,
+			requests: []string{"GET /channels/channel"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := testGuild(tt.perms, 1)
+			var guilds []*discordgo.Guild
+			routes := map[string]fakeResponse{}
+
+			if tt.inState {
+				guilds = append(guilds, g)
+			}
+
+			if tt.routes != nil {
+				routes = tt.routes(g)
+			}
+
+			s, fake := newTestSession(t, routes, guilds...)
+
+			err := checkPermission(s, "channel", tt.perm)
+
+			assert.True(t, errors.Is(err, tt.expected), "expected %v, got %v", tt.expected, err)
+			assert.Equal(t, tt.requests, fake.Requests())
+		})
+	}
+}
+
+func TestCheckPermissionUnavailable(t *testing.T) {
+	s, _ := newTestSession(t, map[string]fakeResponse{"GET /channels/channel": {status: http.StatusInternalServerError}})
+
+	err := checkPermission(s, "channel", discordgo.PermissionBanMembers)
+
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, ErrMissingPermission))
+}
+
+func TestCheckHierarchy(t *testing.T) {
+	tests := []struct {
+		name           string
+		targetPosition int
+		inState        bool
+		target         func(t Target) Target
+		expected       error
+	}{
+		{name: "Below", targetPosition: 1, inState: true},
+		{name: "Everyone", targetPosition: 0, inState: true},
+		{name: "SamePosition", targetPosition: 2, inState: true, expected: ErrTargetAboveBot},
+		{name: "Above", targetPosition: 3, inState: true, expected: ErrTargetAboveBot},
+		{
+			name:           "Owner",
+			targetPosition: 1,
+			inState:        true,
+			target: func(t Target) Target {
+				t.UserID = "owner"
+				return t
+			},
+			expected: ErrTargetIsOwner,
+		},
+		{
+			name:           "MemberOfEvent",
+			targetPosition: 1,
+			inState:        true,
+			target: func(t Target) Target {
+				t.Member = &discordgo.Member{Roles: []string{"high"}}
+				return t
+			},
+			expected: ErrTargetAboveBot,
+		},
+		{name: "RESTBelow", targetPosition: 1},
+		{name: "RESTAbove", targetPosition: 3, expected: ErrTargetAboveBot},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := testGuild(moderatePermissions, tt.targetPosition)
+			var guilds []*discordgo.Guild
+			routes := map[string]fakeResponse{}
+
+			if tt.inState {
+				guilds = append(guilds, g)
+			} else {
+				routes = restGuildRoutes(g)
+			}
+
+			s, fake := newTestSession(t, routes, guilds...)
+			target := testTarget()
+
+			if tt.target != nil {
+				target = tt.target(target)
+			}
+
+			err := checkHierarchy(s, target)
+
+			assert.Equal(t, tt.expected, err)
+
+			if tt.inState {
+				assert.Empty(t, fake.Requests(), "state is used before the REST api")
+			}
+		})
+	}
+}
+
+func TestCheckHierarchyUnknownMember(t *testing.T) {
+	g := testGuild(moderatePermissions, 1)
+	g.Members = g.Members[:1]
+
+	s, _ := newTestSession(t, map[string]fakeResponse{}, g)
+
+	err := checkHierarchy(s, testTarget())
+
+	assert.Error(t, err)
+	assert.NotEqual(t, ErrTargetAboveBot, err)
+}
@@ -0,0 +1,454 @@
+// Package apiclient is a typed client of the guild, rules, infractions and escalation endpoints of the api.
+package apiclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/finkabaj/hyde-bot/internals/logger"
+	"github.com/finkabaj/hyde-bot/internals/utils/common"
+	"github.com/finkabaj/hyde-bot/internals/utils/escalation"
+	"github.com/finkabaj/hyde-bot/internals/utils/guild"
+	"github.com/finkabaj/hyde-bot/internals/utils/infraction"
+	"github.com/finkabaj/hyde-bot/internals/utils/rule"
+)
+
+// ErrUnavailable is returned when the api can't be reached, responds with 5xx or the circuit breaker is open.
+// The request can be retried later.
+var ErrUnavailable = errors.New("api is unavailable")
+
+// knownErrors are api errors that Error unwraps to, so callers can use errors.Is.
+var knownErrors = []error{
+	common.ErrNotFound,
+	common.ErrBadRequest,
+	common.ErrInternal,
+	common.ErrUnauthorized,
+	common.ErrForbidden,
+	common.ErrValidation,
+	common.ErrEmptyBody,
+	guild.ErrGuildConflict,
+	guild.ErrEmptyGuildId,
+	rule.ErrRuleReactionConflict,
+	rule.ErrRuleReactionIncompatible,
+	rule.ErrReactionRoleConflict,
+}
+
+// Error is an error response of the api.
+type Error struct {
+	Status   int
+	Response common.ErrorResponse
+	known    error
+}
+
+func (e *Error) Error() string {
+	if e.Response.Message != "" {
+		return fmt.Sprintf("api responded with %d: %s: %s", e.Status, e.Response.Error, e.Response.Message)
+	}
+
+	return fmt.Sprintf("api responded with %d: %s", e.Status, e.Response.Error)
+}
+
+// Unwrap returns the sentinel error matching the response, e.g. common.ErrNotFound,
+// and ErrUnavailable for 5xx responses.
+func (e *Error) Unwrap() []error {
+	errs := make([]error, 0, 2)
+
+	if e.known != nil {
+		errs = append(errs, e.known)
+	}
+
+	if e.Status >= http.StatusInternalServerError {
+		errs = append(errs, ErrUnavailable)
+	}
+
+	return errs
+}
+
+func newError(status int, res common.ErrorResponse) *Error {
+	e := &Error{Status: status, Response: res}
+
+	for _, known := range knownErrors {
+		if res.Error == known.Error() {
+			e.known = known
+			break
+		}
+	}
+
+	return e
+}
+
+// RetryPolicy of requests that fail with 5xx. Requests without body are also retried on network errors.
+type RetryPolicy struct {
+	MaxRetries int
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 3,
+	MinBackoff: 200 * time.Millisecond,
+	MaxBackoff: 2 * time.Second,
+}
+
+type Client struct {
+	baseUrl string
+	client  *http.Client
+	retry   RetryPolicy
+	breaker *Breaker
+	logger  logger.ILogger
+}
+
+// NewClient returns a client of the api at baseUrl, e.g. common.GetApiUrl(host, port, "").
+// Clients of the same api should share the breaker. nil breaker never opens.
+func NewClient(l logger.ILogger, baseUrl string, client *http.Client, retry RetryPolicy, breaker *Breaker) *Client {
+	return &Client{
+		baseUrl: baseUrl,
+		client:  client,
+		retry:   retry,
+		breaker: breaker,
+		logger:  l,
+	}
+}
+
+// Available reports whether requests reach the api, it's false while the breaker is open.
+func (c *Client) Available() bool {
+	return c.breaker == nil || c.breaker.State() == BreakerClosed
+}
+
+func (c *Client) CreateGuild(ctx context.Context, g guild.GuildCreate) (guild.Guild, error) {
+	var created guild.Guild
+
+	err := c.do(ctx, http.MethodPost, "/guild", g, http.StatusCreated, &created)
+
+	return created, err
+}
+
+func (c *Client) GetGuild(ctx context.Context, gId string) (guild.Guild, error) {
+	var g guild.Guild
+
+	err := c.do(ctx, http.MethodGet, "/guild/"+gId, nil, http.StatusOK, &g)
+
+	return g, err
+}
+
+func (c *Client) UpdateGuildExemptions(ctx context.Context, gId string, exemptions guild.GuildExemptions) (guild.Guild, error) {
+	var g guild.Guild
+
+	err := c.do(ctx, http.MethodPatch, "/guild/"+gId+"/exemptions", exemptions, http.StatusOK, &g)
+
+	return g, err
+}
+
+func (c *Client) UpdateGuildModLog(ctx context.Context, gId string, modLog guild.GuildModLog) (guild.Guild, error) {
+	var g guild.Guild
+
+	err := c.do(ctx, http.MethodPatch, "/guild/"+gId+"/modlog", modLog, http.StatusOK, &g)
+
+	return g, err
+}
+
+func (c *Client) UpdateGuild(ctx context.Context, gId string, u guild.GuildUpdate) (guild.Guild, error) {
+	var g guild.Guild
+
+	err := c.do(ctx, http.MethodPatch, "/guild/"+gId, u, http.StatusOK, &g)
+
+	return g, err
+}
+
+// DeleteGuild soft deletes the guild, the API restores it with its rules if it's created again within the retention.
+func (c *Client) DeleteGuild(ctx context.Context, gId string) error {
+	return c.do(ctx, http.MethodDelete, "/guild/"+gId, nil, http.StatusOK, nil)
+}
+
+func (c *Client) GetReactionRules(ctx context.Context, gId string) ([]rule.ReactionRule, error) {
+	var rules []rule.ReactionRule
+
+	err := c.do(ctx, http.MethodGet, "/rules/reaction/"+gId, nil, http.StatusOK, &rules)
+
+	return rules, err
+}
+
+func (c *Client) CreateReactionRules(ctx context.Context, rules []rule.ReactionRule) ([]rule.ReactionRule, error) {
+	var created []rule.ReactionRule
+
+	err := c.do(ctx, http.MethodPost, "/rules/reaction", rules, http.StatusCreated, &created)
+
+	return created, err
+}
+
+func (c *Client) UpdateReactionRules(ctx context.Context, gId string, updates []rule.ReactionRuleUpdate) ([]rule.ReactionRule, error) {
+	var updated []rule.ReactionRule
+
+	err := c.do(ctx, http.MethodPatch, "/rules/reaction/"+gId, updates, http.StatusOK, &updated)
+
+	return updated, err
+}
+
+func (c *Client) DeleteReactionRules(ctx context.Context, gId string, rules []rule.DeleteReactionRuleQuery) error {
+	path := "/rules/reaction/" + gId + "?" + rule.EncodeDeleteReactQuery(rules)
+
+	return c.do(ctx, http.MethodDelete, path, nil, http.StatusOK, nil)
+}
+
+func (c *Client) GetReactionRoles(ctx context.Context, gId string) ([]rule.ReactionRoleMessage, error) {
+	var messages []rule.ReactionRoleMessage
+
+	err := c.do(ctx, http.MethodGet, "/rules/reaction-roles/"+gId, nil, http.StatusOK, &messages)
+
+	return messages, err
+}
+
+// SaveReactionRoles creates or replaces reaction roles of the message.
+func (c *Client) SaveReactionRoles(ctx context.Context, gId string, messageId string, u rule.ReactionRoleMessageUpdate) (rule.ReactionRoleMessage, error) {
+	var message rule.ReactionRoleMessage
+
+	err := c.do(ctx, http.MethodPut, "/rules/reaction-roles/"+gId+"/"+messageId, u, http.StatusOK, &message)
+
+	return message, err
+}
+
+func (c *Client) DeleteReactionRoles(ctx context.Context, gId string, messageId string) error {
+	return c.do(ctx, http.MethodDelete, "/rules/reaction-roles/"+gId+"/"+messageId, nil, http.StatusOK, nil)
+}
+
+// GetReactionFlood returns the flood rule of the guild. Fails with 404 if the guild has none.
+func (c *Client) GetReactionFlood(ctx context.Context, gId string) (rule.ReactionFloodRule, error) {
+	var f rule.ReactionFloodRule
+
+	err := c.do(ctx, http.MethodGet, "/rules/reaction-flood/"+gId, nil, http.StatusOK, &f)
+
+	return f, err
+}
+
+// SaveReactionFlood creates or replaces the flood rule of the guild.
+func (c *Client) SaveReactionFlood(ctx context.Context, gId string, u rule.ReactionFloodRuleUpdate) (rule.ReactionFloodRule, error) {
+	var f rule.ReactionFloodRule
+
+	err := c.do(ctx, http.MethodPut, "/rules/reaction-flood/"+gId, u, http.StatusOK, &f)
+
+	return f, err
+}
+
+func (c *Client) DeleteReactionFlood(ctx context.Context, gId string) error {
+	return c.do(ctx, http.MethodDelete, "/rules/reaction-flood/"+gId, nil, http.StatusOK, nil)
+}
+
+func (c *Client) CreateInfraction(ctx context.Context, i infraction.InfractionCreate) (infraction.Infraction, error) {
+	var created infraction.Infraction
+
+	err := c.do(ctx, http.MethodPost, "/infractions", i, http.StatusCreated, &created)
+
+	return created, err
+}
+
+// GetInfractions returns a page of infractions of the guild, q.GuildId is the guild.
+func (c *Client) GetInfractions(ctx context.Context, q infraction.InfractionQuery) (infraction.InfractionPage, error) {
+	var page infraction.InfractionPage
+
+	err := c.do(ctx, http.MethodGet, "/infractions/"+q.GuildId+"?"+infraction.EncodeInfractionQuery(q), nil, http.StatusOK, &page)
+
+	return page, err
+}
+
+func (c *Client) GetEscalationPolicy(ctx context.Context, gId string) (escalation.Policy, error) {
+	var policy escalation.Policy
+
+	err := c.do(ctx, http.MethodGet, "/escalation/"+gId, nil, http.StatusOK, &policy)
+
+	return policy, err
+}
+
+func (c *Client) UpdateEscalationPolicy(ctx context.Context, gId string, u escalation.PolicyUpdate) (escalation.Policy, error) {
+	var policy escalation.Policy
+
+	err := c.do(ctx, http.MethodPut, "/escalation/"+gId, u, http.StatusOK, &policy)
+
+	return policy, err
+}
+
+// RuleEvents opens the rule events stream. The stream isn't retried, the caller must close it.
+// It's closed when ctx is done, so the http client must not have a timeout.
+func (c *Client) RuleEvents(ctx context.Context) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseUrl+"/rules/events", nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "text/event-stream")
+
+	if err := c.allow(); err != nil {
+		return nil, err
+	}
+
+	res, err := c.client.Do(req)
+	c.record(ctx, res, err)
+
+	if err != nil {
+		return nil, c.sendError(ctx, http.MethodGet, "/rules/events", err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		defer res.Body.Close()
+		return nil, c.decodeError(res)
+	}
+
+	return res.Body, nil
+}
+
+// do sends the request and decodes the response with status expected to out.
+// Other responses are returned as *Error. out can be nil.
+func (c *Client) do(ctx context.Context, method, path string, body any, expected int, out any) error {
+	var b []byte
+
+	if body != nil {
+		var err error
+
+		if b, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("error marshaling %s %s body: %w", method, path, err)
+		}
+	}
+
+	if err := c.allow(); err != nil {
+		return err
+	}
+
+	backoff := c.retry.MinBackoff
+
+	for attempt := 0; ; attempt++ {
+		res, err := c.send(ctx, method, path, b)
+
+		retry := attempt < c.retry.MaxRetries && ctx.Err() == nil &&
+			((err != nil && body == nil) || (err == nil && res.StatusCode >= http.StatusInternalServerError))
+
+		if !retry {
+			c.record(ctx, res, err)
+
+			if err != nil {
+				return c.sendError(ctx, method, path, err)
+			}
+
+			return c.decodeResponse(res, expected, out)
+		}
+
+		if err == nil {
+			c.logger.Debug("Retrying api request", map[string]any{"method": method, "path": path, "status": res.StatusCode, "retryIn": backoff.String()})
+			res.Body.Close()
+		} else {
+			c.logger.Debug("Retrying api request", map[string]any{"method": method, "path": path, "error": err.Error(), "retryIn": backoff.String()})
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		backoff = min(backoff*2, c.retry.MaxBackoff)
+	}
+}
+
+func (c *Client) allow() error {
+	if c.breaker == nil {
+		return nil
+	}
+
+	return c.breaker.allow()
+}
+
+// record reports the result of the request to the breaker. Requests canceled by the caller are ignored.
+func (c *Client) record(ctx context.Context, res *http.Response, err error) {
+	if c.breaker == nil || ctx.Err() != nil {
+		return
+	}
+
+	if err != nil || res.StatusCode >= http.StatusInternalServerError {
+		c.breaker.failure()
+	} else {
+		c.breaker.success()
+	}
+}
+
+// sendError wraps the transport error with ErrUnavailable, unless the caller canceled the request.
+func (c *Client) sendError(ctx context.Context, method, path string, err error) error {
+	if ctx.Err() != nil {
+		return fmt.Errorf("error sending %s %s: %w", method, path, err)
+	}
+
+	return fmt.Errorf("%w: error sending %s %s: %w", ErrUnavailable, method, path, err)
+}
+
+func (c *Client) send(ctx context.Context, method, path string, body []byte) (*http.Response, error) {
+	var bodyReader io.Reader
+
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseUrl+path, bodyReader)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return c.client.Do(req)
+}
+
+func (c *Client) decodeResponse(res *http.Response, expected int, out any) error {
+	defer res.Body.Close()
+
+	if res.StatusCode != expected {
+		return c.decodeError(res)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	b, err := io.ReadAll(res.Body)
+
+	if err != nil {
+		return fmt.Errorf("error reading api response: %w", err)
+	}
+
+	if err = common.UnmarshalBodyBytes(b, out); err != nil {
+		return fmt.Errorf("error unmarshaling api response: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) decodeError(res *http.Response) error {
+	var errRes common.ErrorResponse
+
+	b, err := io.ReadAll(res.Body)
+
+	if err == nil && len(b) > 0 {
+		err = json.Unmarshal(b, &errRes)
+	}
+
+	if err != nil || errRes.Error == "" {
+		errRes.Error = http.StatusText(res.StatusCode)
+	}
+
+	c.logger.Debug("Error response", map[string]any{"status": res.StatusCode, "error": errRes.Error, "validationErrors": errRes.ValidationErrors, "message": errRes.Message})
+
+	return newError(res.StatusCode, errRes)
+}
+
+// IsError reports whether err is an error response of the api with the status.
+func IsError(err error, status int) bool {
+	var apiErr *Error
+
+	return errors.As(err, &apiErr) && apiErr.Status == status
+}
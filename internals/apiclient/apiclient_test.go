@@ -0,0 +1,264 @@
+package apiclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	mogs "github.com/finkabaj/hyde-bot/internals/backend/mocks"
+	"github.com/finkabaj/hyde-bot/internals/utils/common"
+	"github.com/finkabaj/hyde-bot/internals/utils/guild"
+	"github.com/finkabaj/hyde-bot/internals/utils/rule"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testRetryPolicy = RetryPolicy{
+	MaxRetries: 2,
+	MinBackoff: time.Millisecond,
+	MaxBackoff: 2 * time.Millisecond,
+}
+
+func newTestClient(t *testing.T, h http.HandlerFunc) *Client {
+	s := httptest.NewServer(h)
+	t.Cleanup(s.Close)
+
+	return NewClient(mogs.NewMockLogger(), s.URL, s.Client(), testRetryPolicy, nil)
+}
+
+func sendError(w http.ResponseWriter, status int, err error) {
+	common.NewErrorResponseBuilder(err).SetStatus(status).Send(w)
+}
+
+func TestClient(t *testing.T) {
+	t.Run("CreateGuild", testCreateGuild)
+	t.Run("GetReactionRules", testGetReactionRules)
+	t.Run("DeleteReactionRules", testDeleteReactionRules)
+	t.Run("RuleEvents", testRuleEvents)
+}
+
+func TestErrors(t *testing.T) {
+	t.Run("GuildConflict", testErrorGuildConflict)
+	t.Run("RuleReactionConflict", testErrorRuleReactionConflict)
+	t.Run("NotFound", testErrorNotFound)
+	t.Run("UnknownError", testErrorUnknown)
+}
+
+func TestRetry(t *testing.T) {
+	t.Run("RecoversFrom5xx", testRetryRecoversFrom5xx)
+	t.Run("ResendsBody", testRetryResendsBody)
+	t.Run("GivesUp", testRetryGivesUp)
+	t.Run("No4xxRetry", testRetryNo4xx)
+	t.Run("ContextCanceled", testRetryContextCanceled)
+}
+
+func testCreateGuild(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/guild", r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		var g guild.GuildCreate
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&g))
+
+		common.MarshalBody(w, http.StatusCreated, guild.Guild{GuildId: g.GuildId, OwnerId: g.OwnerId, ExemptAdmins: true})
+	})
+
+	g, err := c.CreateGuild(context.Background(), guild.GuildCreate{GuildId: "1", OwnerId: "2"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, guild.Guild{GuildId: "1", OwnerId: "2", ExemptAdmins: true}, g)
+}
+
+func testGetReactionRules(t *testing.T) {
+	expected := []rule.ReactionRule{{GuildId: "1", EmojiName: "🤡", Actions: rule.Actions{{Type: rule.Delete}}}}
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/rules/reaction/1", r.URL.Path)
+
+		common.MarshalBody(w, http.StatusOK, expected)
+	})
+
+	rules, err := c.GetReactionRules(context.Background(), "1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, rules)
+}
+
+func testDeleteReactionRules(t *testing.T) {
+	query := []rule.DeleteReactionRuleQuery{{EmojiName: "🤡"}, {EmojiName: "custom", EmojiId: "3"}}
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "/rules/reaction/1", r.URL.Path)
+		assert.Equal(t, rule.EncodeDeleteReactQuery(query), r.URL.RawQuery)
+
+		common.MarshalBody(w, http.StatusOK, common.OkResponse{Message: "Reaction rules deleted"})
+	})
+
+	assert.NoError(t, c.DeleteReactionRules(context.Background(), "1", query))
+}
+
+func testRuleEvents(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rules/events", r.URL.Path)
+		assert.Equal(t, "text/event-stream", r.Header.Get("Accept"))
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Write([]byte("data: {}\n\n"))
+	})
+
+	stream, err := c.RuleEvents(context.Background())
+	require.NoError(t, err)
+	defer stream.Close()
+
+	b, err := io.ReadAll(stream)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "data: {}\n\n", string(b))
+}
+
+func testErrorGuildConflict(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		sendError(w, http.StatusConflict, guild.ErrGuildConflict)
+	})
+
+	_, err := c.CreateGuild(context.Background(), guild.GuildCreate{GuildId: "1", OwnerId: "2"})
+
+	assert.ErrorIs(t, err, guild.ErrGuildConflict)
+	assert.True(t, IsError(err, http.StatusConflict))
+}
+
+func testErrorRuleReactionConflict(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		sendError(w, http.StatusConflict, rule.ErrRuleReactionConflict)
+	})
+
+	_, err := c.CreateReactionRules(context.Background(), []rule.ReactionRule{{GuildId: "1", EmojiName: "🤡"}})
+
+	assert.ErrorIs(t, err, rule.ErrRuleReactionConflict)
+}
+
+func testErrorNotFound(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		sendError(w, http.StatusNotFound, common.ErrNotFound)
+	})
+
+	_, err := c.GetGuild(context.Background(), "1")
+
+	assert.ErrorIs(t, err, common.ErrNotFound)
+}
+
+func testErrorUnknown(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	_, err := c.GetGuild(context.Background(), "1")
+
+	var apiErr *Error
+
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusTeapot, apiErr.Status)
+	assert.Equal(t, http.StatusText(http.StatusTeapot), apiErr.Response.Error)
+	assert.False(t, errors.Is(err, ErrUnavailable))
+}
+
+func testRetryRecoversFrom5xx(t *testing.T) {
+	var calls atomic.Int32
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			sendError(w, http.StatusServiceUnavailable, common.ErrInternal)
+			return
+		}
+
+		common.MarshalBody(w, http.StatusOK, guild.Guild{GuildId: "1"})
+	})
+
+	g, err := c.GetGuild(context.Background(), "1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1", g.GuildId)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func testRetryResendsBody(t *testing.T) {
+	var calls atomic.Int32
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var exemptions guild.GuildExemptions
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&exemptions))
+		assert.Equal(t, []string{"role"}, exemptions.ExemptRoles)
+
+		if calls.Add(1) == 1 {
+			sendError(w, http.StatusInternalServerError, common.ErrInternal)
+			return
+		}
+
+		common.MarshalBody(w, http.StatusOK, guild.Guild{GuildId: "1", ExemptRoles: exemptions.ExemptRoles})
+	})
+
+	exemptAdmins := true
+	g, err := c.UpdateGuildExemptions(context.Background(), "1", guild.GuildExemptions{ExemptRoles: []string{"role"}, ExemptAdmins: &exemptAdmins})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"role"}, g.ExemptRoles)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func testRetryGivesUp(t *testing.T) {
+	var calls atomic.Int32
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		sendError(w, http.StatusInternalServerError, common.ErrInternal)
+	})
+
+	_, err := c.GetGuild(context.Background(), "1")
+
+	assert.ErrorIs(t, err, common.ErrInternal)
+	assert.ErrorIs(t, err, ErrUnavailable)
+	assert.True(t, IsError(err, http.StatusInternalServerError))
+	assert.Equal(t, int32(testRetryPolicy.MaxRetries+1), calls.Load())
+}
+
+func testRetryNo4xx(t *testing.T) {
+	var calls atomic.Int32
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		sendError(w, http.StatusBadRequest, common.ErrBadRequest)
+	})
+
+	_, err := c.GetGuild(context.Background(), "1")
+
+	assert.ErrorIs(t, err, common.ErrBadRequest)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func testRetryContextCanceled(t *testing.T) {
+	var calls atomic.Int32
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		sendError(w, http.StatusInternalServerError, common.ErrInternal)
+	})
+
+	c.retry = RetryPolicy{MaxRetries: 5, MinBackoff: time.Minute, MaxBackoff: time.Minute}
+
+	_, err := c.GetGuild(ctx, "1")
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, int32(1), calls.Load())
+}
@@ -0,0 +1,134 @@
+package apiclient
+
+import (
+	"sync"
+	"time"
+
+	"github.com/finkabaj/hyde-bot/internals/logger"
+)
+
+const (
+	DefaultBreakerThreshold = 5
+	DefaultBreakerCooldown  = 30 * time.Second
+)
+
+type BreakerState int
+
+const (
+	BreakerClosed BreakerState = iota
+	BreakerOpen
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// Breaker is a circuit breaker of the api. After threshold consecutive failed requests it opens
+// and requests fail with ErrUnavailable without reaching the api. After cooldown one request is
+// let through, it closes the breaker on success and opens it again on failure.
+type Breaker struct {
+	threshold int
+	cooldown  time.Duration
+	logger    logger.ILogger
+	state     BreakerState
+	failures  int
+	openedAt  time.Time
+	lock      sync.Mutex
+}
+
+// NewBreaker returns a closed breaker. Non positive threshold and cooldown fall back to defaults.
+func NewBreaker(l logger.ILogger, threshold int, cooldown time.Duration) *Breaker {
+	if threshold <= 0 {
+		threshold = DefaultBreakerThreshold
+	}
+
+	if cooldown <= 0 {
+		cooldown = DefaultBreakerCooldown
+	}
+
+	return &Breaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		logger:    l,
+	}
+}
+
+func (b *Breaker) State() BreakerState {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return b.state
+}
+
+// allow returns ErrUnavailable if the request must not reach the api.
+func (b *Breaker) allow() error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if time.Since(b.openedAt) < b.cooldown {
+			return ErrUnavailable
+		}
+
+		b.openedAt = time.Now()
+		b.setState(BreakerHalfOpen)
+		return nil
+	case BreakerHalfOpen:
+		// only the probe request is let through, unless it was canceled without result
+		if time.Since(b.openedAt) < b.cooldown {
+			return ErrUnavailable
+		}
+
+		b.openedAt = time.Now()
+		return nil
+	default:
+		return nil
+	}
+}
+
+func (b *Breaker) success() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.failures = 0
+	b.setState(BreakerClosed)
+}
+
+func (b *Breaker) failure() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.failures++
+
+	if b.state == BreakerHalfOpen || b.failures >= b.threshold {
+		b.openedAt = time.Now()
+		b.setState(BreakerOpen)
+	}
+}
+
+// setState must be called with lock held.
+func (b *Breaker) setState(state BreakerState) {
+	if b.state == state {
+		return
+	}
+
+	b.state = state
+
+	switch state {
+	case BreakerOpen:
+		b.logger.Warn(ErrUnavailable, map[string]any{"details": "api circuit opened", "failures": b.failures, "retryIn": b.cooldown.String()})
+	case BreakerClosed:
+		b.logger.Info("Api circuit closed")
+	}
+}
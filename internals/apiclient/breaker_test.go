@@ -0,0 +1,127 @@
+package apiclient
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	mogs "github.com/finkabaj/hyde-bot/internals/backend/mocks"
+	"github.com/finkabaj/hyde-bot/internals/utils/common"
+	"github.com/finkabaj/hyde-bot/internals/utils/guild"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBreaker(t *testing.T) {
+	t.Run("Opens", testBreakerOpens)
+	t.Run("Closes", testBreakerCloses)
+	t.Run("ReopensOnFailedProbe", testBreakerReopensOnFailedProbe)
+	t.Run("IgnoresClientErrors", testBreakerIgnoresClientErrors)
+}
+
+// newBreakerTestClient returns a client without retries, so every call is one request.
+func newBreakerTestClient(t *testing.T, b *Breaker, fail *atomic.Bool, calls *atomic.Int32) *Client {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+
+		if fail.Load() {
+			sendError(w, http.StatusInternalServerError, common.ErrInternal)
+			return
+		}
+
+		common.MarshalBody(w, http.StatusOK, guild.Guild{GuildId: "1"})
+	})
+
+	c.retry = RetryPolicy{}
+	c.breaker = b
+
+	return c
+}
+
+func testBreakerOpens(t *testing.T) {
+	var fail atomic.Bool
+	var calls atomic.Int32
+
+	fail.Store(true)
+
+	b := NewBreaker(mogs.NewMockLogger(), 2, time.Minute)
+	c := newBreakerTestClient(t, b, &fail, &calls)
+
+	for range 2 {
+		_, err := c.GetGuild(context.Background(), "1")
+		assert.ErrorIs(t, err, ErrUnavailable)
+	}
+
+	assert.Equal(t, BreakerOpen, b.State())
+	assert.False(t, c.Available())
+
+	_, err := c.GetGuild(context.Background(), "1")
+
+	assert.ErrorIs(t, err, ErrUnavailable)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func testBreakerCloses(t *testing.T) {
+	var fail atomic.Bool
+	var calls atomic.Int32
+
+	fail.Store(true)
+
+	b := NewBreaker(mogs.NewMockLogger(), 1, time.Millisecond)
+	c := newBreakerTestClient(t, b, &fail, &calls)
+
+	_, err := c.GetGuild(context.Background(), "1")
+
+	assert.ErrorIs(t, err, ErrUnavailable)
+	assert.Equal(t, BreakerOpen, b.State())
+
+	fail.Store(false)
+	time.Sleep(2 * time.Millisecond)
+
+	g, err := c.GetGuild(context.Background(), "1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1", g.GuildId)
+	assert.Equal(t, BreakerClosed, b.State())
+	assert.True(t, c.Available())
+}
+
+func testBreakerReopensOnFailedProbe(t *testing.T) {
+	var fail atomic.Bool
+	var calls atomic.Int32
+
+	fail.Store(true)
+
+	b := NewBreaker(mogs.NewMockLogger(), 1, 50*time.Millisecond)
+	c := newBreakerTestClient(t, b, &fail, &calls)
+
+	c.GetGuild(context.Background(), "1")
+	time.Sleep(60 * time.Millisecond)
+
+	_, err := c.GetGuild(context.Background(), "1")
+
+	assert.ErrorIs(t, err, ErrUnavailable)
+	assert.Equal(t, BreakerOpen, b.State())
+	assert.Equal(t, int32(2), calls.Load())
+
+	_, err = c.GetGuild(context.Background(), "1")
+
+	assert.ErrorIs(t, err, ErrUnavailable)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func testBreakerIgnoresClientErrors(t *testing.T) {
+	b := NewBreaker(mogs.NewMockLogger(), 1, time.Minute)
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		sendError(w, http.StatusNotFound, common.ErrNotFound)
+	})
+
+	c.breaker = b
+
+	_, err := c.GetGuild(context.Background(), "1")
+
+	assert.ErrorIs(t, err, common.ErrNotFound)
+	assert.NotErrorIs(t, err, ErrUnavailable)
+	assert.Equal(t, BreakerClosed, b.State())
+}
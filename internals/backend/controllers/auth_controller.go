@@ -0,0 +1,156 @@
+package controllers
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/finkabaj/hyde-bot/internals/backend/middleware"
+	"github.com/finkabaj/hyde-bot/internals/backend/services"
+	"github.com/finkabaj/hyde-bot/internals/logger"
+	"github.com/finkabaj/hyde-bot/internals/utils/common"
+	"github.com/finkabaj/hyde-bot/internals/utils/user"
+)
+
+// stateCookie holds the oauth2 state between the login redirect and the callback.
+const stateCookie = "oauth_state"
+
+type AuthController struct {
+	service services.IAuthService
+	logger  logger.ILogger
+}
+
+var authController *AuthController
+
+func NewAuthController(s services.IAuthService, l logger.ILogger) *AuthController {
+	if authController == nil {
+		authController = &AuthController{
+			service: s,
+			logger:  l,
+		}
+	}
+	return authController
+}
+
+func (c *AuthController) RegisterRoutes(router *chi.Mux) {
+	router.Route("/auth", func(r chi.Router) {
+		r.Get("/login", c.login)
+		r.Get("/callback", c.callback)
+		r.With(middleware.ValidateJson[user.RefreshRequest]()).Post("/refresh", c.refresh)
+		r.With(middleware.ValidateJson[user.RefreshRequest]()).Post("/logout", c.logout)
+	})
+}
+
+func (ac *AuthController) login(w http.ResponseWriter, r *http.Request) {
+	state, err := services.RandomState()
+
+	if err != nil {
+		ac.logger.Error(err, map[string]any{"details": "error while generating oauth state"})
+		common.SendInternalError(w)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     stateCookie,
+		Value:    state,
+		Path:     "/auth",
+		MaxAge:   600,
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	http.Redirect(w, r, ac.service.LoginURL(state), http.StatusFound)
+}
+
+func (ac *AuthController) callback(w http.ResponseWriter, r *http.Request) {
+	cookie, err := r.Cookie(stateCookie)
+	state := r.URL.Query().Get("state")
+
+	if err != nil || state == "" || subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(state)) != 1 {
+		common.NewErrorResponseBuilder(user.ErrInvalidState).
+			SetStatus(http.StatusBadRequest).
+			SetMessage("Invalid or expired login attempt").
+			Send(w)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{Name: stateCookie, Path: "/auth", MaxAge: -1})
+
+	code := r.URL.Query().Get("code")
+
+	if code == "" {
+		common.SendBadRequestError(w, "No code provided")
+		return
+	}
+
+	session, err := ac.service.Login(code)
+
+	switch {
+	case err == common.ErrUnauthorized:
+		common.SendUnauthorizedError(w, "Discord rejected the authorization code")
+		return
+	case err == common.ErrInternal:
+		common.SendInternalError(w)
+		return
+	case err != nil:
+		common.SendInternalError(w, "Unexpected error at callback")
+		return
+	}
+
+	if err := common.MarshalBody(w, http.StatusOK, &session); err != nil {
+		ac.logger.Error(err, map[string]any{"details": "error while marshalling session"})
+		common.SendInternalError(w)
+	}
+}
+
+func (ac *AuthController) refresh(w http.ResponseWriter, r *http.Request) {
+	req, ok := middleware.JsonFromContext(r.Context()).(user.RefreshRequest)
+
+	if !ok {
+		logger.Error(errors.New("no refresh request struct found in context"), map[string]any{"details": "error while getting refresh request struct"})
+		common.SendInternalError(w)
+		return
+	}
+
+	session, err := ac.service.Refresh(req.RefreshToken)
+
+	switch {
+	case err == user.ErrInvalidRefreshToken:
+		common.NewErrorResponseBuilder(err).
+			SetStatus(http.StatusUnauthorized).
+			SetMessage("Refresh token is invalid or expired").
+			Send(w)
+		return
+	case err == common.ErrInternal:
+		common.SendInternalError(w)
+		return
+	case err != nil:
+		common.SendInternalError(w, "Unexpected error at refresh")
+		return
+	}
+
+	if err := common.MarshalBody(w, http.StatusOK, &session); err != nil {
+		ac.logger.Error(err, map[string]any{"details": "error while marshalling session"})
+		common.SendInternalError(w)
+	}
+}
+
+func (ac *AuthController) logout(w http.ResponseWriter, r *http.Request) {
+	req, ok := middleware.JsonFromContext(r.Context()).(user.RefreshRequest)
+
+	if !ok {
+		logger.Error(errors.New("no refresh request struct found in context"), map[string]any{"details": "error while getting refresh request struct"})
+		common.SendInternalError(w)
+		return
+	}
+
+	if err := ac.service.Logout(req.RefreshToken); err != nil {
+		common.SendInternalError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
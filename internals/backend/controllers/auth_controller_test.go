@@ -0,0 +1,242 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/finkabaj/hyde-bot/internals/backend/middleware"
+	mogs "github.com/finkabaj/hyde-bot/internals/backend/mocks"
+	"github.com/finkabaj/hyde-bot/internals/utils/common"
+	"github.com/finkabaj/hyde-bot/internals/utils/user"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var mockAuthService *mogs.MockAuthService = mogs.NewMockAuthService()
+var testAuth *middleware.Auth = middleware.NewAuth(mockAuthService, mockAuthService, testServiceToken)
+var authRouter *chi.Mux = chi.NewRouter()
+var ac *AuthController = NewAuthController(mockAuthService, mogs.NewMockLogger())
+
+const testServiceToken = "service"
+
+func init() {
+	ac.RegisterRoutes(authRouter)
+}
+
+// newServiceRequest returns a request authenticated as the bot.
+func newServiceRequest(method, target string, body io.Reader) *http.Request {
+	req := httptest.NewRequest(method, target, body)
+	req.Header.Set("Authorization", common.ServiceAuthScheme+" "+testServiceToken)
+
+	return req
+}
+
+func TestAuthLogin(t *testing.T) {
+	t.Run("Positive", testAuthLoginPositive)
+}
+
+func TestAuthCallback(t *testing.T) {
+	t.Run("Positive", testAuthCallbackPositive)
+	t.Run("NegativeState", testAuthCallbackNegativeState)
+	t.Run("NegativeUnauthorized", testAuthCallbackNegativeUnauthorized)
+}
+
+func TestAuthRefresh(t *testing.T) {
+	t.Run("Positive", testAuthRefreshPositive)
+	t.Run("NegativeInvalidToken", testAuthRefreshNegativeInvalidToken)
+	t.Run("NegativeValidation", testAuthRefreshNegativeValidation)
+}
+
+func TestAuthLogout(t *testing.T) {
+	t.Run("Positive", testAuthLogoutPositive)
+}
+
+func callbackRequest(code, state, cookieState string) *http.Request {
+	req := httptest.NewRequest("GET", fmt.Sprintf("/auth/callback?code=%s&state=%s", code, state), nil)
+	req.AddCookie(&http.Cookie{Name: stateCookie, Value: cookieState})
+
+	return req
+}
+
+func testAuthLoginPositive(t *testing.T) {
+	var state string
+
+	mockAuthService.On("LoginURL", mock.AnythingOfType("string")).Run(func(args mock.Arguments) {
+		state = args.String(0)
+	}).Return("https://discord.test/authorize").Once()
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/auth/login", nil)
+	authRouter.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusFound, rr.Code)
+	assert.Equal(t, "https://discord.test/authorize", rr.Header().Get("Location"))
+
+	cookies := rr.Result().Cookies()
+
+	if assert.Len(t, cookies, 1) {
+		assert.Equal(t, stateCookie, cookies[0].Name)
+		assert.Equal(t, state, cookies[0].Value)
+		assert.True(t, cookies[0].HttpOnly)
+	}
+
+	mockAuthService.AssertExpectations(t)
+}
+
+func testAuthCallbackPositive(t *testing.T) {
+	expectedResponse := user.Session{
+		AccessToken:  "access",
+		RefreshToken: "refresh",
+		ExpiresAt:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		User:         user.User{UserId: "1", Name: "name"},
+	}
+
+	mockAuthService.On("Login", "callbackPositive").Return(expectedResponse, nil).Once()
+
+	rr := httptest.NewRecorder()
+	authRouter.ServeHTTP(rr, callbackRequest("callbackPositive", "state", "state"))
+	defer rr.Result().Body.Close()
+
+	var actualResponse user.Session
+	common.UnmarshalBody(rr.Result().Body, &actualResponse)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, expectedResponse, actualResponse)
+
+	mockAuthService.AssertExpectations(t)
+}
+
+func testAuthCallbackNegativeState(t *testing.T) {
+	expectedResponse := common.NewErrorResponseBuilder(user.ErrInvalidState).
+		SetStatus(http.StatusBadRequest).
+		SetMessage("Invalid or expired login attempt").
+		Get()
+
+	rr := httptest.NewRecorder()
+	authRouter.ServeHTTP(rr, callbackRequest("callbackNegativeState", "state", "other"))
+	defer rr.Result().Body.Close()
+
+	var actualResponse *common.ErrorResponse
+	common.UnmarshalBody(rr.Result().Body, &actualResponse)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, expectedResponse, actualResponse)
+
+	mockAuthService.AssertNotCalled(t, "Login", "callbackNegativeState")
+}
+
+func testAuthCallbackNegativeUnauthorized(t *testing.T) {
+	expectedResponse := common.NewErrorResponseBuilder(common.ErrUnauthorized).
+		SetStatus(http.StatusUnauthorized).
+		SetMessage("Discord rejected the authorization code").
+		Get()
+
+	mockAuthService.On("Login", "callbackNegativeUnauthorized").Return(user.Session{}, common.ErrUnauthorized).Once()
+
+	rr := httptest.NewRecorder()
+	authRouter.ServeHTTP(rr, callbackRequest("callbackNegativeUnauthorized", "state", "state"))
+	defer rr.Result().Body.Close()
+
+	var actualResponse *common.ErrorResponse
+	common.UnmarshalBody(rr.Result().Body, &actualResponse)
+
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	assert.Equal(t, expectedResponse, actualResponse)
+
+	mockAuthService.AssertExpectations(t)
+}
+
+func testAuthRefreshPositive(t *testing.T) {
+	expectedResponse := user.Session{
+		AccessToken:  "access",
+		RefreshToken: "rotated",
+		ExpiresAt:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		User:         user.User{UserId: "1", Name: "name"},
+	}
+
+	mockAuthService.On("Refresh", "refreshPositive").Return(expectedResponse, nil).Once()
+
+	var byf bytes.Buffer
+	json.NewEncoder(&byf).Encode(user.RefreshRequest{RefreshToken: "refreshPositive"})
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/auth/refresh", &byf)
+	authRouter.ServeHTTP(rr, req)
+	defer rr.Result().Body.Close()
+
+	var actualResponse user.Session
+	common.UnmarshalBody(rr.Result().Body, &actualResponse)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, expectedResponse, actualResponse)
+
+	mockAuthService.AssertExpectations(t)
+}
+
+func testAuthRefreshNegativeInvalidToken(t *testing.T) {
+	expectedResponse := common.NewErrorResponseBuilder(user.ErrInvalidRefreshToken).
+		SetStatus(http.StatusUnauthorized).
+		SetMessage("Refresh token is invalid or expired").
+		Get()
+
+	mockAuthService.On("Refresh", "refreshNegative").Return(user.Session{}, user.ErrInvalidRefreshToken).Once()
+
+	var byf bytes.Buffer
+	json.NewEncoder(&byf).Encode(user.RefreshRequest{RefreshToken: "refreshNegative"})
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/auth/refresh", &byf)
+	authRouter.ServeHTTP(rr, req)
+	defer rr.Result().Body.Close()
+
+	var actualResponse *common.ErrorResponse
+	common.UnmarshalBody(rr.Result().Body, &actualResponse)
+
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	assert.Equal(t, expectedResponse, actualResponse)
+
+	mockAuthService.AssertExpectations(t)
+}
+
+func testAuthRefreshNegativeValidation(t *testing.T) {
+	expectedResponse := common.NewErrorResponseBuilder(common.ErrValidation).
+		SetStatus(http.StatusBadRequest).
+		SetValidationFields(map[string]string{"refreshToken": "required"}).
+		Get()
+
+	var byf bytes.Buffer
+	json.NewEncoder(&byf).Encode(user.RefreshRequest{})
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/auth/refresh", &byf)
+	authRouter.ServeHTTP(rr, req)
+	defer rr.Result().Body.Close()
+
+	var actualResponse *common.ErrorResponse
+	common.UnmarshalBody(rr.Result().Body, &actualResponse)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, expectedResponse, actualResponse)
+}
+
+func testAuthLogoutPositive(t *testing.T) {
+	mockAuthService.On("Logout", "logoutPositive").Return(nil).Once()
+
+	var byf bytes.Buffer
+	json.NewEncoder(&byf).Encode(user.RefreshRequest{RefreshToken: "logoutPositive"})
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/auth/logout", &byf)
+	authRouter.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+
+	mockAuthService.AssertExpectations(t)
+}
@@ -0,0 +1,104 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/finkabaj/hyde-bot/internals/backend/middleware"
+	"github.com/finkabaj/hyde-bot/internals/backend/services"
+	"github.com/finkabaj/hyde-bot/internals/logger"
+	"github.com/finkabaj/hyde-bot/internals/utils/common"
+	"github.com/finkabaj/hyde-bot/internals/utils/escalation"
+)
+
+type EscalationController struct {
+	service services.IEscalationService
+	auth    *middleware.Auth
+	logger  logger.ILogger
+}
+
+var escalationController *EscalationController
+
+func NewEscalationController(s services.IEscalationService, auth *middleware.Auth, l logger.ILogger) *EscalationController {
+	if escalationController == nil {
+		escalationController = &EscalationController{
+			service: s,
+			auth:    auth,
+			logger:  l,
+		}
+	}
+	return escalationController
+}
+
+func (c *EscalationController) RegisterRoutes(router *chi.Mux) {
+	requireGuild := c.auth.RequireGuild(middleware.GuildIdParam("id"))
+
+	router.Route("/escalation", func(r chi.Router) {
+		r.Use(c.auth.Authenticate)
+		r.With(requireGuild).Get("/{id}", c.getPolicy)
+		r.With(requireGuild, middleware.ValidateJson[escalation.PolicyUpdate]()).Put("/{id}", c.putPolicy)
+	})
+}
+
+func (c *EscalationController) getPolicy(w http.ResponseWriter, r *http.Request) {
+	gId := chi.URLParam(r, "id")
+
+	policy, err := c.service.GetPolicy(gId)
+
+	switch {
+	case err == common.ErrNotFound:
+		common.SendNotFoundError(w, fmt.Sprintf("No guild with id: %s found", gId))
+		return
+	case err == common.ErrInternal:
+		common.SendInternalError(w)
+		return
+	case err != nil:
+		common.SendInternalError(w, "Unexpected error at getPolicy")
+		return
+	}
+
+	if err := common.MarshalBody(w, http.StatusOK, &policy); err != nil {
+		c.logger.Error(err, map[string]any{"details": "Error while marshaling getPolicy response"})
+		common.SendInternalError(w)
+	}
+}
+
+func (c *EscalationController) putPolicy(w http.ResponseWriter, r *http.Request) {
+	gId := chi.URLParam(r, "id")
+
+	u, ok := middleware.JsonFromContext(r.Context()).(escalation.PolicyUpdate)
+
+	if !ok {
+		logger.Error(errors.New("no policy update struct found in context"), map[string]any{"details": "error while getting policy update struct"})
+		common.SendInternalError(w)
+		return
+	}
+
+	policy, err := c.service.UpdatePolicy(gId, u)
+
+	switch {
+	case err == escalation.ErrDuplicateSteps:
+		common.NewErrorResponseBuilder(err).
+			SetStatus(http.StatusBadRequest).
+			SetMessage("steps must have different warns").
+			Send(w)
+		return
+	case err == common.ErrNotFound:
+		common.SendNotFoundError(w, fmt.Sprintf("No guild with id: %s found", gId))
+		return
+	case err == common.ErrInternal:
+		common.SendInternalError(w)
+		return
+	case err != nil:
+		common.SendInternalError(w, "Unexpected error at putPolicy")
+		return
+	}
+
+	if err := common.MarshalBody(w, http.StatusOK, &policy); err != nil {
+		c.logger.Error(err, map[string]any{"details": "Error while marshaling putPolicy response"})
+		common.SendInternalError(w)
+	}
+}
@@ -0,0 +1,168 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	mogs "github.com/finkabaj/hyde-bot/internals/backend/mocks"
+	"github.com/finkabaj/hyde-bot/internals/utils/common"
+	"github.com/finkabaj/hyde-bot/internals/utils/escalation"
+	"github.com/finkabaj/hyde-bot/internals/utils/infraction"
+	"github.com/stretchr/testify/assert"
+)
+
+var mockEscalationService *mogs.MockEscalationService = mogs.NewMockEscalationService()
+var esc *EscalationController = NewEscalationController(mockEscalationService, testAuth, mogs.NewMockLogger())
+
+func init() {
+	esc.RegisterRoutes(r)
+}
+
+func TestGetEscalationPolicy(t *testing.T) {
+	t.Run("Positive", testGetEscalationPolicyPositive)
+	t.Run("NegativeNotFound", testGetEscalationPolicyNegativeNotFound)
+}
+
+func TestUpdateEscalationPolicy(t *testing.T) {
+	t.Run("Positive", testUpdateEscalationPolicyPositive)
+	t.Run("NegativeValidation", testUpdateEscalationPolicyNegativeValidation)
+	t.Run("NegativeDuplicateSteps", testUpdateEscalationPolicyNegativeDuplicateSteps)
+}
+
+func testGetEscalationPolicyPositive(t *testing.T) {
+	gId := "escalationPositive"
+	expectedResponse := escalation.Policy{
+		GuildId: gId,
+		Steps:   []escalation.Step{{Warns: 3, WindowSeconds: 604800, Action: infraction.Timeout, TimeoutSeconds: 3600}},
+	}
+
+	mockEscalationService.On("GetPolicy", gId).Return(expectedResponse, nil)
+
+	rr := httptest.NewRecorder()
+	req := newServiceRequest("GET", fmt.Sprintf("/escalation/%s", gId), nil)
+	r.ServeHTTP(rr, req)
+	defer rr.Result().Body.Close()
+
+	var actualRespose escalation.Policy
+	common.UnmarshalBody(rr.Result().Body, &actualRespose)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, expectedResponse, actualRespose)
+
+	mockEscalationService.AssertExpectations(t)
+}
+
+func testGetEscalationPolicyNegativeNotFound(t *testing.T) {
+	gId := "escalationNotFound"
+	expectedResponse := common.NewErrorResponseBuilder(common.ErrNotFound).
+		SetStatus(http.StatusNotFound).
+		SetMessage(fmt.Sprintf("No guild with id: %s found", gId)).
+		Get()
+
+	mockEscalationService.On("GetPolicy", gId).Return(escalation.Policy{}, common.ErrNotFound)
+
+	rr := httptest.NewRecorder()
+	req := newServiceRequest("GET", fmt.Sprintf("/escalation/%s", gId), nil)
+	r.ServeHTTP(rr, req)
+	defer rr.Result().Body.Close()
+
+	var actualRespose *common.ErrorResponse
+	common.UnmarshalBody(rr.Result().Body, &actualRespose)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, expectedResponse, actualRespose)
+
+	mockEscalationService.AssertExpectations(t)
+}
+
+func testUpdateEscalationPolicyPositive(t *testing.T) {
+	gId := "escalationUpdate"
+	sendedBody := escalation.PolicyUpdate{
+		Steps: []escalation.Step{
+			{Warns: 3, WindowSeconds: 604800, Action: infraction.Timeout, TimeoutSeconds: 3600},
+			{Warns: 5, WindowSeconds: 604800, Action: infraction.Kick},
+		},
+	}
+	expectedResponse := escalation.Policy{GuildId: gId, Steps: sendedBody.Steps}
+
+	mockEscalationService.On("UpdatePolicy", gId, sendedBody).Return(expectedResponse, nil)
+
+	var byf bytes.Buffer
+	json.NewEncoder(&byf).Encode(sendedBody)
+
+	rr := httptest.NewRecorder()
+	req := newServiceRequest("PUT", fmt.Sprintf("/escalation/%s", gId), &byf)
+	r.ServeHTTP(rr, req)
+	defer rr.Result().Body.Close()
+
+	var actualRespose escalation.Policy
+	common.UnmarshalBody(rr.Result().Body, &actualRespose)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, expectedResponse, actualRespose)
+
+	mockEscalationService.AssertExpectations(t)
+}
+
+func testUpdateEscalationPolicyNegativeValidation(t *testing.T) {
+	gId := "escalationValidation"
+	expectedResponse := common.NewErrorResponseBuilder(common.ErrValidation).
+		SetStatus(http.StatusBadRequest).
+		SetValidationFields(map[string]string{"timeoutSeconds": "required_if", "windowSeconds": "min"}).
+		Get()
+	sendedBody := escalation.PolicyUpdate{
+		Steps: []escalation.Step{{Warns: 3, WindowSeconds: 1, Action: infraction.Timeout}},
+	}
+
+	var byf bytes.Buffer
+	json.NewEncoder(&byf).Encode(sendedBody)
+
+	rr := httptest.NewRecorder()
+	req := newServiceRequest("PUT", fmt.Sprintf("/escalation/%s", gId), &byf)
+	r.ServeHTTP(rr, req)
+	defer rr.Result().Body.Close()
+
+	var actualRespose *common.ErrorResponse
+	common.UnmarshalBody(rr.Result().Body, &actualRespose)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, expectedResponse, actualRespose)
+
+	mockEscalationService.AssertNotCalled(t, "UpdatePolicy", gId, sendedBody)
+}
+
+func testUpdateEscalationPolicyNegativeDuplicateSteps(t *testing.T) {
+	gId := "escalationDuplicate"
+	expectedResponse := common.NewErrorResponseBuilder(escalation.ErrDuplicateSteps).
+		SetStatus(http.StatusBadRequest).
+		SetMessage("steps must have different warns").
+		Get()
+	sendedBody := escalation.PolicyUpdate{
+		Steps: []escalation.Step{
+			{Warns: 3, WindowSeconds: 60, Action: infraction.Kick},
+			{Warns: 3, WindowSeconds: 60, Action: infraction.Ban},
+		},
+	}
+
+	mockEscalationService.On("UpdatePolicy", gId, sendedBody).Return(escalation.Policy{}, escalation.ErrDuplicateSteps)
+
+	var byf bytes.Buffer
+	json.NewEncoder(&byf).Encode(sendedBody)
+
+	rr := httptest.NewRecorder()
+	req := newServiceRequest("PUT", fmt.Sprintf("/escalation/%s", gId), &byf)
+	r.ServeHTTP(rr, req)
+	defer rr.Result().Body.Close()
+
+	var actualRespose *common.ErrorResponse
+	common.UnmarshalBody(rr.Result().Body, &actualRespose)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, expectedResponse, actualRespose)
+
+	mockEscalationService.AssertExpectations(t)
+}
@@ -16,15 +16,17 @@ import (
 
 type GuildController struct {
 	service services.IGuildService
+	auth    *middleware.Auth
 	logger  logger.ILogger
 }
 
 var guildController *GuildController
 
-func NewGuildController(es services.IGuildService, l logger.ILogger) *GuildController {
+func NewGuildController(es services.IGuildService, auth *middleware.Auth, l logger.ILogger) *GuildController {
 	if guildController == nil {
 		guildController = &GuildController{
 			service: es,
+			auth:    auth,
 			logger:  l,
 		}
 	}
@@ -33,8 +35,13 @@ func NewGuildController(es services.IGuildService, l logger.ILogger) *GuildContr
 
 func (c *GuildController) RegisterRoutes(router *chi.Mux) {
 	router.Route("/guild", func(r chi.Router) {
-		r.With(middleware.ValidateJson[guild.GuildCreate]()).Post("/", c.postGuild)
-		r.Get("/{id}", c.getGuild)
+		r.Use(c.auth.Authenticate)
+		r.With(c.auth.RequireService, middleware.ValidateJson[guild.GuildCreate]()).Post("/", c.postGuild)
+		r.With(c.auth.RequireGuild(middleware.GuildIdParam("id"))).Get("/{id}", c.getGuild)
+		r.With(c.auth.RequireService, middleware.ValidateJson[guild.GuildUpdate]()).Patch("/{id}", c.patchGuild)
+		r.With(c.auth.RequireService).Delete("/{id}", c.deleteGuild)
+		r.With(c.auth.RequireGuild(middleware.GuildIdParam("id")), middleware.ValidateJson[guild.GuildExemptions]()).Patch("/{id}/exemptions", c.patchExemptions)
+		r.With(c.auth.RequireGuild(middleware.GuildIdParam("id")), middleware.ValidateJson[guild.GuildModLog]()).Patch("/{id}/modlog", c.patchModLog)
 	})
 }
 
@@ -91,3 +98,123 @@ func (ec *GuildController) getGuild(w http.ResponseWriter, r *http.Request) {
 		common.SendInternalError(w)
 	}
 }
+
+// patchGuild is called by the bot when the owner or metadata of the guild change.
+func (ec *GuildController) patchGuild(w http.ResponseWriter, r *http.Request) {
+	gId := chi.URLParam(r, "id")
+
+	u, ok := middleware.JsonFromContext(r.Context()).(guild.GuildUpdate)
+
+	if !ok {
+		logger.Error(errors.New("no guild update struct found in context"), map[string]any{"details": "error while getting guild update struct"})
+		common.SendInternalError(w)
+		return
+	}
+
+	g, err := ec.service.UpdateGuild(gId, u)
+
+	switch {
+	case err == common.ErrNotFound:
+		common.SendNotFoundError(w, fmt.Sprintf("No guild with id: %s found", gId))
+		return
+	case err == common.ErrInternal:
+		common.SendInternalError(w)
+		return
+	case err != nil:
+		common.SendInternalError(w, "Unexpected error at patchGuild")
+		return
+	}
+
+	if err := common.MarshalBody(w, http.StatusOK, &g); err != nil {
+		ec.logger.Error(err, map[string]any{"details": "Error while marshaling patchGuild response"})
+		common.SendInternalError(w)
+	}
+}
+
+// deleteGuild is called by the bot when it leaves the guild. Data of the guild is kept for the retention.
+func (ec *GuildController) deleteGuild(w http.ResponseWriter, r *http.Request) {
+	gId := chi.URLParam(r, "id")
+
+	err := ec.service.DeleteGuild(gId)
+
+	switch {
+	case err == common.ErrNotFound:
+		common.SendNotFoundError(w, fmt.Sprintf("No guild with id: %s found", gId))
+		return
+	case err == common.ErrInternal:
+		common.SendInternalError(w)
+		return
+	case err != nil:
+		common.SendInternalError(w, "Unexpected error at deleteGuild")
+		return
+	}
+
+	res := common.OkResponse{Message: fmt.Sprintf("successfully deleted guild %s", gId)}
+
+	if err := common.MarshalBody(w, http.StatusOK, &res); err != nil {
+		ec.logger.Error(err, map[string]any{"details": "Error while marshaling deleteGuild response"})
+		common.SendInternalError(w)
+	}
+}
+
+func (ec *GuildController) patchExemptions(w http.ResponseWriter, r *http.Request) {
+	gId := chi.URLParam(r, "id")
+
+	exemptions, ok := middleware.JsonFromContext(r.Context()).(guild.GuildExemptions)
+
+	if !ok {
+		logger.Error(errors.New("no guild exemptions struct found in context"), map[string]any{"details": "error while getting guild exemptions struct"})
+		common.SendInternalError(w)
+		return
+	}
+
+	g, err := ec.service.UpdateGuildExemptions(gId, exemptions)
+
+	switch {
+	case err == common.ErrNotFound:
+		common.SendNotFoundError(w, fmt.Sprintf("No guild with id: %s found", gId))
+		return
+	case err == common.ErrInternal:
+		common.SendInternalError(w)
+		return
+	case err != nil:
+		common.SendInternalError(w, "Unexpected error at patchExemptions")
+		return
+	}
+
+	if err := common.MarshalBody(w, http.StatusOK, &g); err != nil {
+		ec.logger.Error(err, map[string]any{"details": "Error while marshaling guild exemptions"})
+		common.SendInternalError(w)
+	}
+}
+
+func (ec *GuildController) patchModLog(w http.ResponseWriter, r *http.Request) {
+	gId := chi.URLParam(r, "id")
+
+	modLog, ok := middleware.JsonFromContext(r.Context()).(guild.GuildModLog)
+
+	if !ok {
+		logger.Error(errors.New("no guild mod log struct found in context"), map[string]any{"details": "error while getting guild mod log struct"})
+		common.SendInternalError(w)
+		return
+	}
+
+	g, err := ec.service.UpdateGuildModLog(gId, modLog)
+
+	switch {
+	case err == common.ErrNotFound:
+		common.SendNotFoundError(w, fmt.Sprintf("No guild with id: %s found", gId))
+		return
+	case err == common.ErrInternal:
+		common.SendInternalError(w)
+		return
+	case err != nil:
+		common.SendInternalError(w, "Unexpected error at patchModLog")
+		return
+	}
+
+	if err := common.MarshalBody(w, http.StatusOK, &g); err != nil {
+		ec.logger.Error(err, map[string]any{"details": "Error while marshaling guild mod log"})
+		common.SendInternalError(w)
+	}
+}
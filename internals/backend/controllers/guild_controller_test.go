@@ -19,7 +19,7 @@ import (
 
 var mockGuildService *mogs.MockGuildService = mogs.NewMockGuildService()
 var r *chi.Mux = chi.NewRouter()
-var ec *GuildController = NewGuildController(mockGuildService, mogs.NewMockLogger())
+var ec *GuildController = NewGuildController(mockGuildService, testAuth, mogs.NewMockLogger())
 
 func init() {
 	ec.RegisterRoutes(r)
@@ -39,6 +39,30 @@ func TestCreateGuild(t *testing.T) {
 	t.Run("NegativeConflict", testCreateGuildNegativeConflict)
 }
 
+func TestUpdateGuildExemptions(t *testing.T) {
+	t.Run("Positive", testUpdateGuildExemptionsPositive)
+	t.Run("NegativeNotFound", testUpdateGuildExemptionsNegativeNotFound)
+	t.Run("NegativeValidation", testUpdateGuildExemptionsNegativeValidation)
+}
+
+func TestUpdateGuildModLog(t *testing.T) {
+	t.Run("Positive", testUpdateGuildModLogPositive)
+	t.Run("NegativeNotFound", testUpdateGuildModLogNegativeNotFound)
+	t.Run("NegativeValidation", testUpdateGuildModLogNegativeValidation)
+}
+
+func TestUpdateGuild(t *testing.T) {
+	t.Run("Positive", testUpdateGuildPositive)
+	t.Run("NegativeNotFound", testUpdateGuildNegativeNotFound)
+	t.Run("NegativeValidation", testUpdateGuildNegativeValidation)
+}
+
+func TestDeleteGuild(t *testing.T) {
+	t.Run("Positive", testDeleteGuildPositive)
+	t.Run("NegativeNotFound", testDeleteGuildNegativeNotFound)
+	t.Run("NegativeInternalError", testDeleteGuildNegativeInternalError)
+}
+
 func testGetGuildPositive(t *testing.T) {
 	gId := "positive"
 	expectedResponse := guild.Guild{
@@ -49,7 +73,7 @@ func testGetGuildPositive(t *testing.T) {
 	mockGuildService.On("GetGuild", gId).Return(expectedResponse, nil)
 
 	rr := httptest.NewRecorder()
-	req := httptest.NewRequest("GET", fmt.Sprintf("/guild/%s", gId), nil)
+	req := newServiceRequest("GET", fmt.Sprintf("/guild/%s", gId), nil)
 	r.ServeHTTP(rr, req)
 	defer rr.Result().Body.Close()
 
@@ -72,7 +96,7 @@ func testGetGuildNegativeNotFound(t *testing.T) {
 	mockGuildService.On("GetGuild", gId).Return(guild.Guild{}, common.ErrNotFound)
 
 	rr := httptest.NewRecorder()
-	req := httptest.NewRequest("GET", fmt.Sprintf("/guild/%s", gId), nil)
+	req := newServiceRequest("GET", fmt.Sprintf("/guild/%s", gId), nil)
 	r.ServeHTTP(rr, req)
 	defer rr.Result().Body.Close()
 
@@ -96,7 +120,7 @@ func testGetGuildNegativeInternalError(t *testing.T) {
 	mockGuildService.On("GetGuild", gId).Return(guild.Guild{}, common.ErrInternal)
 
 	rr := httptest.NewRecorder()
-	req := httptest.NewRequest("GET", fmt.Sprintf("/guild/%s", gId), nil)
+	req := newServiceRequest("GET", fmt.Sprintf("/guild/%s", gId), nil)
 	r.ServeHTTP(rr, req)
 	defer rr.Result().Body.Close()
 
@@ -125,7 +149,7 @@ func testGetGuildNegativeWtf(t *testing.T) {
 	}, expectedError)
 
 	rr := httptest.NewRecorder()
-	req := httptest.NewRequest("GET", fmt.Sprintf("/guild/%s", gId), nil)
+	req := newServiceRequest("GET", fmt.Sprintf("/guild/%s", gId), nil)
 	r.ServeHTTP(rr, req)
 	defer rr.Result().Body.Close()
 
@@ -152,7 +176,7 @@ func testCreateGuildPositive(t *testing.T) {
 	json.NewEncoder(&byf).Encode(expectedResponse)
 
 	rr := httptest.NewRecorder()
-	req := httptest.NewRequest("POST", "/guild", &byf)
+	req := newServiceRequest("POST", "/guild", &byf)
 	r.ServeHTTP(rr, req)
 	defer rr.Result().Body.Close()
 
@@ -172,7 +196,7 @@ func testCreateGuildNegativeValidationNil(t *testing.T) {
 		Get()
 
 	rr := httptest.NewRecorder()
-	req := httptest.NewRequest("POST", "/guild", nil)
+	req := newServiceRequest("POST", "/guild", nil)
 	r.ServeHTTP(rr, req)
 	defer rr.Result().Body.Close()
 
@@ -197,7 +221,7 @@ func testCreateGuildNegativeValidation(t *testing.T) {
 	json.NewEncoder(&byf).Encode(sendedBody)
 
 	rr := httptest.NewRecorder()
-	req := httptest.NewRequest("POST", "/guild", &byf)
+	req := newServiceRequest("POST", "/guild", &byf)
 	r.ServeHTTP(rr, req)
 	defer rr.Result().Body.Close()
 
@@ -223,7 +247,7 @@ func testCreateGuildNegativeConflict(t *testing.T) {
 	json.NewEncoder(&byf).Encode(sendedBody)
 
 	rr := httptest.NewRecorder()
-	req := httptest.NewRequest("POST", "/guild", &byf)
+	req := newServiceRequest("POST", "/guild", &byf)
 
 	mockGuildService.On("CreateGuild", sendedBody).Return(guild.Guild{}, guild.ErrGuildConflict)
 
@@ -240,3 +264,331 @@ func testCreateGuildNegativeConflict(t *testing.T) {
 
 	mockGuildService.AssertExpectations(t)
 }
+
+func testUpdateGuildExemptionsPositive(t *testing.T) {
+	gId := "exemptionsPositive"
+	exemptAdmins := false
+	sendedBody := guild.GuildExemptions{ExemptRoles: []string{"123"}, ExemptAdmins: &exemptAdmins}
+	expectedResponse := guild.Guild{
+		GuildId:      gId,
+		OwnerId:      "owner",
+		ExemptRoles:  []string{"123"},
+		ExemptAdmins: false,
+	}
+
+	mockGuildService.On("UpdateGuildExemptions", gId, sendedBody).Return(expectedResponse, nil)
+
+	var byf bytes.Buffer
+	json.NewEncoder(&byf).Encode(sendedBody)
+
+	rr := httptest.NewRecorder()
+	req := newServiceRequest("PATCH", fmt.Sprintf("/guild/%s/exemptions", gId), &byf)
+	r.ServeHTTP(rr, req)
+	defer rr.Result().Body.Close()
+
+	var actualRespose guild.Guild
+	common.UnmarshalBody(rr.Result().Body, &actualRespose)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, expectedResponse, actualRespose)
+
+	mockGuildService.AssertExpectations(t)
+}
+
+func testUpdateGuildExemptionsNegativeNotFound(t *testing.T) {
+	gId := "exemptionsNotFound"
+	exemptAdmins := true
+	sendedBody := guild.GuildExemptions{ExemptAdmins: &exemptAdmins}
+	expectedResponse := common.NewErrorResponseBuilder(common.ErrNotFound).
+		SetStatus(http.StatusNotFound).
+		SetMessage(fmt.Sprintf("No guild with id: %s found", gId)).
+		Get()
+
+	mockGuildService.On("UpdateGuildExemptions", gId, sendedBody).Return(guild.Guild{}, common.ErrNotFound)
+
+	var byf bytes.Buffer
+	json.NewEncoder(&byf).Encode(sendedBody)
+
+	rr := httptest.NewRecorder()
+	req := newServiceRequest("PATCH", fmt.Sprintf("/guild/%s/exemptions", gId), &byf)
+	r.ServeHTTP(rr, req)
+	defer rr.Result().Body.Close()
+
+	var actualRespose *common.ErrorResponse
+	if err := common.UnmarshalBody(rr.Result().Body, &actualRespose); err != nil {
+		fmt.Println(err)
+	}
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, expectedResponse, actualRespose)
+
+	mockGuildService.AssertExpectations(t)
+}
+
+func testUpdateGuildExemptionsNegativeValidation(t *testing.T) {
+	expectedResponse := common.NewErrorResponseBuilder(common.ErrValidation).
+		SetStatus(http.StatusBadRequest).
+		SetValidationFields(map[string]string{"exemptAdmins": "required"}).
+		Get()
+	sendedBody := guild.GuildExemptions{ExemptRoles: []string{"123"}}
+
+	var byf bytes.Buffer
+	json.NewEncoder(&byf).Encode(sendedBody)
+
+	rr := httptest.NewRecorder()
+	req := newServiceRequest("PATCH", "/guild/exemptionsValidation/exemptions", &byf)
+	r.ServeHTTP(rr, req)
+	defer rr.Result().Body.Close()
+
+	var actualRespose *common.ErrorResponse
+	if err := common.UnmarshalBody(rr.Result().Body, &actualRespose); err != nil {
+		fmt.Println(err)
+	}
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, expectedResponse, actualRespose)
+
+	mockGuildService.AssertNotCalled(t, "UpdateGuildExemptions")
+}
+
+func testUpdateGuildPositive(t *testing.T) {
+	gId := "updatePositive"
+	ownerId := "new owner"
+	name := "name"
+	sendedBody := guild.GuildUpdate{OwnerId: &ownerId, Name: &name}
+	expectedResponse := guild.Guild{
+		GuildId:      gId,
+		OwnerId:      ownerId,
+		ExemptRoles:  []string{},
+		ExemptAdmins: true,
+		Name:         name,
+	}
+
+	mockGuildService.On("UpdateGuild", gId, sendedBody).Return(expectedResponse, nil)
+
+	var byf bytes.Buffer
+	json.NewEncoder(&byf).Encode(sendedBody)
+
+	rr := httptest.NewRecorder()
+	req := newServiceRequest("PATCH", fmt.Sprintf("/guild/%s", gId), &byf)
+	r.ServeHTTP(rr, req)
+	defer rr.Result().Body.Close()
+
+	var actualRespose guild.Guild
+	common.UnmarshalBody(rr.Result().Body, &actualRespose)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, expectedResponse, actualRespose)
+
+	mockGuildService.AssertExpectations(t)
+}
+
+func testUpdateGuildNegativeNotFound(t *testing.T) {
+	gId := "updateNotFound"
+	name := "name"
+	sendedBody := guild.GuildUpdate{Name: &name}
+	expectedResponse := common.NewErrorResponseBuilder(common.ErrNotFound).
+		SetStatus(http.StatusNotFound).
+		SetMessage(fmt.Sprintf("No guild with id: %s found", gId)).
+		Get()
+
+	mockGuildService.On("UpdateGuild", gId, sendedBody).Return(guild.Guild{}, common.ErrNotFound)
+
+	var byf bytes.Buffer
+	json.NewEncoder(&byf).Encode(sendedBody)
+
+	rr := httptest.NewRecorder()
+	req := newServiceRequest("PATCH", fmt.Sprintf("/guild/%s", gId), &byf)
+	r.ServeHTTP(rr, req)
+	defer rr.Result().Body.Close()
+
+	var actualRespose *common.ErrorResponse
+	if err := common.UnmarshalBody(rr.Result().Body, &actualRespose); err != nil {
+		fmt.Println(err)
+	}
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, expectedResponse, actualRespose)
+
+	mockGuildService.AssertExpectations(t)
+}
+
+func testUpdateGuildNegativeValidation(t *testing.T) {
+	ownerId := ""
+	memberCount := -1
+	expectedResponse := common.NewErrorResponseBuilder(common.ErrValidation).
+		SetStatus(http.StatusBadRequest).
+		SetValidationFields(map[string]string{"ownerId": "min", "memberCount": "min"}).
+		Get()
+	sendedBody := guild.GuildUpdate{OwnerId: &ownerId, MemberCount: &memberCount}
+
+	var byf bytes.Buffer
+	json.NewEncoder(&byf).Encode(sendedBody)
+
+	rr := httptest.NewRecorder()
+	req := newServiceRequest("PATCH", "/guild/updateValidation", &byf)
+	r.ServeHTTP(rr, req)
+	defer rr.Result().Body.Close()
+
+	var actualRespose *common.ErrorResponse
+	if err := common.UnmarshalBody(rr.Result().Body, &actualRespose); err != nil {
+		fmt.Println(err)
+	}
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, expectedResponse, actualRespose)
+
+	mockGuildService.AssertNotCalled(t, "UpdateGuild", "updateValidation", sendedBody)
+}
+
+func testDeleteGuildPositive(t *testing.T) {
+	gId := "deletePositive"
+	expectedResponse := common.OkResponse{Message: fmt.Sprintf("successfully deleted guild %s", gId)}
+
+	mockGuildService.On("DeleteGuild", gId).Return(nil)
+
+	rr := httptest.NewRecorder()
+	req := newServiceRequest("DELETE", fmt.Sprintf("/guild/%s", gId), nil)
+	r.ServeHTTP(rr, req)
+	defer rr.Result().Body.Close()
+
+	var actualRespose common.OkResponse
+	common.UnmarshalBody(rr.Result().Body, &actualRespose)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, expectedResponse, actualRespose)
+
+	mockGuildService.AssertExpectations(t)
+}
+
+func testDeleteGuildNegativeNotFound(t *testing.T) {
+	gId := "deleteNegativeNotFound"
+	expectedResponse := common.NewErrorResponseBuilder(common.ErrNotFound).
+		SetStatus(http.StatusNotFound).
+		SetMessage(fmt.Sprintf("No guild with id: %s found", gId)).
+		Get()
+
+	mockGuildService.On("DeleteGuild", gId).Return(common.ErrNotFound)
+
+	rr := httptest.NewRecorder()
+	req := newServiceRequest("DELETE", fmt.Sprintf("/guild/%s", gId), nil)
+	r.ServeHTTP(rr, req)
+	defer rr.Result().Body.Close()
+
+	var actualRespose *common.ErrorResponse
+	if err := common.UnmarshalBody(rr.Result().Body, &actualRespose); err != nil {
+		fmt.Println(err)
+	}
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, expectedResponse, actualRespose)
+
+	mockGuildService.AssertExpectations(t)
+}
+
+func testDeleteGuildNegativeInternalError(t *testing.T) {
+	gId := "deleteNegativeInternalError"
+	expectedResponse := common.NewErrorResponseBuilder(common.ErrInternal).
+		SetStatus(http.StatusInternalServerError).
+		Get()
+
+	mockGuildService.On("DeleteGuild", gId).Return(common.ErrInternal)
+
+	rr := httptest.NewRecorder()
+	req := newServiceRequest("DELETE", fmt.Sprintf("/guild/%s", gId), nil)
+	r.ServeHTTP(rr, req)
+	defer rr.Result().Body.Close()
+
+	var actualRespose *common.ErrorResponse
+	if err := common.UnmarshalBody(rr.Result().Body, &actualRespose); err != nil {
+		fmt.Println(err)
+	}
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.Equal(t, expectedResponse, actualRespose)
+
+	mockGuildService.AssertExpectations(t)
+}
+
+func testUpdateGuildModLogPositive(t *testing.T) {
+	gId := "modLogPositive"
+	sendedBody := guild.GuildModLog{ChannelId: "123"}
+	expectedResponse := guild.Guild{
+		GuildId:         gId,
+		OwnerId:         "owner",
+		ModLogChannelId: "123",
+	}
+
+	mockGuildService.On("UpdateGuildModLog", gId, sendedBody).Return(expectedResponse, nil)
+
+	var byf bytes.Buffer
+	json.NewEncoder(&byf).Encode(sendedBody)
+
+	rr := httptest.NewRecorder()
+	req := newServiceRequest("PATCH", fmt.Sprintf("/guild/%s/modlog", gId), &byf)
+	r.ServeHTTP(rr, req)
+	defer rr.Result().Body.Close()
+
+	var actualRespose guild.Guild
+	common.UnmarshalBody(rr.Result().Body, &actualRespose)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, expectedResponse, actualRespose)
+
+	mockGuildService.AssertExpectations(t)
+}
+
+func testUpdateGuildModLogNegativeNotFound(t *testing.T) {
+	gId := "modLogNotFound"
+	sendedBody := guild.GuildModLog{}
+	expectedResponse := common.NewErrorResponseBuilder(common.ErrNotFound).
+		SetStatus(http.StatusNotFound).
+		SetMessage(fmt.Sprintf("No guild with id: %s found", gId)).
+		Get()
+
+	mockGuildService.On("UpdateGuildModLog", gId, sendedBody).Return(guild.Guild{}, common.ErrNotFound)
+
+	var byf bytes.Buffer
+	json.NewEncoder(&byf).Encode(sendedBody)
+
+	rr := httptest.NewRecorder()
+	req := newServiceRequest("PATCH", fmt.Sprintf("/guild/%s/modlog", gId), &byf)
+	r.ServeHTTP(rr, req)
+	defer rr.Result().Body.Close()
+
+	var actualRespose *common.ErrorResponse
+	if err := common.UnmarshalBody(rr.Result().Body, &actualRespose); err != nil {
+		fmt.Println(err)
+	}
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, expectedResponse, actualRespose)
+
+	mockGuildService.AssertExpectations(t)
+}
+
+func testUpdateGuildModLogNegativeValidation(t *testing.T) {
+	expectedResponse := common.NewErrorResponseBuilder(common.ErrValidation).
+		SetStatus(http.StatusBadRequest).
+		SetValidationFields(map[string]string{"channelId": "numeric"}).
+		Get()
+	sendedBody := guild.GuildModLog{ChannelId: "general"}
+
+	var byf bytes.Buffer
+	json.NewEncoder(&byf).Encode(sendedBody)
+
+	rr := httptest.NewRecorder()
+	req := newServiceRequest("PATCH", "/guild/modLogValidation/modlog", &byf)
+	r.ServeHTTP(rr, req)
+	defer rr.Result().Body.Close()
+
+	var actualRespose *common.ErrorResponse
+	if err := common.UnmarshalBody(rr.Result().Body, &actualRespose); err != nil {
+		fmt.Println(err)
+	}
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, expectedResponse, actualRespose)
+
+	mockGuildService.AssertNotCalled(t, "UpdateGuildModLog")
+}
@@ -0,0 +1,235 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/finkabaj/hyde-bot/internals/backend/middleware"
+	"github.com/finkabaj/hyde-bot/internals/backend/services"
+	"github.com/finkabaj/hyde-bot/internals/logger"
+	"github.com/finkabaj/hyde-bot/internals/utils/common"
+	"github.com/finkabaj/hyde-bot/internals/utils/infraction"
+)
+
+type InfractionsController struct {
+	service services.IInfractionService
+	auth    *middleware.Auth
+	logger  logger.ILogger
+}
+
+var infractionsController *InfractionsController
+
+func NewInfractionsController(s services.IInfractionService, auth *middleware.Auth, l logger.ILogger) *InfractionsController {
+	if infractionsController == nil {
+		infractionsController = &InfractionsController{
+			service: s,
+			auth:    auth,
+			logger:  l,
+		}
+	}
+	return infractionsController
+}
+
+func (c *InfractionsController) RegisterRoutes(router *chi.Mux) {
+	requireGuild := c.auth.RequireGuild(middleware.GuildIdParam("id"))
+
+	router.Route("/infractions", func(r chi.Router) {
+		r.Use(c.auth.Authenticate)
+		r.With(middleware.ValidateJson[infraction.InfractionCreate](), c.auth.RequireGuild(infractionGuildId)).Post("/", c.postInfraction)
+		r.With(requireGuild, middleware.ValidateQuery(infraction.DecodeInfractionQuery)).Get("/{id}", c.getInfractions)
+		r.With(requireGuild).Get("/{id}/{infractionId}", c.getInfraction)
+		r.With(requireGuild, middleware.ValidateJson[infraction.InfractionUpdate]()).Patch("/{id}/{infractionId}", c.patchInfraction)
+		r.With(requireGuild).Delete("/{id}/{infractionId}", c.deleteInfraction)
+	})
+}
+
+// infractionGuildId returns the guild of the infraction in the validated body.
+func infractionGuildId(r *http.Request) []string {
+	i, _ := middleware.JsonFromContext(r.Context()).(infraction.InfractionCreate)
+	return []string{i.GuildId}
+}
+
+// infractionIdParam returns the infraction id from the url or writes a bad request response.
+func infractionIdParam(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "infractionId"), 10, 64)
+
+	if err != nil || id < 1 {
+		common.NewErrorResponseBuilder(infraction.ErrInvalidId).
+			SetStatus(http.StatusBadRequest).
+			SetMessage("infraction id must be a positive number").
+			Send(w)
+		return 0, false
+	}
+
+	return id, true
+}
+
+// postInfraction records an infraction. Only the bot can record infractions of reaction rules and backdate
+// infractions it queued, infractions recorded by users are issued by them now.
+func (c *InfractionsController) postInfraction(w http.ResponseWriter, r *http.Request) {
+	i, ok := middleware.JsonFromContext(r.Context()).(infraction.InfractionCreate)
+
+	if !ok {
+		logger.Error(errors.New("no infraction create struct found in context"), map[string]any{"details": "error while getting infraction create struct"})
+		common.SendInternalError(w)
+		return
+	}
+
+	if p, _ := middleware.PrincipalFromContext(r.Context()); !p.Service {
+		i.Source = infraction.SourceModerator
+		i.ModeratorId = p.UserId
+		i.Step = ""
+		i.CreatedAt = time.Time{}
+	}
+
+	created, err := c.service.CreateInfraction(i)
+
+	switch {
+	case err == common.ErrNotFound:
+		common.SendNotFoundError(w, fmt.Sprintf("No guild with id: %s found", i.GuildId))
+		return
+	case err == common.ErrInternal:
+		common.SendInternalError(w)
+		return
+	case err != nil:
+		common.SendInternalError(w, "Unexpected error at postInfraction")
+		return
+	}
+
+	if err := common.MarshalBody(w, http.StatusCreated, &created); err != nil {
+		c.logger.Error(err, map[string]any{"details": "Error while marshaling postInfraction response"})
+		common.SendInternalError(w)
+	}
+}
+
+func (c *InfractionsController) getInfractions(w http.ResponseWriter, r *http.Request) {
+	gId := chi.URLParam(r, "id")
+
+	query, ok := middleware.QueryFromContext(r.Context()).(infraction.InfractionQuery)
+
+	if !ok {
+		c.logger.Error(common.ErrInternal, map[string]any{"details": "no value found in context"})
+		common.SendInternalError(w)
+		return
+	}
+
+	query.GuildId = gId
+
+	page, err := c.service.GetInfractions(query)
+
+	switch {
+	case err == common.ErrNotFound:
+		common.SendNotFoundError(w, fmt.Sprintf("No guild with id: %s found", gId))
+		return
+	case err == common.ErrInternal:
+		common.SendInternalError(w)
+		return
+	case err != nil:
+		common.SendInternalError(w, "Unexpected error at getInfractions")
+		return
+	}
+
+	if err := common.MarshalBody(w, http.StatusOK, &page); err != nil {
+		c.logger.Error(err, map[string]any{"details": "Error while marshaling getInfractions response"})
+		common.SendInternalError(w)
+	}
+}
+
+func (c *InfractionsController) getInfraction(w http.ResponseWriter, r *http.Request) {
+	gId := chi.URLParam(r, "id")
+	id, ok := infractionIdParam(w, r)
+
+	if !ok {
+		return
+	}
+
+	i, err := c.service.GetInfraction(gId, id)
+
+	switch {
+	case err == common.ErrNotFound:
+		common.SendNotFoundError(w, fmt.Sprintf("No infraction with id: %d found", id))
+		return
+	case err == common.ErrInternal:
+		common.SendInternalError(w)
+		return
+	case err != nil:
+		common.SendInternalError(w, "Unexpected error at getInfraction")
+		return
+	}
+
+	if err := common.MarshalBody(w, http.StatusOK, &i); err != nil {
+		c.logger.Error(err, map[string]any{"details": "Error while marshaling getInfraction response"})
+		common.SendInternalError(w)
+	}
+}
+
+func (c *InfractionsController) patchInfraction(w http.ResponseWriter, r *http.Request) {
+	gId := chi.URLParam(r, "id")
+	id, ok := infractionIdParam(w, r)
+
+	if !ok {
+		return
+	}
+
+	u, ok := middleware.JsonFromContext(r.Context()).(infraction.InfractionUpdate)
+
+	if !ok {
+		logger.Error(errors.New("no infraction update struct found in context"), map[string]any{"details": "error while getting infraction update struct"})
+		common.SendInternalError(w)
+		return
+	}
+
+	i, err := c.service.UpdateInfraction(gId, id, u)
+
+	switch {
+	case err == common.ErrNotFound:
+		common.SendNotFoundError(w, fmt.Sprintf("No infraction with id: %d found", id))
+		return
+	case err == common.ErrInternal:
+		common.SendInternalError(w)
+		return
+	case err != nil:
+		common.SendInternalError(w, "Unexpected error at patchInfraction")
+		return
+	}
+
+	if err := common.MarshalBody(w, http.StatusOK, &i); err != nil {
+		c.logger.Error(err, map[string]any{"details": "Error while marshaling patchInfraction response"})
+		common.SendInternalError(w)
+	}
+}
+
+func (c *InfractionsController) deleteInfraction(w http.ResponseWriter, r *http.Request) {
+	gId := chi.URLParam(r, "id")
+	id, ok := infractionIdParam(w, r)
+
+	if !ok {
+		return
+	}
+
+	err := c.service.DeleteInfraction(gId, id)
+
+	switch {
+	case err == common.ErrNotFound:
+		common.SendNotFoundError(w, fmt.Sprintf("No infraction with id: %d found", id))
+		return
+	case err == common.ErrInternal:
+		common.SendInternalError(w)
+		return
+	case err != nil:
+		common.SendInternalError(w, "Unexpected error at deleteInfraction")
+		return
+	}
+
+	res := common.OkResponse{Message: fmt.Sprintf("successfully deleted infraction %d", id)}
+
+	if err := common.MarshalBody(w, http.StatusOK, &res); err != nil {
+		c.logger.Error(err, map[string]any{"details": "Error while marshaling deleteInfraction response"})
+		common.SendInternalError(w)
+	}
+}
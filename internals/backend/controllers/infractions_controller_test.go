@@ -0,0 +1,269 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	mogs "github.com/finkabaj/hyde-bot/internals/backend/mocks"
+	"github.com/finkabaj/hyde-bot/internals/utils/common"
+	"github.com/finkabaj/hyde-bot/internals/utils/infraction"
+	"github.com/stretchr/testify/assert"
+)
+
+var mockInfractionService *mogs.MockInfractionService = mogs.NewMockInfractionService()
+var ic *InfractionsController = NewInfractionsController(mockInfractionService, testAuth, mogs.NewMockLogger())
+
+func init() {
+	ic.RegisterRoutes(r)
+}
+
+func TestCreateInfraction(t *testing.T) {
+	t.Run("Positive", testCreateInfractionPositive)
+	t.Run("NegativeValidation", testCreateInfractionNegativeValidation)
+	t.Run("NegativeNotFound", testCreateInfractionNegativeNotFound)
+}
+
+func TestGetInfractions(t *testing.T) {
+	t.Run("Positive", testGetInfractionsPositive)
+	t.Run("NegativeValidation", testGetInfractionsNegativeValidation)
+}
+
+func TestUpdateInfraction(t *testing.T) {
+	t.Run("Positive", testUpdateInfractionPositive)
+	t.Run("NegativeInvalidId", testUpdateInfractionNegativeInvalidId)
+}
+
+func TestDeleteInfraction(t *testing.T) {
+	t.Run("Positive", testDeleteInfractionPositive)
+	t.Run("NegativeNotFound", testDeleteInfractionNegativeNotFound)
+}
+
+func testCreateInfractionPositive(t *testing.T) {
+	sendedBody := infraction.InfractionCreate{
+		GuildId:   "infractionCreate",
+		UserId:    "user",
+		Source:    infraction.SourceRule,
+		Action:    infraction.Ban,
+		EmojiName: "🤰",
+	}
+	expectedResponse := infraction.Infraction{
+		Id:        1,
+		GuildId:   "infractionCreate",
+		UserId:    "user",
+		Source:    infraction.SourceRule,
+		Action:    infraction.Ban,
+		EmojiName: "🤰",
+	}
+
+	mockInfractionService.On("CreateInfraction", sendedBody).Return(expectedResponse, nil)
+
+	var byf bytes.Buffer
+	json.NewEncoder(&byf).Encode(sendedBody)
+
+	rr := httptest.NewRecorder()
+	req := newServiceRequest("POST", "/infractions", &byf)
+	r.ServeHTTP(rr, req)
+	defer rr.Result().Body.Close()
+
+	var actualRespose infraction.Infraction
+	common.UnmarshalBody(rr.Result().Body, &actualRespose)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	assert.Equal(t, expectedResponse, actualRespose)
+
+	mockInfractionService.AssertExpectations(t)
+}
+
+func testCreateInfractionNegativeValidation(t *testing.T) {
+	expectedResponse := common.NewErrorResponseBuilder(common.ErrValidation).
+		SetStatus(http.StatusBadRequest).
+		SetValidationFields(map[string]string{"moderatorId": "required_if", "action": "oneof"}).
+		Get()
+	sendedBody := infraction.InfractionCreate{
+		GuildId: "infractionValidation",
+		UserId:  "user",
+		Source:  infraction.SourceModerator,
+		Action:  "mute",
+	}
+
+	var byf bytes.Buffer
+	json.NewEncoder(&byf).Encode(sendedBody)
+
+	rr := httptest.NewRecorder()
+	req := newServiceRequest("POST", "/infractions", &byf)
+	r.ServeHTTP(rr, req)
+	defer rr.Result().Body.Close()
+
+	var actualRespose *common.ErrorResponse
+	common.UnmarshalBody(rr.Result().Body, &actualRespose)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, expectedResponse, actualRespose)
+
+	mockInfractionService.AssertNotCalled(t, "CreateInfraction", sendedBody)
+}
+
+func testCreateInfractionNegativeNotFound(t *testing.T) {
+	sendedBody := infraction.InfractionCreate{
+		GuildId: "infractionNotFound",
+		UserId:  "user",
+		Source:  infraction.SourceRule,
+		Action:  infraction.Warn,
+	}
+	expectedResponse := common.NewErrorResponseBuilder(common.ErrNotFound).
+		SetStatus(http.StatusNotFound).
+		SetMessage(fmt.Sprintf("No guild with id: %s found", sendedBody.GuildId)).
+		Get()
+
+	mockInfractionService.On("CreateInfraction", sendedBody).Return(infraction.Infraction{}, common.ErrNotFound)
+
+	var byf bytes.Buffer
+	json.NewEncoder(&byf).Encode(sendedBody)
+
+	rr := httptest.NewRecorder()
+	req := newServiceRequest("POST", "/infractions", &byf)
+	r.ServeHTTP(rr, req)
+	defer rr.Result().Body.Close()
+
+	var actualRespose *common.ErrorResponse
+	common.UnmarshalBody(rr.Result().Body, &actualRespose)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, expectedResponse, actualRespose)
+
+	mockInfractionService.AssertExpectations(t)
+}
+
+func testGetInfractionsPositive(t *testing.T) {
+	gId := "infractionsPositive"
+	expectedResponse := infraction.InfractionPage{
+		Infractions: []infraction.Infraction{{Id: 3, GuildId: gId, UserId: "user", Action: infraction.Kick}},
+		Total:       4,
+	}
+
+	mockInfractionService.On("GetInfractions", infraction.InfractionQuery{GuildId: gId, UserId: "user", Limit: 1, Offset: 1}).
+		Return(expectedResponse, nil)
+
+	rr := httptest.NewRecorder()
+	req := newServiceRequest("GET", fmt.Sprintf("/infractions/%s?userId=user&limit=1&offset=1", gId), nil)
+	r.ServeHTTP(rr, req)
+	defer rr.Result().Body.Close()
+
+	var actualRespose infraction.InfractionPage
+	common.UnmarshalBody(rr.Result().Body, &actualRespose)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, expectedResponse, actualRespose)
+
+	mockInfractionService.AssertExpectations(t)
+}
+
+func testGetInfractionsNegativeValidation(t *testing.T) {
+	expectedResponse := common.NewErrorResponseBuilder(common.ErrValidation).
+		SetStatus(http.StatusBadRequest).
+		SetValidationFields(map[string]string{"limit": "max", "offset": "min"}).
+		Get()
+
+	rr := httptest.NewRecorder()
+	req := newServiceRequest("GET", "/infractions/infractionsValidation?limit=1000&offset=abc", nil)
+	r.ServeHTTP(rr, req)
+	defer rr.Result().Body.Close()
+
+	var actualRespose *common.ErrorResponse
+	common.UnmarshalBody(rr.Result().Body, &actualRespose)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, expectedResponse, actualRespose)
+}
+
+func testUpdateInfractionPositive(t *testing.T) {
+	gId := "infractionUpdate"
+	sendedBody := infraction.InfractionUpdate{Reason: "appealed"}
+	expectedResponse := infraction.Infraction{Id: 5, GuildId: gId, UserId: "user", Action: infraction.Warn, Reason: "appealed"}
+
+	mockInfractionService.On("UpdateInfraction", gId, int64(5), sendedBody).Return(expectedResponse, nil)
+
+	var byf bytes.Buffer
+	json.NewEncoder(&byf).Encode(sendedBody)
+
+	rr := httptest.NewRecorder()
+	req := newServiceRequest("PATCH", fmt.Sprintf("/infractions/%s/5", gId), &byf)
+	r.ServeHTTP(rr, req)
+	defer rr.Result().Body.Close()
+
+	var actualRespose infraction.Infraction
+	common.UnmarshalBody(rr.Result().Body, &actualRespose)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, expectedResponse, actualRespose)
+
+	mockInfractionService.AssertExpectations(t)
+}
+
+func testUpdateInfractionNegativeInvalidId(t *testing.T) {
+	expectedResponse := common.NewErrorResponseBuilder(infraction.ErrInvalidId).
+		SetStatus(http.StatusBadRequest).
+		SetMessage("infraction id must be a positive number").
+		Get()
+
+	var byf bytes.Buffer
+	json.NewEncoder(&byf).Encode(infraction.InfractionUpdate{Reason: "appealed"})
+
+	rr := httptest.NewRecorder()
+	req := newServiceRequest("PATCH", "/infractions/infractionInvalidId/abc", &byf)
+	r.ServeHTTP(rr, req)
+	defer rr.Result().Body.Close()
+
+	var actualRespose *common.ErrorResponse
+	common.UnmarshalBody(rr.Result().Body, &actualRespose)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, expectedResponse, actualRespose)
+}
+
+func testDeleteInfractionPositive(t *testing.T) {
+	gId := "infractionDelete"
+	expectedResponse := common.OkResponse{Message: "successfully deleted infraction 7"}
+
+	mockInfractionService.On("DeleteInfraction", gId, int64(7)).Return(nil)
+
+	rr := httptest.NewRecorder()
+	req := newServiceRequest("DELETE", fmt.Sprintf("/infractions/%s/7", gId), nil)
+	r.ServeHTTP(rr, req)
+	defer rr.Result().Body.Close()
+
+	var actualRespose common.OkResponse
+	common.UnmarshalBody(rr.Result().Body, &actualRespose)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, expectedResponse, actualRespose)
+
+	mockInfractionService.AssertExpectations(t)
+}
+
+func testDeleteInfractionNegativeNotFound(t *testing.T) {
+	gId := "infractionDeleteNotFound"
+	expectedResponse := common.NewErrorResponseBuilder(common.ErrNotFound).
+		SetStatus(http.StatusNotFound).
+		SetMessage("No infraction with id: 8 found").
+		Get()
+
+	mockInfractionService.On("DeleteInfraction", gId, int64(8)).Return(common.ErrNotFound)
+
+	rr := httptest.NewRecorder()
+	req := newServiceRequest("DELETE", fmt.Sprintf("/infractions/%s/8", gId), nil)
+	r.ServeHTTP(rr, req)
+	defer rr.Result().Body.Close()
+
+	var actualRespose *common.ErrorResponse
+	common.UnmarshalBody(rr.Result().Body, &actualRespose)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, expectedResponse, actualRespose)
+
+	mockInfractionService.AssertExpectations(t)
+}
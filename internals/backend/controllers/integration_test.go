@@ -0,0 +1,357 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/finkabaj/hyde-bot/internals/backend/middleware"
+	mogs "github.com/finkabaj/hyde-bot/internals/backend/mocks"
+	"github.com/finkabaj/hyde-bot/internals/backend/services"
+	"github.com/finkabaj/hyde-bot/internals/db/memory"
+	"github.com/finkabaj/hyde-bot/internals/oauth2"
+	"github.com/finkabaj/hyde-bot/internals/utils/common"
+	"github.com/finkabaj/hyde-bot/internals/utils/guild"
+	"github.com/finkabaj/hyde-bot/internals/utils/infraction"
+	"github.com/finkabaj/hyde-bot/internals/utils/rule"
+	"github.com/finkabaj/hyde-bot/internals/utils/user"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDiscord is a local oauth2 token endpoint and api. Every code logs in as the user "manager",
+// who has Manage Guild permission in the guilds "integrationAuth" and "integrationRefresh".
+// After a refresh the permission in "integrationRefresh" is gone.
+var fakeDiscord = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/oauth2/token":
+		if r.FormValue("grant_type") == "refresh_token" {
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "refreshed", TokenType: "Bearer", RefreshToken: "refresh"})
+			return
+		}
+
+		json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "access", TokenType: "Bearer", RefreshToken: "refresh"})
+	case "/api/users/@me":
+		json.NewEncoder(w).Encode(oauth2.DiscordUser{Id: "manager", Username: "manager"})
+	case "/api/users/@me/guilds":
+		if r.Header.Get("Authorization") == "Bearer refreshed" {
+			w.Write([]byte(`[{"id": "integrationAuth", "permissions": "32"}, {"id": "integrationAuthOther", "permissions": "0"}, {"id": "integrationRefresh", "permissions": "0"}]`))
+			return
+		}
+
+		w.Write([]byte(`[{"id": "integrationAuth", "permissions": "32"}, {"id": "integrationAuthOther", "permissions": "0"}, {"id": "integrationRefresh", "permissions": "32"}]`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}))
+
+// integrationRouter serves real services over the in-memory database. Services are singletons,
+// so it's built once and tests use their own guild ids. Controllers are built directly,
+// because their constructors return singletons shared with the mock based tests.
+var integrationRouter = newIntegrationRouter()
+
+func newIntegrationRouter() *chi.Mux {
+	router := chi.NewRouter()
+	database := memory.NewMemory()
+	l := mogs.NewMockLogger()
+	broker := services.NewRuleEventBroker(l)
+
+	oauthClient := oauth2.NewClient(oauth2.Config{
+		TokenURL: fakeDiscord.URL + "/oauth2/token",
+		APIURL:   fakeDiscord.URL + "/api",
+	}, fakeDiscord.Client())
+
+	authService := services.NewAuthService(l, database, oauthClient, services.AuthConfig{Secret: []byte("secret")})
+	auth := middleware.NewAuth(authService, authService, testServiceToken)
+	guildService := services.NewGuildService(l, database, broker, 0)
+	reactionService := services.NewReactionService(l, database, guildService, broker)
+	infractionService := services.NewInfractionService(l, database, guildService)
+
+	(&AuthController{service: authService, logger: l}).RegisterRoutes(router)
+	(&GuildController{service: guildService, auth: auth, logger: l}).RegisterRoutes(router)
+	(&RulesController{reactionService: reactionService, events: broker, auth: auth, logger: l}).RegisterRoutes(router)
+	(&InfractionsController{service: infractionService, auth: auth, logger: l}).RegisterRoutes(router)
+
+	return router
+}
+
+// doJson sends the request as the bot.
+func doJson(t *testing.T, router *chi.Mux, method, url string, body any, out any) int {
+	return doJsonAs(t, router, common.ServiceAuthScheme+" "+testServiceToken, method, url, body, out)
+}
+
+// doJsonAs sends the request with the authorization header, empty authorization sends no header.
+func doJsonAs(t *testing.T, router *chi.Mux, authorization, method, url string, body any, out any) int {
+	var byf bytes.Buffer
+
+	if body != nil {
+		require.NoError(t, json.NewEncoder(&byf).Encode(body))
+	}
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(method, url, &byf)
+
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	router.ServeHTTP(rr, req)
+	defer rr.Result().Body.Close()
+
+	if out != nil {
+		require.NoError(t, common.UnmarshalBody(rr.Result().Body, out))
+	}
+
+	return rr.Code
+}
+
+func TestIntegrationReactionRules(t *testing.T) {
+	router := integrationRouter
+	gId := "integration"
+
+	smile := rule.ReactionRule{
+		EmojiName:  "smile",
+		GuildId:    gId,
+		RuleAuthor: "author",
+		Actions:    rule.Actions{{Type: rule.Delete}},
+	}
+
+	var errRes common.ErrorResponse
+
+	code := doJson(t, router, "POST", "/rules/reaction", []rule.ReactionRule{smile}, &errRes)
+	assert.Equal(t, http.StatusNotFound, code, "rules of unknown guild")
+
+	var g guild.Guild
+
+	code = doJson(t, router, "POST", "/guild", guild.GuildCreate{GuildId: gId, OwnerId: "owner"}, &g)
+	require.Equal(t, http.StatusCreated, code)
+	assert.True(t, g.ExemptAdmins)
+
+	code = doJson(t, router, "POST", "/guild", guild.GuildCreate{GuildId: gId, OwnerId: "owner"}, &errRes)
+	assert.Equal(t, http.StatusConflict, code)
+
+	var created []rule.ReactionRule
+
+	code = doJson(t, router, "POST", "/rules/reaction", []rule.ReactionRule{smile}, &created)
+	require.Equal(t, http.StatusCreated, code)
+	assert.Len(t, created, 1)
+
+	code = doJson(t, router, "POST", "/rules/reaction", []rule.ReactionRule{smile}, &errRes)
+	assert.Equal(t, http.StatusConflict, code)
+
+	update := []rule.ReactionRuleUpdate{{EmojiName: "smile", Actions: rule.Actions{{Type: rule.Warn}}}}
+	var updated []rule.ReactionRule
+
+	code = doJson(t, router, "PATCH", fmt.Sprintf("/rules/reaction/%s", gId), update, &updated)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, rule.Warn, updated[0].Actions[0].Type)
+
+	var found []rule.ReactionRule
+
+	code = doJson(t, router, "GET", fmt.Sprintf("/rules/reaction/%s", gId), nil, &found)
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, found, 1)
+	assert.Equal(t, rule.Warn, found[0].Actions[0].Type)
+
+	query := rule.EncodeDeleteReactQuery([]rule.DeleteReactionRuleQuery{{EmojiName: "smile"}})
+	var ok common.OkResponse
+
+	code = doJson(t, router, "DELETE", fmt.Sprintf("/rules/reaction/%s?%s", gId, query), nil, &ok)
+	assert.Equal(t, http.StatusOK, code)
+
+	found = nil
+	code = doJson(t, router, "GET", fmt.Sprintf("/rules/reaction/%s", gId), nil, &found)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Empty(t, found)
+}
+
+func TestIntegrationGuildExemptions(t *testing.T) {
+	router := integrationRouter
+	gId := "integrationExemptions"
+	exemptAdmins := false
+
+	var errRes common.ErrorResponse
+
+	code := doJson(t, router, "PATCH", fmt.Sprintf("/guild/%s/exemptions", gId), guild.GuildExemptions{ExemptAdmins: &exemptAdmins}, &errRes)
+	assert.Equal(t, http.StatusNotFound, code)
+
+	code = doJson(t, router, "POST", "/guild", guild.GuildCreate{GuildId: gId, OwnerId: "owner"}, nil)
+	require.Equal(t, http.StatusCreated, code)
+
+	var g guild.Guild
+
+	code = doJson(t, router, "PATCH", fmt.Sprintf("/guild/%s/exemptions", gId), guild.GuildExemptions{ExemptRoles: []string{"1"}, ExemptAdmins: &exemptAdmins}, &g)
+	require.Equal(t, http.StatusOK, code)
+
+	code = doJson(t, router, "GET", fmt.Sprintf("/guild/%s", gId), nil, &g)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, guild.Guild{GuildId: gId, OwnerId: "owner", ExemptRoles: []string{"1"}, ExemptAdmins: false}, g)
+}
+
+func TestIntegrationGuildLeave(t *testing.T) {
+	router := integrationRouter
+	gId := "integrationLeave"
+	rules := []rule.ReactionRule{{
+		EmojiName:  "smile",
+		GuildId:    gId,
+		RuleAuthor: "author",
+		Actions:    rule.Actions{{Type: rule.Delete}},
+	}}
+
+	code := doJson(t, router, "POST", "/guild", guild.GuildCreate{GuildId: gId, OwnerId: "owner"}, nil)
+	require.Equal(t, http.StatusCreated, code)
+
+	code = doJson(t, router, "POST", "/rules/reaction", rules, nil)
+	require.Equal(t, http.StatusCreated, code)
+
+	code = doJson(t, router, "DELETE", fmt.Sprintf("/guild/%s", gId), nil, nil)
+	require.Equal(t, http.StatusOK, code)
+
+	var errRes common.ErrorResponse
+
+	code = doJson(t, router, "GET", fmt.Sprintf("/guild/%s", gId), nil, &errRes)
+	assert.Equal(t, http.StatusNotFound, code)
+
+	code = doJson(t, router, "DELETE", fmt.Sprintf("/guild/%s", gId), nil, &errRes)
+	assert.Equal(t, http.StatusNotFound, code)
+
+	// rejoining within the retention restores the rules
+	var g guild.Guild
+
+	code = doJson(t, router, "POST", "/guild", guild.GuildCreate{GuildId: gId, OwnerId: "new owner"}, &g)
+	require.Equal(t, http.StatusCreated, code)
+	assert.Equal(t, "new owner", g.OwnerId)
+
+	var found []rule.ReactionRule
+
+	code = doJson(t, router, "GET", fmt.Sprintf("/rules/reaction/%s", gId), nil, &found)
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, found, 1)
+	assert.Equal(t, "smile", found[0].EmojiName)
+}
+
+func TestIntegrationAuthorization(t *testing.T) {
+	router := integrationRouter
+	gId := "integrationAuth"
+	otherGId := "integrationAuthOther"
+
+	require.Equal(t, http.StatusCreated, doJson(t, router, "POST", "/guild", guild.GuildCreate{GuildId: gId, OwnerId: "owner"}, nil))
+	require.Equal(t, http.StatusCreated, doJson(t, router, "POST", "/guild", guild.GuildCreate{GuildId: otherGId, OwnerId: "owner"}, nil))
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/auth/callback?code=code&state=state", nil)
+	req.AddCookie(&http.Cookie{Name: stateCookie, Value: "state"})
+	router.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var session user.Session
+	require.NoError(t, common.UnmarshalBody(rr.Result().Body, &session))
+	bearer := "Bearer " + session.AccessToken
+
+	var errRes common.ErrorResponse
+
+	code := doJsonAs(t, router, "", "GET", fmt.Sprintf("/rules/reaction/%s", gId), nil, &errRes)
+	assert.Equal(t, http.StatusUnauthorized, code, "no credential")
+
+	code = doJsonAs(t, router, common.ServiceAuthScheme+" wrong", "GET", fmt.Sprintf("/rules/reaction/%s", gId), nil, &errRes)
+	assert.Equal(t, http.StatusUnauthorized, code, "wrong service token")
+
+	code = doJsonAs(t, router, bearer, "GET", fmt.Sprintf("/rules/reaction/%s", gId), nil, nil)
+	assert.Equal(t, http.StatusOK, code, "user with manage guild permission")
+
+	code = doJsonAs(t, router, bearer, "GET", fmt.Sprintf("/rules/reaction/%s", otherGId), nil, &errRes)
+	assert.Equal(t, http.StatusForbidden, code, "user without permissions")
+	assert.Equal(t, common.ErrForbidden.Error(), errRes.Error)
+
+	smile := rule.ReactionRule{EmojiName: "smile", RuleAuthor: "manager", Actions: rule.Actions{{Type: rule.Delete}}}
+	own, other := smile, smile
+	own.GuildId, other.GuildId = gId, otherGId
+
+	code = doJsonAs(t, router, bearer, "POST", "/rules/reaction", []rule.ReactionRule{other}, &errRes)
+	assert.Equal(t, http.StatusForbidden, code, "rules of guild without permissions")
+
+	code = doJsonAs(t, router, bearer, "POST", "/rules/reaction", []rule.ReactionRule{own}, nil)
+	assert.Equal(t, http.StatusCreated, code)
+
+	code = doJsonAs(t, router, bearer, "POST", "/guild", guild.GuildCreate{GuildId: "integrationAuthNew", OwnerId: "manager"}, &errRes)
+	assert.Equal(t, http.StatusForbidden, code, "only the bot creates guilds")
+
+	code = doJsonAs(t, router, bearer, "GET", "/rules/events", nil, &errRes)
+	assert.Equal(t, http.StatusForbidden, code, "only the bot streams rule events")
+}
+
+func TestIntegrationRefreshPermissions(t *testing.T) {
+	router := integrationRouter
+	gId := "integrationRefresh"
+
+	require.Equal(t, http.StatusCreated, doJson(t, router, "POST", "/guild", guild.GuildCreate{GuildId: gId, OwnerId: "owner"}, nil))
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/auth/callback?code=code&state=state", nil)
+	req.AddCookie(&http.Cookie{Name: stateCookie, Value: "state"})
+	router.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var session user.Session
+	require.NoError(t, common.UnmarshalBody(rr.Result().Body, &session))
+
+	code := doJsonAs(t, router, "Bearer "+session.AccessToken, "GET", fmt.Sprintf("/rules/reaction/%s", gId), nil, nil)
+	require.Equal(t, http.StatusOK, code, "user with manage guild permission at login")
+
+	var refreshed user.Session
+
+	code = doJsonAs(t, router, "", "POST", "/auth/refresh", user.RefreshRequest{RefreshToken: session.RefreshToken}, &refreshed)
+	require.Equal(t, http.StatusOK, code)
+
+	var errRes common.ErrorResponse
+
+	code = doJsonAs(t, router, "Bearer "+refreshed.AccessToken, "GET", fmt.Sprintf("/rules/reaction/%s", gId), nil, &errRes)
+	assert.Equal(t, http.StatusForbidden, code, "permission removed in discord before the refresh")
+}
+
+func TestIntegrationInfractionCreatedAt(t *testing.T) {
+	router := integrationRouter
+	gId := "integrationAuth"
+
+	doJson(t, router, "POST", "/guild", guild.GuildCreate{GuildId: gId, OwnerId: "owner"}, nil)
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/auth/callback?code=code&state=state", nil)
+	req.AddCookie(&http.Cookie{Name: stateCookie, Value: "state"})
+	router.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var session user.Session
+	require.NoError(t, common.UnmarshalBody(rr.Result().Body, &session))
+
+	backdated := time.Now().Add(-24 * time.Hour).UTC().Truncate(time.Second)
+	create := infraction.InfractionCreate{
+		GuildId:   gId,
+		UserId:    "user",
+		Source:    infraction.SourceEscalation,
+		Action:    infraction.Kick,
+		Step:      "3/3600",
+		CreatedAt: backdated,
+	}
+
+	var created infraction.Infraction
+
+	code := doJson(t, router, "POST", "/infractions", create, &created)
+	require.Equal(t, http.StatusCreated, code)
+	assert.True(t, created.CreatedAt.Equal(backdated), "the bot backdates queued infractions")
+	assert.Equal(t, "3/3600", created.Step)
+
+	var issued infraction.Infraction
+
+	code = doJsonAs(t, router, "Bearer "+session.AccessToken, "POST", "/infractions", create, &issued)
+	require.Equal(t, http.StatusCreated, code)
+	assert.WithinDuration(t, time.Now(), issued.CreatedAt, time.Minute, "users can't backdate infractions")
+	assert.Empty(t, issued.Step)
+	assert.Equal(t, infraction.SourceModerator, issued.Source)
+	assert.Equal(t, "manager", issued.ModeratorId)
+}
@@ -1,8 +1,11 @@
 package controllers
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/finkabaj/hyde-bot/internals/backend/middleware"
 	"github.com/finkabaj/hyde-bot/internals/backend/services"
@@ -13,32 +16,71 @@ import (
 )
 
 type RulesController struct {
-	reactionService services.IReactionService
-	logger          logger.ILogger
+	reactionService     services.IReactionService
+	reactionRoleService services.IReactionRoleService
+	floodService        services.IReactionFloodService
+	events              services.IRuleEventBroker
+	auth                *middleware.Auth
+	logger              logger.ILogger
 }
 
+// ruleEventsHeartbeat keeps idle rule event streams from being closed by proxies.
+const ruleEventsHeartbeat = 30 * time.Second
+
 var rulesController *RulesController
 
-func NewRulesController(reactionService services.IReactionService, logger logger.ILogger) *RulesController {
+func NewRulesController(reactionService services.IReactionService, reactionRoleService services.IReactionRoleService,
+	floodService services.IReactionFloodService, events services.IRuleEventBroker, auth *middleware.Auth, logger logger.ILogger) *RulesController {
 	if rulesController == nil {
 		rulesController = &RulesController{
-			reactionService: reactionService,
-			logger:          logger,
+			reactionService:     reactionService,
+			reactionRoleService: reactionRoleService,
+			floodService:        floodService,
+			events:              events,
+			auth:                auth,
+			logger:              logger,
 		}
 	}
 	return rulesController
 }
 
 func (rc *RulesController) RegisterRoutes(r *chi.Mux) {
+	requireGuild := rc.auth.RequireGuild(middleware.GuildIdParam("id"))
+
 	r.Route("/rules", func(r chi.Router) {
+		r.Use(rc.auth.Authenticate)
+		r.With(rc.auth.RequireService).Get("/events", rc.streamEvents)
 		r.Route("/reaction", func(r chi.Router) {
-			r.Get("/{id}", rc.getReactions)
-			r.With(middleware.ValidateJson[[]rule.ReactionRule]()).Post("/", rc.postReactions)
-			r.With(middleware.ValidateQuery(rule.DecodeDeleteReactQuery)).Delete("/{id}", rc.deleteReactions)
+			r.With(requireGuild).Get("/{id}", rc.getReactions)
+			r.With(middleware.ValidateJson[[]rule.ReactionRule](), rc.auth.RequireGuild(reactionRulesGuildIds)).Post("/", rc.postReactions)
+			r.With(requireGuild, middleware.ValidateJson[[]rule.ReactionRuleUpdate]()).Patch("/{id}", rc.patchReactions)
+			r.With(requireGuild, middleware.ValidateQuery(rule.DecodeDeleteReactQuery)).Delete("/{id}", rc.deleteReactions)
+		})
+		r.Route("/reaction-roles", func(r chi.Router) {
+			r.With(requireGuild).Get("/{id}", rc.getReactionRoles)
+			r.With(requireGuild, middleware.ValidateJson[rule.ReactionRoleMessageUpdate]()).Put("/{id}/{messageId}", rc.putReactionRoles)
+			r.With(requireGuild).Delete("/{id}/{messageId}", rc.deleteReactionRoles)
+		})
+		r.Route("/reaction-flood", func(r chi.Router) {
+			r.With(requireGuild).Get("/{id}", rc.getReactionFlood)
+			r.With(requireGuild, middleware.ValidateJson[rule.ReactionFloodRuleUpdate]()).Put("/{id}", rc.putReactionFlood)
+			r.With(requireGuild).Delete("/{id}", rc.deleteReactionFlood)
 		})
 	})
 }
 
+// reactionRulesGuildIds returns guilds of the rules in the validated body.
+func reactionRulesGuildIds(r *http.Request) []string {
+	rules, _ := middleware.JsonFromContext(r.Context()).([]rule.ReactionRule)
+	guildIds := make([]string, 0, len(rules))
+
+	for _, rule := range rules {
+		guildIds = append(guildIds, rule.GuildId)
+	}
+
+	return common.RemoveDuplicates(guildIds)
+}
+
 func (rc *RulesController) getReactions(w http.ResponseWriter, r *http.Request) {
 	gId := chi.URLParam(r, "id")
 
@@ -100,6 +142,12 @@ func (rc *RulesController) postReactions(w http.ResponseWriter, r *http.Request)
 			SetMessage("rule on this reaction already exists").
 			Send(w)
 		return
+	case rule.ErrReactionRoleConflict:
+		common.NewErrorResponseBuilder(err).
+			SetStatus(http.StatusConflict).
+			SetMessage("the emoji is a reaction role in a channel of the rule").
+			Send(w)
+		return
 	case common.ErrBadRequest:
 		common.NewErrorResponseBuilder(err).
 			SetStatus(http.StatusBadRequest).
@@ -120,6 +168,42 @@ func (rc *RulesController) postReactions(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+func (rc *RulesController) patchReactions(w http.ResponseWriter, r *http.Request) {
+	gId := chi.URLParam(r, "id")
+	rRules, ok := middleware.JsonFromContext(r.Context()).([]rule.ReactionRuleUpdate)
+
+	if !ok {
+		rc.logger.Error(common.ErrInternal, map[string]any{"details": "error while validating patchReactions"})
+		common.SendInternalError(w, "Error while validating")
+		return
+	}
+
+	updatedRules, err := rc.reactionService.UpdateReactionRules(rRules, gId)
+
+	switch err {
+	case common.ErrInternal:
+		common.SendInternalError(w)
+		return
+	case common.ErrBadRequest:
+		common.SendBadRequestError(w, "invalid request body")
+		return
+	case common.ErrNotFound:
+		common.SendNotFoundError(w, "guild or rule not found")
+		return
+	case rule.ErrReactionRoleConflict:
+		common.NewErrorResponseBuilder(err).
+			SetStatus(http.StatusConflict).
+			SetMessage("the emoji is a reaction role in a channel of the rule").
+			Send(w)
+		return
+	}
+
+	if err := common.MarshalBody(w, http.StatusOK, &updatedRules); err != nil {
+		rc.logger.Error(err, map[string]any{"details": "error while marshaling patchReactions response"})
+		common.SendInternalError(w, "error while marshaling patchReactions response")
+	}
+}
+
 func (rc *RulesController) deleteReactions(w http.ResponseWriter, r *http.Request) {
 	gId := chi.URLParam(r, "id")
 	query, ok := middleware.QueryFromContext(r.Context()).([]rule.DeleteReactionRuleQuery)
@@ -155,3 +239,224 @@ func (rc *RulesController) deleteReactions(w http.ResponseWriter, r *http.Reques
 		common.SendInternalError(w, "error while marshaling deleteReactions response")
 	}
 }
+
+func (rc *RulesController) getReactionRoles(w http.ResponseWriter, r *http.Request) {
+	gId := chi.URLParam(r, "id")
+
+	messages, err := rc.reactionRoleService.GetReactionRoles(gId)
+
+	switch {
+	case err == common.ErrNotFound:
+		common.SendNotFoundError(w, fmt.Sprintf("No guild with id: %s found", gId))
+		return
+	case err != nil:
+		common.SendInternalError(w)
+		return
+	}
+
+	if err := common.MarshalBody(w, http.StatusOK, &messages); err != nil {
+		rc.logger.Error(err, map[string]any{"details": "error while marshaling getReactionRoles response"})
+		common.SendInternalError(w)
+	}
+}
+
+func (rc *RulesController) putReactionRoles(w http.ResponseWriter, r *http.Request) {
+	gId := chi.URLParam(r, "id")
+	messageId := chi.URLParam(r, "messageId")
+
+	if !isSnowflake(messageId) {
+		common.SendBadRequestError(w, "invalid message id")
+		return
+	}
+
+	u, ok := middleware.JsonFromContext(r.Context()).(rule.ReactionRoleMessageUpdate)
+
+	if !ok {
+		rc.logger.Error(common.ErrInternal, map[string]any{"details": "error while validating putReactionRoles"})
+		common.SendInternalError(w, "Error while validating")
+		return
+	}
+
+	message, err := rc.reactionRoleService.SaveReactionRoles(gId, messageId, u)
+
+	switch {
+	case err == rule.ErrDuplicateReactionRoles:
+		common.SendBadRequestError(w, "roles must have different emojis")
+		return
+	case err == rule.ErrReactionRoleConflict:
+		common.NewErrorResponseBuilder(err).
+			SetStatus(http.StatusConflict).
+			SetMessage("a reaction rule is enforced on the emoji in the channel").
+			Send(w)
+		return
+	case err == common.ErrNotFound:
+		common.SendNotFoundError(w, fmt.Sprintf("No guild with id: %s found", gId))
+		return
+	case err != nil:
+		common.SendInternalError(w)
+		return
+	}
+
+	if err := common.MarshalBody(w, http.StatusOK, &message); err != nil {
+		rc.logger.Error(err, map[string]any{"details": "error while marshaling putReactionRoles response"})
+		common.SendInternalError(w)
+	}
+}
+
+func (rc *RulesController) deleteReactionRoles(w http.ResponseWriter, r *http.Request) {
+	gId := chi.URLParam(r, "id")
+	messageId := chi.URLParam(r, "messageId")
+
+	err := rc.reactionRoleService.DeleteReactionRoles(gId, messageId)
+
+	switch {
+	case err == common.ErrNotFound:
+		common.SendNotFoundError(w, "guild or message not found")
+		return
+	case err != nil:
+		common.SendInternalError(w)
+		return
+	}
+
+	res := common.OkResponse{Message: "successfully deleted reaction roles of the message"}
+
+	if err := common.MarshalBody(w, http.StatusOK, &res); err != nil {
+		rc.logger.Error(err, map[string]any{"details": "error while marshaling deleteReactionRoles response"})
+		common.SendInternalError(w)
+	}
+}
+
+func (rc *RulesController) getReactionFlood(w http.ResponseWriter, r *http.Request) {
+	gId := chi.URLParam(r, "id")
+
+	f, err := rc.floodService.GetReactionFloodRule(gId)
+
+	switch {
+	case err == common.ErrNotFound:
+		common.SendNotFoundError(w, "guild or reaction flood rule not found")
+		return
+	case err != nil:
+		common.SendInternalError(w)
+		return
+	}
+
+	if err := common.MarshalBody(w, http.StatusOK, &f); err != nil {
+		rc.logger.Error(err, map[string]any{"details": "error while marshaling getReactionFlood response"})
+		common.SendInternalError(w)
+	}
+}
+
+func (rc *RulesController) putReactionFlood(w http.ResponseWriter, r *http.Request) {
+	gId := chi.URLParam(r, "id")
+
+	u, ok := middleware.JsonFromContext(r.Context()).(rule.ReactionFloodRuleUpdate)
+
+	if !ok {
+		rc.logger.Error(common.ErrInternal, map[string]any{"details": "error while validating putReactionFlood"})
+		common.SendInternalError(w, "Error while validating")
+		return
+	}
+
+	f, err := rc.floodService.SaveReactionFloodRule(gId, u)
+
+	switch {
+	case err == common.ErrBadRequest:
+		common.SendBadRequestError(w, "actions must be valid and different")
+		return
+	case err == common.ErrNotFound:
+		common.SendNotFoundError(w, fmt.Sprintf("No guild with id: %s found", gId))
+		return
+	case err != nil:
+		common.SendInternalError(w)
+		return
+	}
+
+	if err := common.MarshalBody(w, http.StatusOK, &f); err != nil {
+		rc.logger.Error(err, map[string]any{"details": "error while marshaling putReactionFlood response"})
+		common.SendInternalError(w)
+	}
+}
+
+func (rc *RulesController) deleteReactionFlood(w http.ResponseWriter, r *http.Request) {
+	gId := chi.URLParam(r, "id")
+
+	err := rc.floodService.DeleteReactionFloodRule(gId)
+
+	switch {
+	case err == common.ErrNotFound:
+		common.SendNotFoundError(w, "guild or reaction flood rule not found")
+		return
+	case err != nil:
+		common.SendInternalError(w)
+		return
+	}
+
+	res := common.OkResponse{Message: "successfully deleted reaction flood rule"}
+
+	if err := common.MarshalBody(w, http.StatusOK, &res); err != nil {
+		rc.logger.Error(err, map[string]any{"details": "error while marshaling deleteReactionFlood response"})
+		common.SendInternalError(w)
+	}
+}
+
+// isSnowflake reports whether id can be a discord id.
+func isSnowflake(id string) bool {
+	_, err := strconv.ParseUint(id, 10, 64)
+	return err == nil
+}
+
+// streamEvents streams rule changes as server-sent events until the client disconnects.
+func (rc *RulesController) streamEvents(w http.ResponseWriter, r *http.Request) {
+	resController := http.NewResponseController(w)
+
+	// server write timeout would close the stream otherwise
+	if err := resController.SetWriteDeadline(time.Time{}); err != nil && err != http.ErrNotSupported {
+		rc.logger.Warn(err, map[string]any{"details": "error while removing write deadline in streamEvents"})
+	}
+
+	events, unsubscribe := rc.events.Subscribe()
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	if err := resController.Flush(); err != nil {
+		rc.logger.Error(err, map[string]any{"details": "error while flushing streamEvents"})
+		return
+	}
+
+	heartbeat := time.NewTicker(ruleEventsHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+
+			b, err := json.Marshal(e)
+
+			if err != nil {
+				rc.logger.Error(err, map[string]any{"details": "error while marshaling rule event"})
+				continue
+			}
+
+			if _, err := fmt.Fprintf(w, "event: rule\ndata: %s\n\n", b); err != nil {
+				return
+			}
+		}
+
+		if err := resController.Flush(); err != nil {
+			return
+		}
+	}
+}
@@ -1,24 +1,29 @@
 package controllers
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	mogs "github.com/finkabaj/hyde-bot/internals/backend/mocks"
+	"github.com/finkabaj/hyde-bot/internals/backend/services"
 	"github.com/finkabaj/hyde-bot/internals/utils/common"
 	"github.com/finkabaj/hyde-bot/internals/utils/rule"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var mockReactionService *mogs.MockReactionService = mogs.NewMockReactionService()
-var rc *RulesController = NewRulesController(mockReactionService, mogs.NewMockLogger())
-
-const rac = rule.ReactActionCount
+var ruleEvents *services.RuleEventBroker = services.NewRuleEventBroker(mogs.NewMockLogger())
+var mockReactionRoleService *mogs.MockReactionRoleService = mogs.NewMockReactionRoleService()
+var mockReactionFloodService *mogs.MockReactionFloodService = mogs.NewMockReactionFloodService()
+var rc *RulesController = NewRulesController(mockReactionService, mockReactionRoleService, mockReactionFloodService, ruleEvents, testAuth, mogs.NewMockLogger())
 
 func init() {
 	rc.RegisterRoutes(r)
@@ -27,6 +32,7 @@ func init() {
 func TestCreateReactionRules(t *testing.T) {
 	t.Run("Positive", testCreateReactionRulePositive)
 	t.Run("NegativeConflict", testCreateReactionRuleNegativeConflict)
+	t.Run("NegativeReactionRoleConflict", testCreateReactionRuleNegativeReactionRoleConflict)
 	t.Run("NegativeBadRequest", testCreateReactionRuleNegativeBadRequest)
 	t.Run("NegativeInternalError", testCreateReactionRuleNegativeInternalError)
 }
@@ -38,6 +44,81 @@ func TestGetReactionsRules(t *testing.T) {
 	t.Run("TeapodStatus", testGetReactionRulesTeapot)
 }
 
+func TestUpdateReactionRules(t *testing.T) {
+	t.Run("Positive", testUpdateReactionRulesPositive)
+	t.Run("NegativeNotFound", testUpdateReactionRulesNotFound)
+	t.Run("NegativeBadRequest", testUpdateReactionRulesBadRequest)
+	t.Run("NegativeValidation", testUpdateReactionRulesValidation)
+}
+
+func TestSaveReactionRoles(t *testing.T) {
+	t.Run("Positive", testSaveReactionRolesPositive)
+	t.Run("NegativeConflict", testSaveReactionRolesConflict)
+	t.Run("NegativeMessageId", testSaveReactionRolesMessageId)
+	t.Run("NegativeValidation", testSaveReactionRolesValidation)
+}
+
+func TestDeleteReactionRoles(t *testing.T) {
+	t.Run("Positive", testDeleteReactionRolesPositive)
+	t.Run("NegativeNotFound", testDeleteReactionRolesNotFound)
+}
+
+func TestSaveReactionFlood(t *testing.T) {
+	t.Run("Positive", testSaveReactionFloodPositive)
+	t.Run("NegativeBadRequest", testSaveReactionFloodBadRequest)
+	t.Run("NegativeValidation", testSaveReactionFloodValidation)
+}
+
+func TestGetReactionFlood(t *testing.T) {
+	t.Run("Positive", testGetReactionFloodPositive)
+	t.Run("NegativeNotFound", testGetReactionFloodNotFound)
+}
+
+func TestDeleteReactionFlood(t *testing.T) {
+	t.Run("Positive", testDeleteReactionFloodPositive)
+}
+
+func TestStreamRuleEvents(t *testing.T) {
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	req, err := http.NewRequest("GET", server.URL+"/rules/events", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", common.ServiceAuthScheme+" "+testServiceToken)
+
+	res, err := server.Client().Do(req)
+
+	assert.Nil(t, err)
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+
+	expectedEvent := rule.RuleEvent{
+		GuildId: "QaK6KDIezh0ckrQEv",
+		Op:      rule.RuleEventDeleted,
+		DeletedReactionRules: []rule.DeleteReactionRuleQuery{
+			{
+				EmojiName: "🤰",
+			},
+		},
+	}
+
+	ruleEvents.Publish(expectedEvent)
+
+	scanner := bufio.NewScanner(res.Body)
+	var actualEvent rule.RuleEvent
+
+	for scanner.Scan() {
+		if data, ok := strings.CutPrefix(scanner.Text(), "data: "); ok {
+			assert.Nil(t, json.Unmarshal([]byte(data), &actualEvent))
+			break
+		}
+	}
+
+	assert.Equal(t, expectedEvent, actualEvent)
+}
+
 func TestDeleteReactionRules(t *testing.T) {
 	t.Run("Positive", testDeleteReactionRulesPositive)
 	t.Run("NegativeNotFound", testDeleteReactionRulesNotFound)
@@ -52,14 +133,14 @@ func testCreateReactionRulePositive(t *testing.T) {
 			IsCustom:   false,
 			RuleAuthor: "J3nxJ5WHIoHJinXjSX",
 			GuildId:    "QaK6KDIezh0ckrQhySh",
-			Actions:    [rac]rule.ReactAction{rule.Delete, rule.Ban},
+			Actions:    rule.Actions{{Type: rule.Delete}, {Type: rule.Ban}},
 		},
 		{
 			EmojiName:  "💦",
 			IsCustom:   false,
 			RuleAuthor: "J3nxJ5WHIoHJinXjSD",
 			GuildId:    "QaK6KDIezh0ckrQhyS",
-			Actions:    [rac]rule.ReactAction{rule.Ban},
+			Actions:    rule.Actions{{Type: rule.Ban}},
 		},
 		{
 			EmojiId:    "12321",
@@ -67,7 +148,8 @@ func testCreateReactionRulePositive(t *testing.T) {
 			IsCustom:   true,
 			RuleAuthor: "QaK6KDIezh0ckrQhyShD",
 			GuildId:    "QaK6KDIezh0ckrQhyS",
-			Actions:    [rac]rule.ReactAction{rule.Kick},
+			Actions:    rule.Actions{{Type: rule.Kick}},
+			Threshold:  &rule.ReactionThreshold{Count: 5, WindowSeconds: 600},
 		},
 	}
 
@@ -77,7 +159,7 @@ func testCreateReactionRulePositive(t *testing.T) {
 	json.NewEncoder(&byf).Encode(expectedResponse)
 
 	rr := httptest.NewRecorder()
-	req := httptest.NewRequest("POST", "/rules/reaction/", &byf)
+	req := newServiceRequest("POST", "/rules/reaction/", &byf)
 	r.ServeHTTP(rr, req)
 	defer rr.Result().Body.Close()
 
@@ -98,7 +180,7 @@ func testCreateReactionRuleNegativeInternalError(t *testing.T) {
 			IsCustom:   false,
 			RuleAuthor: "J3nxJ5WHIoHJinXjIE",
 			GuildId:    "QaK6KDIezh0ckrQhy",
-			Actions:    [rac]rule.ReactAction{rule.Ban},
+			Actions:    rule.Actions{{Type: rule.Ban}},
 		},
 	}
 
@@ -108,7 +190,7 @@ func testCreateReactionRuleNegativeInternalError(t *testing.T) {
 	json.NewEncoder(&byf).Encode(sendedBody)
 
 	rr := httptest.NewRecorder()
-	req := httptest.NewRequest("POST", "/rules/reaction/", &byf)
+	req := newServiceRequest("POST", "/rules/reaction/", &byf)
 	r.ServeHTTP(rr, req)
 	defer rr.Result().Body.Close()
 
@@ -132,7 +214,7 @@ func testCreateReactionRuleNegativeConflict(t *testing.T) {
 			IsCustom:   false,
 			RuleAuthor: "J3nxJ5WHIoHJinXjSX",
 			GuildId:    "QaK6KDIezh0ckrQhysh",
-			Actions:    [rac]rule.ReactAction{rule.Ban},
+			Actions:    rule.Actions{{Type: rule.Ban}},
 		},
 	}
 
@@ -142,7 +224,40 @@ func testCreateReactionRuleNegativeConflict(t *testing.T) {
 	json.NewEncoder(&byf).Encode(sendedBody)
 
 	rr := httptest.NewRecorder()
-	req := httptest.NewRequest("POST", "/rules/reaction/", &byf)
+	req := newServiceRequest("POST", "/rules/reaction/", &byf)
+	r.ServeHTTP(rr, req)
+	defer rr.Result().Body.Close()
+
+	var actualResponse common.ErrorResponse
+	common.UnmarshalBody(rr.Result().Body, &actualResponse)
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	assert.Equal(t, expectedResponse, &actualResponse)
+
+	mockReactionService.AssertExpectations(t)
+}
+
+func testCreateReactionRuleNegativeReactionRoleConflict(t *testing.T) {
+	expectedResponse := common.NewErrorResponseBuilder(rule.ErrReactionRoleConflict).
+		SetMessage("the emoji is a reaction role in a channel of the rule").
+		SetStatus(http.StatusConflict).
+		Get()
+	sendedBody := []rule.ReactionRule{
+		{
+			EmojiName:  "🧷",
+			RuleAuthor: "J3nxJ5WHIoHJinXjSX",
+			GuildId:    "QaK6KDIezh0ckrQhysh",
+			Actions:    rule.Actions{{Type: rule.Delete}},
+		},
+	}
+
+	mockReactionService.On("CreateReactionRules", sendedBody).Return([]rule.ReactionRule{}, rule.ErrReactionRoleConflict)
+
+	var byf bytes.Buffer
+	json.NewEncoder(&byf).Encode(sendedBody)
+
+	rr := httptest.NewRecorder()
+	req := newServiceRequest("POST", "/rules/reaction/", &byf)
 	r.ServeHTTP(rr, req)
 	defer rr.Result().Body.Close()
 
@@ -166,7 +281,7 @@ func testCreateReactionRuleNegativeBadRequest(t *testing.T) {
 			IsCustom:   false,
 			RuleAuthor: "J3nxJ5WHIoHJinXjxx",
 			GuildId:    "QaK6KDIezh0ckrQhyxx",
-			Actions:    [rac]rule.ReactAction{rule.Ban},
+			Actions:    rule.Actions{{Type: rule.Ban}},
 		},
 	}
 
@@ -176,7 +291,7 @@ func testCreateReactionRuleNegativeBadRequest(t *testing.T) {
 	json.NewEncoder(&byf).Encode(sendedBody)
 
 	rr := httptest.NewRecorder()
-	req := httptest.NewRequest("POST", "/rules/reaction", &byf)
+	req := newServiceRequest("POST", "/rules/reaction", &byf)
 	r.ServeHTTP(rr, req)
 	defer rr.Result().Body.Close()
 
@@ -195,26 +310,26 @@ func testGetReactionRulesPositive(t *testing.T) {
 			EmojiName:  "🤰",
 			RuleAuthor: "J3nxJ5WHIoHJinXjSD",
 			GuildId:    "QaK6KDIezh0ckrQhy",
-			Actions:    [rac]rule.ReactAction{rule.Ban},
+			Actions:    rule.Actions{{Type: rule.Ban}},
 		},
 		{
 			EmojiName:  "💦",
 			RuleAuthor: "J3nxJ5WHIoHJinXjSD",
 			GuildId:    "QaK6KDIezh0ckrQhy",
-			Actions:    [rac]rule.ReactAction{rule.Ban, rule.Kick},
+			Actions:    rule.Actions{{Type: rule.Ban}, {Type: rule.Kick}},
 		},
 		{
 			EmojiId:    "12321",
 			RuleAuthor: "QaK6KDIezh0ckrQhyShD",
 			GuildId:    "QaK7KDIezh0ckrQhy",
-			Actions:    [rac]rule.ReactAction{rule.Ban},
+			Actions:    rule.Actions{{Type: rule.Ban}},
 		},
 	}
 
 	mockReactionService.On("GetReactionRules", "QaK6KDIezh0ckrQP8").Return(expectedResponse, nil)
 
 	rr := httptest.NewRecorder()
-	req := httptest.NewRequest("GET", "/rules/reaction/QaK6KDIezh0ckrQP8", nil)
+	req := newServiceRequest("GET", "/rules/reaction/QaK6KDIezh0ckrQP8", nil)
 	r.ServeHTTP(rr, req)
 	defer rr.Result().Body.Close()
 
@@ -237,7 +352,7 @@ func testGetReactionRulesTeapot(t *testing.T) {
 	mockReactionService.On("GetReactionRules", "QaK6KDIezh0ckrQTe").Return([]rule.ReactionRule{}, wtfErr)
 
 	rr := httptest.NewRecorder()
-	req := httptest.NewRequest("GET", "/rules/reaction/QaK6KDIezh0ckrQTe", nil)
+	req := newServiceRequest("GET", "/rules/reaction/QaK6KDIezh0ckrQTe", nil)
 	r.ServeHTTP(rr, req)
 	defer rr.Result().Body.Close()
 
@@ -259,7 +374,7 @@ func testGetReactionRulesNotFound(t *testing.T) {
 	mockReactionService.On("GetReactionRules", "QaK6KDIezh0ckrQB9").Return([]rule.ReactionRule{}, common.ErrNotFound)
 
 	rr := httptest.NewRecorder()
-	req := httptest.NewRequest("GET", "/rules/reaction/QaK6KDIezh0ckrQB9", nil)
+	req := newServiceRequest("GET", "/rules/reaction/QaK6KDIezh0ckrQB9", nil)
 	r.ServeHTTP(rr, req)
 	defer rr.Result().Body.Close()
 
@@ -281,7 +396,7 @@ func testGetReactionRulesInternalError(t *testing.T) {
 	mockReactionService.On("GetReactionRules", "QaK6KDIezh0ckrQIE").Return([]rule.ReactionRule{}, common.ErrInternal)
 
 	rr := httptest.NewRecorder()
-	req := httptest.NewRequest("GET", "/rules/reaction/QaK6KDIezh0ckrQIE", nil)
+	req := newServiceRequest("GET", "/rules/reaction/QaK6KDIezh0ckrQIE", nil)
 	r.ServeHTTP(rr, req)
 	defer rr.Result().Body.Close()
 
@@ -314,7 +429,7 @@ func testDeleteReactionRulesPositive(t *testing.T) {
 	mockReactionService.On("DeleteReactionRules", query, gId).Return(nil)
 
 	rr := httptest.NewRecorder()
-	req := httptest.NewRequest("DELETE", fmt.Sprintf("/rules/reaction/%s?%s", gId, encodedQuery), nil)
+	req := newServiceRequest("DELETE", fmt.Sprintf("/rules/reaction/%s?%s", gId, encodedQuery), nil)
 	r.ServeHTTP(rr, req)
 	defer rr.Result().Body.Close()
 
@@ -344,7 +459,7 @@ func testDeleteReactionRulesNotFound(t *testing.T) {
 	mockReactionService.On("DeleteReactionRules", query, gId).Return(common.ErrNotFound)
 
 	rr := httptest.NewRecorder()
-	req := httptest.NewRequest("DELETE", fmt.Sprintf("/rules/reaction/%s?%s", gId, encodedQuery), nil)
+	req := newServiceRequest("DELETE", fmt.Sprintf("/rules/reaction/%s?%s", gId, encodedQuery), nil)
 	r.ServeHTTP(rr, req)
 	defer rr.Result().Body.Close()
 
@@ -373,7 +488,7 @@ func testDeleteReactionRulesInternalError(t *testing.T) {
 	mockReactionService.On("DeleteReactionRules", query, gId).Return(common.ErrInternal)
 
 	rr := httptest.NewRecorder()
-	req := httptest.NewRequest("DELETE", fmt.Sprintf("/rules/reaction/%s?%s", gId, encodedQuery), nil)
+	req := newServiceRequest("DELETE", fmt.Sprintf("/rules/reaction/%s?%s", gId, encodedQuery), nil)
 	r.ServeHTTP(rr, req)
 	defer rr.Result().Body.Close()
 
@@ -403,7 +518,108 @@ func testDeleteReactionRulesBadRequest(t *testing.T) {
 	mockReactionService.On("DeleteReactionRules", query, gId).Return(common.ErrBadRequest)
 
 	rr := httptest.NewRecorder()
-	req := httptest.NewRequest("DELETE", fmt.Sprintf("/rules/reaction/%s?%s", gId, encodedQuery), nil)
+	req := newServiceRequest("DELETE", fmt.Sprintf("/rules/reaction/%s?%s", gId, encodedQuery), nil)
+	r.ServeHTTP(rr, req)
+	defer rr.Result().Body.Close()
+
+	var actualResponse common.ErrorResponse
+	common.UnmarshalBody(rr.Result().Body, &actualResponse)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, expectedResponse, &actualResponse)
+
+	mockReactionService.AssertExpectations(t)
+}
+
+func testUpdateReactionRulesPositive(t *testing.T) {
+	gId := "QaK6KDIezh0ckrQUp"
+	sendedBody := []rule.ReactionRuleUpdate{
+		{
+			EmojiName: "🤰",
+			Actions:   rule.Actions{{Type: rule.Delete}, {Type: rule.Warn}},
+		},
+	}
+	expectedResponse := []rule.ReactionRule{
+		{
+			EmojiName:  "🤰",
+			RuleAuthor: "J3nxJ5WHIoHJinXjSX",
+			GuildId:    gId,
+			Actions:    rule.Actions{{Type: rule.Delete}, {Type: rule.Warn}},
+		},
+	}
+
+	mockReactionService.On("UpdateReactionRules", sendedBody, gId).Return(expectedResponse, nil)
+
+	var byf bytes.Buffer
+	json.NewEncoder(&byf).Encode(sendedBody)
+
+	rr := httptest.NewRecorder()
+	req := newServiceRequest("PATCH", fmt.Sprintf("/rules/reaction/%s", gId), &byf)
+	r.ServeHTTP(rr, req)
+	defer rr.Result().Body.Close()
+
+	var actualResponse []rule.ReactionRule
+	common.UnmarshalBody(rr.Result().Body, &actualResponse)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, expectedResponse, actualResponse)
+
+	mockReactionService.AssertExpectations(t)
+}
+
+func testUpdateReactionRulesNotFound(t *testing.T) {
+	gId := "QaK6KDIezh0ckrQUnf"
+	expectedResponse := common.NewErrorResponseBuilder(common.ErrNotFound).
+		SetMessage("guild or rule not found").
+		SetStatus(http.StatusNotFound).
+		Get()
+	sendedBody := []rule.ReactionRuleUpdate{
+		{
+			EmojiName: "bust",
+			EmojiId:   "12321",
+			Actions:   rule.Actions{{Type: rule.Kick}},
+		},
+	}
+
+	mockReactionService.On("UpdateReactionRules", sendedBody, gId).Return([]rule.ReactionRule{}, common.ErrNotFound)
+
+	var byf bytes.Buffer
+	json.NewEncoder(&byf).Encode(sendedBody)
+
+	rr := httptest.NewRecorder()
+	req := newServiceRequest("PATCH", fmt.Sprintf("/rules/reaction/%s", gId), &byf)
+	r.ServeHTTP(rr, req)
+	defer rr.Result().Body.Close()
+
+	var actualResponse common.ErrorResponse
+	common.UnmarshalBody(rr.Result().Body, &actualResponse)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, expectedResponse, &actualResponse)
+
+	mockReactionService.AssertExpectations(t)
+}
+
+func testUpdateReactionRulesBadRequest(t *testing.T) {
+	gId := "QaK6KDIezh0ckrQUbr"
+	expectedResponse := common.NewErrorResponseBuilder(common.ErrBadRequest).
+		SetMessage("invalid request body").
+		SetStatus(http.StatusBadRequest).
+		Get()
+	sendedBody := []rule.ReactionRuleUpdate{
+		{
+			EmojiName: "💦",
+			Actions:   rule.Actions{{Type: rule.Ban}, {Type: rule.Ban}},
+		},
+	}
+
+	mockReactionService.On("UpdateReactionRules", sendedBody, gId).Return([]rule.ReactionRule{}, common.ErrBadRequest)
+
+	var byf bytes.Buffer
+	json.NewEncoder(&byf).Encode(sendedBody)
+
+	rr := httptest.NewRecorder()
+	req := newServiceRequest("PATCH", fmt.Sprintf("/rules/reaction/%s", gId), &byf)
 	r.ServeHTTP(rr, req)
 	defer rr.Result().Body.Close()
 
@@ -415,3 +631,314 @@ func testDeleteReactionRulesBadRequest(t *testing.T) {
 
 	mockReactionService.AssertExpectations(t)
 }
+
+func testUpdateReactionRulesValidation(t *testing.T) {
+	gId := "QaK6KDIezh0ckrQUv"
+	expectedResponse := common.NewErrorResponseBuilder(common.ErrValidation).
+		SetStatus(http.StatusBadRequest).
+		SetValidationFields(map[string]string{"type": "lte"}).
+		Get()
+	sendedBody := []rule.ReactionRuleUpdate{
+		{
+			EmojiName: "💦",
+			Actions:   rule.Actions{{Type: rule.Delete}, {Type: 42}},
+		},
+	}
+
+	var byf bytes.Buffer
+	json.NewEncoder(&byf).Encode(sendedBody)
+
+	rr := httptest.NewRecorder()
+	req := newServiceRequest("PATCH", fmt.Sprintf("/rules/reaction/%s", gId), &byf)
+	r.ServeHTTP(rr, req)
+	defer rr.Result().Body.Close()
+
+	var actualResponse common.ErrorResponse
+	common.UnmarshalBody(rr.Result().Body, &actualResponse)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, expectedResponse, &actualResponse)
+
+	mockReactionService.AssertNotCalled(t, "UpdateReactionRules", sendedBody, gId)
+}
+
+func testSaveReactionRolesPositive(t *testing.T) {
+	gId := "QaK6KDIezh0ckrQRr"
+	messageId := "1100"
+	sendedBody := rule.ReactionRoleMessageUpdate{
+		ChannelId: "1000",
+		Mode:      rule.ReactionRoleUnique,
+		Roles:     []rule.ReactionRole{{EmojiName: "🔴", RoleId: "1"}, {EmojiName: "blue", EmojiId: "123", RoleId: "2"}},
+	}
+	expectedResponse := rule.ReactionRoleMessage{
+		GuildId:   gId,
+		ChannelId: sendedBody.ChannelId,
+		MessageId: messageId,
+		Mode:      sendedBody.Mode,
+		Roles:     sendedBody.Roles,
+	}
+
+	mockReactionRoleService.On("SaveReactionRoles", gId, messageId, sendedBody).Return(expectedResponse, nil)
+
+	var byf bytes.Buffer
+	json.NewEncoder(&byf).Encode(sendedBody)
+
+	rr := httptest.NewRecorder()
+	req := newServiceRequest("PUT", fmt.Sprintf("/rules/reaction-roles/%s/%s", gId, messageId), &byf)
+	r.ServeHTTP(rr, req)
+	defer rr.Result().Body.Close()
+
+	var actualResponse rule.ReactionRoleMessage
+	common.UnmarshalBody(rr.Result().Body, &actualResponse)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, expectedResponse, actualResponse)
+
+	mockReactionRoleService.AssertExpectations(t)
+}
+
+func testSaveReactionRolesConflict(t *testing.T) {
+	gId := "QaK6KDIezh0ckrQRc"
+	messageId := "1200"
+	sendedBody := rule.ReactionRoleMessageUpdate{
+		ChannelId: "1000",
+		Mode:      rule.ReactionRoleToggle,
+		Roles:     []rule.ReactionRole{{EmojiName: "🤰", RoleId: "1"}},
+	}
+	expectedResponse := common.NewErrorResponseBuilder(rule.ErrReactionRoleConflict).
+		SetStatus(http.StatusConflict).
+		SetMessage("a reaction rule is enforced on the emoji in the channel").
+		Get()
+
+	mockReactionRoleService.On("SaveReactionRoles", gId, messageId, sendedBody).Return(rule.ReactionRoleMessage{}, rule.ErrReactionRoleConflict)
+
+	var byf bytes.Buffer
+	json.NewEncoder(&byf).Encode(sendedBody)
+
+	rr := httptest.NewRecorder()
+	req := newServiceRequest("PUT", fmt.Sprintf("/rules/reaction-roles/%s/%s", gId, messageId), &byf)
+	r.ServeHTTP(rr, req)
+	defer rr.Result().Body.Close()
+
+	var actualResponse common.ErrorResponse
+	common.UnmarshalBody(rr.Result().Body, &actualResponse)
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	assert.Equal(t, expectedResponse, &actualResponse)
+}
+
+func testSaveReactionRolesMessageId(t *testing.T) {
+	gId := "QaK6KDIezh0ckrQRm"
+	sendedBody := rule.ReactionRoleMessageUpdate{
+		ChannelId: "1000",
+		Mode:      rule.ReactionRoleToggle,
+		Roles:     []rule.ReactionRole{{EmojiName: "🔴", RoleId: "1"}},
+	}
+
+	var byf bytes.Buffer
+	json.NewEncoder(&byf).Encode(sendedBody)
+
+	rr := httptest.NewRecorder()
+	req := newServiceRequest("PUT", fmt.Sprintf("/rules/reaction-roles/%s/not-a-message", gId), &byf)
+	r.ServeHTTP(rr, req)
+	defer rr.Result().Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	mockReactionRoleService.AssertNotCalled(t, "SaveReactionRoles", gId, "not-a-message", sendedBody)
+}
+
+func testSaveReactionRolesValidation(t *testing.T) {
+	gId := "QaK6KDIezh0ckrQRv"
+	expectedResponse := common.NewErrorResponseBuilder(common.ErrValidation).
+		SetStatus(http.StatusBadRequest).
+		SetValidationFields(map[string]string{"mode": "oneof", "roleId": "numeric"}).
+		Get()
+	sendedBody := rule.ReactionRoleMessageUpdate{
+		ChannelId: "1000",
+		Mode:      "random",
+		Roles:     []rule.ReactionRole{{EmojiName: "🔴", RoleId: "red"}},
+	}
+
+	var byf bytes.Buffer
+	json.NewEncoder(&byf).Encode(sendedBody)
+
+	rr := httptest.NewRecorder()
+	req := newServiceRequest("PUT", fmt.Sprintf("/rules/reaction-roles/%s/1300", gId), &byf)
+	r.ServeHTTP(rr, req)
+	defer rr.Result().Body.Close()
+
+	var actualResponse common.ErrorResponse
+	common.UnmarshalBody(rr.Result().Body, &actualResponse)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, expectedResponse, &actualResponse)
+
+	mockReactionRoleService.AssertNotCalled(t, "SaveReactionRoles", gId, "1300", sendedBody)
+}
+
+func testDeleteReactionRolesPositive(t *testing.T) {
+	gId := "QaK6KDIezh0ckrQRd"
+	messageId := "1400"
+
+	mockReactionRoleService.On("DeleteReactionRoles", gId, messageId).Return(nil)
+
+	rr := httptest.NewRecorder()
+	req := newServiceRequest("DELETE", fmt.Sprintf("/rules/reaction-roles/%s/%s", gId, messageId), nil)
+	r.ServeHTTP(rr, req)
+	defer rr.Result().Body.Close()
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	mockReactionRoleService.AssertExpectations(t)
+}
+
+func testDeleteReactionRolesNotFound(t *testing.T) {
+	gId := "QaK6KDIezh0ckrQRn"
+	messageId := "1500"
+	expectedResponse := common.NewErrorResponseBuilder(common.ErrNotFound).
+		SetStatus(http.StatusNotFound).
+		SetMessage("guild or message not found").
+		Get()
+
+	mockReactionRoleService.On("DeleteReactionRoles", gId, messageId).Return(common.ErrNotFound)
+
+	rr := httptest.NewRecorder()
+	req := newServiceRequest("DELETE", fmt.Sprintf("/rules/reaction-roles/%s/%s", gId, messageId), nil)
+	r.ServeHTTP(rr, req)
+	defer rr.Result().Body.Close()
+
+	var actualResponse common.ErrorResponse
+	common.UnmarshalBody(rr.Result().Body, &actualResponse)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, expectedResponse, &actualResponse)
+}
+
+func testSaveReactionFloodPositive(t *testing.T) {
+	gId := "QaK6KDIezh0ckrQFp"
+	sendedBody := rule.ReactionFloodRuleUpdate{MaxReactions: 10, WindowSeconds: 30, Actions: rule.Actions{{Type: rule.Delete}, {Type: rule.Timeout, DurationSeconds: 600}}}
+	expectedResponse := rule.ReactionFloodRule{GuildId: gId, MaxReactions: 10, WindowSeconds: 30, Actions: sendedBody.Actions}
+
+	mockReactionFloodService.On("SaveReactionFloodRule", gId, sendedBody).Return(expectedResponse, nil)
+
+	var byf bytes.Buffer
+	json.NewEncoder(&byf).Encode(sendedBody)
+
+	rr := httptest.NewRecorder()
+	req := newServiceRequest("PUT", fmt.Sprintf("/rules/reaction-flood/%s", gId), &byf)
+	r.ServeHTTP(rr, req)
+	defer rr.Result().Body.Close()
+
+	var actualResponse rule.ReactionFloodRule
+	common.UnmarshalBody(rr.Result().Body, &actualResponse)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, expectedResponse, actualResponse)
+
+	mockReactionFloodService.AssertExpectations(t)
+}
+
+func testSaveReactionFloodBadRequest(t *testing.T) {
+	gId := "QaK6KDIezh0ckrQFb"
+	sendedBody := rule.ReactionFloodRuleUpdate{MaxReactions: 10, WindowSeconds: 30, Actions: rule.Actions{{Type: rule.Kick}, {Type: rule.Kick}}}
+	expectedResponse := common.NewErrorResponseBuilder(common.ErrBadRequest).
+		SetStatus(http.StatusBadRequest).
+		SetMessage("actions must be valid and different").
+		Get()
+
+	mockReactionFloodService.On("SaveReactionFloodRule", gId, sendedBody).Return(rule.ReactionFloodRule{}, common.ErrBadRequest)
+
+	var byf bytes.Buffer
+	json.NewEncoder(&byf).Encode(sendedBody)
+
+	rr := httptest.NewRecorder()
+	req := newServiceRequest("PUT", fmt.Sprintf("/rules/reaction-flood/%s", gId), &byf)
+	r.ServeHTTP(rr, req)
+	defer rr.Result().Body.Close()
+
+	var actualResponse common.ErrorResponse
+	common.UnmarshalBody(rr.Result().Body, &actualResponse)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, expectedResponse, &actualResponse)
+}
+
+func testSaveReactionFloodValidation(t *testing.T) {
+	gId := "QaK6KDIezh0ckrQFv"
+	expectedResponse := common.NewErrorResponseBuilder(common.ErrValidation).
+		SetStatus(http.StatusBadRequest).
+		SetValidationFields(map[string]string{"maxReactions": "lte", "windowSeconds": "gte"}).
+		Get()
+	sendedBody := rule.ReactionFloodRuleUpdate{MaxReactions: 1000, WindowSeconds: 0, Actions: rule.Actions{{Type: rule.Delete}}}
+
+	var byf bytes.Buffer
+	json.NewEncoder(&byf).Encode(sendedBody)
+
+	rr := httptest.NewRecorder()
+	req := newServiceRequest("PUT", fmt.Sprintf("/rules/reaction-flood/%s", gId), &byf)
+	r.ServeHTTP(rr, req)
+	defer rr.Result().Body.Close()
+
+	var actualResponse common.ErrorResponse
+	common.UnmarshalBody(rr.Result().Body, &actualResponse)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, expectedResponse, &actualResponse)
+
+	mockReactionFloodService.AssertNotCalled(t, "SaveReactionFloodRule", gId, sendedBody)
+}
+
+func testGetReactionFloodPositive(t *testing.T) {
+	gId := "QaK6KDIezh0ckrQFg"
+	expectedResponse := rule.ReactionFloodRule{GuildId: gId, MaxReactions: 5, WindowSeconds: 10, Actions: rule.Actions{{Type: rule.Warn}}}
+
+	mockReactionFloodService.On("GetReactionFloodRule", gId).Return(expectedResponse, nil)
+
+	rr := httptest.NewRecorder()
+	req := newServiceRequest("GET", fmt.Sprintf("/rules/reaction-flood/%s", gId), nil)
+	r.ServeHTTP(rr, req)
+	defer rr.Result().Body.Close()
+
+	var actualResponse rule.ReactionFloodRule
+	common.UnmarshalBody(rr.Result().Body, &actualResponse)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, expectedResponse, actualResponse)
+}
+
+func testGetReactionFloodNotFound(t *testing.T) {
+	gId := "QaK6KDIezh0ckrQFn"
+	expectedResponse := common.NewErrorResponseBuilder(common.ErrNotFound).
+		SetStatus(http.StatusNotFound).
+		SetMessage("guild or reaction flood rule not found").
+		Get()
+
+	mockReactionFloodService.On("GetReactionFloodRule", gId).Return(rule.ReactionFloodRule{}, common.ErrNotFound)
+
+	rr := httptest.NewRecorder()
+	req := newServiceRequest("GET", fmt.Sprintf("/rules/reaction-flood/%s", gId), nil)
+	r.ServeHTTP(rr, req)
+	defer rr.Result().Body.Close()
+
+	var actualResponse common.ErrorResponse
+	common.UnmarshalBody(rr.Result().Body, &actualResponse)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, expectedResponse, &actualResponse)
+}
+
+func testDeleteReactionFloodPositive(t *testing.T) {
+	gId := "QaK6KDIezh0ckrQFd"
+
+	mockReactionFloodService.On("DeleteReactionFloodRule", gId).Return(nil)
+
+	rr := httptest.NewRecorder()
+	req := newServiceRequest("DELETE", fmt.Sprintf("/rules/reaction-flood/%s", gId), nil)
+	r.ServeHTTP(rr, req)
+	defer rr.Result().Body.Close()
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	mockReactionFloodService.AssertExpectations(t)
+}
@@ -0,0 +1,149 @@
+package middleware
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/finkabaj/hyde-bot/internals/logger"
+	"github.com/finkabaj/hyde-bot/internals/utils/common"
+	"github.com/go-chi/chi/v5"
+)
+
+type AccessTokenVerifier interface {
+	// VerifyAccessToken returns the id of the user the access token was issued to.
+	VerifyAccessToken(accessToken string) (string, error)
+}
+
+type GuildAuthorizer interface {
+	CanManageGuild(userId, gId string) (bool, error)
+}
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	// Service is true for the bot, it can access every guild.
+	Service bool
+	UserId  string
+}
+
+type principalCtxKey struct{}
+
+// Auth authenticates requests with the Authorization header. The bot sends
+// "Bot <service token>", users send "Bearer <access token>" of their session.
+type Auth struct {
+	verifier     AccessTokenVerifier
+	authorizer   GuildAuthorizer
+	serviceToken string
+}
+
+// NewAuth returns Auth that accepts serviceToken as the bot credential. Empty serviceToken disables bot access.
+func NewAuth(v AccessTokenVerifier, a GuildAuthorizer, serviceToken string) *Auth {
+	return &Auth{
+		verifier:     v,
+		authorizer:   a,
+		serviceToken: serviceToken,
+	}
+}
+
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalCtxKey{}).(Principal)
+	return p, ok
+}
+
+// Authenticate responds with 401 if the request has no valid credential and puts the Principal into the context otherwise.
+func (a *Auth) Authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scheme, credential, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+
+		var p Principal
+
+		switch {
+		case scheme == common.ServiceAuthScheme:
+			if a.serviceToken == "" || subtle.ConstantTimeCompare([]byte(credential), []byte(a.serviceToken)) != 1 {
+				common.SendUnauthorizedError(w, "Invalid service token")
+				return
+			}
+
+			p = Principal{Service: true}
+		case strings.EqualFold(scheme, "Bearer"):
+			userId, err := a.verifier.VerifyAccessToken(credential)
+
+			if err != nil {
+				common.SendUnauthorizedError(w, "Invalid or expired access token")
+				return
+			}
+
+			p = Principal{UserId: userId}
+		default:
+			common.SendUnauthorizedError(w, "Authorization header is required")
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), principalCtxKey{}, p)))
+	})
+}
+
+// RequireService responds with 403 to everyone except the bot. Must be used after Authenticate.
+func (a *Auth) RequireService(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p, ok := PrincipalFromContext(r.Context())
+
+		if !ok {
+			common.SendUnauthorizedError(w)
+			return
+		}
+
+		if !p.Service {
+			common.SendForbiddenError(w, "Only the bot can use this endpoint")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RequireGuild responds with 403 if the user can't manage any of the guilds returned by guildIds.
+// The bot can access every guild. Must be used after Authenticate.
+func (a *Auth) RequireGuild(guildIds func(r *http.Request) []string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			p, ok := PrincipalFromContext(r.Context())
+
+			if !ok {
+				common.SendUnauthorizedError(w)
+				return
+			}
+
+			if p.Service {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			for _, gId := range guildIds(r) {
+				canManage, err := a.authorizer.CanManageGuild(p.UserId, gId)
+
+				if err != nil {
+					logger.Error(err, map[string]any{"details": "error while checking guild permissions", "guildId": gId})
+					common.SendInternalError(w)
+					return
+				}
+
+				if !canManage {
+					common.SendForbiddenError(w, fmt.Sprintf("You can't manage guild with id: %s", gId))
+					return
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// GuildIdParam returns the guild id from the url param for RequireGuild.
+func GuildIdParam(param string) func(r *http.Request) []string {
+	return func(r *http.Request) []string {
+		return []string{chi.URLParam(r, param)}
+	}
+}
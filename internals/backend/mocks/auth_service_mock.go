@@ -0,0 +1,50 @@
+package mogs
+
+import (
+	"github.com/finkabaj/hyde-bot/internals/utils/user"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAuthService struct {
+	mock.Mock
+}
+
+func NewMockAuthService() *MockAuthService {
+	return &MockAuthService{}
+}
+
+func (m *MockAuthService) LoginURL(state string) string {
+	args := m.Called(state)
+
+	return args.String(0)
+}
+
+func (m *MockAuthService) Login(code string) (user.Session, error) {
+	args := m.Called(code)
+
+	return args.Get(0).(user.Session), args.Error(1)
+}
+
+func (m *MockAuthService) Refresh(refreshToken string) (user.Session, error) {
+	args := m.Called(refreshToken)
+
+	return args.Get(0).(user.Session), args.Error(1)
+}
+
+func (m *MockAuthService) Logout(refreshToken string) error {
+	args := m.Called(refreshToken)
+
+	return args.Error(0)
+}
+
+func (m *MockAuthService) VerifyAccessToken(accessToken string) (string, error) {
+	args := m.Called(accessToken)
+
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockAuthService) CanManageGuild(userId, gId string) (bool, error) {
+	args := m.Called(userId, gId)
+
+	return args.Bool(0), args.Error(1)
+}
@@ -1,9 +1,14 @@
 package mogs
 
 import (
+	"time"
+
 	"github.com/finkabaj/hyde-bot/internals/db"
+	"github.com/finkabaj/hyde-bot/internals/utils/escalation"
 	"github.com/finkabaj/hyde-bot/internals/utils/guild"
+	"github.com/finkabaj/hyde-bot/internals/utils/infraction"
 	"github.com/finkabaj/hyde-bot/internals/utils/rule"
+	"github.com/finkabaj/hyde-bot/internals/utils/user"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -29,6 +34,41 @@ func (m *DbMock) Status() error {
 	return args.Error(0)
 }
 
+func (m *DbMock) UpsertUser(u user.User) (user.User, error) {
+	args := m.Called(u)
+	return args.Get(0).(user.User), args.Error(1)
+}
+
+func (m *DbMock) ReadUser(userId string) (user.User, error) {
+	args := m.Called(userId)
+	return args.Get(0).(user.User), args.Error(1)
+}
+
+func (m *DbMock) SaveRefreshToken(t user.RefreshToken) error {
+	args := m.Called(t)
+	return args.Error(0)
+}
+
+func (m *DbMock) ReadRefreshToken(tokenHash string) (user.RefreshToken, error) {
+	args := m.Called(tokenHash)
+	return args.Get(0).(user.RefreshToken), args.Error(1)
+}
+
+func (m *DbMock) DeleteRefreshToken(userId string) error {
+	args := m.Called(userId)
+	return args.Error(0)
+}
+
+func (m *DbMock) SaveUserGuilds(userId string, guilds []user.UserGuild) error {
+	args := m.Called(userId, guilds)
+	return args.Error(0)
+}
+
+func (m *DbMock) ReadUserGuild(userId, guildId string) (user.UserGuild, error) {
+	args := m.Called(userId, guildId)
+	return args.Get(0).(user.UserGuild), args.Error(1)
+}
+
 func (m *DbMock) CreateGuild(g guild.GuildCreate) (guild.Guild, error) {
 	args := m.Called(g)
 	return args.Get(0).(guild.Guild), args.Error(1)
@@ -39,6 +79,36 @@ func (m *DbMock) ReadGuild(guildId string) (guild.Guild, error) {
 	return args.Get(0).(guild.Guild), args.Error(1)
 }
 
+func (m *DbMock) UpdateGuildExemptions(guildId string, exemptions guild.GuildExemptions) (guild.Guild, error) {
+	args := m.Called(guildId, exemptions)
+	return args.Get(0).(guild.Guild), args.Error(1)
+}
+
+func (m *DbMock) UpdateGuildModLog(guildId string, modLog guild.GuildModLog) (guild.Guild, error) {
+	args := m.Called(guildId, modLog)
+	return args.Get(0).(guild.Guild), args.Error(1)
+}
+
+func (m *DbMock) UpdateGuild(guildId string, update guild.GuildUpdate) (guild.Guild, error) {
+	args := m.Called(guildId, update)
+	return args.Get(0).(guild.Guild), args.Error(1)
+}
+
+func (m *DbMock) DeleteGuild(guildId string, deletedAt time.Time) error {
+	args := m.Called(guildId, deletedAt)
+	return args.Error(0)
+}
+
+func (m *DbMock) RestoreGuild(g guild.GuildCreate, deletedAfter time.Time) (guild.Guild, error) {
+	args := m.Called(g, deletedAfter)
+	return args.Get(0).(guild.Guild), args.Error(1)
+}
+
+func (m *DbMock) PurgeGuilds(deletedBefore time.Time) (int, error) {
+	args := m.Called(deletedBefore)
+	return args.Int(0), args.Error(1)
+}
+
 func (m *DbMock) CreateReactionRules(rules []rule.ReactionRule) ([]rule.ReactionRule, error) {
 	args := m.Called(rules)
 	return args.Get(0).([]rule.ReactionRule), args.Error(1)
@@ -53,3 +123,73 @@ func (m *DbMock) ReadReactionRules(gId string) ([]rule.ReactionRule, error) {
 	args := m.Called(gId)
 	return args.Get(0).([]rule.ReactionRule), args.Error(1)
 }
+
+func (m *DbMock) UpdateReactionRules(rules []rule.ReactionRuleUpdate, gId string) ([]rule.ReactionRule, error) {
+	args := m.Called(rules, gId)
+	return args.Get(0).([]rule.ReactionRule), args.Error(1)
+}
+
+func (m *DbMock) ReadReactionRoleMessages(gId string) ([]rule.ReactionRoleMessage, error) {
+	args := m.Called(gId)
+	return args.Get(0).([]rule.ReactionRoleMessage), args.Error(1)
+}
+
+func (m *DbMock) SaveReactionRoleMessage(msg rule.ReactionRoleMessage) (rule.ReactionRoleMessage, error) {
+	args := m.Called(msg)
+	return args.Get(0).(rule.ReactionRoleMessage), args.Error(1)
+}
+
+func (m *DbMock) DeleteReactionRoleMessage(gId string, messageId string) error {
+	args := m.Called(gId, messageId)
+	return args.Error(0)
+}
+
+func (m *DbMock) CreateInfraction(i infraction.InfractionCreate) (infraction.Infraction, error) {
+	args := m.Called(i)
+	return args.Get(0).(infraction.Infraction), args.Error(1)
+}
+
+func (m *DbMock) ReadInfraction(gId string, id int64) (infraction.Infraction, error) {
+	args := m.Called(gId, id)
+	return args.Get(0).(infraction.Infraction), args.Error(1)
+}
+
+func (m *DbMock) ReadInfractions(query infraction.InfractionQuery) ([]infraction.Infraction, int, error) {
+	args := m.Called(query)
+	return args.Get(0).([]infraction.Infraction), args.Int(1), args.Error(2)
+}
+
+func (m *DbMock) UpdateInfraction(gId string, id int64, u infraction.InfractionUpdate) (infraction.Infraction, error) {
+	args := m.Called(gId, id, u)
+	return args.Get(0).(infraction.Infraction), args.Error(1)
+}
+
+func (m *DbMock) DeleteInfraction(gId string, id int64) error {
+	args := m.Called(gId, id)
+	return args.Error(0)
+}
+
+func (m *DbMock) ReadEscalationPolicy(gId string) (escalation.Policy, error) {
+	args := m.Called(gId)
+	return args.Get(0).(escalation.Policy), args.Error(1)
+}
+
+func (m *DbMock) UpdateEscalationPolicy(gId string, steps []escalation.Step) (escalation.Policy, error) {
+	args := m.Called(gId, steps)
+	return args.Get(0).(escalation.Policy), args.Error(1)
+}
+
+func (m *DbMock) ReadReactionFloodRule(gId string) (rule.ReactionFloodRule, error) {
+	args := m.Called(gId)
+	return args.Get(0).(rule.ReactionFloodRule), args.Error(1)
+}
+
+func (m *DbMock) SaveReactionFloodRule(f rule.ReactionFloodRule) (rule.ReactionFloodRule, error) {
+	args := m.Called(f)
+	return args.Get(0).(rule.ReactionFloodRule), args.Error(1)
+}
+
+func (m *DbMock) DeleteReactionFloodRule(gId string) error {
+	args := m.Called(gId)
+	return args.Error(0)
+}
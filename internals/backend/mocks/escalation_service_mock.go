@@ -0,0 +1,26 @@
+package mogs
+
+import (
+	"github.com/finkabaj/hyde-bot/internals/utils/escalation"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockEscalationService struct {
+	mock.Mock
+}
+
+func NewMockEscalationService() *MockEscalationService {
+	return &MockEscalationService{}
+}
+
+func (m *MockEscalationService) GetPolicy(gId string) (escalation.Policy, error) {
+	args := m.Called(gId)
+
+	return args.Get(0).(escalation.Policy), args.Error(1)
+}
+
+func (m *MockEscalationService) UpdatePolicy(gId string, u escalation.PolicyUpdate) (escalation.Policy, error) {
+	args := m.Called(gId, u)
+
+	return args.Get(0).(escalation.Policy), args.Error(1)
+}
@@ -24,3 +24,27 @@ func (m *MockGuildService) GetGuild(gId string) (guild.Guild, error) {
 
 	return args.Get(0).(guild.Guild), args.Error(1)
 }
+
+func (m *MockGuildService) UpdateGuildExemptions(gId string, e guild.GuildExemptions) (guild.Guild, error) {
+	args := m.Called(gId, e)
+
+	return args.Get(0).(guild.Guild), args.Error(1)
+}
+
+func (m *MockGuildService) UpdateGuildModLog(gId string, modLog guild.GuildModLog) (guild.Guild, error) {
+	args := m.Called(gId, modLog)
+
+	return args.Get(0).(guild.Guild), args.Error(1)
+}
+
+func (m *MockGuildService) UpdateGuild(gId string, u guild.GuildUpdate) (guild.Guild, error) {
+	args := m.Called(gId, u)
+
+	return args.Get(0).(guild.Guild), args.Error(1)
+}
+
+func (m *MockGuildService) DeleteGuild(gId string) error {
+	args := m.Called(gId)
+
+	return args.Error(0)
+}
@@ -0,0 +1,43 @@
+package mogs
+
+import (
+	"github.com/finkabaj/hyde-bot/internals/utils/infraction"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockInfractionService struct {
+	mock.Mock
+}
+
+func NewMockInfractionService() *MockInfractionService {
+	return &MockInfractionService{}
+}
+
+func (m *MockInfractionService) CreateInfraction(i infraction.InfractionCreate) (infraction.Infraction, error) {
+	args := m.Called(i)
+
+	return args.Get(0).(infraction.Infraction), args.Error(1)
+}
+
+func (m *MockInfractionService) GetInfraction(gId string, id int64) (infraction.Infraction, error) {
+	args := m.Called(gId, id)
+
+	return args.Get(0).(infraction.Infraction), args.Error(1)
+}
+
+func (m *MockInfractionService) GetInfractions(query infraction.InfractionQuery) (infraction.InfractionPage, error) {
+	args := m.Called(query)
+
+	return args.Get(0).(infraction.InfractionPage), args.Error(1)
+}
+
+func (m *MockInfractionService) UpdateInfraction(gId string, id int64, u infraction.InfractionUpdate) (infraction.Infraction, error) {
+	args := m.Called(gId, id, u)
+
+	return args.Get(0).(infraction.Infraction), args.Error(1)
+}
+
+func (m *MockInfractionService) DeleteInfraction(gId string, id int64) error {
+	args := m.Called(gId, id)
+	return args.Error(0)
+}
@@ -0,0 +1,32 @@
+package mogs
+
+import (
+	"github.com/finkabaj/hyde-bot/internals/utils/rule"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockReactionFloodService struct {
+	mock.Mock
+}
+
+func NewMockReactionFloodService() *MockReactionFloodService {
+	return &MockReactionFloodService{}
+}
+
+func (m *MockReactionFloodService) GetReactionFloodRule(gId string) (rule.ReactionFloodRule, error) {
+	args := m.Called(gId)
+
+	return args.Get(0).(rule.ReactionFloodRule), args.Error(1)
+}
+
+func (m *MockReactionFloodService) SaveReactionFloodRule(gId string, u rule.ReactionFloodRuleUpdate) (rule.ReactionFloodRule, error) {
+	args := m.Called(gId, u)
+
+	return args.Get(0).(rule.ReactionFloodRule), args.Error(1)
+}
+
+func (m *MockReactionFloodService) DeleteReactionFloodRule(gId string) error {
+	args := m.Called(gId)
+
+	return args.Error(0)
+}
@@ -0,0 +1,32 @@
+package mogs
+
+import (
+	"github.com/finkabaj/hyde-bot/internals/utils/rule"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockReactionRoleService struct {
+	mock.Mock
+}
+
+func NewMockReactionRoleService() *MockReactionRoleService {
+	return &MockReactionRoleService{}
+}
+
+func (m *MockReactionRoleService) GetReactionRoles(gId string) ([]rule.ReactionRoleMessage, error) {
+	args := m.Called(gId)
+
+	return args.Get(0).([]rule.ReactionRoleMessage), args.Error(1)
+}
+
+func (m *MockReactionRoleService) SaveReactionRoles(gId string, messageId string, u rule.ReactionRoleMessageUpdate) (rule.ReactionRoleMessage, error) {
+	args := m.Called(gId, messageId, u)
+
+	return args.Get(0).(rule.ReactionRoleMessage), args.Error(1)
+}
+
+func (m *MockReactionRoleService) DeleteReactionRoles(gId string, messageId string) error {
+	args := m.Called(gId, messageId)
+
+	return args.Error(0)
+}
@@ -29,3 +29,9 @@ func (m *MockReactionService) DeleteReactionRules(query []rule.DeleteReactionRul
 	args := m.Called(query, gId)
 	return args.Error(0)
 }
+
+func (m *MockReactionService) UpdateReactionRules(rules []rule.ReactionRuleUpdate, gId string) ([]rule.ReactionRule, error) {
+	args := m.Called(rules, gId)
+
+	return args.Get(0).([]rule.ReactionRule), args.Error(1)
+}
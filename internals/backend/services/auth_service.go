@@ -0,0 +1,384 @@
+package services
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/finkabaj/hyde-bot/internals/db"
+	"github.com/finkabaj/hyde-bot/internals/logger"
+	"github.com/finkabaj/hyde-bot/internals/oauth2"
+	"github.com/finkabaj/hyde-bot/internals/utils/common"
+	"github.com/finkabaj/hyde-bot/internals/utils/user"
+)
+
+const (
+	DefaultSessionTTL      = 15 * time.Minute
+	DefaultRefreshTokenTTL = 30 * 24 * time.Hour
+)
+
+type AuthConfig struct {
+	// Secret signs access tokens and encrypts stored Discord tokens, changing it ends all sessions.
+	Secret          []byte
+	SessionTTL      time.Duration
+	RefreshTokenTTL time.Duration
+}
+
+type IAuthService interface {
+	LoginURL(state string) string
+	Login(code string) (user.Session, error)
+	Refresh(refreshToken string) (user.Session, error)
+	Logout(refreshToken string) error
+	// VerifyAccessToken returns the id of the user the access token was issued to.
+	VerifyAccessToken(accessToken string) (string, error)
+	// CanManageGuild reports whether the user owns the guild or had Manage Guild or Administrator
+	// permission in it at the last login or refresh.
+	CanManageGuild(userId, gId string) (bool, error)
+}
+
+type AuthService struct {
+	database db.Database
+	oauth    oauth2.IClient
+	config   AuthConfig
+	logger   logger.ILogger
+}
+
+var as *AuthService
+
+func NewAuthService(l logger.ILogger, d db.Database, o oauth2.IClient, c AuthConfig) *AuthService {
+	if c.SessionTTL <= 0 {
+		c.SessionTTL = DefaultSessionTTL
+	}
+
+	if c.RefreshTokenTTL <= 0 {
+		c.RefreshTokenTTL = DefaultRefreshTokenTTL
+	}
+
+	if as == nil {
+		as = &AuthService{
+			database: d,
+			oauth:    o,
+			config:   c,
+			logger:   l,
+		}
+	}
+	return as
+}
+
+func (s *AuthService) LoginURL(state string) string {
+	return s.oauth.AuthCodeURL(state)
+}
+
+// Login exchanges the oauth2 code for the Discord user, saves the user and starts a new session.
+// Codes rejected by Discord return common.ErrUnauthorized.
+func (s *AuthService) Login(code string) (user.Session, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	token, err := s.oauth.Exchange(ctx, code)
+
+	if errors.Is(err, oauth2.ErrExchange) {
+		return user.Session{}, common.ErrUnauthorized
+	} else if err != nil {
+		s.logger.Error(err, map[string]any{"details": "error while exchanging oauth2 code"})
+		return user.Session{}, common.ErrInternal
+	}
+
+	discordUser, err := s.oauth.FetchUser(ctx, token.AccessToken)
+
+	if err != nil {
+		s.logger.Error(err, map[string]any{"details": "error while fetching discord user"})
+		return user.Session{}, common.ErrInternal
+	}
+
+	u, err := s.database.UpsertUser(user.User{UserId: discordUser.Id, Name: discordUser.DisplayName()})
+
+	if err != nil {
+		return user.Session{}, err
+	}
+
+	if err := s.saveGuilds(ctx, u.UserId, token.AccessToken); err != nil {
+		return user.Session{}, err
+	}
+
+	return s.newSession(u, token.RefreshToken)
+}
+
+// Refresh rotates the refresh token and fetches the guilds of the user again, so permissions
+// removed in Discord stop granting access. Unknown and expired tokens and tokens Discord no
+// longer accepts return user.ErrInvalidRefreshToken.
+func (s *AuthService) Refresh(refreshToken string) (user.Session, error) {
+	t, err := s.database.ReadRefreshToken(hashToken(refreshToken))
+
+	if err == common.ErrNotFound {
+		return user.Session{}, user.ErrInvalidRefreshToken
+	} else if err != nil {
+		return user.Session{}, err
+	}
+
+	if time.Now().After(t.Expires) {
+		return user.Session{}, s.revoke(t.UserId)
+	}
+
+	u, err := s.database.ReadUser(t.UserId)
+
+	if err == common.ErrNotFound {
+		return user.Session{}, user.ErrInvalidRefreshToken
+	} else if err != nil {
+		return user.Session{}, err
+	}
+
+	// tokens saved before Discord tokens were stored have none, the user has to log in again
+	discordRefreshToken, err := s.open(t.DiscordToken)
+
+	if err != nil {
+		return user.Session{}, s.revoke(t.UserId)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	token, err := s.oauth.Refresh(ctx, discordRefreshToken)
+
+	if errors.Is(err, oauth2.ErrExchange) {
+		return user.Session{}, s.revoke(t.UserId)
+	} else if err != nil {
+		s.logger.Error(err, map[string]any{"details": "error while refreshing discord token"})
+		return user.Session{}, common.ErrInternal
+	}
+
+	if err := s.saveGuilds(ctx, u.UserId, token.AccessToken); err != nil {
+		return user.Session{}, err
+	}
+
+	return s.newSession(u, token.RefreshToken)
+}
+
+// Logout revokes the refresh token. Unknown tokens are ignored.
+// Access tokens stay valid until they expire.
+func (s *AuthService) Logout(refreshToken string) error {
+	t, err := s.database.ReadRefreshToken(hashToken(refreshToken))
+
+	if err == common.ErrNotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	return s.database.DeleteRefreshToken(t.UserId)
+}
+
+func (s *AuthService) VerifyAccessToken(accessToken string) (string, error) {
+	payload, signature, ok := strings.Cut(accessToken, ".")
+
+	if !ok || !hmac.Equal([]byte(signature), []byte(s.sign(payload))) {
+		return "", common.ErrUnauthorized
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(payload)
+
+	if err != nil {
+		return "", common.ErrUnauthorized
+	}
+
+	userId, expires, ok := strings.Cut(string(decoded), ":")
+
+	if !ok {
+		return "", common.ErrUnauthorized
+	}
+
+	unix, err := strconv.ParseInt(expires, 10, 64)
+
+	if err != nil || time.Now().After(time.Unix(unix, 0)) {
+		return "", common.ErrUnauthorized
+	}
+
+	return userId, nil
+}
+
+func (s *AuthService) CanManageGuild(userId, gId string) (bool, error) {
+	g, err := s.database.ReadGuild(gId)
+
+	if err == nil && g.OwnerId == userId {
+		return true, nil
+	} else if err != nil && err != common.ErrNotFound {
+		return false, err
+	}
+
+	userGuild, err := s.database.ReadUserGuild(userId, gId)
+
+	if err == common.ErrNotFound {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	return userGuild.CanManage(), nil
+}
+
+// revoke deletes the refresh token of the user and returns user.ErrInvalidRefreshToken.
+func (s *AuthService) revoke(userId string) error {
+	if err := s.database.DeleteRefreshToken(userId); err != nil {
+		return err
+	}
+
+	return user.ErrInvalidRefreshToken
+}
+
+// saveGuilds replaces the guilds of the user with the guilds Discord reports for accessToken.
+func (s *AuthService) saveGuilds(ctx context.Context, userId, accessToken string) error {
+	discordGuilds, err := s.oauth.FetchGuilds(ctx, accessToken)
+
+	if err != nil {
+		s.logger.Error(err, map[string]any{"details": "error while fetching guilds of discord user"})
+		return common.ErrInternal
+	}
+
+	userGuilds := make([]user.UserGuild, 0, len(discordGuilds))
+
+	for _, g := range discordGuilds {
+		userGuilds = append(userGuilds, user.UserGuild{
+			UserId:      userId,
+			GuildId:     g.Id,
+			Owner:       g.Owner,
+			Permissions: g.Permissions,
+		})
+	}
+
+	return s.database.SaveUserGuilds(userId, userGuilds)
+}
+
+// newSession issues an access token and replaces the refresh token of the user.
+// discordRefreshToken is stored with it for the next refresh.
+func (s *AuthService) newSession(u user.User, discordRefreshToken string) (user.Session, error) {
+	refreshToken, err := randomToken()
+
+	if err != nil {
+		s.logger.Error(err, map[string]any{"details": "error while generating refresh token"})
+		return user.Session{}, common.ErrInternal
+	}
+
+	discordToken, err := s.seal(discordRefreshToken)
+
+	if err != nil {
+		s.logger.Error(err, map[string]any{"details": "error while encrypting discord token"})
+		return user.Session{}, common.ErrInternal
+	}
+
+	err = s.database.SaveRefreshToken(user.RefreshToken{
+		UserId:       u.UserId,
+		TokenHash:    hashToken(refreshToken),
+		Expires:      time.Now().Add(s.config.RefreshTokenTTL),
+		DiscordToken: discordToken,
+	})
+
+	if err != nil {
+		return user.Session{}, err
+	}
+
+	expiresAt := time.Now().Add(s.config.SessionTTL).Truncate(time.Second)
+	payload := base64.RawURLEncoding.EncodeToString([]byte(u.UserId + ":" + strconv.FormatInt(expiresAt.Unix(), 10)))
+
+	return user.Session{
+		AccessToken:  payload + "." + s.sign(payload),
+		RefreshToken: refreshToken,
+		ExpiresAt:    expiresAt,
+		User:         u,
+	}, nil
+}
+
+func (s *AuthService) sign(payload string) string {
+	mac := hmac.New(sha256.New, s.config.Secret)
+	mac.Write([]byte(payload))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// seal encrypts a Discord token for storage with a key derived from the secret.
+func (s *AuthService) seal(token string) (string, error) {
+	gcm, err := s.cipher()
+
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(gcm.Seal(nonce, nonce, []byte(token), nil)), nil
+}
+
+// open decrypts a token encrypted by seal. Empty and tampered tokens return an error.
+func (s *AuthService) open(sealed string) (string, error) {
+	gcm, err := s.cipher()
+
+	if err != nil {
+		return "", err
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(sealed)
+
+	if err != nil {
+		return "", err
+	}
+
+	if len(b) < gcm.NonceSize() {
+		return "", errors.New("sealed token too short")
+	}
+
+	token, err := gcm.Open(nil, b[:gcm.NonceSize()], b[gcm.NonceSize():], nil)
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(token), nil
+}
+
+func (s *AuthService) cipher() (cipher.AEAD, error) {
+	key := hmac.New(sha256.New, s.config.Secret)
+	key.Write([]byte("discord token"))
+
+	block, err := aes.NewCipher(key.Sum(nil))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// randomToken returns 32 random bytes encoded for use in urls and cookies.
+func randomToken() (string, error) {
+	b := make([]byte, 32)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// RandomState returns a value for the oauth2 state parameter.
+func RandomState() (string, error) {
+	return randomToken()
+}
+
+// hashToken is how refresh tokens are stored, a leaked table can't be used to refresh sessions.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,301 @@
+package services
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	mogs "github.com/finkabaj/hyde-bot/internals/backend/mocks"
+	"github.com/finkabaj/hyde-bot/internals/oauth2"
+	"github.com/finkabaj/hyde-bot/internals/utils/common"
+	"github.com/finkabaj/hyde-bot/internals/utils/guild"
+	"github.com/finkabaj/hyde-bot/internals/utils/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDiscord is a local token endpoint that accepts only the code "valid" and the refresh token
+// "discordRefresh". The refreshed user lost Manage Guild permission in the guild "managed".
+var fakeDiscord = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/oauth2/token":
+		switch {
+		case r.FormValue("grant_type") == "authorization_code" && r.FormValue("code") == "valid":
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "access", TokenType: "Bearer", RefreshToken: "discordRefresh"})
+		case r.FormValue("grant_type") == "refresh_token" && r.FormValue("refresh_token") == "discordRefresh":
+			json.NewEncoder(w).Encode(oauth2.Token{AccessToken: "refreshed", TokenType: "Bearer", RefreshToken: "discordRotated"})
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	case "/api/users/@me":
+		json.NewEncoder(w).Encode(oauth2.DiscordUser{Id: "authUser", Username: "name"})
+	case "/api/users/@me/guilds":
+		if r.Header.Get("Authorization") == "Bearer refreshed" {
+			w.Write([]byte(`[{"id": "managed", "owner": false, "permissions": "0"}]`))
+			return
+		}
+
+		w.Write([]byte(`[{"id": "managed", "owner": false, "permissions": "32"}]`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}))
+
+var mockAuthService = NewAuthService(mogs.NewMockLogger(), mockDb, oauth2.NewClient(oauth2.Config{
+	TokenURL: fakeDiscord.URL + "/oauth2/token",
+	APIURL:   fakeDiscord.URL + "/api",
+}, fakeDiscord.Client()), AuthConfig{Secret: []byte("secret")})
+
+func TestLogin(t *testing.T) {
+	t.Run("Positive", testLoginPositive)
+	t.Run("InvalidCode", testLoginInvalidCode)
+}
+
+func TestRefresh(t *testing.T) {
+	t.Run("Positive", testRefreshPositive)
+	t.Run("NotFound", testRefreshNotFound)
+	t.Run("Expired", testRefreshExpired)
+	t.Run("RevokedInDiscord", testRefreshRevokedInDiscord)
+	t.Run("WithoutDiscordToken", testRefreshWithoutDiscordToken)
+}
+
+func TestLogout(t *testing.T) {
+	t.Run("Positive", testLogoutPositive)
+	t.Run("NotFound", testLogoutNotFound)
+}
+
+func TestCanManageGuild(t *testing.T) {
+	t.Run("Owner", testCanManageGuildOwner)
+	t.Run("Permissions", testCanManageGuildPermissions)
+	t.Run("NoPermissions", testCanManageGuildNoPermissions)
+	t.Run("NotMember", testCanManageGuildNotMember)
+}
+
+func TestVerifyAccessToken(t *testing.T) {
+	t.Run("Positive", testVerifyAccessTokenPositive)
+	t.Run("Tampered", testVerifyAccessTokenTampered)
+	t.Run("Expired", testVerifyAccessTokenExpired)
+}
+
+func testLoginPositive(t *testing.T) {
+	u := user.User{UserId: "authUser", Name: "name"}
+
+	mockDb.On("UpsertUser", u).Return(u, nil).Once()
+	mockDb.On("SaveUserGuilds", u.UserId, []user.UserGuild{{UserId: u.UserId, GuildId: "managed", Permissions: 32}}).Return(nil).Once()
+	mockDb.On("SaveRefreshToken", mock.MatchedBy(func(rt user.RefreshToken) bool {
+		discordToken, err := mockAuthService.open(rt.DiscordToken)
+
+		return rt.UserId == u.UserId && rt.TokenHash != "" && rt.Expires.After(time.Now()) && err == nil && discordToken == "discordRefresh"
+	})).Return(nil).Once()
+
+	session, err := mockAuthService.Login("valid")
+
+	assert.NoError(t, err)
+	assert.Equal(t, u, session.User)
+	assert.NotEmpty(t, session.RefreshToken)
+
+	userId, err := mockAuthService.VerifyAccessToken(session.AccessToken)
+
+	assert.NoError(t, err)
+	assert.Equal(t, u.UserId, userId)
+
+	mockDb.AssertExpectations(t)
+}
+
+func testLoginInvalidCode(t *testing.T) {
+	_, err := mockAuthService.Login("invalid")
+
+	assert.Equal(t, common.ErrUnauthorized, err)
+}
+
+func testRefreshPositive(t *testing.T) {
+	u := user.User{UserId: "refreshUser", Name: "name"}
+	discordToken, err := mockAuthService.seal("discordRefresh")
+	require.NoError(t, err)
+
+	mockDb.On("ReadRefreshToken", hashToken("refreshPositive")).Return(user.RefreshToken{UserId: u.UserId, TokenHash: hashToken("refreshPositive"), Expires: time.Now().Add(time.Hour), DiscordToken: discordToken}, nil).Once()
+	mockDb.On("ReadUser", u.UserId).Return(u, nil).Once()
+	mockDb.On("SaveUserGuilds", u.UserId, []user.UserGuild{{UserId: u.UserId, GuildId: "managed", Permissions: 0}}).Return(nil).Once()
+	mockDb.On("SaveRefreshToken", mock.MatchedBy(func(rt user.RefreshToken) bool {
+		discordToken, err := mockAuthService.open(rt.DiscordToken)
+
+		return rt.UserId == u.UserId && rt.TokenHash != hashToken("refreshPositive") && err == nil && discordToken == "discordRotated"
+	})).Return(nil).Once()
+
+	session, err := mockAuthService.Refresh("refreshPositive")
+
+	assert.NoError(t, err)
+	assert.Equal(t, u, session.User)
+	assert.NotEqual(t, "refreshPositive", session.RefreshToken, "refresh token must be rotated")
+
+	mockDb.AssertExpectations(t)
+}
+
+func testRefreshRevokedInDiscord(t *testing.T) {
+	u := user.User{UserId: "revokedUser", Name: "name"}
+	discordToken, err := mockAuthService.seal("revoked")
+	require.NoError(t, err)
+
+	mockDb.On("ReadRefreshToken", hashToken("refreshRevoked")).Return(user.RefreshToken{UserId: u.UserId, Expires: time.Now().Add(time.Hour), DiscordToken: discordToken}, nil).Once()
+	mockDb.On("ReadUser", u.UserId).Return(u, nil).Once()
+	mockDb.On("DeleteRefreshToken", u.UserId).Return(nil).Once()
+
+	_, err = mockAuthService.Refresh("refreshRevoked")
+
+	assert.Equal(t, user.ErrInvalidRefreshToken, err)
+
+	mockDb.AssertExpectations(t)
+}
+
+func testRefreshWithoutDiscordToken(t *testing.T) {
+	u := user.User{UserId: "legacyUser", Name: "name"}
+
+	mockDb.On("ReadRefreshToken", hashToken("refreshLegacy")).Return(user.RefreshToken{UserId: u.UserId, Expires: time.Now().Add(time.Hour)}, nil).Once()
+	mockDb.On("ReadUser", u.UserId).Return(u, nil).Once()
+	mockDb.On("DeleteRefreshToken", u.UserId).Return(nil).Once()
+
+	_, err := mockAuthService.Refresh("refreshLegacy")
+
+	assert.Equal(t, user.ErrInvalidRefreshToken, err)
+
+	mockDb.AssertExpectations(t)
+}
+
+func testRefreshNotFound(t *testing.T) {
+	mockDb.On("ReadRefreshToken", hashToken("refreshNotFound")).Return(user.RefreshToken{}, common.ErrNotFound).Once()
+
+	_, err := mockAuthService.Refresh("refreshNotFound")
+
+	assert.Equal(t, user.ErrInvalidRefreshToken, err)
+
+	mockDb.AssertExpectations(t)
+}
+
+func testRefreshExpired(t *testing.T) {
+	mockDb.On("ReadRefreshToken", hashToken("refreshExpired")).Return(user.RefreshToken{UserId: "expiredUser", Expires: time.Now().Add(-time.Minute)}, nil).Once()
+	mockDb.On("DeleteRefreshToken", "expiredUser").Return(nil).Once()
+
+	_, err := mockAuthService.Refresh("refreshExpired")
+
+	assert.Equal(t, user.ErrInvalidRefreshToken, err)
+
+	mockDb.AssertExpectations(t)
+}
+
+func testLogoutPositive(t *testing.T) {
+	mockDb.On("ReadRefreshToken", hashToken("logoutPositive")).Return(user.RefreshToken{UserId: "logoutUser"}, nil).Once()
+	mockDb.On("DeleteRefreshToken", "logoutUser").Return(nil).Once()
+
+	assert.NoError(t, mockAuthService.Logout("logoutPositive"))
+
+	mockDb.AssertExpectations(t)
+}
+
+func testLogoutNotFound(t *testing.T) {
+	mockDb.On("ReadRefreshToken", hashToken("logoutNotFound")).Return(user.RefreshToken{}, common.ErrNotFound).Once()
+
+	assert.NoError(t, mockAuthService.Logout("logoutNotFound"))
+
+	mockDb.AssertExpectations(t)
+}
+
+func testVerifyAccessTokenPositive(t *testing.T) {
+	u := user.User{UserId: "verifyUser"}
+
+	mockDb.On("SaveRefreshToken", mock.MatchedBy(func(rt user.RefreshToken) bool { return rt.UserId == u.UserId })).Return(nil).Once()
+
+	session, err := mockAuthService.newSession(u, "discordRefresh")
+	assert.NoError(t, err)
+
+	userId, err := mockAuthService.VerifyAccessToken(session.AccessToken)
+
+	assert.NoError(t, err)
+	assert.Equal(t, u.UserId, userId)
+}
+
+func testVerifyAccessTokenTampered(t *testing.T) {
+	u := user.User{UserId: "tamperedUser"}
+
+	mockDb.On("SaveRefreshToken", mock.MatchedBy(func(rt user.RefreshToken) bool { return rt.UserId == u.UserId })).Return(nil).Once()
+
+	session, err := mockAuthService.newSession(u, "discordRefresh")
+	assert.NoError(t, err)
+
+	_, signature, _ := strings.Cut(session.AccessToken, ".")
+	forged := base64.RawURLEncoding.EncodeToString([]byte("otherUser:99999999999")) + "." + signature
+
+	_, err = mockAuthService.VerifyAccessToken(forged)
+
+	assert.Equal(t, common.ErrUnauthorized, err)
+
+	_, err = mockAuthService.VerifyAccessToken("garbage")
+
+	assert.Equal(t, common.ErrUnauthorized, err)
+}
+
+func testVerifyAccessTokenExpired(t *testing.T) {
+	expired := &AuthService{config: AuthConfig{Secret: []byte("secret"), SessionTTL: -time.Minute}, database: mockDb}
+	u := user.User{UserId: "expiredSessionUser"}
+
+	mockDb.On("SaveRefreshToken", mock.MatchedBy(func(rt user.RefreshToken) bool { return rt.UserId == u.UserId })).Return(nil).Once()
+
+	session, err := expired.newSession(u, "discordRefresh")
+	assert.NoError(t, err)
+
+	_, err = mockAuthService.VerifyAccessToken(session.AccessToken)
+
+	assert.Equal(t, common.ErrUnauthorized, err)
+}
+
+func testCanManageGuildOwner(t *testing.T) {
+	mockDb.On("ReadGuild", "ownedGuild").Return(guild.Guild{GuildId: "ownedGuild", OwnerId: "owner"}, nil).Once()
+
+	ok, err := mockAuthService.CanManageGuild("owner", "ownedGuild")
+
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	mockDb.AssertExpectations(t)
+}
+
+func testCanManageGuildPermissions(t *testing.T) {
+	mockDb.On("ReadGuild", "managedGuild").Return(guild.Guild{GuildId: "managedGuild", OwnerId: "owner"}, nil).Once()
+	mockDb.On("ReadUserGuild", "manager", "managedGuild").Return(user.UserGuild{UserId: "manager", GuildId: "managedGuild", Permissions: discordgo.PermissionManageServer}, nil).Once()
+
+	ok, err := mockAuthService.CanManageGuild("manager", "managedGuild")
+
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	mockDb.AssertExpectations(t)
+}
+
+func testCanManageGuildNoPermissions(t *testing.T) {
+	mockDb.On("ReadGuild", "memberGuild").Return(guild.Guild{GuildId: "memberGuild", OwnerId: "owner"}, nil).Once()
+	mockDb.On("ReadUserGuild", "member", "memberGuild").Return(user.UserGuild{UserId: "member", GuildId: "memberGuild", Permissions: discordgo.PermissionSendMessages}, nil).Once()
+
+	ok, err := mockAuthService.CanManageGuild("member", "memberGuild")
+
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	mockDb.AssertExpectations(t)
+}
+
+func testCanManageGuildNotMember(t *testing.T) {
+	mockDb.On("ReadGuild", "unknownGuild").Return(guild.Guild{}, common.ErrNotFound).Once()
+	mockDb.On("ReadUserGuild", "stranger", "unknownGuild").Return(user.UserGuild{}, common.ErrNotFound).Once()
+
+	ok, err := mockAuthService.CanManageGuild("stranger", "unknownGuild")
+
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	mockDb.AssertExpectations(t)
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"github.com/finkabaj/hyde-bot/internals/db"
+	"github.com/finkabaj/hyde-bot/internals/logger"
+	"github.com/finkabaj/hyde-bot/internals/utils/escalation"
+)
+
+type IEscalationService interface {
+	GetPolicy(gId string) (escalation.Policy, error)
+	UpdatePolicy(gId string, u escalation.PolicyUpdate) (escalation.Policy, error)
+}
+
+type EscalationService struct {
+	logger       logger.ILogger
+	database     db.Database
+	guildService IGuildService
+}
+
+var escalationService *EscalationService
+
+func NewEscalationService(l logger.ILogger, d db.Database, g IGuildService) *EscalationService {
+	if escalationService == nil {
+		escalationService = &EscalationService{
+			logger:       l,
+			database:     d,
+			guildService: g,
+		}
+	}
+	return escalationService
+}
+
+func (es *EscalationService) GetPolicy(gId string) (escalation.Policy, error) {
+	if _, err := es.guildService.GetGuild(gId); err != nil {
+		return escalation.Policy{}, err
+	}
+
+	return es.database.ReadEscalationPolicy(gId)
+}
+
+// UpdatePolicy replaces steps of the policy. Returns escalation.ErrDuplicateSteps if two steps have the same warns.
+func (es *EscalationService) UpdatePolicy(gId string, u escalation.PolicyUpdate) (escalation.Policy, error) {
+	if _, err := es.guildService.GetGuild(gId); err != nil {
+		return escalation.Policy{}, err
+	}
+
+	steps, err := escalation.SortSteps(u.Steps)
+
+	if err != nil {
+		return escalation.Policy{}, err
+	}
+
+	return es.database.UpdateEscalationPolicy(gId, steps)
+}
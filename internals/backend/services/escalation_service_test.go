@@ -0,0 +1,67 @@
+package services
+
+import (
+	"testing"
+
+	mogs "github.com/finkabaj/hyde-bot/internals/backend/mocks"
+	"github.com/finkabaj/hyde-bot/internals/utils/common"
+	"github.com/finkabaj/hyde-bot/internals/utils/escalation"
+	"github.com/finkabaj/hyde-bot/internals/utils/guild"
+	"github.com/finkabaj/hyde-bot/internals/utils/infraction"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var mockEscalationService = NewEscalationService(mogs.NewMockLogger(), mockDb, mockGuildService)
+
+func TestUpdateEscalationPolicy(t *testing.T) {
+	t.Run("Positive", testUpdateEscalationPolicyPositive)
+	t.Run("DuplicateSteps", testUpdateEscalationPolicyDuplicateSteps)
+	t.Run("GuildNotFound", testUpdateEscalationPolicyGuildNotFound)
+}
+
+func testUpdateEscalationPolicyPositive(t *testing.T) {
+	gId := "escalation-update"
+	ban := escalation.Step{Warns: 7, WindowSeconds: 604800, Action: infraction.Ban, TimeoutSeconds: 60}
+	timeout := escalation.Step{Warns: 3, WindowSeconds: 604800, Action: infraction.Timeout, TimeoutSeconds: 3600}
+	sortedSteps := []escalation.Step{timeout, {Warns: 7, WindowSeconds: 604800, Action: infraction.Ban}}
+	expectedResult := escalation.Policy{GuildId: gId, Steps: sortedSteps}
+
+	mockGuildService.On("GetGuild", gId).Return(guild.Guild{GuildId: gId}, nil)
+	mockDb.On("UpdateEscalationPolicy", gId, sortedSteps).Return(expectedResult, nil)
+
+	actualResult, err := mockEscalationService.UpdatePolicy(gId, escalation.PolicyUpdate{Steps: []escalation.Step{ban, timeout}})
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedResult, actualResult)
+
+	mockDb.AssertExpectations(t)
+}
+
+func testUpdateEscalationPolicyDuplicateSteps(t *testing.T) {
+	gId := "escalation-duplicate"
+	steps := []escalation.Step{
+		{Warns: 3, WindowSeconds: 60, Action: infraction.Kick},
+		{Warns: 3, WindowSeconds: 60, Action: infraction.Ban},
+	}
+
+	mockGuildService.On("GetGuild", gId).Return(guild.Guild{GuildId: gId}, nil)
+
+	_, err := mockEscalationService.UpdatePolicy(gId, escalation.PolicyUpdate{Steps: steps})
+
+	assert.Equal(t, escalation.ErrDuplicateSteps, err)
+
+	mockDb.AssertNotCalled(t, "UpdateEscalationPolicy", gId, mock.Anything)
+}
+
+func testUpdateEscalationPolicyGuildNotFound(t *testing.T) {
+	gId := "escalation-missing"
+
+	mockGuildService.On("GetGuild", gId).Return(guild.Guild{}, common.ErrNotFound)
+
+	_, err := mockEscalationService.UpdatePolicy(gId, escalation.PolicyUpdate{})
+
+	assert.Equal(t, common.ErrNotFound, err)
+
+	mockDb.AssertNotCalled(t, "UpdateEscalationPolicy", gId, mock.Anything)
+}
@@ -1,33 +1,60 @@
 package services
 
 import (
+	"context"
 	"reflect"
+	"time"
 
 	"github.com/finkabaj/hyde-bot/internals/db"
+	"github.com/finkabaj/hyde-bot/internals/logger"
 	"github.com/finkabaj/hyde-bot/internals/utils/common"
 	"github.com/finkabaj/hyde-bot/internals/utils/guild"
+	"github.com/finkabaj/hyde-bot/internals/utils/rule"
 )
 
 type IGuildService interface {
 	CreateGuild(g guild.GuildCreate) (guild.Guild, error)
 	GetGuild(gId string) (guild.Guild, error)
+	UpdateGuildExemptions(gId string, e guild.GuildExemptions) (guild.Guild, error)
+	UpdateGuildModLog(gId string, m guild.GuildModLog) (guild.Guild, error)
+	UpdateGuild(gId string, u guild.GuildUpdate) (guild.Guild, error)
+	DeleteGuild(gId string) error
 }
 
+const (
+	// DefaultGuildRetention is how long data of a guild the bot left is kept.
+	DefaultGuildRetention = 30 * 24 * time.Hour
+	// GuildPurgeInterval is how often guilds deleted longer than the retention ago are purged.
+	GuildPurgeInterval = time.Hour
+)
+
 type GuildService struct {
-	database db.Database
+	database  db.Database
+	events    IRuleEventBroker
+	logger    logger.ILogger
+	retention time.Duration
 }
 
 var es *GuildService
 
-func NewGuildService(d db.Database) *GuildService {
+// NewGuildService returns the guild service. Non positive retention falls back to DefaultGuildRetention.
+func NewGuildService(l logger.ILogger, d db.Database, e IRuleEventBroker, retention time.Duration) *GuildService {
+	if retention <= 0 {
+		retention = DefaultGuildRetention
+	}
+
 	if es == nil {
 		es = &GuildService{
-			database: d,
+			database:  d,
+			events:    e,
+			logger:    l,
+			retention: retention,
 		}
 	}
 	return es
 }
 
+// CreateGuild creates the guild. A guild deleted within the retention is restored with its rules instead.
 func (e *GuildService) CreateGuild(g guild.GuildCreate) (guild.Guild, error) {
 	if g, err := e.GetGuild(g.GuildId); err != nil && err != common.ErrNotFound {
 		return guild.Guild{}, err
@@ -35,6 +62,14 @@ func (e *GuildService) CreateGuild(g guild.GuildCreate) (guild.Guild, error) {
 		return guild.Guild{}, guild.ErrGuildConflict
 	}
 
+	restoredGuild, err := e.database.RestoreGuild(g, time.Now().Add(-e.retention))
+
+	if err == nil {
+		return restoredGuild, nil
+	} else if err != common.ErrNotFound {
+		return guild.Guild{}, err
+	}
+
 	newGuild, err := e.database.CreateGuild(g)
 
 	return newGuild, err
@@ -45,3 +80,78 @@ func (e *GuildService) GetGuild(gId string) (guild.Guild, error) {
 
 	return guild, err
 }
+
+func (e *GuildService) UpdateGuildExemptions(gId string, exemptions guild.GuildExemptions) (guild.Guild, error) {
+	updatedGuild, err := e.database.UpdateGuildExemptions(gId, exemptions)
+
+	if err != nil {
+		return guild.Guild{}, err
+	}
+
+	e.events.Publish(rule.RuleEvent{
+		GuildId: gId,
+		Op:      rule.RuleEventExemptionsUpdated,
+		Exemptions: &guild.GuildExemptions{
+			ExemptRoles:  updatedGuild.ExemptRoles,
+			ExemptAdmins: &updatedGuild.ExemptAdmins,
+		},
+	})
+
+	return updatedGuild, nil
+}
+
+// UpdateGuildModLog sets the moderation log channel and notifies the bot.
+func (e *GuildService) UpdateGuildModLog(gId string, modLog guild.GuildModLog) (guild.Guild, error) {
+	updatedGuild, err := e.database.UpdateGuildModLog(gId, modLog)
+
+	if err != nil {
+		return guild.Guild{}, err
+	}
+
+	e.events.Publish(rule.RuleEvent{
+		GuildId: gId,
+		Op:      rule.RuleEventModLogUpdated,
+		ModLog:  &guild.GuildModLog{ChannelId: updatedGuild.ModLogChannelId},
+	})
+
+	return updatedGuild, nil
+}
+
+// UpdateGuild sets the owner and metadata of the guild, nil fields of u are kept.
+func (e *GuildService) UpdateGuild(gId string, u guild.GuildUpdate) (guild.Guild, error) {
+	return e.database.UpdateGuild(gId, u)
+}
+
+// DeleteGuild soft deletes the guild, it's restored if created again within the retention.
+func (e *GuildService) DeleteGuild(gId string) error {
+	return e.database.DeleteGuild(gId, time.Now())
+}
+
+// PurgeGuilds purges guilds deleted longer than the retention ago every interval until ctx is done.
+func (e *GuildService) PurgeGuilds(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		e.purgeGuilds()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (e *GuildService) purgeGuilds() {
+	purged, err := e.database.PurgeGuilds(time.Now().Add(-e.retention))
+
+	if err != nil {
+		e.logger.Error(err, map[string]any{"details": "error while purging deleted guilds"})
+		return
+	}
+
+	if purged > 0 {
+		e.logger.Info("Deleted guilds purged", map[string]any{"purged": purged, "retention": e.retention.String()})
+	}
+}
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"time"
+
+	"github.com/finkabaj/hyde-bot/internals/db"
+	"github.com/finkabaj/hyde-bot/internals/logger"
+	"github.com/finkabaj/hyde-bot/internals/utils/infraction"
+)
+
+type IInfractionService interface {
+	CreateInfraction(i infraction.InfractionCreate) (infraction.Infraction, error)
+	GetInfraction(gId string, id int64) (infraction.Infraction, error)
+	GetInfractions(query infraction.InfractionQuery) (infraction.InfractionPage, error)
+	UpdateInfraction(gId string, id int64, u infraction.InfractionUpdate) (infraction.Infraction, error)
+	DeleteInfraction(gId string, id int64) error
+}
+
+type InfractionService struct {
+	logger       logger.ILogger
+	database     db.Database
+	guildService IGuildService
+}
+
+var infractionService *InfractionService
+
+func NewInfractionService(l logger.ILogger, d db.Database, g IGuildService) *InfractionService {
+	if infractionService == nil {
+		infractionService = &InfractionService{
+			logger:       l,
+			database:     d,
+			guildService: g,
+		}
+	}
+	return infractionService
+}
+
+func (is *InfractionService) CreateInfraction(i infraction.InfractionCreate) (infraction.Infraction, error) {
+	if _, err := is.guildService.GetGuild(i.GuildId); err != nil {
+		return infraction.Infraction{}, err
+	}
+
+	if i.CreatedAt.IsZero() {
+		i.CreatedAt = time.Now().UTC()
+	}
+
+	return is.database.CreateInfraction(i)
+}
+
+func (is *InfractionService) GetInfraction(gId string, id int64) (infraction.Infraction, error) {
+	return is.database.ReadInfraction(gId, id)
+}
+
+// GetInfractions returns a page of infractions. Zero limit falls back to infraction.DefaultPageLimit.
+func (is *InfractionService) GetInfractions(query infraction.InfractionQuery) (infraction.InfractionPage, error) {
+	if _, err := is.guildService.GetGuild(query.GuildId); err != nil {
+		return infraction.InfractionPage{}, err
+	}
+
+	if query.Limit <= 0 {
+		query.Limit = infraction.DefaultPageLimit
+	}
+
+	query.Limit = min(query.Limit, infraction.MaxPageLimit)
+	query.Offset = max(query.Offset, 0)
+
+	infractions, total, err := is.database.ReadInfractions(query)
+
+	if err != nil {
+		return infraction.InfractionPage{}, err
+	}
+
+	return infraction.InfractionPage{Infractions: infractions, Total: total}, nil
+}
+
+func (is *InfractionService) UpdateInfraction(gId string, id int64, u infraction.InfractionUpdate) (infraction.Infraction, error) {
+	return is.database.UpdateInfraction(gId, id, u)
+}
+
+func (is *InfractionService) DeleteInfraction(gId string, id int64) error {
+	return is.database.DeleteInfraction(gId, id)
+}
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	mogs "github.com/finkabaj/hyde-bot/internals/backend/mocks"
+	"github.com/finkabaj/hyde-bot/internals/utils/common"
+	"github.com/finkabaj/hyde-bot/internals/utils/guild"
+	"github.com/finkabaj/hyde-bot/internals/utils/infraction"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var mockInfractionService = NewInfractionService(mogs.NewMockLogger(), mockDb, mockGuildService)
+
+func TestCreateInfraction(t *testing.T) {
+	t.Run("Positive", testCreateInfractionPositive)
+	t.Run("KeepsCreatedAt", testCreateInfractionKeepsCreatedAt)
+	t.Run("GuildNotFound", testCreateInfractionGuildNotFound)
+}
+
+func TestGetInfractions(t *testing.T) {
+	t.Run("DefaultLimit", testGetInfractionsDefaultLimit)
+	t.Run("MaxLimit", testGetInfractionsMaxLimit)
+	t.Run("GuildNotFound", testGetInfractionsGuildNotFound)
+}
+
+func testCreateInfractionPositive(t *testing.T) {
+	gId := "infraction-create"
+	create := infraction.InfractionCreate{
+		GuildId: gId,
+		UserId:  "user",
+		Source:  infraction.SourceRule,
+		Action:  infraction.Kick,
+	}
+	expectedResult := infraction.Infraction{Id: 1, GuildId: gId, UserId: "user", Source: infraction.SourceRule, Action: infraction.Kick}
+
+	mockGuildService.On("GetGuild", gId).Return(guild.Guild{GuildId: gId}, nil)
+	mockDb.On("CreateInfraction", mock.MatchedBy(func(i infraction.InfractionCreate) bool {
+		return i.GuildId == gId && time.Since(i.CreatedAt) < time.Minute
+	})).Return(expectedResult, nil)
+
+	actualResult, err := mockInfractionService.CreateInfraction(create)
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedResult, actualResult)
+
+	mockGuildService.AssertExpectations(t)
+	mockDb.AssertExpectations(t)
+}
+
+func testCreateInfractionKeepsCreatedAt(t *testing.T) {
+	gId := "infraction-created-at"
+	create := infraction.InfractionCreate{
+		GuildId:   gId,
+		UserId:    "user",
+		Source:    infraction.SourceRule,
+		Action:    infraction.Warn,
+		CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	mockGuildService.On("GetGuild", gId).Return(guild.Guild{GuildId: gId}, nil)
+	mockDb.On("CreateInfraction", create).Return(infraction.Infraction{Id: 2, GuildId: gId}, nil)
+
+	_, err := mockInfractionService.CreateInfraction(create)
+
+	assert.Nil(t, err)
+
+	mockDb.AssertExpectations(t)
+}
+
+func testCreateInfractionGuildNotFound(t *testing.T) {
+	gId := "infraction-missing"
+
+	mockGuildService.On("GetGuild", gId).Return(guild.Guild{}, common.ErrNotFound)
+
+	_, err := mockInfractionService.CreateInfraction(infraction.InfractionCreate{GuildId: gId})
+
+	assert.Equal(t, common.ErrNotFound, err)
+
+	mockDb.AssertNotCalled(t, "CreateInfraction", mock.MatchedBy(func(i infraction.InfractionCreate) bool {
+		return i.GuildId == gId
+	}))
+}
+
+func testGetInfractionsDefaultLimit(t *testing.T) {
+	gId := "infraction-default-limit"
+	expectedResult := infraction.InfractionPage{
+		Infractions: []infraction.Infraction{{Id: 1, GuildId: gId, UserId: "user"}},
+		Total:       1,
+	}
+
+	mockGuildService.On("GetGuild", gId).Return(guild.Guild{GuildId: gId}, nil)
+	mockDb.On("ReadInfractions", infraction.InfractionQuery{GuildId: gId, UserId: "user", Limit: infraction.DefaultPageLimit}).
+		Return(expectedResult.Infractions, 1, nil)
+
+	actualResult, err := mockInfractionService.GetInfractions(infraction.InfractionQuery{GuildId: gId, UserId: "user"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedResult, actualResult)
+
+	mockDb.AssertExpectations(t)
+}
+
+func testGetInfractionsMaxLimit(t *testing.T) {
+	gId := "infraction-max-limit"
+
+	mockGuildService.On("GetGuild", gId).Return(guild.Guild{GuildId: gId}, nil)
+	mockDb.On("ReadInfractions", infraction.InfractionQuery{GuildId: gId, Limit: infraction.MaxPageLimit, Offset: 5}).
+		Return([]infraction.Infraction{}, 0, nil)
+
+	_, err := mockInfractionService.GetInfractions(infraction.InfractionQuery{GuildId: gId, Limit: 1000, Offset: 5})
+
+	assert.Nil(t, err)
+
+	mockDb.AssertExpectations(t)
+}
+
+func testGetInfractionsGuildNotFound(t *testing.T) {
+	gId := "infraction-missing-guild"
+
+	mockGuildService.On("GetGuild", gId).Return(guild.Guild{}, common.ErrNotFound)
+
+	actualResult, err := mockInfractionService.GetInfractions(infraction.InfractionQuery{GuildId: gId})
+
+	assert.Equal(t, infraction.InfractionPage{}, actualResult)
+	assert.Equal(t, common.ErrNotFound, err)
+}
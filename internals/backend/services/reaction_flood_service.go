@@ -0,0 +1,103 @@
+package services
+
+import (
+	"github.com/finkabaj/hyde-bot/internals/db"
+	"github.com/finkabaj/hyde-bot/internals/logger"
+	"github.com/finkabaj/hyde-bot/internals/utils/common"
+	"github.com/finkabaj/hyde-bot/internals/utils/rule"
+)
+
+type IReactionFloodService interface {
+	GetReactionFloodRule(gId string) (rule.ReactionFloodRule, error)
+	SaveReactionFloodRule(gId string, u rule.ReactionFloodRuleUpdate) (rule.ReactionFloodRule, error)
+	DeleteReactionFloodRule(gId string) error
+}
+
+type ReactionFloodService struct {
+	logger       logger.ILogger
+	database     db.Database
+	guildService IGuildService
+	events       IRuleEventBroker
+}
+
+var reactionFloodService *ReactionFloodService
+
+func NewReactionFloodService(l logger.ILogger, d db.Database, g IGuildService, e IRuleEventBroker) *ReactionFloodService {
+	if reactionFloodService == nil {
+		reactionFloodService = &ReactionFloodService{
+			logger:       l,
+			database:     d,
+			guildService: g,
+			events:       e,
+		}
+	}
+	return reactionFloodService
+}
+
+// GetReactionFloodRule returns the flood rule of the guild or common.ErrNotFound if the guild has none.
+func (fs *ReactionFloodService) GetReactionFloodRule(gId string) (rule.ReactionFloodRule, error) {
+	if _, err := fs.guildService.GetGuild(gId); err != nil {
+		return rule.ReactionFloodRule{}, err
+	}
+
+	return fs.database.ReadReactionFloodRule(gId)
+}
+
+// SaveReactionFloodRule creates or replaces the flood rule of the guild.
+func (fs *ReactionFloodService) SaveReactionFloodRule(gId string, u rule.ReactionFloodRuleUpdate) (rule.ReactionFloodRule, error) {
+	if _, err := fs.guildService.GetGuild(gId); err != nil {
+		return rule.ReactionFloodRule{}, err
+	}
+
+	if !u.IsValid() {
+		return rule.ReactionFloodRule{}, common.ErrBadRequest
+	}
+
+	if !common.HaveActions(u.Actions) {
+		return rule.ReactionFloodRule{}, common.ErrBadRequest
+	}
+
+	if common.HaveInvalidActions(u.Actions) {
+		return rule.ReactionFloodRule{}, common.ErrBadRequest
+	}
+
+	if common.HaveDuplicatesActions(u.Actions) {
+		return rule.ReactionFloodRule{}, common.ErrBadRequest
+	}
+
+	saved, err := fs.database.SaveReactionFloodRule(rule.ReactionFloodRule{
+		GuildId:       gId,
+		MaxReactions:  u.MaxReactions,
+		WindowSeconds: u.WindowSeconds,
+		Actions:       u.Actions,
+	})
+
+	if err != nil {
+		return rule.ReactionFloodRule{}, err
+	}
+
+	fs.events.Publish(rule.RuleEvent{
+		GuildId:       gId,
+		Op:            rule.RuleEventReactionFloodUpdated,
+		ReactionFlood: &saved,
+	})
+
+	return saved, nil
+}
+
+func (fs *ReactionFloodService) DeleteReactionFloodRule(gId string) error {
+	if _, err := fs.guildService.GetGuild(gId); err != nil {
+		return err
+	}
+
+	if err := fs.database.DeleteReactionFloodRule(gId); err != nil {
+		return err
+	}
+
+	fs.events.Publish(rule.RuleEvent{
+		GuildId: gId,
+		Op:      rule.RuleEventReactionFloodDeleted,
+	})
+
+	return nil
+}
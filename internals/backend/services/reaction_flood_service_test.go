@@ -0,0 +1,117 @@
+package services
+
+import (
+	"testing"
+
+	mogs "github.com/finkabaj/hyde-bot/internals/backend/mocks"
+	"github.com/finkabaj/hyde-bot/internals/utils/common"
+	"github.com/finkabaj/hyde-bot/internals/utils/guild"
+	"github.com/finkabaj/hyde-bot/internals/utils/rule"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var reactionFloodEvents = &RuleEventBroker{logger: mogs.NewMockLogger(), subscribers: make(map[chan rule.RuleEvent]struct{})}
+var mockReactionFloodService = NewReactionFloodService(mogs.NewMockLogger(), mockDb, mockGuildService, reactionFloodEvents)
+
+func TestSaveReactionFloodRule(t *testing.T) {
+	t.Run("Positive", testSaveReactionFloodRulePositive)
+	t.Run("InvalidLimits", testSaveReactionFloodRuleInvalidLimits)
+	t.Run("DuplicateActions", testSaveReactionFloodRuleDuplicateActions)
+	t.Run("GuildNotFound", testSaveReactionFloodRuleGuildNotFound)
+}
+
+func TestDeleteReactionFloodRule(t *testing.T) {
+	t.Run("Positive", testDeleteReactionFloodRulePositive)
+	t.Run("NotFound", testDeleteReactionFloodRuleNotFound)
+}
+
+func testSaveReactionFloodRulePositive(t *testing.T) {
+	gId := "reaction-flood-save"
+	u := rule.ReactionFloodRuleUpdate{MaxReactions: 10, WindowSeconds: 30, Actions: rule.Actions{{Type: rule.Delete}, {Type: rule.Warn}}}
+	expectedResult := rule.ReactionFloodRule{GuildId: gId, MaxReactions: 10, WindowSeconds: 30, Actions: u.Actions}
+
+	events, unsubscribe := reactionFloodEvents.Subscribe()
+	defer unsubscribe()
+
+	mockGuildService.On("GetGuild", gId).Return(guild.Guild{GuildId: gId}, nil)
+	mockDb.On("SaveReactionFloodRule", expectedResult).Return(expectedResult, nil)
+
+	actualResult, err := mockReactionFloodService.SaveReactionFloodRule(gId, u)
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedResult, actualResult)
+	assert.Equal(t, rule.RuleEvent{
+		GuildId:       gId,
+		Op:            rule.RuleEventReactionFloodUpdated,
+		ReactionFlood: &expectedResult,
+	}, <-events)
+
+	mockDb.AssertExpectations(t)
+}
+
+func testSaveReactionFloodRuleInvalidLimits(t *testing.T) {
+	gId := "reaction-flood-invalid"
+
+	mockGuildService.On("GetGuild", gId).Return(guild.Guild{GuildId: gId}, nil)
+
+	for _, u := range []rule.ReactionFloodRuleUpdate{
+		{MaxReactions: 0, WindowSeconds: 30, Actions: rule.Actions{{Type: rule.Delete}}},
+		{MaxReactions: rule.MaxFloodReactions + 1, WindowSeconds: 30, Actions: rule.Actions{{Type: rule.Delete}}},
+		{MaxReactions: 10, WindowSeconds: 601, Actions: rule.Actions{{Type: rule.Delete}}},
+		{MaxReactions: 10, WindowSeconds: 30, Actions: rule.Actions{}},
+	} {
+		_, err := mockReactionFloodService.SaveReactionFloodRule(gId, u)
+
+		assert.Equal(t, common.ErrBadRequest, err)
+	}
+
+	mockDb.AssertNotCalled(t, "SaveReactionFloodRule", mock.MatchedBy(func(f rule.ReactionFloodRule) bool { return f.GuildId == gId }))
+}
+
+func testSaveReactionFloodRuleDuplicateActions(t *testing.T) {
+	gId := "reaction-flood-duplicate"
+	u := rule.ReactionFloodRuleUpdate{MaxReactions: 10, WindowSeconds: 30, Actions: rule.Actions{{Type: rule.Kick}, {Type: rule.Kick}}}
+
+	mockGuildService.On("GetGuild", gId).Return(guild.Guild{GuildId: gId}, nil)
+
+	_, err := mockReactionFloodService.SaveReactionFloodRule(gId, u)
+
+	assert.Equal(t, common.ErrBadRequest, err)
+}
+
+func testSaveReactionFloodRuleGuildNotFound(t *testing.T) {
+	gId := "reaction-flood-missing"
+
+	mockGuildService.On("GetGuild", gId).Return(guild.Guild{}, common.ErrNotFound)
+
+	_, err := mockReactionFloodService.SaveReactionFloodRule(gId, rule.ReactionFloodRuleUpdate{})
+
+	assert.Equal(t, common.ErrNotFound, err)
+}
+
+func testDeleteReactionFloodRulePositive(t *testing.T) {
+	gId := "reaction-flood-delete"
+
+	events, unsubscribe := reactionFloodEvents.Subscribe()
+	defer unsubscribe()
+
+	mockGuildService.On("GetGuild", gId).Return(guild.Guild{GuildId: gId}, nil)
+	mockDb.On("DeleteReactionFloodRule", gId).Return(nil)
+
+	err := mockReactionFloodService.DeleteReactionFloodRule(gId)
+
+	assert.Nil(t, err)
+	assert.Equal(t, rule.RuleEvent{GuildId: gId, Op: rule.RuleEventReactionFloodDeleted}, <-events)
+}
+
+func testDeleteReactionFloodRuleNotFound(t *testing.T) {
+	gId := "reaction-flood-delete-missing"
+
+	mockGuildService.On("GetGuild", gId).Return(guild.Guild{GuildId: gId}, nil)
+	mockDb.On("DeleteReactionFloodRule", gId).Return(common.ErrNotFound)
+
+	err := mockReactionFloodService.DeleteReactionFloodRule(gId)
+
+	assert.Equal(t, common.ErrNotFound, err)
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"slices"
+
+	"github.com/finkabaj/hyde-bot/internals/db"
+	"github.com/finkabaj/hyde-bot/internals/logger"
+	"github.com/finkabaj/hyde-bot/internals/utils/rule"
+)
+
+type IReactionRoleService interface {
+	GetReactionRoles(gId string) ([]rule.ReactionRoleMessage, error)
+	SaveReactionRoles(gId string, messageId string, u rule.ReactionRoleMessageUpdate) (rule.ReactionRoleMessage, error)
+	DeleteReactionRoles(gId string, messageId string) error
+}
+
+type ReactionRoleService struct {
+	logger       logger.ILogger
+	database     db.Database
+	guildService IGuildService
+	events       IRuleEventBroker
+}
+
+var reactionRoleService *ReactionRoleService
+
+func NewReactionRoleService(l logger.ILogger, d db.Database, g IGuildService, e IRuleEventBroker) *ReactionRoleService {
+	if reactionRoleService == nil {
+		reactionRoleService = &ReactionRoleService{
+			logger:       l,
+			database:     d,
+			guildService: g,
+			events:       e,
+		}
+	}
+	return reactionRoleService
+}
+
+func (rs *ReactionRoleService) GetReactionRoles(gId string) ([]rule.ReactionRoleMessage, error) {
+	if _, err := rs.guildService.GetGuild(gId); err != nil {
+		return nil, err
+	}
+
+	return rs.database.ReadReactionRoleMessages(gId)
+}
+
+// SaveReactionRoles creates or replaces reaction roles of the message. Returns rule.ErrDuplicateReactionRoles
+// if two roles have the same emoji and rule.ErrReactionRoleConflict if a reaction rule is enforced on one of the emojis in the channel.
+func (rs *ReactionRoleService) SaveReactionRoles(gId string, messageId string, u rule.ReactionRoleMessageUpdate) (rule.ReactionRoleMessage, error) {
+	if _, err := rs.guildService.GetGuild(gId); err != nil {
+		return rule.ReactionRoleMessage{}, err
+	}
+
+	if rule.HaveDuplicateReactionRoles(u.Roles) {
+		return rule.ReactionRoleMessage{}, rule.ErrDuplicateReactionRoles
+	}
+
+	m := rule.ReactionRoleMessage{
+		GuildId:   gId,
+		ChannelId: u.ChannelId,
+		MessageId: messageId,
+		Mode:      u.Mode,
+		Roles:     u.Roles,
+	}
+
+	reactionRules, err := rs.database.ReadReactionRules(gId)
+
+	if err != nil {
+		return rule.ReactionRoleMessage{}, err
+	}
+
+	if slices.ContainsFunc(reactionRules, m.ConflictsWith) {
+		return rule.ReactionRoleMessage{}, rule.ErrReactionRoleConflict
+	}
+
+	saved, err := rs.database.SaveReactionRoleMessage(m)
+
+	if err != nil {
+		return rule.ReactionRoleMessage{}, err
+	}
+
+	rs.events.Publish(rule.RuleEvent{
+		GuildId:       gId,
+		Op:            rule.RuleEventReactionRolesUpdated,
+		ReactionRoles: []rule.ReactionRoleMessage{saved},
+	})
+
+	return saved, nil
+}
+
+func (rs *ReactionRoleService) DeleteReactionRoles(gId string, messageId string) error {
+	if _, err := rs.guildService.GetGuild(gId); err != nil {
+		return err
+	}
+
+	if err := rs.database.DeleteReactionRoleMessage(gId, messageId); err != nil {
+		return err
+	}
+
+	rs.events.Publish(rule.RuleEvent{
+		GuildId:                     gId,
+		Op:                          rule.RuleEventReactionRolesDeleted,
+		DeletedReactionRoleMessages: []string{messageId},
+	})
+
+	return nil
+}
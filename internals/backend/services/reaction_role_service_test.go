@@ -0,0 +1,121 @@
+package services
+
+import (
+	"testing"
+
+	mogs "github.com/finkabaj/hyde-bot/internals/backend/mocks"
+	"github.com/finkabaj/hyde-bot/internals/utils/common"
+	"github.com/finkabaj/hyde-bot/internals/utils/guild"
+	"github.com/finkabaj/hyde-bot/internals/utils/rule"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var reactionRoleEvents = &RuleEventBroker{logger: mogs.NewMockLogger(), subscribers: make(map[chan rule.RuleEvent]struct{})}
+var mockReactionRoleService = NewReactionRoleService(mogs.NewMockLogger(), mockDb, mockGuildService, reactionRoleEvents)
+
+func TestSaveReactionRoles(t *testing.T) {
+	t.Run("Positive", testSaveReactionRolesPositive)
+	t.Run("DuplicateEmojis", testSaveReactionRolesDuplicateEmojis)
+	t.Run("Conflict", testSaveReactionRolesConflict)
+	t.Run("RuleInOtherChannel", testSaveReactionRolesRuleInOtherChannel)
+	t.Run("GuildNotFound", testSaveReactionRolesGuildNotFound)
+}
+
+func testSaveReactionRolesPositive(t *testing.T) {
+	gId := "reaction-roles-save"
+	u := rule.ReactionRoleMessageUpdate{
+		ChannelId: "10",
+		Mode:      rule.ReactionRoleToggle,
+		Roles:     []rule.ReactionRole{{EmojiName: "🔴", RoleId: "1"}},
+	}
+	expectedResult := rule.ReactionRoleMessage{GuildId: gId, ChannelId: "10", MessageId: "20", Mode: u.Mode, Roles: u.Roles}
+
+	events, unsubscribe := reactionRoleEvents.Subscribe()
+	defer unsubscribe()
+
+	mockGuildService.On("GetGuild", gId).Return(guild.Guild{GuildId: gId}, nil)
+	mockDb.On("ReadReactionRules", gId).Return([]rule.ReactionRule{}, nil)
+	mockDb.On("SaveReactionRoleMessage", expectedResult).Return(expectedResult, nil)
+
+	actualResult, err := mockReactionRoleService.SaveReactionRoles(gId, "20", u)
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedResult, actualResult)
+	assert.Equal(t, rule.RuleEvent{
+		GuildId:       gId,
+		Op:            rule.RuleEventReactionRolesUpdated,
+		ReactionRoles: []rule.ReactionRoleMessage{expectedResult},
+	}, <-events)
+
+	mockDb.AssertExpectations(t)
+}
+
+func testSaveReactionRolesDuplicateEmojis(t *testing.T) {
+	gId := "reaction-roles-duplicate"
+	u := rule.ReactionRoleMessageUpdate{
+		ChannelId: "10",
+		Mode:      rule.ReactionRoleUnique,
+		Roles:     []rule.ReactionRole{{EmojiName: "🔴", RoleId: "1"}, {EmojiName: "🔴", RoleId: "2"}},
+	}
+
+	mockGuildService.On("GetGuild", gId).Return(guild.Guild{GuildId: gId}, nil)
+
+	_, err := mockReactionRoleService.SaveReactionRoles(gId, "20", u)
+
+	assert.Equal(t, rule.ErrDuplicateReactionRoles, err)
+
+	mockDb.AssertNotCalled(t, "SaveReactionRoleMessage", mock.MatchedBy(func(m rule.ReactionRoleMessage) bool { return m.GuildId == gId }))
+}
+
+func testSaveReactionRolesConflict(t *testing.T) {
+	gId := "reaction-roles-conflict"
+	u := rule.ReactionRoleMessageUpdate{
+		ChannelId: "10",
+		Mode:      rule.ReactionRoleVerify,
+		Roles:     []rule.ReactionRole{{EmojiName: "blue", EmojiId: "123", RoleId: "1"}},
+	}
+
+	mockGuildService.On("GetGuild", gId).Return(guild.Guild{GuildId: gId}, nil)
+	mockDb.On("ReadReactionRules", gId).Return([]rule.ReactionRule{
+		{GuildId: gId, EmojiName: "blue", EmojiId: "123", IsCustom: true, Actions: rule.Actions{{Type: rule.Delete}}, IncludeChannels: []string{"10"}},
+	}, nil)
+
+	_, err := mockReactionRoleService.SaveReactionRoles(gId, "20", u)
+
+	assert.Equal(t, rule.ErrReactionRoleConflict, err)
+
+	mockDb.AssertNotCalled(t, "SaveReactionRoleMessage", mock.MatchedBy(func(m rule.ReactionRoleMessage) bool { return m.GuildId == gId }))
+}
+
+func testSaveReactionRolesRuleInOtherChannel(t *testing.T) {
+	gId := "reaction-roles-other-channel"
+	u := rule.ReactionRoleMessageUpdate{
+		ChannelId: "10",
+		Mode:      rule.ReactionRoleToggle,
+		Roles:     []rule.ReactionRole{{EmojiName: "🔴", RoleId: "1"}},
+	}
+	expectedResult := rule.ReactionRoleMessage{GuildId: gId, ChannelId: "10", MessageId: "20", Mode: u.Mode, Roles: u.Roles}
+
+	mockGuildService.On("GetGuild", gId).Return(guild.Guild{GuildId: gId}, nil)
+	mockDb.On("ReadReactionRules", gId).Return([]rule.ReactionRule{
+		{GuildId: gId, EmojiName: "🔴", Actions: rule.Actions{{Type: rule.Delete}}, ExcludeChannels: []string{"10"}},
+	}, nil)
+	mockDb.On("SaveReactionRoleMessage", expectedResult).Return(expectedResult, nil)
+
+	_, err := mockReactionRoleService.SaveReactionRoles(gId, "20", u)
+
+	assert.Nil(t, err, "rules not enforced in the channel don't conflict")
+}
+
+func testSaveReactionRolesGuildNotFound(t *testing.T) {
+	gId := "reaction-roles-missing"
+
+	mockGuildService.On("GetGuild", gId).Return(guild.Guild{}, common.ErrNotFound)
+
+	_, err := mockReactionRoleService.SaveReactionRoles(gId, "20", rule.ReactionRoleMessageUpdate{})
+
+	assert.Equal(t, common.ErrNotFound, err)
+
+	mockDb.AssertNotCalled(t, "ReadReactionRules", gId)
+}
@@ -13,22 +13,25 @@ type IReactionService interface {
 	CreateReactionRules(rules []rule.ReactionRule) ([]rule.ReactionRule, error)
 	GetReactionRules(gId string) ([]rule.ReactionRule, error)
 	DeleteReactionRules(query []rule.DeleteReactionRuleQuery, gId string) error
+	UpdateReactionRules(rules []rule.ReactionRuleUpdate, gId string) ([]rule.ReactionRule, error)
 }
 
 type ReactionService struct {
 	logger       logger.ILogger
 	database     db.Database
 	guildService IGuildService
+	events       IRuleEventBroker
 }
 
 var reactionService *ReactionService
 
-func NewReactionService(l logger.ILogger, d db.Database, g IGuildService) *ReactionService {
+func NewReactionService(l logger.ILogger, d db.Database, g IGuildService, e IRuleEventBroker) *ReactionService {
 	if reactionService == nil {
 		reactionService = &ReactionService{
 			logger:       l,
 			database:     d,
 			guildService: g,
+			events:       e,
 		}
 	}
 	return reactionService
@@ -68,9 +71,33 @@ func (rs *ReactionService) CreateReactionRules(rules []rule.ReactionRule) ([]rul
 			return []rule.ReactionRule{}, common.ErrBadRequest
 		}
 
+		if !common.HaveActions(v.Actions) {
+			return []rule.ReactionRule{}, common.ErrBadRequest
+		}
+
+		if common.HaveInvalidActions(v.Actions) {
+			return []rule.ReactionRule{}, common.ErrBadRequest
+		}
+
 		if common.HaveDuplicatesActions(v.Actions) {
 			return []rule.ReactionRule{}, common.ErrBadRequest
 		}
+
+		if common.HaveIntersection(v.IncludeChannels, v.ExcludeChannels) {
+			return []rule.ReactionRule{}, common.ErrBadRequest
+		}
+
+		if v.Threshold != nil && !v.Threshold.IsValid() {
+			return []rule.ReactionRule{}, common.ErrBadRequest
+		}
+
+		if !v.IsValidMatcher() {
+			return []rule.ReactionRule{}, common.ErrBadRequest
+		}
+	}
+
+	if err := rs.checkReactionRoles(gId, rules); err != nil {
+		return []rule.ReactionRule{}, err
 	}
 
 	createdRules, err := rs.database.CreateReactionRules(rules)
@@ -79,6 +106,12 @@ func (rs *ReactionService) CreateReactionRules(rules []rule.ReactionRule) ([]rul
 		return []rule.ReactionRule{}, err
 	}
 
+	rs.events.Publish(rule.RuleEvent{
+		GuildId:       gId,
+		Op:            rule.RuleEventCreated,
+		ReactionRules: createdRules,
+	})
+
 	return createdRules, nil
 }
 
@@ -121,5 +154,93 @@ func (rs *ReactionService) DeleteReactionRules(query []rule.DeleteReactionRuleQu
 		return err
 	}
 
+	rs.events.Publish(rule.RuleEvent{
+		GuildId:              gId,
+		Op:                   rule.RuleEventDeleted,
+		DeletedReactionRules: query,
+	})
+
+	return nil
+}
+
+func (rs *ReactionService) UpdateReactionRules(rules []rule.ReactionRuleUpdate, gId string) ([]rule.ReactionRule, error) {
+	if len(rules) < 1 {
+		return []rule.ReactionRule{}, common.ErrBadRequest
+	}
+
+	_, err := rs.guildService.GetGuild(gId)
+
+	if err != nil {
+		return []rule.ReactionRule{}, err
+	}
+
+	for _, r := range rules {
+		if r.EmojiId == "" && r.EmojiName == "" {
+			return []rule.ReactionRule{}, common.ErrBadRequest
+		}
+
+		if !common.HaveActions(r.Actions) {
+			return []rule.ReactionRule{}, common.ErrBadRequest
+		}
+
+		if common.HaveInvalidActions(r.Actions) {
+			return []rule.ReactionRule{}, common.ErrBadRequest
+		}
+
+		if common.HaveDuplicatesActions(r.Actions) {
+			return []rule.ReactionRule{}, common.ErrBadRequest
+		}
+	}
+
+	foundRules, err := rs.database.ReadReactionRules(gId)
+
+	if err != nil {
+		return []rule.ReactionRule{}, err
+	}
+
+	updated := slices.DeleteFunc(foundRules, func(r rule.ReactionRule) bool {
+		return !slices.ContainsFunc(rules, func(u rule.ReactionRuleUpdate) bool {
+			return r.EmojiName == u.EmojiName && r.EmojiId == u.EmojiId
+		})
+	})
+
+	if err := rs.checkReactionRoles(gId, updated); err != nil {
+		return []rule.ReactionRule{}, err
+	}
+
+	updatedRules, err := rs.database.UpdateReactionRules(rules, gId)
+
+	if err != nil {
+		return []rule.ReactionRule{}, err
+	}
+
+	rs.events.Publish(rule.RuleEvent{
+		GuildId:       gId,
+		Op:            rule.RuleEventUpdated,
+		ReactionRules: updatedRules,
+	})
+
+	return updatedRules, nil
+}
+
+// checkReactionRoles returns rule.ErrReactionRoleConflict if one of the rules is enforced on an emoji
+// of a reaction role message in its channel, the rule would never be enforced there.
+func (rs *ReactionService) checkReactionRoles(gId string, rules []rule.ReactionRule) error {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	messages, err := rs.database.ReadReactionRoleMessages(gId)
+
+	if err != nil {
+		return err
+	}
+
+	for _, m := range messages {
+		if slices.ContainsFunc(rules, m.ConflictsWith) {
+			return rule.ErrReactionRoleConflict
+		}
+	}
+
 	return nil
 }
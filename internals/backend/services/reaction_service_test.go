@@ -1,6 +1,7 @@
 package services
 
 import (
+	"strings"
 	"testing"
 
 	mogs "github.com/finkabaj/hyde-bot/internals/backend/mocks"
@@ -12,9 +13,7 @@ import (
 
 var mockDb = mogs.NewDbMock()
 var mockGuildService = mogs.NewMockGuildService()
-var mockReactionService = NewReactionService(mogs.NewMockLogger(), mockDb, mockGuildService)
-
-const rac = rule.ReactActionCount
+var mockReactionService = NewReactionService(mogs.NewMockLogger(), mockDb, mockGuildService, NewRuleEventBroker(mogs.NewMockLogger()))
 
 func TestGetReactionRules(t *testing.T) {
 	t.Run("Positive", testGetReactionRulesPositive)
@@ -32,9 +31,26 @@ func TestCreateReactionRules(t *testing.T) {
 	t.Run("EmptyEmojiIdAndName", testCreateReactionRulesEmptyEmojiIdAndName)
 	t.Run("EmptyActions", testCreateReactionRulesEmptyActions)
 	t.Run("DuplicateActions", testCreateReactionRulesDuplicateActions)
+	t.Run("InvalidActions", testCreateReactionRulesInvalidActions)
+	t.Run("InvalidTimeout", testCreateReactionRulesInvalidTimeout)
+	t.Run("IntersectingChannels", testCreateReactionRulesIntersectingChannels)
+	t.Run("InvalidThreshold", testCreateReactionRulesInvalidThreshold)
+	t.Run("InvalidMatcher", testCreateReactionRulesInvalidMatcher)
+	t.Run("ReactionRoleConflict", testCreateReactionRulesReactionRoleConflict)
 	t.Run("DbReturnError", testCreateReactionRulesDbReturnError)
 }
 
+func TestUpdateReactionRules(t *testing.T) {
+	t.Run("Positive", testUpdateReactionRulesPositive)
+	t.Run("MinLen", testUpdateReactionRulesMinLen)
+	t.Run("GuildNotFound", testUpdateReactionRulesGuildNotFound)
+	t.Run("EmptyEmojiIdAndName", testUpdateReactionRulesEmptyEmojiIdAndName)
+	t.Run("EmptyActions", testUpdateReactionRulesEmptyActions)
+	t.Run("DuplicateActions", testUpdateReactionRulesDuplicateActions)
+	t.Run("RuleNotFound", testUpdateReactionRulesRuleNotFound)
+	t.Run("ReactionRoleConflict", testUpdateReactionRulesReactionRoleConflict)
+}
+
 func TestDeleteReactionRules(t *testing.T) {
 	t.Run("Positive", testDeleteReactionRulesPositive)
 	t.Run("MinLen", testDeleteReactionRulesMinLen)
@@ -54,13 +70,13 @@ func testGetReactionRulesPositive(t *testing.T) {
 			GuildId:    gId,
 			RuleAuthor: "asdsa",
 			EmojiId:    "1231",
-			Actions:    [rac]rule.ReactAction{1, 2},
+			Actions:    rule.Actions{{Type: 1}, {Type: 2}},
 		},
 		{
 			GuildId:    gId,
 			RuleAuthor: "fsd",
 			EmojiName:  "das",
-			Actions:    [rac]rule.ReactAction{1},
+			Actions:    rule.Actions{{Type: 1}},
 		},
 	}
 
@@ -116,18 +132,19 @@ func testCreateReactionRulesPositive(t *testing.T) {
 			EmojiName:  "🚌",
 			RuleAuthor: "me",
 			GuildId:    gId,
-			Actions:    [rac]rule.ReactAction{0, 1},
+			Actions:    rule.Actions{{Type: 1}},
 		},
 		{
 			EmojiId:    "1337",
 			RuleAuthor: "not me",
 			GuildId:    gId,
-			Actions:    [rac]rule.ReactAction{2},
+			Actions:    rule.Actions{{Type: 2}},
 		},
 	}
 
 	mockGuildService.On("GetGuild", gId).Return(guild.Guild{}, nil)
 	mockDb.On("ReadReactionRules", gId).Return([]rule.ReactionRule{}, nil)
+	mockDb.On("ReadReactionRoleMessages", gId).Return([]rule.ReactionRoleMessage{}, nil)
 	mockDb.On("CreateReactionRules", expectedResult).Return(expectedResult, nil)
 
 	actualResult, err := mockReactionService.CreateReactionRules(expectedResult)
@@ -159,7 +176,7 @@ func testCreateReactionRulesGuildNotFound(t *testing.T) {
 		GuildId:    gId,
 		RuleAuthor: "me)",
 		EmojiId:    "131",
-		Actions:    [rac]rule.ReactAction{0},
+		Actions:    rule.Actions{{Type: rule.Delete}},
 	}})
 
 	assert.Equal(t, []rule.ReactionRule{}, actualResponse)
@@ -177,19 +194,19 @@ func testCreateReactionRulesNotEqualGID(t *testing.T) {
 			GuildId:    gId,
 			RuleAuthor: "fsdf",
 			EmojiId:    "fsfsgf",
-			Actions:    [rac]rule.ReactAction{0},
+			Actions:    rule.Actions{{Type: rule.Delete}},
 		},
 		{
 			GuildId:    gId,
 			RuleAuthor: "sdfds",
 			EmojiName:  "1",
-			Actions:    [rac]rule.ReactAction{0, 1},
+			Actions:    rule.Actions{{Type: 1}},
 		},
 		{
 			GuildId:    "fdsf",
 			RuleAuthor: "fs",
 			EmojiId:    "ffsd",
-			Actions:    [rac]rule.ReactAction{0, 2},
+			Actions:    rule.Actions{{Type: 2}},
 		},
 	}
 
@@ -213,13 +230,13 @@ func testCreateReactionRulesEmojiIdConflict(t *testing.T) {
 			GuildId:    gId,
 			RuleAuthor: "fsdf",
 			EmojiId:    "fsfsgf",
-			Actions:    [rac]rule.ReactAction{0},
+			Actions:    rule.Actions{{Type: rule.Delete}},
 		},
 		{
 			GuildId:    gId,
 			RuleAuthor: "sdfds",
 			EmojiId:    "vsd",
-			Actions:    [rac]rule.ReactAction{0, 1},
+			Actions:    rule.Actions{{Type: 1}},
 		},
 	}
 	foundRules := []rule.ReactionRule{
@@ -227,13 +244,13 @@ func testCreateReactionRulesEmojiIdConflict(t *testing.T) {
 			GuildId:    gId,
 			RuleAuthor: "fsdf",
 			EmojiId:    "sdvsvs",
-			Actions:    [rac]rule.ReactAction{0},
+			Actions:    rule.Actions{{Type: rule.Delete}},
 		},
 		{
 			GuildId:    gId,
 			RuleAuthor: "sdfds",
 			EmojiId:    "vsd",
-			Actions:    [rac]rule.ReactAction{0, 1},
+			Actions:    rule.Actions{{Type: 1}},
 		},
 	}
 
@@ -257,13 +274,13 @@ func testCreateReactionRulesEmojiNameConflict(t *testing.T) {
 			GuildId:    gId,
 			RuleAuthor: "fsdf",
 			EmojiName:  "🚌",
-			Actions:    [rac]rule.ReactAction{0},
+			Actions:    rule.Actions{{Type: rule.Delete}},
 		},
 		{
 			GuildId:    gId,
 			RuleAuthor: "sdfds",
 			EmojiName:  "131",
-			Actions:    [rac]rule.ReactAction{0, 1},
+			Actions:    rule.Actions{{Type: 1}},
 		},
 	}
 	foundRules := []rule.ReactionRule{
@@ -271,13 +288,13 @@ func testCreateReactionRulesEmojiNameConflict(t *testing.T) {
 			GuildId:    gId,
 			RuleAuthor: "fsdf",
 			EmojiName:  "🚌",
-			Actions:    [rac]rule.ReactAction{0},
+			Actions:    rule.Actions{{Type: rule.Delete}},
 		},
 		{
 			GuildId:    gId,
 			RuleAuthor: "sdfds",
 			EmojiName:  "1",
-			Actions:    [rac]rule.ReactAction{0, 1},
+			Actions:    rule.Actions{{Type: 1}},
 		},
 	}
 
@@ -300,7 +317,7 @@ func testCreateReactionRulesEmptyEmojiIdAndName(t *testing.T) {
 		{
 			GuildId:    gId,
 			RuleAuthor: "fsdf",
-			Actions:    [rac]rule.ReactAction{0},
+			Actions:    rule.Actions{{Type: rule.Delete}},
 		},
 	}
 
@@ -324,7 +341,7 @@ func testCreateReactionRulesEmptyActions(t *testing.T) {
 			GuildId:    gId,
 			RuleAuthor: "fsdf",
 			EmojiId:    "123",
-			Actions:    [rac]rule.ReactAction{},
+			Actions:    rule.Actions{},
 		},
 	}
 
@@ -348,7 +365,7 @@ func testCreateReactionRulesDuplicateActions(t *testing.T) {
 			GuildId:    gId,
 			RuleAuthor: "fsdf",
 			EmojiId:    "123",
-			Actions:    [rac]rule.ReactAction{0, 0},
+			Actions:    rule.Actions{{Type: rule.Delete}, {Type: rule.Delete}},
 		},
 	}
 
@@ -365,6 +382,178 @@ func testCreateReactionRulesDuplicateActions(t *testing.T) {
 	mockDb.AssertNotCalled(t, "CreateReactionRules")
 }
 
+func testCreateReactionRulesInvalidActions(t *testing.T) {
+	gId := "invalidActions"
+	rules := []rule.ReactionRule{
+		{
+			GuildId:    gId,
+			RuleAuthor: "fsdf",
+			EmojiId:    "123",
+			Actions:    rule.Actions{{Type: rule.Delete}, {Type: 42}},
+		},
+	}
+
+	mockGuildService.On("GetGuild", gId).Return(guild.Guild{}, nil)
+	mockDb.On("ReadReactionRules", gId).Return([]rule.ReactionRule{}, nil)
+
+	actualResponse, err := mockReactionService.CreateReactionRules(rules)
+
+	assert.Equal(t, []rule.ReactionRule{}, actualResponse)
+	assert.Equal(t, common.ErrBadRequest, err)
+
+	mockGuildService.AssertExpectations(t)
+	mockDb.AssertExpectations(t)
+	mockDb.AssertNotCalled(t, "CreateReactionRules", rules)
+}
+
+func testCreateReactionRulesInvalidTimeout(t *testing.T) {
+	gId := "invalidTimeout"
+
+	mockGuildService.On("GetGuild", gId).Return(guild.Guild{}, nil)
+	mockDb.On("ReadReactionRules", gId).Return([]rule.ReactionRule{}, nil)
+
+	for _, actions := range []rule.Actions{
+		{{Type: rule.Timeout}},
+		{{Type: rule.Timeout, DurationSeconds: int(rule.MaxTimeout.Seconds()) + 1}},
+		{{Type: rule.Delete, DurationSeconds: 60}},
+	} {
+		rules := []rule.ReactionRule{
+			{
+				GuildId:    gId,
+				RuleAuthor: "fsdf",
+				EmojiId:    "123",
+				Actions:    actions,
+			},
+		}
+
+		actualResponse, err := mockReactionService.CreateReactionRules(rules)
+
+		assert.Equal(t, []rule.ReactionRule{}, actualResponse)
+		assert.Equal(t, common.ErrBadRequest, err)
+	}
+
+	mockGuildService.AssertExpectations(t)
+	mockDb.AssertExpectations(t)
+	mockDb.AssertNotCalled(t, "CreateReactionRules")
+}
+
+func testCreateReactionRulesInvalidThreshold(t *testing.T) {
+	gId := "invalidThreshold"
+
+	mockGuildService.On("GetGuild", gId).Return(guild.Guild{}, nil)
+	mockDb.On("ReadReactionRules", gId).Return([]rule.ReactionRule{}, nil)
+
+	for _, threshold := range []rule.ReactionThreshold{
+		{Count: 1, WindowSeconds: 600},
+		{Count: rule.MaxThresholdCount + 1, WindowSeconds: 600},
+		{Count: 5},
+		{Count: 5, WindowSeconds: int(rule.MaxThresholdWindow.Seconds()) + 1},
+	} {
+		rules := []rule.ReactionRule{
+			{
+				GuildId:    gId,
+				RuleAuthor: "fsdf",
+				EmojiId:    "123",
+				Actions:    rule.Actions{{Type: rule.Delete}},
+				Threshold:  &threshold,
+			},
+		}
+
+		actualResponse, err := mockReactionService.CreateReactionRules(rules)
+
+		assert.Equal(t, []rule.ReactionRule{}, actualResponse)
+		assert.Equal(t, common.ErrBadRequest, err)
+	}
+
+	mockGuildService.AssertExpectations(t)
+	mockDb.AssertExpectations(t)
+	mockDb.AssertNotCalled(t, "CreateReactionRules")
+}
+
+func testCreateReactionRulesInvalidMatcher(t *testing.T) {
+	gId := "invalidMatcher"
+
+	mockGuildService.On("GetGuild", gId).Return(guild.Guild{}, nil)
+	mockDb.On("ReadReactionRules", gId).Return([]rule.ReactionRule{}, nil)
+
+	for _, r := range []rule.ReactionRule{
+		{EmojiName: "pepe", Match: rule.MatchBase},
+		{EmojiName: "🚩", Match: rule.MatchCategory},
+		{EmojiName: "flags", EmojiId: "123", Match: rule.MatchCategory},
+		{EmojiName: "nsfw_(", Match: rule.MatchPattern},
+		{EmojiName: "^" + strings.Repeat("a", rule.MaxPatternLength), Match: rule.MatchPattern},
+		{EmojiName: "🚩", Match: "unknown"},
+	} {
+		r.GuildId = gId
+		r.RuleAuthor = "fsdf"
+		r.Actions = rule.Actions{{Type: rule.Delete}}
+
+		actualResponse, err := mockReactionService.CreateReactionRules([]rule.ReactionRule{r})
+
+		assert.Equal(t, []rule.ReactionRule{}, actualResponse)
+		assert.Equal(t, common.ErrBadRequest, err)
+	}
+
+	mockGuildService.AssertExpectations(t)
+	mockDb.AssertExpectations(t)
+	mockDb.AssertNotCalled(t, "CreateReactionRules")
+}
+
+func testCreateReactionRulesReactionRoleConflict(t *testing.T) {
+	gId := "reactionRoleConflict"
+	rules := []rule.ReactionRule{
+		{
+			GuildId:         gId,
+			RuleAuthor:      "fsdf",
+			EmojiName:       "🚌",
+			Actions:         rule.Actions{{Type: rule.Delete}},
+			IncludeChannels: []string{"10"},
+		},
+	}
+
+	mockGuildService.On("GetGuild", gId).Return(guild.Guild{}, nil)
+	mockDb.On("ReadReactionRules", gId).Return([]rule.ReactionRule{}, nil)
+	mockDb.On("ReadReactionRoleMessages", gId).Return([]rule.ReactionRoleMessage{
+		{GuildId: gId, ChannelId: "20", MessageId: "1", Roles: []rule.ReactionRole{{EmojiName: "🚌", RoleId: "2"}}},
+		{GuildId: gId, ChannelId: "10", MessageId: "3", Roles: []rule.ReactionRole{{EmojiName: "🚌", RoleId: "2"}}},
+	}, nil)
+
+	actualResponse, err := mockReactionService.CreateReactionRules(rules)
+
+	assert.Equal(t, []rule.ReactionRule{}, actualResponse)
+	assert.Equal(t, rule.ErrReactionRoleConflict, err)
+
+	mockGuildService.AssertExpectations(t)
+	mockDb.AssertExpectations(t)
+	mockDb.AssertNotCalled(t, "CreateReactionRules")
+}
+
+func testCreateReactionRulesIntersectingChannels(t *testing.T) {
+	gId := "intersectingChannels"
+	rules := []rule.ReactionRule{
+		{
+			GuildId:         gId,
+			RuleAuthor:      "fsdf",
+			EmojiId:         "123",
+			Actions:         rule.Actions{{Type: rule.Delete}},
+			IncludeChannels: []string{"1", "2"},
+			ExcludeChannels: []string{"2"},
+		},
+	}
+
+	mockGuildService.On("GetGuild", gId).Return(guild.Guild{}, nil)
+	mockDb.On("ReadReactionRules", gId).Return([]rule.ReactionRule{}, nil)
+
+	actualResponse, err := mockReactionService.CreateReactionRules(rules)
+
+	assert.Equal(t, []rule.ReactionRule{}, actualResponse)
+	assert.Equal(t, common.ErrBadRequest, err)
+
+	mockGuildService.AssertExpectations(t)
+	mockDb.AssertExpectations(t)
+	mockDb.AssertNotCalled(t, "CreateReactionRules", rules)
+}
+
 func testCreateReactionRulesDbReturnError(t *testing.T) {
 	gId := "beepboop"
 	rules := []rule.ReactionRule{
@@ -372,12 +561,13 @@ func testCreateReactionRulesDbReturnError(t *testing.T) {
 			GuildId:    gId,
 			RuleAuthor: "fsdf",
 			EmojiId:    "123",
-			Actions:    [rac]rule.ReactAction{0},
+			Actions:    rule.Actions{{Type: rule.Delete}},
 		},
 	}
 
 	mockGuildService.On("GetGuild", gId).Return(guild.Guild{}, nil)
 	mockDb.On("ReadReactionRules", gId).Return([]rule.ReactionRule{}, nil)
+	mockDb.On("ReadReactionRoleMessages", gId).Return([]rule.ReactionRoleMessage{}, nil)
 	mockDb.On("CreateReactionRules", rules).Return([]rule.ReactionRule{}, common.ErrInternal)
 
 	actualResponse, err := mockReactionService.CreateReactionRules(rules)
@@ -481,3 +671,172 @@ func testDeleteReactionRulesDbReturnError(t *testing.T) {
 	mockGuildService.AssertExpectations(t)
 	mockDb.AssertExpectations(t)
 }
+
+func testUpdateReactionRulesPositive(t *testing.T) {
+	gId := "upd"
+	rules := []rule.ReactionRuleUpdate{
+		{
+			EmojiName: "🚌",
+			Actions:   rule.Actions{{Type: rule.Delete}, {Type: rule.Warn}},
+		},
+	}
+	expectedResult := []rule.ReactionRule{
+		{
+			EmojiName:  "🚌",
+			RuleAuthor: "me",
+			GuildId:    gId,
+			Actions:    rule.Actions{{Type: rule.Delete}, {Type: rule.Warn}},
+		},
+	}
+
+	mockGuildService.On("GetGuild", gId).Return(guild.Guild{}, nil)
+	mockDb.On("ReadReactionRules", gId).Return([]rule.ReactionRule{{EmojiName: "🚌", RuleAuthor: "me", GuildId: gId, Actions: rule.Actions{{Type: rule.Delete}}}}, nil)
+	mockDb.On("ReadReactionRoleMessages", gId).Return([]rule.ReactionRoleMessage{}, nil)
+	mockDb.On("UpdateReactionRules", rules, gId).Return(expectedResult, nil)
+
+	actualResult, err := mockReactionService.UpdateReactionRules(rules, gId)
+
+	assert.Equal(t, expectedResult, actualResult)
+	assert.Nil(t, err)
+
+	mockGuildService.AssertExpectations(t)
+	mockDb.AssertExpectations(t)
+}
+
+func testUpdateReactionRulesMinLen(t *testing.T) {
+	actualResult, err := mockReactionService.UpdateReactionRules([]rule.ReactionRuleUpdate{}, "updMinLen")
+
+	assert.Equal(t, []rule.ReactionRule{}, actualResult)
+	assert.Equal(t, common.ErrBadRequest, err)
+
+	mockGuildService.AssertNotCalled(t, "GetGuild", "updMinLen")
+}
+
+func testUpdateReactionRulesGuildNotFound(t *testing.T) {
+	gId := "updNotFound"
+	rules := []rule.ReactionRuleUpdate{
+		{
+			EmojiId:   "1",
+			EmojiName: "a",
+			Actions:   rule.Actions{{Type: rule.Delete}},
+		},
+	}
+
+	mockGuildService.On("GetGuild", gId).Return(guild.Guild{}, common.ErrNotFound)
+
+	actualResult, err := mockReactionService.UpdateReactionRules(rules, gId)
+
+	assert.Equal(t, []rule.ReactionRule{}, actualResult)
+	assert.Equal(t, common.ErrNotFound, err)
+
+	mockGuildService.AssertExpectations(t)
+	mockDb.AssertNotCalled(t, "UpdateReactionRules", rules, gId)
+}
+
+func testUpdateReactionRulesEmptyEmojiIdAndName(t *testing.T) {
+	gId := "updEmptyEmoji"
+	rules := []rule.ReactionRuleUpdate{
+		{
+			Actions: rule.Actions{{Type: rule.Delete}},
+		},
+	}
+
+	mockGuildService.On("GetGuild", gId).Return(guild.Guild{}, nil)
+
+	actualResult, err := mockReactionService.UpdateReactionRules(rules, gId)
+
+	assert.Equal(t, []rule.ReactionRule{}, actualResult)
+	assert.Equal(t, common.ErrBadRequest, err)
+
+	mockGuildService.AssertExpectations(t)
+	mockDb.AssertNotCalled(t, "UpdateReactionRules", rules, gId)
+}
+
+func testUpdateReactionRulesEmptyActions(t *testing.T) {
+	gId := "updEmptyActions"
+	rules := []rule.ReactionRuleUpdate{
+		{
+			EmojiName: "a",
+		},
+	}
+
+	mockGuildService.On("GetGuild", gId).Return(guild.Guild{}, nil)
+
+	actualResult, err := mockReactionService.UpdateReactionRules(rules, gId)
+
+	assert.Equal(t, []rule.ReactionRule{}, actualResult)
+	assert.Equal(t, common.ErrBadRequest, err)
+
+	mockGuildService.AssertExpectations(t)
+	mockDb.AssertNotCalled(t, "UpdateReactionRules", rules, gId)
+}
+
+func testUpdateReactionRulesDuplicateActions(t *testing.T) {
+	gId := "updDuplicateActions"
+	rules := []rule.ReactionRuleUpdate{
+		{
+			EmojiName: "a",
+			Actions:   rule.Actions{{Type: rule.Kick}, {Type: rule.Kick}},
+		},
+	}
+
+	mockGuildService.On("GetGuild", gId).Return(guild.Guild{}, nil)
+
+	actualResult, err := mockReactionService.UpdateReactionRules(rules, gId)
+
+	assert.Equal(t, []rule.ReactionRule{}, actualResult)
+	assert.Equal(t, common.ErrBadRequest, err)
+
+	mockGuildService.AssertExpectations(t)
+	mockDb.AssertNotCalled(t, "UpdateReactionRules", rules, gId)
+}
+
+func testUpdateReactionRulesRuleNotFound(t *testing.T) {
+	gId := "updRuleNotFound"
+	rules := []rule.ReactionRuleUpdate{
+		{
+			EmojiName: "a",
+			Actions:   rule.Actions{{Type: rule.Ban}},
+		},
+	}
+
+	mockGuildService.On("GetGuild", gId).Return(guild.Guild{}, nil)
+	mockDb.On("ReadReactionRules", gId).Return([]rule.ReactionRule{}, nil)
+	mockDb.On("UpdateReactionRules", rules, gId).Return([]rule.ReactionRule{}, common.ErrNotFound)
+
+	actualResult, err := mockReactionService.UpdateReactionRules(rules, gId)
+
+	assert.Equal(t, []rule.ReactionRule{}, actualResult)
+	assert.Equal(t, common.ErrNotFound, err)
+
+	mockGuildService.AssertExpectations(t)
+	mockDb.AssertExpectations(t)
+}
+
+func testUpdateReactionRulesReactionRoleConflict(t *testing.T) {
+	gId := "updReactionRoleConflict"
+	rules := []rule.ReactionRuleUpdate{
+		{
+			EmojiName: "a",
+			Actions:   rule.Actions{{Type: rule.Ban}},
+		},
+	}
+
+	mockGuildService.On("GetGuild", gId).Return(guild.Guild{}, nil)
+	mockDb.On("ReadReactionRules", gId).Return([]rule.ReactionRule{
+		{GuildId: gId, EmojiName: "a", Actions: rule.Actions{{Type: rule.Delete}}},
+		{GuildId: gId, EmojiName: "b", Actions: rule.Actions{{Type: rule.Delete}}},
+	}, nil)
+	mockDb.On("ReadReactionRoleMessages", gId).Return([]rule.ReactionRoleMessage{
+		{GuildId: gId, ChannelId: "10", MessageId: "1", Roles: []rule.ReactionRole{{EmojiName: "a", RoleId: "2"}}},
+	}, nil)
+
+	actualResult, err := mockReactionService.UpdateReactionRules(rules, gId)
+
+	assert.Equal(t, []rule.ReactionRule{}, actualResult)
+	assert.Equal(t, rule.ErrReactionRoleConflict, err)
+
+	mockGuildService.AssertExpectations(t)
+	mockDb.AssertExpectations(t)
+	mockDb.AssertNotCalled(t, "UpdateReactionRules", rules, gId)
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/finkabaj/hyde-bot/internals/logger"
+	"github.com/finkabaj/hyde-bot/internals/utils/rule"
+)
+
+// subscriberBuffer is how many events a subscriber can fall behind before events are dropped for it.
+const subscriberBuffer = 64
+
+type IRuleEventBroker interface {
+	Publish(e rule.RuleEvent)
+	// Subscribe returns a channel with all published events. Call unsubscribe when done reading.
+	Subscribe() (events <-chan rule.RuleEvent, unsubscribe func())
+}
+
+// RuleEventBroker fans out rule change events to subscribers of this API instance.
+type RuleEventBroker struct {
+	logger      logger.ILogger
+	subscribers map[chan rule.RuleEvent]struct{}
+	lock        sync.RWMutex
+}
+
+var ruleEventBroker *RuleEventBroker
+
+func NewRuleEventBroker(l logger.ILogger) *RuleEventBroker {
+	if ruleEventBroker == nil {
+		ruleEventBroker = &RuleEventBroker{
+			logger:      l,
+			subscribers: make(map[chan rule.RuleEvent]struct{}),
+		}
+	}
+	return ruleEventBroker
+}
+
+// Publish never blocks. If a subscriber is too slow, the event is dropped for it.
+func (b *RuleEventBroker) Publish(e rule.RuleEvent) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- e:
+		default:
+			b.logger.Warn(errors.New("rule event dropped"), map[string]any{"guildId": e.GuildId, "op": e.Op})
+		}
+	}
+}
+
+func (b *RuleEventBroker) Subscribe() (<-chan rule.RuleEvent, func()) {
+	ch := make(chan rule.RuleEvent, subscriberBuffer)
+
+	b.lock.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.lock.Unlock()
+
+	var once sync.Once
+
+	return ch, func() {
+		once.Do(func() {
+			b.lock.Lock()
+			delete(b.subscribers, ch)
+			b.lock.Unlock()
+			close(ch)
+		})
+	}
+}
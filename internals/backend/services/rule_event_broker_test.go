@@ -0,0 +1,58 @@
+package services
+
+import (
+	"testing"
+
+	mogs "github.com/finkabaj/hyde-bot/internals/backend/mocks"
+	"github.com/finkabaj/hyde-bot/internals/utils/rule"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleEventBroker(t *testing.T) {
+	t.Run("Publish", testRuleEventBrokerPublish)
+	t.Run("SlowSubscriber", testRuleEventBrokerSlowSubscriber)
+	t.Run("Unsubscribe", testRuleEventBrokerUnsubscribe)
+}
+
+func testRuleEventBrokerPublish(t *testing.T) {
+	broker := &RuleEventBroker{logger: mogs.NewMockLogger(), subscribers: make(map[chan rule.RuleEvent]struct{})}
+	expectedEvent := rule.RuleEvent{GuildId: "pub", Op: rule.RuleEventCreated}
+
+	first, unsubscribeFirst := broker.Subscribe()
+	defer unsubscribeFirst()
+	second, unsubscribeSecond := broker.Subscribe()
+	defer unsubscribeSecond()
+
+	broker.Publish(expectedEvent)
+
+	assert.Equal(t, expectedEvent, <-first)
+	assert.Equal(t, expectedEvent, <-second)
+}
+
+func testRuleEventBrokerSlowSubscriber(t *testing.T) {
+	broker := &RuleEventBroker{logger: mogs.NewMockLogger(), subscribers: make(map[chan rule.RuleEvent]struct{})}
+
+	events, unsubscribe := broker.Subscribe()
+	defer unsubscribe()
+
+	for i := 0; i < subscriberBuffer+10; i++ {
+		broker.Publish(rule.RuleEvent{GuildId: "slow"})
+	}
+
+	assert.Equal(t, subscriberBuffer, len(events))
+}
+
+func testRuleEventBrokerUnsubscribe(t *testing.T) {
+	broker := &RuleEventBroker{logger: mogs.NewMockLogger(), subscribers: make(map[chan rule.RuleEvent]struct{})}
+
+	events, unsubscribe := broker.Subscribe()
+	unsubscribe()
+	unsubscribe()
+
+	broker.Publish(rule.RuleEvent{GuildId: "unsub"})
+
+	_, ok := <-events
+
+	assert.False(t, ok)
+	assert.Empty(t, broker.subscribers)
+}
@@ -6,6 +6,8 @@ import (
 	"sync"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/finkabaj/hyde-bot/internals/actions"
+	"github.com/finkabaj/hyde-bot/internals/escalation"
 	"github.com/finkabaj/hyde-bot/internals/logger"
 	"github.com/finkabaj/hyde-bot/internals/rules"
 	commandUtils "github.com/finkabaj/hyde-bot/internals/utils/command"
@@ -23,17 +25,22 @@ type CommandManager struct {
 	commands            map[string]map[string]*Command // commands[name][guildID] = command
 	messageInteractions *commandUtils.MessageInteractions
 	rm                  *rules.RuleManager
+	executor            *actions.Executor
+	evaluator           *escalation.Evaluator
 	lock                sync.RWMutex
 }
 
 var cmdManagerInstance *CommandManager
 
-func NewCommandManager(rm *rules.RuleManager, messageInteractions *commandUtils.MessageInteractions) *CommandManager {
+func NewCommandManager(rm *rules.RuleManager, messageInteractions *commandUtils.MessageInteractions, executor *actions.Executor,
+	evaluator *escalation.Evaluator) *CommandManager {
 	if cmdManagerInstance == nil {
 		cmdManagerInstance = &CommandManager{
 			commands:            make(map[string]map[string]*Command),
 			messageInteractions: messageInteractions,
 			rm:                  rm,
+			executor:            executor,
+			evaluator:           evaluator,
 		}
 	}
 	return cmdManagerInstance
@@ -54,10 +61,42 @@ func (cm *CommandManager) RegisterDefaultCommandsToManager() {
 		CreateReactionRuleHandler(s, i, cm.rm)
 	}, guildID)
 
+	cm.RegisterCommandToManager(EditReactionRuleCommand, func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		EditReactionRuleHandler(s, i, cm.rm)
+	}, guildID)
+
+	cm.RegisterCommandToManager(ReactionExemptionsCommand, func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		ReactionExemptionsHandler(s, i, cm.rm)
+	}, guildID)
+
 	cm.RegisterCommandToManager(DeleteReactionRuleCommand, func(s *discordgo.Session, i *discordgo.InteractionCreate) {
 		DeleteReactionRulesCommandHandler(s, i, cm.rm, cm.messageInteractions)
 	}, guildID)
 
+	cm.RegisterCommandToManager(InfractionsCommand, func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		InfractionsHandler(s, i, cm.rm)
+	}, guildID)
+
+	cm.RegisterCommandToManager(WarnCommand, func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		WarnHandler(s, i, cm.rm, cm.executor, cm.evaluator)
+	}, guildID)
+
+	cm.RegisterCommandToManager(EscalationCommand, func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		EscalationHandler(s, i, cm.rm)
+	}, guildID)
+
+	cm.RegisterCommandToManager(ModLogCommand, func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		ModLogHandler(s, i, cm.rm)
+	}, guildID)
+
+	cm.RegisterCommandToManager(ReactionRolesCommand, func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		ReactionRolesHandler(s, i, cm.rm)
+	}, guildID)
+
+	cm.RegisterCommandToManager(ReactionFloodCommand, func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		ReactionFloodHandler(s, i, cm.rm)
+	}, guildID)
+
 	if os.Getenv("ENV") == "development" {
 		cm.RegisterCommandToManager(DeleteCommand, func(s *discordgo.Session, i *discordgo.InteractionCreate) {
 			DeleteCommandHandler(s, i, cm)
@@ -1,9 +1,17 @@
 package commands
 
 import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/bwmarrin/discordgo"
 	"github.com/finkabaj/hyde-bot/internals/logger"
 	"github.com/finkabaj/hyde-bot/internals/rules"
+	"github.com/finkabaj/hyde-bot/internals/utils/common"
+	"github.com/finkabaj/hyde-bot/internals/utils/rule"
 )
 
 var dmCreateReactionRulePermission = false
@@ -30,13 +38,25 @@ func CreateReactionRuleHandler(s *discordgo.Session, i *discordgo.InteractionCre
 							CustomID:    "emoji_ban",
 							Label:       "reactions",
 							Style:       discordgo.TextInputShort,
-							Placeholder: "provide reactions to ban by name or id, separated by space",
+							Placeholder: "reactions by name or id, or base:👍 category:flags pattern:^nsfw_",
 							Required:    true,
 							MaxLength:   300,
 							MinLength:   1,
 						},
 					},
 				},
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						discordgo.TextInput{
+							CustomID:    ReactionRuleThresholdCustomID,
+							Label:       "threshold (optional)",
+							Style:       discordgo.TextInputShort,
+							Placeholder: "act on the message once members react, e.g. 5 in 10m",
+							Required:    false,
+							MaxLength:   20,
+						},
+					},
+				},
 			},
 		},
 	})
@@ -46,3 +66,121 @@ func CreateReactionRuleHandler(s *discordgo.Session, i *discordgo.InteractionCre
 		return
 	}
 }
+
+const (
+	ReactionRuleActionsCustomID         = "reaction_rule_actions"
+	ReactionRuleIncludeChannelsCustomID = "reaction_rule_include_channels"
+	ReactionRuleExcludeChannelsCustomID = "reaction_rule_exclude_channels"
+	ReactionRuleExemptRolesCustomID     = "reaction_rule_exempt_roles"
+	ReactionRuleCreateCustomID          = "reaction_rule_create"
+	ReactionRuleCancelCustomID          = "reaction_rule_cancel"
+	// ReactionRuleThresholdCustomID is the input of the create reaction rules modal.
+	ReactionRuleThresholdCustomID = "reaction_rule_threshold"
+	// ReactionRuleCustomIDPrefix is shared by all components of the reaction rule configuration message.
+	ReactionRuleCustomIDPrefix = "reaction_rule_"
+)
+
+// ReactionRuleConfigTimeout is how long the admin has to configure submitted reaction rules.
+const ReactionRuleConfigTimeout = 60 * time.Second
+
+// maxSelectValues is the discord limit of values in a select menu.
+const maxSelectValues = 25
+
+var ErrInvalidThreshold = errors.New("invalid reaction threshold")
+
+// ParseReactionThreshold parses a threshold like "5 in 10m" or "5 10m". Empty input is no threshold and returns nil.
+func ParseReactionThreshold(input string) (*rule.ReactionThreshold, error) {
+	fields := slices.DeleteFunc(strings.Fields(input), func(f string) bool { return f == "in" })
+
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	if len(fields) != 2 {
+		return nil, ErrInvalidThreshold
+	}
+
+	count, err := strconv.Atoi(fields[0])
+
+	if err != nil {
+		return nil, ErrInvalidThreshold
+	}
+
+	window, err := common.ParseDuration(fields[1])
+
+	if err != nil || window%time.Second != 0 {
+		return nil, ErrInvalidThreshold
+	}
+
+	t := &rule.ReactionThreshold{Count: count, WindowSeconds: int(window.Seconds())}
+
+	if !t.IsValid() {
+		return nil, ErrInvalidThreshold
+	}
+
+	return t, nil
+}
+
+// ReactionRuleConfigComponents builds the second step of reaction rules creation,
+// where admin selects actions, channels where the rules apply and roles exempt from the rules.
+func ReactionRuleConfigComponents(actions rule.Actions) []discordgo.MessageComponent {
+	minValues := 0
+	channelTypes := []discordgo.ChannelType{discordgo.ChannelTypeGuildText, discordgo.ChannelTypeGuildNews, discordgo.ChannelTypeGuildForum}
+
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				ReactActionsSelectMenu(ReactionRuleActionsCustomID, actions),
+			},
+		},
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.SelectMenu{
+					MenuType:     discordgo.ChannelSelectMenu,
+					CustomID:     ReactionRuleIncludeChannelsCustomID,
+					Placeholder:  "Apply only in channels (all channels if empty)",
+					MinValues:    &minValues,
+					MaxValues:    maxSelectValues,
+					ChannelTypes: channelTypes,
+				},
+			},
+		},
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.SelectMenu{
+					MenuType:     discordgo.ChannelSelectMenu,
+					CustomID:     ReactionRuleExcludeChannelsCustomID,
+					Placeholder:  "Never apply in channels",
+					MinValues:    &minValues,
+					MaxValues:    maxSelectValues,
+					ChannelTypes: channelTypes,
+				},
+			},
+		},
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.SelectMenu{
+					MenuType:    discordgo.RoleSelectMenu,
+					CustomID:    ReactionRuleExemptRolesCustomID,
+					Placeholder: "Roles exempt from the rules",
+					MinValues:   &minValues,
+					MaxValues:   maxSelectValues,
+				},
+			},
+		},
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    "Create",
+					Style:    discordgo.SuccessButton,
+					CustomID: ReactionRuleCreateCustomID,
+				},
+				discordgo.Button{
+					Label:    "Cancel",
+					Style:    discordgo.SecondaryButton,
+					CustomID: ReactionRuleCancelCustomID,
+				},
+			},
+		},
+	}
+}
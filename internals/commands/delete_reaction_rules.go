@@ -9,6 +9,7 @@ import (
 	"github.com/finkabaj/hyde-bot/internals/logger"
 	"github.com/finkabaj/hyde-bot/internals/rules"
 	commandUtils "github.com/finkabaj/hyde-bot/internals/utils/command"
+	"github.com/finkabaj/hyde-bot/internals/utils/rule"
 )
 
 var dmDeleteReactionRulesPermision = false
@@ -129,23 +130,25 @@ func createSelectMenuOptions(rm *rules.RuleManager, guildId string) ([]discordgo
 		return nil, fmt.Errorf("failed to get reaction rules in createSelectMenuOptions: %w", err)
 	}
 
-	for _, rule := range rRules {
-		if rule.IsCustom {
+	for _, r := range rRules {
+		if r.Match != rule.MatchExact {
+			options = append(options, matcherSelectMenuOption(r))
+		} else if r.IsCustom {
 			options = append(options, discordgo.SelectMenuOption{
 				Label: "server emoji",
-				Value: fmt.Sprintf("%s:%s", rule.EmojiName, rule.EmojiId),
+				Value: fmt.Sprintf("%s:%s", r.EmojiName, r.EmojiId),
 				Emoji: discordgo.ComponentEmoji{
-					ID:   rule.EmojiId,
-					Name: rule.EmojiName,
+					ID:   r.EmojiId,
+					Name: r.EmojiName,
 				},
 			})
 
 		} else {
 			options = append(options, discordgo.SelectMenuOption{
 				Label: "ordinary emoji",
-				Value: fmt.Sprintf("%s:NULL", rule.EmojiName),
+				Value: fmt.Sprintf("%s:NULL", r.EmojiName),
 				Emoji: discordgo.ComponentEmoji{
-					Name: rule.EmojiName,
+					Name: r.EmojiName,
 				},
 			})
 		}
@@ -153,3 +156,25 @@ func createSelectMenuOptions(rm *rules.RuleManager, guildId string) ([]discordgo
 
 	return options, nil
 }
+
+// matcherSelectMenuOption describes a base emoji, category or pattern rule. Category and pattern rules have no emoji of their own.
+func matcherSelectMenuOption(r rule.ReactionRule) discordgo.SelectMenuOption {
+	option := discordgo.SelectMenuOption{
+		Value:       fmt.Sprintf("%s:NULL", r.EmojiName),
+		Description: r.EmojiName,
+	}
+
+	switch r.Match {
+	case rule.MatchBase:
+		option.Label = "emoji with any skin tone"
+		option.Emoji = discordgo.ComponentEmoji{Name: r.EmojiName}
+	case rule.MatchCategory:
+		option.Label = "emoji category"
+		option.Emoji = discordgo.ComponentEmoji{Name: "🗂️"}
+	case rule.MatchPattern:
+		option.Label = "server emoji pattern"
+		option.Emoji = discordgo.ComponentEmoji{Name: "🔍"}
+	}
+
+	return option
+}
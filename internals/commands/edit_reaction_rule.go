@@ -0,0 +1,156 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/enescakir/emoji"
+	"github.com/finkabaj/hyde-bot/internals/logger"
+	"github.com/finkabaj/hyde-bot/internals/rules"
+	commandUtils "github.com/finkabaj/hyde-bot/internals/utils/command"
+	"github.com/finkabaj/hyde-bot/internals/utils/rule"
+)
+
+var dmEditReactionRulePermission = false
+var editReactionRulePermission int64 = discordgo.PermissionAdministrator
+
+var EditReactionRuleCommand = &discordgo.ApplicationCommand{
+	Name:                     "edit-reaction-rule",
+	Description:              "Change actions of an existing reaction rule",
+	Type:                     discordgo.ChatApplicationCommand,
+	DMPermission:             &dmEditReactionRulePermission,
+	DefaultMemberPermissions: &editReactionRulePermission,
+	Options: []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "emoji",
+			Description: "Emoji of the rule to edit",
+			Required:    true,
+		},
+	},
+}
+
+// EditReactionRuleCustomIDPrefix is followed by "emojiName:emojiId" of the edited rule.
+const EditReactionRuleCustomIDPrefix = "edit_reaction_rule:"
+
+var customEmojiRegexp = regexp.MustCompile(`^<a?:(\w+):(\d+)>$`)
+
+func EditReactionRuleHandler(s *discordgo.Session, i *discordgo.InteractionCreate, rm *rules.RuleManager) {
+	input := i.ApplicationCommandData().Options[0].StringValue()
+
+	rRules, err := rm.GetReactionRules(i.GuildID, false)
+
+	if errors.Is(err, rules.ErrRulesNotFound) || len(rRules) == 0 {
+		commandUtils.SendDefaultResponse(s, i, "No reaction rules found")
+		return
+	}
+
+	if err != nil {
+		logger.Error(err, commandUtils.FillFields(i))
+		commandUtils.SendDefaultResponse(s, i, "Failed to get reaction rules")
+		return
+	}
+
+	r, ok := FindReactionRule(rRules, input)
+
+	if !ok {
+		commandUtils.SendDefaultResponse(s, i, "No reaction rule found for "+input)
+		return
+	}
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: fmt.Sprintf("Select new actions for %s", ruleEmojiMention(r)),
+			Flags:   1 << 6,
+			Components: []discordgo.MessageComponent{
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						ReactActionsSelectMenu(EditReactionRuleCustomIDPrefix+r.EmojiName+":"+r.EmojiId, r.Actions),
+					},
+				},
+			},
+		},
+	})
+
+	if err != nil {
+		logger.Error(err, map[string]any{"details": "failed to respond to edit reaction rule command"})
+	}
+}
+
+// FindReactionRule finds a rule by user input. Input can be a unicode emoji, emoji alias, custom emoji, its name or id,
+// or a matcher like base:👍, category:flags or pattern:^nsfw_.
+func FindReactionRule(rRules []rule.ReactionRule, input string) (rule.ReactionRule, bool) {
+	input = strings.TrimSpace(input)
+
+	if kind, value, ok := strings.Cut(input, ":"); ok && rule.MatchKind(kind) != rule.MatchExact {
+		if rule.MatchKind(kind) == rule.MatchBase {
+			value = rule.BaseEmoji(emoji.Parse(value))
+		}
+
+		i := slices.IndexFunc(rRules, func(r rule.ReactionRule) bool {
+			return r.Match == rule.MatchKind(kind) && strings.EqualFold(r.EmojiName, value)
+		})
+
+		if i != -1 {
+			return rRules[i], true
+		}
+	}
+
+	name := strings.Trim(input, ":")
+	id := input
+
+	if m := customEmojiRegexp.FindStringSubmatch(input); m != nil {
+		name, id = m[1], m[2]
+	}
+
+	for _, r := range rRules {
+		if r.Match != rule.MatchExact {
+			continue
+		}
+
+		if r.IsCustom && (r.EmojiId == id || r.EmojiName == name) {
+			return r, true
+		}
+
+		if !r.IsCustom && (r.EmojiName == input || emoji.Parse(r.EmojiName) == emoji.Parse(input)) {
+			return r, true
+		}
+	}
+
+	return rule.ReactionRule{}, false
+}
+
+// ParseEditReactionRuleCustomID returns emoji name and id from the custom id of the edit select menu.
+func ParseEditReactionRuleCustomID(customID string) (emojiName string, emojiId string, ok bool) {
+	v, found := strings.CutPrefix(customID, EditReactionRuleCustomIDPrefix)
+
+	if !found {
+		return "", "", false
+	}
+
+	// patterns may contain colons, ids never do
+	sep := strings.LastIndex(v, ":")
+
+	if sep == -1 {
+		return "", "", false
+	}
+
+	return v[:sep], v[sep+1:], true
+}
+
+func ruleEmojiMention(r rule.ReactionRule) string {
+	if r.Match != rule.MatchExact {
+		return r.Describe()
+	}
+
+	if r.IsCustom {
+		return fmt.Sprintf("<:%s:%s>", r.EmojiName, r.EmojiId)
+	}
+
+	return emoji.Parse(r.EmojiName)
+}
@@ -0,0 +1,231 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/finkabaj/hyde-bot/internals/logger"
+	"github.com/finkabaj/hyde-bot/internals/rules"
+	commandUtils "github.com/finkabaj/hyde-bot/internals/utils/command"
+	"github.com/finkabaj/hyde-bot/internals/utils/common"
+	"github.com/finkabaj/hyde-bot/internals/utils/escalation"
+	"github.com/finkabaj/hyde-bot/internals/utils/infraction"
+)
+
+var dmEscalationPermission = false
+var escalationPermission int64 = discordgo.PermissionAdministrator
+
+var minEscalationWarns = 1.0
+
+const (
+	minEscalationWindow = time.Minute
+	maxEscalationWindow = 365 * 24 * time.Hour
+)
+
+var EscalationCommand = &discordgo.ApplicationCommand{
+	Name:                     "escalation",
+	Description:              "Configure actions taken when members accumulate warnings",
+	Type:                     discordgo.ChatApplicationCommand,
+	DMPermission:             &dmEscalationPermission,
+	DefaultMemberPermissions: &escalationPermission,
+	Options: []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "set",
+			Description: "Add or replace the step taken at a number of warnings",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "warns",
+					Description: "Number of warnings that triggers the step",
+					Required:    true,
+					MinValue:    &minEscalationWarns,
+					MaxValue:    100,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "window",
+					Description: "Warnings within this time are counted, e.g. 7d or 12h",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "action",
+					Description: "Action taken on the member",
+					Required:    true,
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{Name: "Timeout", Value: string(infraction.Timeout)},
+						{Name: "Kick", Value: string(infraction.Kick)},
+						{Name: "Ban", Value: string(infraction.Ban)},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "timeout",
+					Description: "Duration of the timeout, e.g. 1h. Up to 28d",
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "remove",
+			Description: "Remove the step taken at a number of warnings",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "warns",
+					Description: "Number of warnings of the step",
+					Required:    true,
+					MinValue:    &minEscalationWarns,
+					MaxValue:    100,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "show",
+			Description: "Show the escalation policy",
+		},
+	},
+}
+
+var errInvalidEscalationStep = errors.New("invalid escalation step")
+
+func EscalationHandler(s *discordgo.Session, i *discordgo.InteractionCreate, rm *rules.RuleManager) {
+	subcommand := i.ApplicationCommandData().Options[0]
+
+	policy, err := rm.FetchEscalationPolicy(i.GuildID)
+
+	if err != nil {
+		logger.Error(err, commandUtils.FillFields(i))
+		commandUtils.SendDefaultResponse(s, i, "Failed to get the escalation policy")
+		return
+	}
+
+	steps := policy.Steps
+
+	switch subcommand.Name {
+	case "show":
+		commandUtils.SendDefaultResponse(s, i, EscalationPolicyContent(steps))
+		return
+	case "set":
+		step, err := escalationStep(subcommand.Options)
+
+		if err != nil {
+			commandUtils.SendDefaultResponse(s, i, err.Error())
+			return
+		}
+
+		steps = slices.DeleteFunc(steps, func(st escalation.Step) bool {
+			return st.Warns == step.Warns
+		})
+
+		if len(steps) >= escalation.MaxSteps {
+			commandUtils.SendDefaultResponse(s, i, fmt.Sprintf("The policy can have at most %d steps", escalation.MaxSteps))
+			return
+		}
+
+		steps = append(steps, step)
+	case "remove":
+		warns := int(subcommand.Options[0].IntValue())
+		n := len(steps)
+
+		steps = slices.DeleteFunc(steps, func(st escalation.Step) bool {
+			return st.Warns == warns
+		})
+
+		if len(steps) == n {
+			commandUtils.SendDefaultResponse(s, i, fmt.Sprintf("No step at %d warnings", warns))
+			return
+		}
+	default:
+		return
+	}
+
+	steps, err = escalation.SortSteps(steps)
+
+	if err != nil {
+		logger.Error(err, commandUtils.FillFields(i))
+		commandUtils.SendDefaultResponse(s, i, "Failed to update the escalation policy")
+		return
+	}
+
+	err = rm.UpdateEscalationPolicyApi(i.GuildID, steps, WriteOrigin(i))
+
+	switch {
+	case errors.Is(err, rules.ErrQueued):
+		commandUtils.SendDefaultResponse(s, i, EscalationPolicyContent(steps)+"\n\n"+QueuedMessage)
+	case err != nil:
+		logger.Error(err, map[string]any{"details": "failed to update escalation policy", "guildId": i.GuildID})
+		commandUtils.SendDefaultResponse(s, i, "Failed to update the escalation policy")
+	default:
+		commandUtils.SendDefaultResponse(s, i, EscalationPolicyContent(steps))
+	}
+}
+
+// escalationStep returns the step described by options of the set subcommand.
+func escalationStep(options []*discordgo.ApplicationCommandInteractionDataOption) (escalation.Step, error) {
+	var step escalation.Step
+	var window, timeout string
+
+	for _, o := range options {
+		switch o.Name {
+		case "warns":
+			step.Warns = int(o.IntValue())
+		case "window":
+			window = o.StringValue()
+		case "action":
+			step.Action = infraction.Action(o.StringValue())
+		case "timeout":
+			timeout = o.StringValue()
+		}
+	}
+
+	w, err := common.ParseDuration(window)
+
+	if err != nil || w < minEscalationWindow || w > maxEscalationWindow {
+		return escalation.Step{}, fmt.Errorf("%w: window must be between 1m and 365d", errInvalidEscalationStep)
+	}
+
+	step.WindowSeconds = int(w.Seconds())
+
+	if step.Action != infraction.Timeout {
+		return step, nil
+	}
+
+	d, err := common.ParseDuration(timeout)
+
+	if err != nil || d < time.Second || d > escalation.MaxTimeout {
+		return escalation.Step{}, fmt.Errorf("%w: timeout must be between 1s and 28d", errInvalidEscalationStep)
+	}
+
+	step.TimeoutSeconds = int(d.Seconds())
+
+	return step, nil
+}
+
+// EscalationPolicyContent describes steps of the escalation policy.
+func EscalationPolicyContent(steps []escalation.Step) string {
+	if len(steps) == 0 {
+		return "No escalation steps, warnings don't lead to other actions"
+	}
+
+	lines := make([]string, 0, len(steps)+1)
+	lines = append(lines, "Escalation policy:")
+
+	for _, st := range steps {
+		action := string(st.Action)
+
+		if st.Action == infraction.Timeout {
+			action += " for " + common.FormatDuration(st.Timeout())
+		}
+
+		lines = append(lines, fmt.Sprintf("%d warnings in %s → %s", st.Warns, common.FormatDuration(st.Window()), action))
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,146 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/finkabaj/hyde-bot/internals/logger"
+	"github.com/finkabaj/hyde-bot/internals/rules"
+	commandUtils "github.com/finkabaj/hyde-bot/internals/utils/command"
+	"github.com/finkabaj/hyde-bot/internals/utils/infraction"
+)
+
+var dmInfractionsPermission = false
+var infractionsPermission int64 = discordgo.PermissionModerateMembers
+
+var InfractionsCommand = &discordgo.ApplicationCommand{
+	Name:                     "infractions",
+	Description:              "Show warnings, timeouts, kicks and bans of a member",
+	Type:                     discordgo.ChatApplicationCommand,
+	DMPermission:             &dmInfractionsPermission,
+	DefaultMemberPermissions: &infractionsPermission,
+	Options: []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionUser,
+			Name:        "user",
+			Description: "Member to show infractions of",
+			Required:    true,
+		},
+	},
+}
+
+// InfractionsPageCustomIDPrefix is followed by "userId:offset" of the page the button opens.
+const InfractionsPageCustomIDPrefix = "infractions_page:"
+
+// InfractionsPageSize is the number of infractions on a page of the /infractions message.
+const InfractionsPageSize = 5
+
+func InfractionsHandler(s *discordgo.Session, i *discordgo.InteractionCreate, rm *rules.RuleManager) {
+	userId := i.ApplicationCommandData().Options[0].UserValue(nil).ID
+
+	data, err := InfractionsPage(rm, i.GuildID, userId, 0)
+
+	if err != nil {
+		logger.Error(err, commandUtils.FillFields(i))
+		commandUtils.SendDefaultResponse(s, i, "Failed to get infractions")
+		return
+	}
+
+	data.Flags = 1 << 6
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: data,
+	})
+
+	if err != nil {
+		logger.Error(err, map[string]any{"details": "failed to respond to infractions command"})
+	}
+}
+
+// InfractionsPage returns the message with infractions of the member starting at offset, newest first.
+func InfractionsPage(rm *rules.RuleManager, guildId, userId string, offset int) (*discordgo.InteractionResponseData, error) {
+	page, err := rm.FetchInfractions(infraction.InfractionQuery{
+		GuildId: guildId,
+		UserId:  userId,
+		Limit:   InfractionsPageSize,
+		Offset:  offset,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if page.Total == 0 {
+		return &discordgo.InteractionResponseData{
+			Content:    fmt.Sprintf("<@%s> has no infractions", userId),
+			Components: []discordgo.MessageComponent{},
+		}, nil
+	}
+
+	fields := make([]*discordgo.MessageEmbedField, 0, len(page.Infractions))
+
+	for _, inf := range page.Infractions {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  fmt.Sprintf("#%d %s", inf.Id, strings.ToUpper(string(inf.Action))),
+			Value: infractionDescription(inf),
+		})
+	}
+
+	pages := (page.Total + InfractionsPageSize - 1) / InfractionsPageSize
+
+	return &discordgo.InteractionResponseData{
+		Embeds: []*discordgo.MessageEmbed{
+			{
+				Title:       "Infractions",
+				Description: fmt.Sprintf("<@%s> has %d infractions", userId, page.Total),
+				Fields:      fields,
+				Footer: &discordgo.MessageEmbedFooter{
+					Text: fmt.Sprintf("Page %d of %d", offset/InfractionsPageSize+1, pages),
+				},
+			},
+		},
+		Components: []discordgo.MessageComponent{
+			discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{
+					discordgo.Button{
+						Label:    "Previous",
+						Style:    discordgo.SecondaryButton,
+						CustomID: fmt.Sprintf("%s%s:%d", InfractionsPageCustomIDPrefix, userId, max(offset-InfractionsPageSize, 0)),
+						Disabled: offset == 0,
+					},
+					discordgo.Button{
+						Label:    "Next",
+						Style:    discordgo.SecondaryButton,
+						CustomID: fmt.Sprintf("%s%s:%d", InfractionsPageCustomIDPrefix, userId, offset+InfractionsPageSize),
+						Disabled: offset+InfractionsPageSize >= page.Total,
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+func infractionDescription(inf infraction.Infraction) string {
+	issuer := "reaction rule"
+
+	switch inf.Source {
+	case infraction.SourceModerator:
+		issuer = "<@" + inf.ModeratorId + ">"
+	case infraction.SourceEscalation:
+		issuer = "escalation policy"
+	}
+
+	lines := []string{fmt.Sprintf("By %s <t:%d:R>", issuer, inf.CreatedAt.Unix())}
+
+	if inf.Reason != "" {
+		lines = append(lines, inf.Reason)
+	}
+
+	if inf.MessageId != "" {
+		lines = append(lines, fmt.Sprintf("https://discord.com/channels/%s/%s/%s", inf.GuildId, inf.ChannelId, inf.MessageId))
+	}
+
+	return strings.Join(lines, "\n")
+}
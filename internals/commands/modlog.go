@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/finkabaj/hyde-bot/internals/logger"
+	"github.com/finkabaj/hyde-bot/internals/rules"
+	commandUtils "github.com/finkabaj/hyde-bot/internals/utils/command"
+)
+
+var dmModLogPermission = false
+var modLogPermission int64 = discordgo.PermissionAdministrator
+
+// modLogPermissions are required by the bot in the mod log channel.
+const modLogPermissions = discordgo.PermissionViewChannel | discordgo.PermissionSendMessages | discordgo.PermissionEmbedLinks
+
+var ModLogCommand = &discordgo.ApplicationCommand{
+	Name:                     "modlog",
+	Description:              "Configure the channel where moderation actions and rule changes are logged",
+	Type:                     discordgo.ChatApplicationCommand,
+	DMPermission:             &dmModLogPermission,
+	DefaultMemberPermissions: &modLogPermission,
+	Options: []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "set",
+			Description: "Set the mod log channel",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:         discordgo.ApplicationCommandOptionChannel,
+					Name:         "channel",
+					Description:  "Channel where entries are posted",
+					Required:     true,
+					ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText, discordgo.ChannelTypeGuildNews},
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "disable",
+			Description: "Stop posting the mod log",
+		},
+	},
+}
+
+func ModLogHandler(s *discordgo.Session, i *discordgo.InteractionCreate, rm *rules.RuleManager) {
+	subcommand := i.ApplicationCommandData().Options[0]
+
+	var channelID, content string
+
+	switch subcommand.Name {
+	case "set":
+		channelID = fmt.Sprint(subcommand.Options[0].Value)
+
+		perms, err := s.State.UserChannelPermissions(s.State.User.ID, channelID)
+
+		if err != nil {
+			logger.Error(err, commandUtils.FillFields(i))
+			commandUtils.SendDefaultResponse(s, i, "Failed to check permissions of the bot in the channel")
+			return
+		}
+
+		if perms&modLogPermissions != modLogPermissions {
+			commandUtils.SendDefaultResponse(s, i, fmt.Sprintf("The bot needs View Channel, Send Messages and Embed Links permissions in <#%s>", channelID))
+			return
+		}
+
+		content = fmt.Sprintf("Mod log is posted to <#%s>", channelID)
+	case "disable":
+		content = "Mod log is disabled"
+	default:
+		return
+	}
+
+	err := rm.UpdateModLogApi(i.GuildID, channelID, WriteOrigin(i))
+
+	switch {
+	case errors.Is(err, rules.ErrQueued):
+		commandUtils.SendDefaultResponse(s, i, content+"\n\n"+QueuedMessage)
+	case err != nil:
+		logger.Error(err, map[string]any{"details": "failed to update mod log", "guildId": i.GuildID})
+		commandUtils.SendDefaultResponse(s, i, "Failed to update the mod log")
+	default:
+		commandUtils.SendDefaultResponse(s, i, content)
+	}
+}
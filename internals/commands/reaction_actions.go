@@ -0,0 +1,117 @@
+package commands
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/finkabaj/hyde-bot/internals/utils/common"
+	"github.com/finkabaj/hyde-bot/internals/utils/rule"
+)
+
+var ErrInvalidActionValue = errors.New("invalid reaction action value")
+
+var reactActionDescriptions = map[rule.ReactAction]string{
+	rule.Delete:  "Remove the reaction from the message",
+	rule.Warn:    "Warn the user in direct messages",
+	rule.Ban:     "Ban the user from the server",
+	rule.Kick:    "Kick the user from the server",
+	rule.Timeout: "Time out the user, they can't chat or react",
+}
+
+// timeoutPresets are timeout durations offered in ReactActionsSelectMenu.
+var timeoutPresets = []time.Duration{
+	10 * time.Minute,
+	time.Hour,
+	24 * time.Hour,
+	7 * 24 * time.Hour,
+}
+
+// ReactActionsSelectMenu builds a select menu with all reaction actions. Timeout is offered with preset durations
+// and the duration of a selected timeout. Actions from selected are marked as default.
+func ReactActionsSelectMenu(customID string, selected rule.Actions) discordgo.SelectMenu {
+	minValues := 1
+	options := make([]discordgo.SelectMenuOption, 0, rule.ReactActionCount+len(timeoutPresets))
+
+	for a := rule.Delete; a.IsValid(); a++ {
+		if a == rule.Timeout {
+			continue
+		}
+
+		options = append(options, reactActionOption(rule.Action{Type: a}, selected))
+	}
+
+	timeouts := slices.Clone(timeoutPresets)
+
+	for _, a := range selected {
+		if a.Type == rule.Timeout && !slices.Contains(timeouts, a.Duration()) {
+			timeouts = append(timeouts, a.Duration())
+		}
+	}
+
+	slices.Sort(timeouts)
+
+	for _, d := range timeouts {
+		options = append(options, reactActionOption(rule.Action{Type: rule.Timeout, DurationSeconds: int(d.Seconds())}, selected))
+	}
+
+	return discordgo.SelectMenu{
+		CustomID:    customID,
+		Placeholder: "Select actions to apply, at most one timeout",
+		MinValues:   &minValues,
+		MaxValues:   len(options),
+		Options:     options,
+	}
+}
+
+func reactActionOption(a rule.Action, selected rule.Actions) discordgo.SelectMenuOption {
+	label := a.Type.String()
+	value := strconv.Itoa(int(a.Type))
+
+	if a.Type == rule.Timeout {
+		label += " " + common.FormatDuration(a.Duration())
+		value += ":" + strconv.Itoa(a.DurationSeconds)
+	}
+
+	return discordgo.SelectMenuOption{
+		Label:       label,
+		Value:       value,
+		Description: reactActionDescriptions[a.Type],
+		Default:     slices.Contains(selected, a),
+	}
+}
+
+// ParseReactActions converts values of ReactActionsSelectMenu back to actions keeping their order.
+// A value is the action number, followed by ":seconds" for timeouts.
+func ParseReactActions(values []string) (rule.Actions, error) {
+	result := make(rule.Actions, 0, len(values))
+
+	for _, v := range values {
+		t, seconds, hasDuration := strings.Cut(v, ":")
+
+		i, err := strconv.Atoi(t)
+
+		if err != nil {
+			return nil, ErrInvalidActionValue
+		}
+
+		a := rule.Action{Type: rule.ReactAction(i)}
+
+		if hasDuration {
+			if a.DurationSeconds, err = strconv.Atoi(seconds); err != nil {
+				return nil, ErrInvalidActionValue
+			}
+		}
+
+		if !a.IsValid() {
+			return nil, ErrInvalidActionValue
+		}
+
+		result = append(result, a)
+	}
+
+	return result, nil
+}
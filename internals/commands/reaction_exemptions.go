@@ -0,0 +1,112 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/finkabaj/hyde-bot/internals/logger"
+	"github.com/finkabaj/hyde-bot/internals/rules"
+	commandUtils "github.com/finkabaj/hyde-bot/internals/utils/command"
+)
+
+var dmReactionExemptionsPermission = false
+var reactionExemptionsPermission int64 = discordgo.PermissionAdministrator
+
+var ReactionExemptionsCommand = &discordgo.ApplicationCommand{
+	Name:                     "reaction-exemptions",
+	Description:              "Choose roles that are exempt from all reaction rules",
+	Type:                     discordgo.ChatApplicationCommand,
+	DMPermission:             &dmReactionExemptionsPermission,
+	DefaultMemberPermissions: &reactionExemptionsPermission,
+}
+
+const (
+	ReactionExemptionsRolesCustomID  = "reaction_exemptions_roles"
+	ReactionExemptionsAdminsCustomID = "reaction_exemptions_admins"
+	// ReactionExemptionsCustomIDPrefix is shared by all components of the exemptions message.
+	ReactionExemptionsCustomIDPrefix = "reaction_exemptions_"
+)
+
+func ReactionExemptionsHandler(s *discordgo.Session, i *discordgo.InteractionCreate, rm *rules.RuleManager) {
+	guildRules, err := rm.GetRules(i.GuildID, false)
+
+	if err != nil {
+		logger.Error(err, commandUtils.FillFields(i))
+		commandUtils.SendDefaultResponse(s, i, "Failed to get reaction exemptions")
+		return
+	}
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content:    ReactionExemptionsContent(guildRules.ExemptRoles, guildRules.ExemptAdmins),
+			Flags:      1 << 6,
+			Components: ReactionExemptionsComponents(guildRules.ExemptAdmins),
+		},
+	})
+
+	if err != nil {
+		logger.Error(err, map[string]any{"details": "failed to respond to reaction exemptions command"})
+	}
+}
+
+// ReactionExemptionsContent describes current guild wide exemptions.
+func ReactionExemptionsContent(exemptRoles []string, exemptAdmins bool) string {
+	roles := "none"
+
+	if len(exemptRoles) > 0 {
+		mentions := make([]string, 0, len(exemptRoles))
+
+		for _, id := range exemptRoles {
+			mentions = append(mentions, "<@&"+id+">")
+		}
+
+		roles = strings.Join(mentions, " ")
+	}
+
+	admins := "no"
+
+	if exemptAdmins {
+		admins = "yes"
+	}
+
+	return fmt.Sprintf("Exempt roles: %s\nOwner and administrators exempt: %s", roles, admins)
+}
+
+func ReactionExemptionsComponents(exemptAdmins bool) []discordgo.MessageComponent {
+	minValues := 0
+
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.SelectMenu{
+					MenuType:    discordgo.RoleSelectMenu,
+					CustomID:    ReactionExemptionsRolesCustomID,
+					Placeholder: "Roles exempt from all reaction rules",
+					MinValues:   &minValues,
+					MaxValues:   maxSelectValues,
+				},
+			},
+		},
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.SelectMenu{
+					CustomID: ReactionExemptionsAdminsCustomID,
+					Options: []discordgo.SelectMenuOption{
+						{
+							Label:   "Exempt owner and administrators",
+							Value:   "true",
+							Default: exemptAdmins,
+						},
+						{
+							Label:   "Apply rules to owner and administrators",
+							Value:   "false",
+							Default: !exemptAdmins,
+						},
+					},
+				},
+			},
+		},
+	}
+}
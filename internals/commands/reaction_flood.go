@@ -0,0 +1,212 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/finkabaj/hyde-bot/internals/logger"
+	"github.com/finkabaj/hyde-bot/internals/rules"
+	commandUtils "github.com/finkabaj/hyde-bot/internals/utils/command"
+	"github.com/finkabaj/hyde-bot/internals/utils/common"
+	"github.com/finkabaj/hyde-bot/internals/utils/rule"
+)
+
+var dmReactionFloodPermission = false
+var reactionFloodPermission int64 = discordgo.PermissionAdministrator
+
+var minFloodReactions = 1.0
+
+var ReactionFloodCommand = &discordgo.ApplicationCommand{
+	Name:                     "reaction-flood",
+	Description:              "Limit how fast members can react",
+	Type:                     discordgo.ChatApplicationCommand,
+	DMPermission:             &dmReactionFloodPermission,
+	DefaultMemberPermissions: &reactionFloodPermission,
+	Options: []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "set",
+			Description: "Take an action on members who react more than allowed, replaces the current limit",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "max-reactions",
+					Description: "Reactions a member can add within the window",
+					Required:    true,
+					MinValue:    &minFloodReactions,
+					MaxValue:    rule.MaxFloodReactions,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "window",
+					Description: "Reactions within this time are counted, e.g. 30s. Up to 10m",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "action",
+					Description: "Action taken on the member",
+					Required:    true,
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{Name: "Remove the reactions", Value: int(rule.Delete)},
+						{Name: "Warn", Value: int(rule.Warn)},
+						{Name: "Timeout", Value: int(rule.Timeout)},
+						{Name: "Kick", Value: int(rule.Kick)},
+						{Name: "Ban", Value: int(rule.Ban)},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "timeout",
+					Description: "Duration of the timeout, e.g. 1h. Up to 28d",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        "remove-reactions",
+					Description: "Also remove reactions of the flood, true by default",
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "disable",
+			Description: "Stop limiting reactions",
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "show",
+			Description: "Show the reaction limit",
+		},
+	},
+}
+
+var errInvalidReactionFlood = errors.New("invalid reaction flood rule")
+
+func ReactionFloodHandler(s *discordgo.Session, i *discordgo.InteractionCreate, rm *rules.RuleManager) {
+	subcommand := i.ApplicationCommandData().Options[0]
+
+	guildRules, err := rm.GetRules(i.GuildID, false)
+
+	if err != nil {
+		logger.Error(err, commandUtils.FillFields(i))
+		commandUtils.SendDefaultResponse(s, i, "Failed to get the reaction limit")
+		return
+	}
+
+	var content string
+
+	switch subcommand.Name {
+	case "show":
+		commandUtils.SendDefaultResponse(s, i, ReactionFloodContent(guildRules.ReactionFlood))
+		return
+	case "set":
+		var f rule.ReactionFloodRule
+
+		f, err = reactionFloodRule(i.GuildID, subcommand.Options)
+
+		if err != nil {
+			commandUtils.SendDefaultResponse(s, i, err.Error())
+			return
+		}
+
+		content = ReactionFloodContent(&f)
+		err = rm.SaveReactionFloodApi(f, WriteOrigin(i))
+	case "disable":
+		if guildRules.ReactionFlood == nil {
+			commandUtils.SendDefaultResponse(s, i, ReactionFloodContent(nil))
+			return
+		}
+
+		content = ReactionFloodContent(nil)
+		err = rm.DeleteReactionFloodApi(i.GuildID, WriteOrigin(i))
+	default:
+		return
+	}
+
+	switch {
+	case errors.Is(err, rules.ErrQueued):
+		commandUtils.SendDefaultResponse(s, i, content+"\n\n"+QueuedMessage)
+	case err != nil:
+		logger.Error(err, map[string]any{"details": "failed to update reaction flood rule", "guildId": i.GuildID})
+		commandUtils.SendDefaultResponse(s, i, "Failed to update the reaction limit")
+	default:
+		commandUtils.SendDefaultResponse(s, i, content)
+	}
+}
+
+// reactionFloodRule returns the flood rule described by options of the set subcommand.
+func reactionFloodRule(guildID string, options []*discordgo.ApplicationCommandInteractionDataOption) (rule.ReactionFloodRule, error) {
+	f := rule.ReactionFloodRule{GuildId: guildID}
+	action := rule.Action{}
+	removeReactions := true
+	var window, timeout string
+
+	for _, o := range options {
+		switch o.Name {
+		case "max-reactions":
+			f.MaxReactions = int(o.IntValue())
+		case "window":
+			window = o.StringValue()
+		case "action":
+			action.Type = rule.ReactAction(o.IntValue())
+		case "timeout":
+			timeout = o.StringValue()
+		case "remove-reactions":
+			removeReactions = o.BoolValue()
+		}
+	}
+
+	w, err := common.ParseDuration(window)
+
+	if err != nil || w < time.Second || w > rule.MaxFloodWindow {
+		return rule.ReactionFloodRule{}, fmt.Errorf("%w: window must be between 1s and 10m", errInvalidReactionFlood)
+	}
+
+	f.WindowSeconds = int(w.Seconds())
+
+	if action.Type == rule.Timeout {
+		d, err := common.ParseDuration(timeout)
+
+		if err != nil || d < time.Second || d > rule.MaxTimeout {
+			return rule.ReactionFloodRule{}, fmt.Errorf("%w: timeout must be between 1s and 28d", errInvalidReactionFlood)
+		}
+
+		action.DurationSeconds = int(d.Seconds())
+	}
+
+	if removeReactions && action.Type != rule.Delete {
+		f.Actions = append(f.Actions, rule.Action{Type: rule.Delete})
+	}
+
+	f.Actions = append(f.Actions, action)
+
+	return f, nil
+}
+
+// ReactionFloodContent describes the flood rule, nil if the guild has none.
+func ReactionFloodContent(f *rule.ReactionFloodRule) string {
+	if f == nil {
+		return "No reaction limit, members can react as fast as they want"
+	}
+
+	actions := make([]string, 0, len(f.Actions))
+
+	for _, a := range f.Actions {
+		label := a.Type.String()
+
+		switch a.Type {
+		case rule.Delete:
+			label = "remove the reactions"
+		case rule.Timeout:
+			label += " for " + common.FormatDuration(a.Duration())
+		}
+
+		actions = append(actions, label)
+	}
+
+	return fmt.Sprintf("Members who add more than %d reactions in %s → %s",
+		f.MaxReactions, common.FormatDuration(f.Window()), strings.Join(actions, ", "))
+}
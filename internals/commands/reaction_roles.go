@@ -0,0 +1,313 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/enescakir/emoji"
+	"github.com/finkabaj/hyde-bot/internals/actions"
+	"github.com/finkabaj/hyde-bot/internals/logger"
+	"github.com/finkabaj/hyde-bot/internals/rules"
+	commandUtils "github.com/finkabaj/hyde-bot/internals/utils/command"
+	"github.com/finkabaj/hyde-bot/internals/utils/rule"
+)
+
+var dmReactionRolesPermission = false
+var reactionRolesPermission int64 = discordgo.PermissionAdministrator
+
+var messageOption = &discordgo.ApplicationCommandOption{
+	Type:        discordgo.ApplicationCommandOptionString,
+	Name:        "message",
+	Description: "Link to the message, or its id if it's in this channel",
+	Required:    true,
+}
+
+var ReactionRolesCommand = &discordgo.ApplicationCommand{
+	Name:                     "reaction-roles",
+	Description:              "Grant roles to members who react to a message",
+	Type:                     discordgo.ChatApplicationCommand,
+	DMPermission:             &dmReactionRolesPermission,
+	DefaultMemberPermissions: &reactionRolesPermission,
+	Options: []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "add",
+			Description: "Grant a role for reacting with an emoji, replaces the role of the emoji",
+			Options: []*discordgo.ApplicationCommandOption{
+				messageOption,
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "emoji",
+					Description: "Emoji members react with",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionRole,
+					Name:        "role",
+					Description: "Role granted for the reaction",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "mode",
+					Description: "How roles of the message behave, toggle by default",
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{Name: "Toggle: removing the reaction revokes the role", Value: string(rule.ReactionRoleToggle)},
+						{Name: "Unique: members keep one role of the message", Value: string(rule.ReactionRoleUnique)},
+						{Name: "Verify: removing the reaction keeps the role", Value: string(rule.ReactionRoleVerify)},
+					},
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "remove",
+			Description: "Stop granting a role for reacting with an emoji",
+			Options: []*discordgo.ApplicationCommandOption{
+				messageOption,
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "emoji",
+					Description: "Emoji of the reaction role",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "clear",
+			Description: "Remove all reaction roles of a message",
+			Options:     []*discordgo.ApplicationCommandOption{messageOption},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "list",
+			Description: "Show messages with reaction roles",
+		},
+	},
+}
+
+var messageLinkRegexp = regexp.MustCompile(`^https://(?:\w+\.)?discord(?:app)?\.com/channels/(\d+)/(\d+)/(\d+)$`)
+
+var errInvalidMessage = errors.New("invalid message")
+
+func ReactionRolesHandler(s *discordgo.Session, i *discordgo.InteractionCreate, rm *rules.RuleManager) {
+	subcommand := i.ApplicationCommandData().Options[0]
+
+	if subcommand.Name == "list" {
+		guildRules, err := rm.GetRules(i.GuildID, false)
+
+		if err != nil {
+			logger.Error(err, commandUtils.FillFields(i))
+			commandUtils.SendDefaultResponse(s, i, "Failed to get reaction roles")
+			return
+		}
+
+		commandUtils.SendDefaultResponse(s, i, ReactionRolesContent(guildRules.ReactionRoles))
+		return
+	}
+
+	options := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(subcommand.Options))
+
+	for _, o := range subcommand.Options {
+		options[o.Name] = o
+	}
+
+	channelID, messageID, err := parseMessageRef(options["message"].StringValue(), i.GuildID, i.ChannelID)
+
+	if err != nil {
+		commandUtils.SendDefaultResponse(s, i, "Message must be a message link of this server or an id of a message in this channel")
+		return
+	}
+
+	m, ok := rm.ReactionRoles(i.GuildID, messageID)
+
+	if !ok {
+		m = rule.ReactionRoleMessage{GuildId: i.GuildID, ChannelId: channelID, MessageId: messageID, Mode: rule.ReactionRoleToggle}
+	}
+
+	var content string
+
+	switch subcommand.Name {
+	case "add":
+		var rr rule.ReactionRole
+
+		rr, content, ok = addReactionRole(s, i, &m, options)
+
+		if !ok {
+			commandUtils.SendDefaultResponse(s, i, content)
+			return
+		}
+
+		e := discordgo.Emoji{Name: rr.EmojiName, ID: rr.EmojiId}
+
+		// reacting checks that the message and emoji exist, and members have the reaction to click
+		if err := s.MessageReactionAdd(m.ChannelId, m.MessageId, e.APIName()); err != nil {
+			logger.Error(err, map[string]any{"details": "failed to react to reaction roles message", "guildId": i.GuildID, "messageId": m.MessageId})
+			commandUtils.SendDefaultResponse(s, i, "Failed to react to the message, check the message and emoji exist and the bot can use them")
+			return
+		}
+
+		err = rm.SaveReactionRolesApi(m, WriteOrigin(i))
+	case "remove":
+		name, id, valid := parseEmoji(options["emoji"].StringValue())
+		rr, found := m.Role(name, id)
+
+		if !valid || !found {
+			commandUtils.SendDefaultResponse(s, i, "The message has no reaction role for the emoji")
+			return
+		}
+
+		m.Roles = slices.DeleteFunc(slices.Clone(m.Roles), rr.SameEmoji)
+		content = "Reaction role removed\n\n" + ReactionRolesContent([]rule.ReactionRoleMessage{m})
+
+		e := discordgo.Emoji{Name: rr.EmojiName, ID: rr.EmojiId}
+
+		if err := s.MessageReactionRemove(m.ChannelId, m.MessageId, e.APIName(), "@me"); err != nil {
+			logger.Debug("Failed to remove reaction of removed reaction role: "+err.Error(), map[string]any{"guildId": i.GuildID, "messageId": m.MessageId})
+		}
+
+		if len(m.Roles) == 0 {
+			content = "Reaction role removed, the message has no reaction roles left"
+			err = rm.DeleteReactionRolesApi(i.GuildID, m.MessageId, WriteOrigin(i))
+		} else {
+			err = rm.SaveReactionRolesApi(m, WriteOrigin(i))
+		}
+	case "clear":
+		if !ok {
+			commandUtils.SendDefaultResponse(s, i, "The message has no reaction roles")
+			return
+		}
+
+		content = "Reaction roles of the message are removed"
+		err = rm.DeleteReactionRolesApi(i.GuildID, m.MessageId, WriteOrigin(i))
+	default:
+		return
+	}
+
+	switch {
+	case errors.Is(err, rules.ErrQueued):
+		commandUtils.SendDefaultResponse(s, i, content+"\n\n"+QueuedMessage)
+	case errors.Is(err, rule.ErrReactionRoleConflict):
+		commandUtils.SendDefaultResponse(s, i, "A reaction rule is enforced on the emoji in this channel, delete it or exclude the channel first")
+	case err != nil:
+		logger.Error(err, map[string]any{"details": "failed to update reaction roles", "guildId": i.GuildID, "messageId": m.MessageId})
+		commandUtils.SendDefaultResponse(s, i, "Failed to update reaction roles")
+	default:
+		commandUtils.SendDefaultResponse(s, i, content)
+	}
+}
+
+// addReactionRole adds the reaction role described by options of the add subcommand to m.
+// Returns the added role and the response content, or false and the reason the role can't be added.
+func addReactionRole(s *discordgo.Session, i *discordgo.InteractionCreate, m *rule.ReactionRoleMessage,
+	options map[string]*discordgo.ApplicationCommandInteractionDataOption) (rule.ReactionRole, string, bool) {
+	name, id, ok := parseEmoji(options["emoji"].StringValue())
+
+	if !ok {
+		return rule.ReactionRole{}, "Emoji must be a unicode emoji, its :alias: or a custom emoji", false
+	}
+
+	role := options["role"].RoleValue(nil, i.GuildID)
+
+	if resolved, ok := i.ApplicationCommandData().Resolved.Roles[role.ID]; ok {
+		role = resolved
+	}
+
+	if role.ID == i.GuildID || role.Managed {
+		return rule.ReactionRole{}, "The role can't be granted, it's @everyone or managed by an integration", false
+	}
+
+	switch err := actions.CanManageRole(s, i.GuildID, role.ID); {
+	case errors.Is(err, actions.ErrMissingPermission):
+		return rule.ReactionRole{}, "The bot needs the Manage Roles permission", false
+	case errors.Is(err, actions.ErrRoleAboveBot):
+		return rule.ReactionRole{}, fmt.Sprintf("<@&%s> must be below the highest role of the bot", role.ID), false
+	case err != nil:
+		logger.Error(err, commandUtils.FillFields(i))
+		return rule.ReactionRole{}, "Failed to check permissions of the bot", false
+	}
+
+	rr := rule.ReactionRole{EmojiName: name, EmojiId: id, RoleId: role.ID}
+	roles := slices.DeleteFunc(slices.Clone(m.Roles), rr.SameEmoji)
+
+	if len(roles) >= rule.MaxReactionRoles {
+		return rule.ReactionRole{}, fmt.Sprintf("A message can have at most %d reaction roles", rule.MaxReactionRoles), false
+	}
+
+	m.Roles = append(roles, rr)
+
+	if o, ok := options["mode"]; ok {
+		m.Mode = rule.ReactionRoleMode(o.StringValue())
+	}
+
+	return rr, "Reaction role added\n\n" + ReactionRolesContent([]rule.ReactionRoleMessage{*m}), true
+}
+
+// parseMessageRef returns the channel and message of a message link of the guild, or of a message id in channelID.
+func parseMessageRef(input, guildID, channelID string) (string, string, error) {
+	input = strings.TrimSpace(input)
+
+	if m := messageLinkRegexp.FindStringSubmatch(input); m != nil {
+		if m[1] != guildID {
+			return "", "", errInvalidMessage
+		}
+
+		return m[2], m[3], nil
+	}
+
+	if input == "" || strings.Trim(input, "0123456789") != "" {
+		return "", "", errInvalidMessage
+	}
+
+	return channelID, input, nil
+}
+
+// parseEmoji returns the name and id of a custom emoji, or the name of a unicode emoji given as is or by its :alias:.
+func parseEmoji(input string) (name string, id string, ok bool) {
+	input = strings.TrimSpace(input)
+
+	if m := customEmojiRegexp.FindStringSubmatch(input); m != nil {
+		return m[1], m[2], true
+	}
+
+	name = emoji.Parse(input)
+
+	// unknown aliases and plain text are left as is
+	if name == "" || strings.ContainsAny(name, ": ") || strings.IndexFunc(name, isASCIILetter) != -1 {
+		return "", "", false
+	}
+
+	return name, "", true
+}
+
+func isASCIILetter(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
+
+// ReactionRolesContent describes messages with reaction roles.
+func ReactionRolesContent(messages []rule.ReactionRoleMessage) string {
+	if len(messages) == 0 {
+		return "No reaction roles"
+	}
+
+	lines := make([]string, 0, len(messages)*2)
+
+	for _, m := range messages {
+		roles := make([]string, 0, len(m.Roles))
+
+		for _, rr := range m.Roles {
+			roles = append(roles, fmt.Sprintf("%s → <@&%s>", actions.EmojiMention(discordgo.Emoji{Name: rr.EmojiName, ID: rr.EmojiId}), rr.RoleId))
+		}
+
+		lines = append(lines,
+			fmt.Sprintf("https://discord.com/channels/%s/%s/%s (%s)", m.GuildId, m.ChannelId, m.MessageId, m.Mode),
+			strings.Join(roles, ", "))
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,114 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/finkabaj/hyde-bot/internals/actions"
+	"github.com/finkabaj/hyde-bot/internals/escalation"
+	"github.com/finkabaj/hyde-bot/internals/logger"
+	"github.com/finkabaj/hyde-bot/internals/rules"
+	commandUtils "github.com/finkabaj/hyde-bot/internals/utils/command"
+	"github.com/finkabaj/hyde-bot/internals/utils/infraction"
+)
+
+var dmWarnPermission = false
+var warnPermission int64 = discordgo.PermissionModerateMembers
+
+const defaultWarnReason = "No reason given"
+
+var WarnCommand = &discordgo.ApplicationCommand{
+	Name:                     "warn",
+	Description:              "Warn a member, warnings count towards the escalation policy",
+	Type:                     discordgo.ChatApplicationCommand,
+	DMPermission:             &dmWarnPermission,
+	DefaultMemberPermissions: &warnPermission,
+	Options: []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionUser,
+			Name:        "user",
+			Description: "Member to warn",
+			Required:    true,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "reason",
+			Description: "Reason of the warning, shown to the member",
+			MaxLength:   1024,
+		},
+	},
+}
+
+func WarnHandler(s *discordgo.Session, i *discordgo.InteractionCreate, rm *rules.RuleManager, executor *actions.Executor, evaluator *escalation.Evaluator) {
+	var user *discordgo.User
+	reason := defaultWarnReason
+
+	for _, o := range i.ApplicationCommandData().Options {
+		switch o.Name {
+		case "user":
+			user = o.UserValue(nil)
+		case "reason":
+			reason = o.StringValue()
+		}
+	}
+
+	if user.Bot {
+		commandUtils.SendDefaultResponse(s, i, "Bots can't be warned")
+		return
+	}
+
+	// warning and escalating may take longer than the interaction allows
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{Flags: discordgo.MessageFlagsEphemeral},
+	})
+
+	if err != nil {
+		logger.Error(err, commandUtils.FillFields(i))
+		return
+	}
+
+	t := actions.Target{GuildID: i.GuildID, ChannelID: i.ChannelID, UserID: user.ID}
+
+	if err := executor.Warn(s, t, reason); err != nil {
+		logger.Error(err, map[string]any{"details": "failed to warn member", "guildId": i.GuildID, "userId": user.ID})
+		editWarnResponse(s, i, fmt.Sprintf("Failed to warn <@%s>", user.ID))
+		return
+	}
+
+	content := fmt.Sprintf("<@%s> is warned: %s", user.ID, reason)
+
+	err = rm.RecordInfraction(infraction.InfractionCreate{
+		GuildId:     i.GuildID,
+		UserId:      user.ID,
+		ModeratorId: i.Member.User.ID,
+		Source:      infraction.SourceModerator,
+		Action:      infraction.Warn,
+		Reason:      reason,
+		ChannelId:   i.ChannelID,
+	})
+
+	switch {
+	case errors.Is(err, rules.ErrQueued):
+		content += "\n\n" + QueuedMessage
+	case err != nil:
+		logger.Error(err, map[string]any{"details": "failed to record warning", "guildId": i.GuildID, "userId": user.ID})
+		editWarnResponse(s, i, content+"\n\nFailed to record the warning, it doesn't count towards the escalation policy")
+		return
+	}
+
+	if step, ok := evaluator.Evaluate(s, t); ok {
+		content += fmt.Sprintf("\n\nEscalation: %s", step.Reason())
+	}
+
+	editWarnResponse(s, i, content)
+}
+
+func editWarnResponse(s *discordgo.Session, i *discordgo.InteractionCreate, content string) {
+	_, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Content: &content})
+
+	if err != nil {
+		logger.Error(err, commandUtils.FillFields(i))
+	}
+}
@@ -0,0 +1,19 @@
+package commands
+
+import (
+	"github.com/bwmarrin/discordgo"
+	"github.com/finkabaj/hyde-bot/internals/rules"
+)
+
+// QueuedMessage is shown for changes made while the API is unavailable.
+const QueuedMessage = "The bot can't reach its API right now. The change is already enforced and will be saved when the API is back, you'll get a message with the result"
+
+// WriteOrigin returns the origin of writes made by the interaction.
+func WriteOrigin(i *discordgo.InteractionCreate) *rules.WriteOrigin {
+	return &rules.WriteOrigin{
+		UserId:           i.Member.User.ID,
+		ChannelId:        i.ChannelID,
+		AppId:            i.AppID,
+		InteractionToken: i.Token,
+	}
+}
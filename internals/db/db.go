@@ -1,8 +1,13 @@
 package db
 
 import (
+	"time"
+
+	"github.com/finkabaj/hyde-bot/internals/utils/escalation"
 	"github.com/finkabaj/hyde-bot/internals/utils/guild"
+	"github.com/finkabaj/hyde-bot/internals/utils/infraction"
 	"github.com/finkabaj/hyde-bot/internals/utils/rule"
+	"github.com/finkabaj/hyde-bot/internals/utils/user"
 )
 
 type Database interface {
@@ -10,10 +15,42 @@ type Database interface {
 	Close()
 	Status() error
 
+	//* USERS *//
+
+	// UpsertUser creates the user or updates the name of the existing one.
+	UpsertUser(u user.User) (user.User, error)
+	ReadUser(userId string) (user.User, error)
+	// SaveRefreshToken replaces the refresh token of the user. Returns common.ErrNotFound if the user doesn't exist.
+	SaveRefreshToken(t user.RefreshToken) error
+	// Returns common.ErrNotFound if no token has the hash.
+	ReadRefreshToken(tokenHash string) (user.RefreshToken, error)
+	DeleteRefreshToken(userId string) error
+	// SaveUserGuilds replaces the guilds of the user. Returns common.ErrNotFound if the user doesn't exist.
+	SaveUserGuilds(userId string, guilds []user.UserGuild) error
+	// Returns common.ErrNotFound if the user isn't in the guild.
+	ReadUserGuild(userId, guildId string) (user.UserGuild, error)
+
 	//* GUILDS *//
 
+	// Guilds are soft deleted, deleted guilds are invisible to the other methods until restored or purged.
+
+	// CreateGuild returns guild.ErrGuildConflict if the guild exists. A deleted guild is purged and created anew.
 	CreateGuild(guild guild.GuildCreate) (guild.Guild, error)
 	ReadGuild(guildId string) (guild.Guild, error)
+	// Returns common.ErrNotFound if the guild doesn't exist.
+	UpdateGuildExemptions(guildId string, exemptions guild.GuildExemptions) (guild.Guild, error)
+	// Returns common.ErrNotFound if the guild doesn't exist.
+	UpdateGuildModLog(guildId string, modLog guild.GuildModLog) (guild.Guild, error)
+	// UpdateGuild sets the owner and metadata of the guild, nil fields of update are kept.
+	// Returns common.ErrNotFound if the guild doesn't exist.
+	UpdateGuild(guildId string, update guild.GuildUpdate) (guild.Guild, error)
+	// DeleteGuild soft deletes the guild, its rules are kept. Returns common.ErrNotFound if the guild doesn't exist.
+	DeleteGuild(guildId string, deletedAt time.Time) error
+	// RestoreGuild undeletes the guild with its rules and sets its owner and metadata, if it was deleted at or after deletedAfter.
+	// Returns common.ErrNotFound otherwise.
+	RestoreGuild(guild guild.GuildCreate, deletedAfter time.Time) (guild.Guild, error)
+	// PurgeGuilds hard deletes guilds deleted before deletedBefore with their rules and returns how many were purged.
+	PurgeGuilds(deletedBefore time.Time) (int, error)
 
 	// * RULES * //
 
@@ -22,6 +59,64 @@ type Database interface {
 	CreateReactionRules(rules []rule.ReactionRule) ([]rule.ReactionRule, error)
 	DeleteReactionRules(rules []rule.DeleteReactionRuleQuery, gId string) error
 	ReadReactionRules(gId string) ([]rule.ReactionRule, error)
+	// Returns common.ErrNotFound if any of the rules doesn't exist. Nothing is updated in that case.
+	UpdateReactionRules(rules []rule.ReactionRuleUpdate, gId string) ([]rule.ReactionRule, error)
+
+	/// ** REACTION ROLES ** ///
+
+	// ReadReactionRoleMessages returns messages with reaction roles of the guild ordered by message id.
+	ReadReactionRoleMessages(gId string) ([]rule.ReactionRoleMessage, error)
+	// SaveReactionRoleMessage creates the message or replaces its channel, mode and roles.
+	// Returns common.ErrNotFound if the guild doesn't exist.
+	SaveReactionRoleMessage(m rule.ReactionRoleMessage) (rule.ReactionRoleMessage, error)
+	// Returns common.ErrNotFound if the guild has no message with the id.
+	DeleteReactionRoleMessage(gId string, messageId string) error
+
+	/// ** REACTION FLOOD ** ///
+
+	// Returns common.ErrNotFound if the guild has no flood rule.
+	ReadReactionFloodRule(gId string) (rule.ReactionFloodRule, error)
+	// SaveReactionFloodRule creates or replaces the flood rule of the guild.
+	// Returns common.ErrNotFound if the guild doesn't exist.
+	SaveReactionFloodRule(f rule.ReactionFloodRule) (rule.ReactionFloodRule, error)
+	// Returns common.ErrNotFound if the guild has no flood rule.
+	DeleteReactionFloodRule(gId string) error
+
+	//* INFRACTIONS *//
+
+	// Returns common.ErrNotFound if the guild doesn't exist.
+	CreateInfraction(i infraction.InfractionCreate) (infraction.Infraction, error)
+	// Returns common.ErrNotFound if the guild has no infraction with the id.
+	ReadInfraction(gId string, id int64) (infraction.Infraction, error)
+	// ReadInfractions returns a page of infractions matching the query newest first and how many match in total.
+	ReadInfractions(query infraction.InfractionQuery) ([]infraction.Infraction, int, error)
+	// Returns common.ErrNotFound if the guild has no infraction with the id.
+	UpdateInfraction(gId string, id int64, u infraction.InfractionUpdate) (infraction.Infraction, error)
+	// Returns common.ErrNotFound if the guild has no infraction with the id.
+	DeleteInfraction(gId string, id int64) error
+
+	//* ESCALATION *//
+
+	// ReadEscalationPolicy returns the policy of the guild with steps sorted by warns. Guilds without a policy have no steps.
+	ReadEscalationPolicy(gId string) (escalation.Policy, error)
+	// UpdateEscalationPolicy replaces steps of the policy. Returns common.ErrNotFound if the guild doesn't exist.
+	UpdateEscalationPolicy(gId string, steps []escalation.Step) (escalation.Policy, error)
+}
+
+// Migrator is implemented by databases with a versioned schema.
+type Migrator interface {
+	// MigrateUp applies all pending migrations.
+	MigrateUp() error
+	// MigrateDown reverts the last steps applied migrations.
+	MigrateDown(steps int) error
+	MigrationStatus() ([]MigrationStatus, error)
+}
+
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	Applied   bool
+	AppliedAt time.Time // AppliedAt is zero if the migration is not applied
 }
 
 type DatabaseCredentials struct {
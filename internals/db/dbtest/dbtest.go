@@ -0,0 +1,974 @@
+// Package dbtest is a conformance suite for db.Database implementations.
+package dbtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/finkabaj/hyde-bot/internals/db"
+	"github.com/finkabaj/hyde-bot/internals/utils/common"
+	"github.com/finkabaj/hyde-bot/internals/utils/escalation"
+	"github.com/finkabaj/hyde-bot/internals/utils/guild"
+	"github.com/finkabaj/hyde-bot/internals/utils/infraction"
+	"github.com/finkabaj/hyde-bot/internals/utils/rule"
+	"github.com/finkabaj/hyde-bot/internals/utils/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// NewDatabase returns a connected database without any data.
+type NewDatabase func(t *testing.T) db.Database
+
+// Run runs the suite. Every subtest gets a new database from newDB.
+func Run(t *testing.T, newDB NewDatabase) {
+	tests := map[string]func(t *testing.T, d db.Database){
+		"UpsertUser":                       testUpsertUser,
+		"ReadUserNotFound":                 testReadUserNotFound,
+		"RefreshToken":                     testRefreshToken,
+		"RefreshTokenUserNotFound":         testRefreshTokenUserNotFound,
+		"SaveUserGuilds":                   testSaveUserGuilds,
+		"SaveUserGuildsUserNotFound":       testSaveUserGuildsUserNotFound,
+		"CreateGuild":                      testCreateGuild,
+		"CreateGuildConflict":              testCreateGuildConflict,
+		"ReadGuildNotFound":                testReadGuildNotFound,
+		"UpdateGuildExemptions":            testUpdateGuildExemptions,
+		"UpdateGuildExemptionsNotFound":    testUpdateGuildExemptionsNotFound,
+		"UpdateGuildModLog":                testUpdateGuildModLog,
+		"UpdateGuildModLogNotFound":        testUpdateGuildModLogNotFound,
+		"CreateGuildMetadata":              testCreateGuildMetadata,
+		"UpdateGuild":                      testUpdateGuild,
+		"UpdateGuildNotFound":              testUpdateGuildNotFound,
+		"DeleteGuild":                      testDeleteGuild,
+		"DeleteGuildNotFound":              testDeleteGuildNotFound,
+		"RestoreGuild":                     testRestoreGuild,
+		"RestoreGuildExpired":              testRestoreGuildExpired,
+		"CreateDeletedGuild":               testCreateDeletedGuild,
+		"PurgeGuilds":                      testPurgeGuilds,
+		"CreateReactionRules":              testCreateReactionRules,
+		"CreateReactionRulesConflict":      testCreateReactionRulesConflict,
+		"CreateReactionRulesGuildNotFound": testCreateReactionRulesGuildNotFound,
+		"ReadReactionRulesEmpty":           testReadReactionRulesEmpty,
+		"UpdateReactionRules":              testUpdateReactionRules,
+		"UpdateReactionRulesNotFound":      testUpdateReactionRulesNotFound,
+		"DeleteReactionRules":              testDeleteReactionRules,
+		"ReactionRulesOfDeletedGuild":      testReactionRulesOfDeletedGuild,
+		"SaveReactionRoleMessage":          testSaveReactionRoleMessage,
+		"SaveReactionRoleMessageNotFound":  testSaveReactionRoleMessageNotFound,
+		"DeleteReactionRoleMessage":        testDeleteReactionRoleMessage,
+		"PurgeGuildReactionRoles":          testPurgeGuildReactionRoles,
+		"CreateInfraction":                 testCreateInfraction,
+		"CreateInfractionGuildNotFound":    testCreateInfractionGuildNotFound,
+		"CreateEscalationInfraction":       testCreateEscalationInfraction,
+		"ReadInfractionNotFound":           testReadInfractionNotFound,
+		"ReadInfractions":                  testReadInfractions,
+		"UpdateInfraction":                 testUpdateInfraction,
+		"DeleteInfraction":                 testDeleteInfraction,
+		"PurgeGuildInfractions":            testPurgeGuildInfractions,
+		"ReadEscalationPolicyEmpty":        testReadEscalationPolicyEmpty,
+		"UpdateEscalationPolicy":           testUpdateEscalationPolicy,
+		"UpdateEscalationPolicyNotFound":   testUpdateEscalationPolicyNotFound,
+		"SaveReactionFloodRule":            testSaveReactionFloodRule,
+		"SaveReactionFloodRuleNotFound":    testSaveReactionFloodRuleNotFound,
+		"DeleteReactionFloodRule":          testDeleteReactionFloodRule,
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test(t, newDB(t))
+		})
+	}
+}
+
+func createGuild(t *testing.T, d db.Database, gId string) guild.Guild {
+	g, err := d.CreateGuild(guild.GuildCreate{GuildId: gId, OwnerId: "owner"})
+	require.NoError(t, err)
+
+	return g
+}
+
+func reactionRule(gId, emojiName, emojiId string) rule.ReactionRule {
+	return rule.ReactionRule{
+		EmojiName:       emojiName,
+		EmojiId:         emojiId,
+		IsCustom:        emojiId != "",
+		GuildId:         gId,
+		RuleAuthor:      "author",
+		Actions:         rule.Actions{{Type: rule.Delete}},
+		IncludeChannels: []string{"1"},
+		ExcludeChannels: []string{},
+		ExemptRoles:     []string{"2"},
+	}
+}
+
+func testUpsertUser(t *testing.T, d db.Database) {
+	created, err := d.UpsertUser(user.User{UserId: "user", Name: "old"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, user.User{UserId: "user", Name: "old"}, created)
+
+	updated, err := d.UpsertUser(user.User{UserId: "user", Name: "new"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, user.User{UserId: "user", Name: "new"}, updated)
+
+	found, err := d.ReadUser("user")
+
+	assert.NoError(t, err)
+	assert.Equal(t, updated, found)
+}
+
+func testReadUserNotFound(t *testing.T, d db.Database) {
+	_, err := d.ReadUser("missing")
+
+	assert.Equal(t, common.ErrNotFound, err)
+}
+
+func testRefreshToken(t *testing.T, d db.Database) {
+	_, err := d.UpsertUser(user.User{UserId: "user", Name: "name"})
+	require.NoError(t, err)
+
+	expires := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
+	require.NoError(t, d.SaveRefreshToken(user.RefreshToken{UserId: "user", TokenHash: "old", Expires: expires}))
+	require.NoError(t, d.SaveRefreshToken(user.RefreshToken{UserId: "user", TokenHash: "new", Expires: expires, DiscordToken: "discord"}))
+
+	_, err = d.ReadRefreshToken("old")
+
+	assert.Equal(t, common.ErrNotFound, err, "saving a token replaces the previous one")
+
+	found, err := d.ReadRefreshToken("new")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "user", found.UserId)
+	assert.True(t, expires.Equal(found.Expires))
+	assert.Equal(t, "discord", found.DiscordToken)
+
+	require.NoError(t, d.DeleteRefreshToken("user"))
+
+	_, err = d.ReadRefreshToken("new")
+
+	assert.Equal(t, common.ErrNotFound, err)
+}
+
+func testRefreshTokenUserNotFound(t *testing.T, d db.Database) {
+	err := d.SaveRefreshToken(user.RefreshToken{UserId: "missing", TokenHash: "hash", Expires: time.Now()})
+
+	assert.Equal(t, common.ErrNotFound, err)
+}
+
+func testSaveUserGuilds(t *testing.T, d db.Database) {
+	_, err := d.UpsertUser(user.User{UserId: "user", Name: "name"})
+	require.NoError(t, err)
+
+	require.NoError(t, d.SaveUserGuilds("user", []user.UserGuild{
+		{GuildId: "old", Permissions: 8},
+		{GuildId: "kept", Owner: true},
+	}))
+	require.NoError(t, d.SaveUserGuilds("user", []user.UserGuild{
+		{GuildId: "kept", Permissions: 1 << 40},
+	}))
+
+	_, err = d.ReadUserGuild("user", "old")
+
+	assert.Equal(t, common.ErrNotFound, err, "saving guilds replaces the previous ones")
+
+	found, err := d.ReadUserGuild("user", "kept")
+
+	assert.NoError(t, err)
+	assert.Equal(t, user.UserGuild{UserId: "user", GuildId: "kept", Permissions: 1 << 40}, found)
+}
+
+func testSaveUserGuildsUserNotFound(t *testing.T, d db.Database) {
+	err := d.SaveUserGuilds("missing", []user.UserGuild{{GuildId: "guild"}})
+
+	assert.Equal(t, common.ErrNotFound, err)
+}
+
+func testCreateGuild(t *testing.T, d db.Database) {
+	created := createGuild(t, d, "guild")
+
+	expected := guild.Guild{GuildId: "guild", OwnerId: "owner", ExemptRoles: []string{}, ExemptAdmins: true}
+	assert.Equal(t, expected, created)
+
+	found, err := d.ReadGuild("guild")
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, found)
+}
+
+func testCreateGuildConflict(t *testing.T, d db.Database) {
+	createGuild(t, d, "guild")
+
+	_, err := d.CreateGuild(guild.GuildCreate{GuildId: "guild", OwnerId: "other"})
+
+	assert.Equal(t, guild.ErrGuildConflict, err)
+}
+
+func testReadGuildNotFound(t *testing.T, d db.Database) {
+	_, err := d.ReadGuild("missing")
+
+	assert.Equal(t, common.ErrNotFound, err)
+}
+
+func testUpdateGuildExemptions(t *testing.T, d db.Database) {
+	createGuild(t, d, "guild")
+	exemptAdmins := false
+
+	updated, err := d.UpdateGuildExemptions("guild", guild.GuildExemptions{ExemptRoles: []string{"1", "2"}, ExemptAdmins: &exemptAdmins})
+
+	expected := guild.Guild{GuildId: "guild", OwnerId: "owner", ExemptRoles: []string{"1", "2"}, ExemptAdmins: false}
+	assert.NoError(t, err)
+	assert.Equal(t, expected, updated)
+
+	found, err := d.ReadGuild("guild")
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, found)
+}
+
+func testUpdateGuildExemptionsNotFound(t *testing.T, d db.Database) {
+	exemptAdmins := true
+
+	_, err := d.UpdateGuildExemptions("missing", guild.GuildExemptions{ExemptAdmins: &exemptAdmins})
+
+	assert.Equal(t, common.ErrNotFound, err)
+}
+
+func testUpdateGuildModLog(t *testing.T, d db.Database) {
+	createGuild(t, d, "guild")
+
+	updated, err := d.UpdateGuildModLog("guild", guild.GuildModLog{ChannelId: "123"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "123", updated.ModLogChannelId)
+
+	found, err := d.ReadGuild("guild")
+
+	assert.NoError(t, err)
+	assert.Equal(t, updated, found)
+
+	disabled, err := d.UpdateGuildModLog("guild", guild.GuildModLog{})
+
+	assert.NoError(t, err)
+	assert.Empty(t, disabled.ModLogChannelId)
+}
+
+func testUpdateGuildModLogNotFound(t *testing.T, d db.Database) {
+	_, err := d.UpdateGuildModLog("missing", guild.GuildModLog{ChannelId: "123"})
+
+	assert.Equal(t, common.ErrNotFound, err)
+}
+
+func testCreateGuildMetadata(t *testing.T, d db.Database) {
+	joinedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	created, err := d.CreateGuild(guild.GuildCreate{GuildId: "guild", OwnerId: "owner", Name: "name", Icon: "icon", MemberCount: 42, JoinedAt: &joinedAt})
+
+	assert.NoError(t, err)
+	assertGuildMetadata(t, created, "owner", "name", "icon", 42, &joinedAt)
+
+	found, err := d.ReadGuild("guild")
+
+	assert.NoError(t, err)
+	assertGuildMetadata(t, found, "owner", "name", "icon", 42, &joinedAt)
+}
+
+func testUpdateGuild(t *testing.T, d db.Database) {
+	createGuild(t, d, "guild")
+	ownerId := "new owner"
+	name := "name"
+	joinedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	updated, err := d.UpdateGuild("guild", guild.GuildUpdate{OwnerId: &ownerId, Name: &name, JoinedAt: &joinedAt})
+
+	assert.NoError(t, err)
+	assertGuildMetadata(t, updated, "new owner", "name", "", 0, &joinedAt)
+
+	icon := "icon"
+	memberCount := 7
+
+	updated, err = d.UpdateGuild("guild", guild.GuildUpdate{Icon: &icon, MemberCount: &memberCount})
+
+	assert.NoError(t, err)
+	assertGuildMetadata(t, updated, "new owner", "name", "icon", 7, &joinedAt)
+	assert.True(t, updated.ExemptAdmins, "exemptions must be kept")
+
+	found, err := d.ReadGuild("guild")
+
+	assert.NoError(t, err)
+	assertGuildMetadata(t, found, "new owner", "name", "icon", 7, &joinedAt)
+}
+
+func testUpdateGuildNotFound(t *testing.T, d db.Database) {
+	name := "name"
+
+	_, err := d.UpdateGuild("missing", guild.GuildUpdate{Name: &name})
+
+	assert.Equal(t, common.ErrNotFound, err)
+
+	createGuild(t, d, "deleted")
+	require.NoError(t, d.DeleteGuild("deleted", time.Now()))
+
+	_, err = d.UpdateGuild("deleted", guild.GuildUpdate{Name: &name})
+
+	assert.Equal(t, common.ErrNotFound, err)
+}
+
+// assertGuildMetadata compares joinedAt as an instant, databases return it in different locations.
+func assertGuildMetadata(t *testing.T, g guild.Guild, ownerId, name, icon string, memberCount int, joinedAt *time.Time) {
+	t.Helper()
+
+	assert.Equal(t, ownerId, g.OwnerId)
+	assert.Equal(t, name, g.Name)
+	assert.Equal(t, icon, g.Icon)
+	assert.Equal(t, memberCount, g.MemberCount)
+
+	if joinedAt == nil {
+		assert.Nil(t, g.JoinedAt)
+	} else if assert.NotNil(t, g.JoinedAt) {
+		assert.True(t, joinedAt.Equal(*g.JoinedAt), "expected joinedAt %s, got %s", joinedAt, g.JoinedAt)
+	}
+}
+
+func testDeleteGuild(t *testing.T, d db.Database) {
+	createGuild(t, d, "guild")
+	exemptAdmins := true
+
+	err := d.DeleteGuild("guild", time.Now())
+
+	assert.NoError(t, err)
+
+	_, err = d.ReadGuild("guild")
+	assert.Equal(t, common.ErrNotFound, err)
+
+	_, err = d.UpdateGuildExemptions("guild", guild.GuildExemptions{ExemptAdmins: &exemptAdmins})
+	assert.Equal(t, common.ErrNotFound, err)
+
+	_, err = d.CreateReactionRules([]rule.ReactionRule{reactionRule("guild", "smile", "")})
+	assert.Equal(t, common.ErrNotFound, err)
+
+	err = d.DeleteGuild("guild", time.Now())
+	assert.Equal(t, common.ErrNotFound, err, "deleted guild can't be deleted again")
+}
+
+func testDeleteGuildNotFound(t *testing.T, d db.Database) {
+	err := d.DeleteGuild("missing", time.Now())
+
+	assert.Equal(t, common.ErrNotFound, err)
+}
+
+func testRestoreGuild(t *testing.T, d db.Database) {
+	createGuild(t, d, "guild")
+	r := reactionRule("guild", "smile", "")
+
+	_, err := d.CreateReactionRules([]rule.ReactionRule{r})
+	require.NoError(t, err)
+
+	deletedAt := time.Now()
+	require.NoError(t, d.DeleteGuild("guild", deletedAt))
+
+	restored, err := d.RestoreGuild(guild.GuildCreate{GuildId: "guild", OwnerId: "new owner"}, deletedAt.Add(-time.Hour))
+
+	expected := guild.Guild{GuildId: "guild", OwnerId: "new owner", ExemptRoles: []string{}, ExemptAdmins: true}
+	assert.NoError(t, err)
+	assert.Equal(t, expected, restored)
+
+	found, err := d.ReadGuild("guild")
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, found)
+
+	foundRules, err := d.ReadReactionRules("guild")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []rule.ReactionRule{r}, foundRules)
+
+	_, err = d.RestoreGuild(guild.GuildCreate{GuildId: "guild", OwnerId: "owner"}, deletedAt.Add(-time.Hour))
+	assert.Equal(t, common.ErrNotFound, err, "guild that isn't deleted can't be restored")
+}
+
+func testRestoreGuildExpired(t *testing.T, d db.Database) {
+	createGuild(t, d, "guild")
+
+	deletedAt := time.Now()
+	require.NoError(t, d.DeleteGuild("guild", deletedAt))
+
+	_, err := d.RestoreGuild(guild.GuildCreate{GuildId: "guild", OwnerId: "owner"}, deletedAt.Add(time.Second))
+
+	assert.Equal(t, common.ErrNotFound, err)
+
+	_, err = d.ReadGuild("guild")
+	assert.Equal(t, common.ErrNotFound, err)
+}
+
+func testCreateDeletedGuild(t *testing.T, d db.Database) {
+	createGuild(t, d, "guild")
+
+	_, err := d.CreateReactionRules([]rule.ReactionRule{reactionRule("guild", "smile", "")})
+	require.NoError(t, err)
+	require.NoError(t, d.DeleteGuild("guild", time.Now()))
+
+	created, err := d.CreateGuild(guild.GuildCreate{GuildId: "guild", OwnerId: "new owner"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, guild.Guild{GuildId: "guild", OwnerId: "new owner", ExemptRoles: []string{}, ExemptAdmins: true}, created)
+
+	found, err := d.ReadReactionRules("guild")
+
+	assert.NoError(t, err)
+	assert.Empty(t, found, "rules of the deleted guild must be purged")
+}
+
+func testPurgeGuilds(t *testing.T, d db.Database) {
+	createGuild(t, d, "expired")
+	createGuild(t, d, "retained")
+	createGuild(t, d, "active")
+
+	_, err := d.CreateReactionRules([]rule.ReactionRule{reactionRule("expired", "smile", "")})
+	require.NoError(t, err)
+
+	now := time.Now()
+	require.NoError(t, d.DeleteGuild("expired", now.Add(-2*time.Hour)))
+	require.NoError(t, d.DeleteGuild("retained", now))
+
+	purged, err := d.PurgeGuilds(now.Add(-time.Hour))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, purged)
+
+	_, err = d.RestoreGuild(guild.GuildCreate{GuildId: "expired", OwnerId: "owner"}, time.Time{})
+	assert.Equal(t, common.ErrNotFound, err, "purged guild can't be restored")
+
+	found, err := d.ReadReactionRules("expired")
+
+	assert.NoError(t, err)
+	assert.Empty(t, found, "rules of the purged guild must be purged")
+
+	_, err = d.RestoreGuild(guild.GuildCreate{GuildId: "retained", OwnerId: "owner"}, now.Add(-time.Hour))
+	assert.NoError(t, err)
+
+	_, err = d.ReadGuild("active")
+	assert.NoError(t, err)
+}
+
+func testCreateReactionRules(t *testing.T, d db.Database) {
+	createGuild(t, d, "guild")
+	rules := []rule.ReactionRule{reactionRule("guild", "smile", ""), reactionRule("guild", "pepe", "123"),
+		reactionRule("guild", "flags", ""), reactionRule("guild", "^nsfw_", "")}
+	rules[1].Threshold = &rule.ReactionThreshold{Count: 5, WindowSeconds: 600}
+	rules[2].Match = rule.MatchCategory
+	rules[3].Match = rule.MatchPattern
+	rules[3].IsCustom = true
+
+	created, err := d.CreateReactionRules(rules)
+
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, rules, created)
+
+	found, err := d.ReadReactionRules("guild")
+
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, rules, found)
+}
+
+func testCreateReactionRulesConflict(t *testing.T, d db.Database) {
+	createGuild(t, d, "guild")
+
+	_, err := d.CreateReactionRules([]rule.ReactionRule{reactionRule("guild", "smile", "")})
+	require.NoError(t, err)
+
+	_, err = d.CreateReactionRules([]rule.ReactionRule{reactionRule("guild", "pepe", "123"), reactionRule("guild", "smile", "")})
+
+	assert.Equal(t, rule.ErrRuleReactionConflict, err)
+
+	found, err := d.ReadReactionRules("guild")
+
+	assert.NoError(t, err)
+	assert.Len(t, found, 1, "rules must not be created partially")
+}
+
+func testCreateReactionRulesGuildNotFound(t *testing.T, d db.Database) {
+	_, err := d.CreateReactionRules([]rule.ReactionRule{reactionRule("missing", "smile", "")})
+
+	assert.Equal(t, common.ErrNotFound, err)
+}
+
+func testReadReactionRulesEmpty(t *testing.T, d db.Database) {
+	createGuild(t, d, "guild")
+
+	found, err := d.ReadReactionRules("guild")
+
+	assert.NoError(t, err)
+	assert.Empty(t, found)
+}
+
+func testUpdateReactionRules(t *testing.T, d db.Database) {
+	createGuild(t, d, "guild")
+	r := reactionRule("guild", "smile", "")
+	// threshold and match are kept, they're set only on creation
+	r.Threshold = &rule.ReactionThreshold{Count: 3, WindowSeconds: 60}
+	r.Match = rule.MatchBase
+
+	_, err := d.CreateReactionRules([]rule.ReactionRule{r})
+	require.NoError(t, err)
+
+	r.Actions = rule.Actions{{Type: rule.Warn}, {Type: rule.Timeout, DurationSeconds: 3600}}
+
+	updated, err := d.UpdateReactionRules([]rule.ReactionRuleUpdate{{EmojiName: r.EmojiName, EmojiId: r.EmojiId, Actions: r.Actions}}, "guild")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []rule.ReactionRule{r}, updated)
+
+	found, err := d.ReadReactionRules("guild")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []rule.ReactionRule{r}, found)
+}
+
+func testUpdateReactionRulesNotFound(t *testing.T, d db.Database) {
+	createGuild(t, d, "guild")
+	r := reactionRule("guild", "smile", "")
+
+	_, err := d.CreateReactionRules([]rule.ReactionRule{r})
+	require.NoError(t, err)
+
+	_, err = d.UpdateReactionRules([]rule.ReactionRuleUpdate{
+		{EmojiName: "smile", Actions: rule.Actions{{Type: rule.Ban}}},
+		{EmojiName: "missing", Actions: rule.Actions{{Type: rule.Ban}}},
+	}, "guild")
+
+	assert.Equal(t, common.ErrNotFound, err)
+
+	found, err := d.ReadReactionRules("guild")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []rule.ReactionRule{r}, found, "rules must not be updated partially")
+}
+
+func testDeleteReactionRules(t *testing.T, d db.Database) {
+	createGuild(t, d, "guild")
+	kept := reactionRule("guild", "pepe", "123")
+
+	_, err := d.CreateReactionRules([]rule.ReactionRule{reactionRule("guild", "smile", ""), kept})
+	require.NoError(t, err)
+
+	err = d.DeleteReactionRules([]rule.DeleteReactionRuleQuery{{EmojiName: "smile"}}, "guild")
+
+	assert.NoError(t, err)
+
+	found, err := d.ReadReactionRules("guild")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []rule.ReactionRule{kept}, found)
+
+	err = d.DeleteReactionRules([]rule.DeleteReactionRuleQuery{{EmojiName: "missing"}}, "guild")
+
+	assert.NoError(t, err, "missing rules are ignored")
+}
+
+func testReactionRulesOfDeletedGuild(t *testing.T, d db.Database) {
+	createGuild(t, d, "guild")
+	r := reactionRule("guild", "smile", "")
+
+	_, err := d.CreateReactionRules([]rule.ReactionRule{r})
+	require.NoError(t, err)
+
+	deletedAt := time.Now()
+	require.NoError(t, d.DeleteGuild("guild", deletedAt))
+
+	found, err := d.ReadReactionRules("guild")
+
+	assert.NoError(t, err)
+	assert.Empty(t, found)
+
+	_, err = d.UpdateReactionRules([]rule.ReactionRuleUpdate{{EmojiName: r.EmojiName, EmojiId: r.EmojiId, Actions: r.Actions}}, "guild")
+	assert.Equal(t, common.ErrNotFound, err)
+
+	err = d.DeleteReactionRules([]rule.DeleteReactionRuleQuery{{EmojiName: r.EmojiName}}, "guild")
+	assert.NoError(t, err)
+
+	_, err = d.RestoreGuild(guild.GuildCreate{GuildId: "guild", OwnerId: "owner"}, deletedAt.Add(-time.Hour))
+	require.NoError(t, err)
+
+	found, err = d.ReadReactionRules("guild")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []rule.ReactionRule{r}, found, "rules of the deleted guild are kept until it's purged")
+}
+
+func reactionRoleMessage(gId, messageId string, roles ...rule.ReactionRole) rule.ReactionRoleMessage {
+	return rule.ReactionRoleMessage{
+		GuildId:   gId,
+		ChannelId: "100",
+		MessageId: messageId,
+		Mode:      rule.ReactionRoleToggle,
+		Roles:     roles,
+	}
+}
+
+func testSaveReactionRoleMessage(t *testing.T, d db.Database) {
+	createGuild(t, d, "guild")
+	red := rule.ReactionRole{EmojiName: "🔴", RoleId: "1"}
+	blue := rule.ReactionRole{EmojiName: "blue", EmojiId: "123", RoleId: "2"}
+	green := rule.ReactionRole{EmojiName: "🟢", RoleId: "3"}
+
+	found, err := d.ReadReactionRoleMessages("guild")
+
+	assert.NoError(t, err)
+	assert.Empty(t, found)
+
+	_, err = d.SaveReactionRoleMessage(reactionRoleMessage("guild", "20", red, blue))
+	require.NoError(t, err)
+	_, err = d.SaveReactionRoleMessage(reactionRoleMessage("guild", "10", green))
+	require.NoError(t, err)
+
+	replaced := reactionRoleMessage("guild", "20", blue, green)
+	replaced.Mode = rule.ReactionRoleUnique
+	replaced.ChannelId = "200"
+
+	saved, err := d.SaveReactionRoleMessage(replaced)
+
+	assert.NoError(t, err)
+	assert.Equal(t, replaced, saved)
+
+	found, err = d.ReadReactionRoleMessages("guild")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []rule.ReactionRoleMessage{reactionRoleMessage("guild", "10", green), replaced}, found, "messages are sorted and roles replaced in order")
+}
+
+func testSaveReactionRoleMessageNotFound(t *testing.T, d db.Database) {
+	_, err := d.SaveReactionRoleMessage(reactionRoleMessage("missing", "10", rule.ReactionRole{EmojiName: "🔴", RoleId: "1"}))
+	assert.Equal(t, common.ErrNotFound, err)
+
+	createGuild(t, d, "deleted")
+	require.NoError(t, d.DeleteGuild("deleted", time.Now()))
+
+	_, err = d.SaveReactionRoleMessage(reactionRoleMessage("deleted", "10", rule.ReactionRole{EmojiName: "🔴", RoleId: "1"}))
+	assert.Equal(t, common.ErrNotFound, err)
+}
+
+func testDeleteReactionRoleMessage(t *testing.T, d db.Database) {
+	createGuild(t, d, "guild")
+
+	_, err := d.SaveReactionRoleMessage(reactionRoleMessage("guild", "10", rule.ReactionRole{EmojiName: "🔴", RoleId: "1"}))
+	require.NoError(t, err)
+
+	assert.NoError(t, d.DeleteReactionRoleMessage("guild", "10"))
+	assert.Equal(t, common.ErrNotFound, d.DeleteReactionRoleMessage("guild", "10"))
+
+	found, err := d.ReadReactionRoleMessages("guild")
+
+	assert.NoError(t, err)
+	assert.Empty(t, found)
+}
+
+func testPurgeGuildReactionRoles(t *testing.T, d db.Database) {
+	createGuild(t, d, "guild")
+
+	_, err := d.SaveReactionRoleMessage(reactionRoleMessage("guild", "10", rule.ReactionRole{EmojiName: "🔴", RoleId: "1"}))
+	require.NoError(t, err)
+
+	require.NoError(t, d.DeleteGuild("guild", time.Now().Add(-time.Hour)))
+	_, err = d.PurgeGuilds(time.Now())
+	require.NoError(t, err)
+
+	createGuild(t, d, "guild")
+
+	found, err := d.ReadReactionRoleMessages("guild")
+
+	assert.NoError(t, err)
+	assert.Empty(t, found, "reaction roles are purged with the guild")
+}
+
+func infractionCreate(gId, userId string, createdAt time.Time) infraction.InfractionCreate {
+	return infraction.InfractionCreate{
+		GuildId:   gId,
+		UserId:    userId,
+		Source:    infraction.SourceRule,
+		Action:    infraction.Warn,
+		Reason:    "reaction",
+		EmojiName: "smile",
+		ChannelId: "channel",
+		MessageId: "message",
+		CreatedAt: createdAt,
+	}
+}
+
+func testCreateInfraction(t *testing.T, d db.Database) {
+	createGuild(t, d, "guild")
+	createdAt := time.Now().UTC().Truncate(time.Second)
+
+	created, err := d.CreateInfraction(infraction.InfractionCreate{
+		GuildId:     "guild",
+		UserId:      "user",
+		ModeratorId: "moderator",
+		Source:      infraction.SourceModerator,
+		Action:      infraction.Ban,
+		Reason:      "spam",
+		CreatedAt:   createdAt,
+	})
+
+	require.NoError(t, err)
+	assert.NotZero(t, created.Id)
+	assert.Equal(t, "moderator", created.ModeratorId)
+	assert.Equal(t, infraction.SourceModerator, created.Source)
+	assert.Equal(t, infraction.Ban, created.Action)
+	assert.True(t, createdAt.Equal(created.CreatedAt))
+
+	found, err := d.ReadInfraction("guild", created.Id)
+
+	assert.NoError(t, err)
+	assert.Equal(t, created.Id, found.Id)
+	assert.Equal(t, "spam", found.Reason)
+	assert.True(t, createdAt.Equal(found.CreatedAt))
+
+	_, err = d.ReadInfraction("other", created.Id)
+	assert.Equal(t, common.ErrNotFound, err, "infractions are read by guild")
+}
+
+func testCreateEscalationInfraction(t *testing.T, d db.Database) {
+	createGuild(t, d, "guild")
+
+	created, err := d.CreateInfraction(infraction.InfractionCreate{
+		GuildId: "guild",
+		UserId:  "user",
+		Source:  infraction.SourceEscalation,
+		Action:  infraction.Kick,
+		Reason:  "Escalation: 5 warnings in 7d",
+		Step:    "5/604800",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "5/604800", created.Step)
+
+	// the step is kept when the reason is edited
+	_, err = d.UpdateInfraction("guild", created.Id, infraction.InfractionUpdate{Reason: "edited"})
+	require.NoError(t, err)
+
+	found, err := d.ReadInfraction("guild", created.Id)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "5/604800", found.Step)
+}
+
+func testCreateInfractionGuildNotFound(t *testing.T, d db.Database) {
+	_, err := d.CreateInfraction(infractionCreate("missing", "user", time.Now()))
+	assert.Equal(t, common.ErrNotFound, err)
+
+	createGuild(t, d, "deleted")
+	require.NoError(t, d.DeleteGuild("deleted", time.Now()))
+
+	_, err = d.CreateInfraction(infractionCreate("deleted", "user", time.Now()))
+	assert.Equal(t, common.ErrNotFound, err, "deleted guilds get no infractions")
+}
+
+func testReadInfractionNotFound(t *testing.T, d db.Database) {
+	createGuild(t, d, "guild")
+
+	_, err := d.ReadInfraction("guild", 1)
+	assert.Equal(t, common.ErrNotFound, err)
+}
+
+func testReadInfractions(t *testing.T, d db.Database) {
+	createGuild(t, d, "guild")
+	createGuild(t, d, "other")
+	now := time.Now().UTC().Truncate(time.Second)
+
+	oldest, err := d.CreateInfraction(infractionCreate("guild", "user", now.Add(-2*time.Hour)))
+	require.NoError(t, err)
+	newest, err := d.CreateInfraction(infractionCreate("guild", "user", now))
+	require.NoError(t, err)
+	middle, err := d.CreateInfraction(infractionCreate("guild", "user", now.Add(-time.Hour)))
+	require.NoError(t, err)
+	_, err = d.CreateInfraction(infractionCreate("guild", "another", now))
+	require.NoError(t, err)
+	_, err = d.CreateInfraction(infractionCreate("other", "user", now))
+	require.NoError(t, err)
+
+	ids := func(infractions []infraction.Infraction) []int64 {
+		ids := make([]int64, 0, len(infractions))
+		for _, i := range infractions {
+			ids = append(ids, i.Id)
+		}
+		return ids
+	}
+
+	found, total, err := d.ReadInfractions(infraction.InfractionQuery{GuildId: "guild", UserId: "user", Limit: 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, total)
+	assert.Equal(t, []int64{newest.Id, middle.Id}, ids(found), "infractions are read newest first")
+
+	found, total, err = d.ReadInfractions(infraction.InfractionQuery{GuildId: "guild", UserId: "user", Limit: 2, Offset: 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, total)
+	assert.Equal(t, []int64{oldest.Id}, ids(found))
+
+	found, total, err = d.ReadInfractions(infraction.InfractionQuery{GuildId: "guild", Limit: 10})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4, total, "empty user selects every member")
+	assert.Len(t, found, 4)
+
+	found, total, err = d.ReadInfractions(infraction.InfractionQuery{GuildId: "guild", UserId: "missing", Limit: 10})
+
+	assert.NoError(t, err)
+	assert.Zero(t, total)
+	assert.Empty(t, found)
+}
+
+func testUpdateInfraction(t *testing.T, d db.Database) {
+	createGuild(t, d, "guild")
+
+	created, err := d.CreateInfraction(infractionCreate("guild", "user", time.Now()))
+	require.NoError(t, err)
+
+	updated, err := d.UpdateInfraction("guild", created.Id, infraction.InfractionUpdate{Reason: "appealed"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "appealed", updated.Reason)
+	assert.Equal(t, created.Id, updated.Id)
+	assert.Equal(t, created.Action, updated.Action)
+
+	_, err = d.UpdateInfraction("other", created.Id, infraction.InfractionUpdate{Reason: "appealed"})
+	assert.Equal(t, common.ErrNotFound, err)
+}
+
+func testDeleteInfraction(t *testing.T, d db.Database) {
+	createGuild(t, d, "guild")
+
+	created, err := d.CreateInfraction(infractionCreate("guild", "user", time.Now()))
+	require.NoError(t, err)
+
+	assert.Equal(t, common.ErrNotFound, d.DeleteInfraction("other", created.Id))
+	assert.NoError(t, d.DeleteInfraction("guild", created.Id))
+
+	_, err = d.ReadInfraction("guild", created.Id)
+	assert.Equal(t, common.ErrNotFound, err)
+
+	assert.Equal(t, common.ErrNotFound, d.DeleteInfraction("guild", created.Id))
+}
+
+func testPurgeGuildInfractions(t *testing.T, d db.Database) {
+	createGuild(t, d, "guild")
+
+	_, err := d.CreateInfraction(infractionCreate("guild", "user", time.Now()))
+	require.NoError(t, err)
+
+	require.NoError(t, d.DeleteGuild("guild", time.Now().Add(-time.Hour)))
+
+	_, err = d.PurgeGuilds(time.Now())
+	require.NoError(t, err)
+
+	createGuild(t, d, "guild")
+
+	found, total, err := d.ReadInfractions(infraction.InfractionQuery{GuildId: "guild", Limit: 10})
+
+	assert.NoError(t, err)
+	assert.Zero(t, total, "infractions of the purged guild must be purged")
+	assert.Empty(t, found)
+}
+
+func testReadEscalationPolicyEmpty(t *testing.T, d db.Database) {
+	createGuild(t, d, "guild")
+
+	found, err := d.ReadEscalationPolicy("guild")
+
+	assert.NoError(t, err)
+	assert.Equal(t, escalation.Policy{GuildId: "guild", Steps: []escalation.Step{}}, found)
+}
+
+func testUpdateEscalationPolicy(t *testing.T, d db.Database) {
+	createGuild(t, d, "guild")
+	timeout := escalation.Step{Warns: 3, WindowSeconds: 604800, Action: infraction.Timeout, TimeoutSeconds: 3600}
+	kick := escalation.Step{Warns: 5, WindowSeconds: 604800, Action: infraction.Kick}
+	ban := escalation.Step{Warns: 7, WindowSeconds: 604800, Action: infraction.Ban}
+
+	_, err := d.UpdateEscalationPolicy("guild", []escalation.Step{timeout, kick})
+	require.NoError(t, err)
+
+	updated, err := d.UpdateEscalationPolicy("guild", []escalation.Step{timeout, ban})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []escalation.Step{timeout, ban}, updated.Steps)
+
+	found, err := d.ReadEscalationPolicy("guild")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []escalation.Step{timeout, ban}, found.Steps, "steps are replaced")
+
+	_, err = d.UpdateEscalationPolicy("guild", nil)
+	require.NoError(t, err)
+
+	found, err = d.ReadEscalationPolicy("guild")
+
+	assert.NoError(t, err)
+	assert.Empty(t, found.Steps)
+}
+
+func testUpdateEscalationPolicyNotFound(t *testing.T, d db.Database) {
+	_, err := d.UpdateEscalationPolicy("missing", []escalation.Step{{Warns: 3, WindowSeconds: 60, Action: infraction.Kick}})
+	assert.Equal(t, common.ErrNotFound, err)
+
+	createGuild(t, d, "deleted")
+	require.NoError(t, d.DeleteGuild("deleted", time.Now()))
+
+	_, err = d.UpdateEscalationPolicy("deleted", []escalation.Step{{Warns: 3, WindowSeconds: 60, Action: infraction.Kick}})
+	assert.Equal(t, common.ErrNotFound, err)
+}
+
+func testSaveReactionFloodRule(t *testing.T, d db.Database) {
+	createGuild(t, d, "guild")
+
+	_, err := d.ReadReactionFloodRule("guild")
+	assert.Equal(t, common.ErrNotFound, err)
+
+	f := rule.ReactionFloodRule{GuildId: "guild", MaxReactions: 10, WindowSeconds: 30, Actions: rule.Actions{{Type: rule.Delete}}}
+
+	saved, err := d.SaveReactionFloodRule(f)
+
+	assert.NoError(t, err)
+	assert.Equal(t, f, saved)
+
+	f.MaxReactions = 5
+	f.Actions = rule.Actions{{Type: rule.Delete}, {Type: rule.Timeout, DurationSeconds: 600}}
+
+	saved, err = d.SaveReactionFloodRule(f)
+
+	assert.NoError(t, err)
+	assert.Equal(t, f, saved)
+
+	found, err := d.ReadReactionFloodRule("guild")
+
+	assert.NoError(t, err)
+	assert.Equal(t, f, found, "the rule is replaced")
+}
+
+func testSaveReactionFloodRuleNotFound(t *testing.T, d db.Database) {
+	f := rule.ReactionFloodRule{GuildId: "missing", MaxReactions: 10, WindowSeconds: 30, Actions: rule.Actions{{Type: rule.Delete}}}
+
+	_, err := d.SaveReactionFloodRule(f)
+	assert.Equal(t, common.ErrNotFound, err)
+
+	createGuild(t, d, "deleted")
+	require.NoError(t, d.DeleteGuild("deleted", time.Now()))
+
+	f.GuildId = "deleted"
+	_, err = d.SaveReactionFloodRule(f)
+	assert.Equal(t, common.ErrNotFound, err)
+}
+
+func testDeleteReactionFloodRule(t *testing.T, d db.Database) {
+	createGuild(t, d, "guild")
+
+	_, err := d.SaveReactionFloodRule(rule.ReactionFloodRule{GuildId: "guild", MaxReactions: 10, WindowSeconds: 30, Actions: rule.Actions{{Type: rule.Kick}}})
+	require.NoError(t, err)
+
+	assert.NoError(t, d.DeleteReactionFloodRule("guild"))
+	assert.Equal(t, common.ErrNotFound, d.DeleteReactionFloodRule("guild"))
+
+	_, err = d.ReadReactionFloodRule("guild")
+	assert.Equal(t, common.ErrNotFound, err)
+}
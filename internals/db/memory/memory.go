@@ -0,0 +1,751 @@
+package memory
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/finkabaj/hyde-bot/internals/db"
+	"github.com/finkabaj/hyde-bot/internals/utils/common"
+	"github.com/finkabaj/hyde-bot/internals/utils/escalation"
+	"github.com/finkabaj/hyde-bot/internals/utils/guild"
+	"github.com/finkabaj/hyde-bot/internals/utils/infraction"
+	"github.com/finkabaj/hyde-bot/internals/utils/rule"
+	"github.com/finkabaj/hyde-bot/internals/utils/user"
+)
+
+// Memory is a thread safe in-memory db.Database for local runs and tests.
+// It follows error semantics of postgresql.Postgresql. Data is lost on Close.
+type Memory struct {
+	users         map[string]user.User
+	refreshTokens map[string]user.RefreshToken // refreshTokens[userId]
+	userGuilds    map[string][]user.UserGuild  // userGuilds[userId]
+	guilds        map[string]guild.Guild
+	deletedGuilds map[string]time.Time                  // deletedGuilds[guildId] is when the guild was soft deleted
+	reactionRules map[string][]rule.ReactionRule        // reactionRules[guildId] in insertion order
+	reactionRoles map[string][]rule.ReactionRoleMessage // reactionRoles[guildId] sorted by message id
+	infractions   []infraction.Infraction               // infractions in insertion order
+	lastId        int64                                 // lastId is the id of the last created infraction
+	escalations   map[string][]escalation.Step          // escalations[guildId] sorted by warns
+	reactionFlood map[string]rule.ReactionFloodRule     // reactionFlood[guildId]
+	lock          sync.RWMutex
+}
+
+func NewMemory() *Memory {
+	return &Memory{
+		users:         make(map[string]user.User),
+		refreshTokens: make(map[string]user.RefreshToken),
+		userGuilds:    make(map[string][]user.UserGuild),
+		guilds:        make(map[string]guild.Guild),
+		deletedGuilds: make(map[string]time.Time),
+		reactionRules: make(map[string][]rule.ReactionRule),
+		reactionRoles: make(map[string][]rule.ReactionRoleMessage),
+		escalations:   make(map[string][]escalation.Step),
+		reactionFlood: make(map[string]rule.ReactionFloodRule),
+	}
+}
+
+func (m *Memory) Connect(credentials db.DatabaseCredentials) error {
+	return nil
+}
+
+func (m *Memory) Close() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.users = make(map[string]user.User)
+	m.refreshTokens = make(map[string]user.RefreshToken)
+	m.userGuilds = make(map[string][]user.UserGuild)
+	m.guilds = make(map[string]guild.Guild)
+	m.deletedGuilds = make(map[string]time.Time)
+	m.reactionRules = make(map[string][]rule.ReactionRule)
+	m.reactionRoles = make(map[string][]rule.ReactionRoleMessage)
+	m.infractions = nil
+	m.lastId = 0
+	m.escalations = make(map[string][]escalation.Step)
+	m.reactionFlood = make(map[string]rule.ReactionFloodRule)
+}
+
+func (m *Memory) Status() error {
+	return nil
+}
+
+func (m *Memory) UpsertUser(u user.User) (user.User, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.users[u.UserId] = u
+
+	return u, nil
+}
+
+func (m *Memory) ReadUser(userId string) (user.User, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	u, ok := m.users[userId]
+
+	if !ok {
+		return user.User{}, common.ErrNotFound
+	}
+
+	return u, nil
+}
+
+func (m *Memory) SaveRefreshToken(t user.RefreshToken) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.users[t.UserId]; !ok {
+		return common.ErrNotFound
+	}
+
+	m.refreshTokens[t.UserId] = t
+
+	return nil
+}
+
+func (m *Memory) ReadRefreshToken(tokenHash string) (user.RefreshToken, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	for _, t := range m.refreshTokens {
+		if t.TokenHash == tokenHash {
+			return t, nil
+		}
+	}
+
+	return user.RefreshToken{}, common.ErrNotFound
+}
+
+func (m *Memory) DeleteRefreshToken(userId string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	delete(m.refreshTokens, userId)
+
+	return nil
+}
+
+func (m *Memory) SaveUserGuilds(userId string, guilds []user.UserGuild) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.users[userId]; !ok {
+		return common.ErrNotFound
+	}
+
+	saved := make([]user.UserGuild, 0, len(guilds))
+
+	for _, g := range guilds {
+		g.UserId = userId
+		saved = append(saved, g)
+	}
+
+	m.userGuilds[userId] = saved
+
+	return nil
+}
+
+func (m *Memory) ReadUserGuild(userId, guildId string) (user.UserGuild, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	for _, g := range m.userGuilds[userId] {
+		if g.GuildId == guildId {
+			return g, nil
+		}
+	}
+
+	return user.UserGuild{}, common.ErrNotFound
+}
+
+func (m *Memory) CreateGuild(gc guild.GuildCreate) (guild.Guild, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.hasGuild(gc.GuildId) {
+		return guild.Guild{}, guild.ErrGuildConflict
+	}
+
+	m.purgeGuild(gc.GuildId)
+
+	// same defaults as the guilds table
+	g := guild.Guild{
+		GuildId:      gc.GuildId,
+		OwnerId:      gc.OwnerId,
+		ExemptRoles:  []string{},
+		ExemptAdmins: true,
+		Name:         gc.Name,
+		Icon:         gc.Icon,
+		MemberCount:  gc.MemberCount,
+		JoinedAt:     cloneTime(gc.JoinedAt),
+	}
+
+	m.guilds[g.GuildId] = g
+
+	return cloneGuild(g), nil
+}
+
+func (m *Memory) ReadGuild(guildId string) (guild.Guild, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	if !m.hasGuild(guildId) {
+		return guild.Guild{}, common.ErrNotFound
+	}
+
+	g := m.guilds[guildId]
+
+	return cloneGuild(g), nil
+}
+
+func (m *Memory) UpdateGuildExemptions(guildId string, exemptions guild.GuildExemptions) (guild.Guild, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if !m.hasGuild(guildId) {
+		return guild.Guild{}, common.ErrNotFound
+	}
+
+	g := m.guilds[guildId]
+
+	g.ExemptRoles = cloneStrings(exemptions.ExemptRoles)
+	g.ExemptAdmins = *exemptions.ExemptAdmins
+	m.guilds[guildId] = g
+
+	return cloneGuild(g), nil
+}
+
+func (m *Memory) UpdateGuildModLog(guildId string, modLog guild.GuildModLog) (guild.Guild, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if !m.hasGuild(guildId) {
+		return guild.Guild{}, common.ErrNotFound
+	}
+
+	g := m.guilds[guildId]
+
+	g.ModLogChannelId = modLog.ChannelId
+	m.guilds[guildId] = g
+
+	return cloneGuild(g), nil
+}
+
+func (m *Memory) UpdateGuild(guildId string, update guild.GuildUpdate) (guild.Guild, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if !m.hasGuild(guildId) {
+		return guild.Guild{}, common.ErrNotFound
+	}
+
+	g := m.guilds[guildId]
+
+	if update.OwnerId != nil {
+		g.OwnerId = *update.OwnerId
+	}
+
+	if update.Name != nil {
+		g.Name = *update.Name
+	}
+
+	if update.Icon != nil {
+		g.Icon = *update.Icon
+	}
+
+	if update.MemberCount != nil {
+		g.MemberCount = *update.MemberCount
+	}
+
+	if update.JoinedAt != nil {
+		g.JoinedAt = cloneTime(update.JoinedAt)
+	}
+
+	m.guilds[guildId] = g
+
+	return cloneGuild(g), nil
+}
+
+func (m *Memory) DeleteGuild(guildId string, deletedAt time.Time) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if !m.hasGuild(guildId) {
+		return common.ErrNotFound
+	}
+
+	m.deletedGuilds[guildId] = deletedAt
+
+	return nil
+}
+
+func (m *Memory) RestoreGuild(gc guild.GuildCreate, deletedAfter time.Time) (guild.Guild, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	deletedAt, ok := m.deletedGuilds[gc.GuildId]
+
+	if !ok || deletedAt.Before(deletedAfter) {
+		return guild.Guild{}, common.ErrNotFound
+	}
+
+	delete(m.deletedGuilds, gc.GuildId)
+
+	g := m.guilds[gc.GuildId]
+	g.OwnerId = gc.OwnerId
+	g.Name = gc.Name
+	g.Icon = gc.Icon
+	g.MemberCount = gc.MemberCount
+	g.JoinedAt = cloneTime(gc.JoinedAt)
+	m.guilds[gc.GuildId] = g
+
+	return cloneGuild(g), nil
+}
+
+func (m *Memory) PurgeGuilds(deletedBefore time.Time) (int, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	purged := 0
+
+	for guildId, deletedAt := range m.deletedGuilds {
+		if deletedAt.Before(deletedBefore) {
+			m.purgeGuild(guildId)
+			purged++
+		}
+	}
+
+	return purged, nil
+}
+
+// hasGuild reports whether the guild exists and isn't deleted. Must be called with lock held.
+func (m *Memory) hasGuild(guildId string) bool {
+	_, ok := m.guilds[guildId]
+	_, deleted := m.deletedGuilds[guildId]
+
+	return ok && !deleted
+}
+
+// purgeGuild removes the guild with its rules. Must be called with lock held.
+func (m *Memory) purgeGuild(guildId string) {
+	delete(m.guilds, guildId)
+	delete(m.deletedGuilds, guildId)
+	delete(m.reactionRules, guildId)
+	delete(m.reactionRoles, guildId)
+	delete(m.escalations, guildId)
+	delete(m.reactionFlood, guildId)
+
+	m.infractions = slices.DeleteFunc(m.infractions, func(i infraction.Infraction) bool {
+		return i.GuildId == guildId
+	})
+}
+
+// CreateReactionRules inserts all rules or none. Rules of unknown guild return common.ErrNotFound,
+// rules with existing (guildId, emojiId, emojiName) return rule.ErrRuleReactionConflict.
+func (m *Memory) CreateReactionRules(rules []rule.ReactionRule) ([]rule.ReactionRule, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	created := make([]rule.ReactionRule, 0, len(rules))
+
+	for _, r := range rules {
+		if !m.hasGuild(r.GuildId) {
+			return []rule.ReactionRule{}, common.ErrNotFound
+		}
+
+		samePrimaryKey := func(e rule.ReactionRule) bool {
+			return e.GuildId == r.GuildId && e.SameEmoji(r)
+		}
+
+		if slices.ContainsFunc(m.reactionRules[r.GuildId], samePrimaryKey) || slices.ContainsFunc(created, samePrimaryKey) {
+			return []rule.ReactionRule{}, rule.ErrRuleReactionConflict
+		}
+
+		created = append(created, cloneReactionRule(r))
+	}
+
+	for _, r := range created {
+		m.reactionRules[r.GuildId] = append(m.reactionRules[r.GuildId], r)
+	}
+
+	result := make([]rule.ReactionRule, 0, len(created))
+
+	for _, r := range created {
+		result = append(result, cloneReactionRule(r))
+	}
+
+	return result, nil
+}
+
+// DeleteReactionRules deletes rules of the guild matching any of the queries. Missing rules are ignored.
+func (m *Memory) DeleteReactionRules(rules []rule.DeleteReactionRuleQuery, gId string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if !m.hasGuild(gId) {
+		return nil
+	}
+
+	remaining := slices.DeleteFunc(m.reactionRules[gId], func(r rule.ReactionRule) bool {
+		return slices.ContainsFunc(rules, func(q rule.DeleteReactionRuleQuery) bool {
+			return q.EmojiId == r.EmojiId && q.EmojiName == r.EmojiName
+		})
+	})
+
+	if len(remaining) == 0 {
+		delete(m.reactionRules, gId)
+	} else {
+		m.reactionRules[gId] = remaining
+	}
+
+	return nil
+}
+
+// ReadReactionRules returns nil without error if the guild has no rules or is deleted.
+func (m *Memory) ReadReactionRules(gId string) ([]rule.ReactionRule, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	var foundRules []rule.ReactionRule
+
+	if !m.hasGuild(gId) {
+		return foundRules, nil
+	}
+
+	for _, r := range m.reactionRules[gId] {
+		foundRules = append(foundRules, cloneReactionRule(r))
+	}
+
+	return foundRules, nil
+}
+
+func (m *Memory) UpdateReactionRules(rules []rule.ReactionRuleUpdate, gId string) ([]rule.ReactionRule, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if !m.hasGuild(gId) {
+		return []rule.ReactionRule{}, common.ErrNotFound
+	}
+
+	existing := m.reactionRules[gId]
+	indexes := make([]int, 0, len(rules))
+
+	// nothing is updated if any of the rules doesn't exist
+	for _, u := range rules {
+		i := slices.IndexFunc(existing, func(r rule.ReactionRule) bool {
+			return r.EmojiId == u.EmojiId && r.EmojiName == u.EmojiName
+		})
+
+		if i == -1 {
+			return []rule.ReactionRule{}, common.ErrNotFound
+		}
+
+		indexes = append(indexes, i)
+	}
+
+	updatedRules := make([]rule.ReactionRule, 0, len(rules))
+
+	for n, i := range indexes {
+		existing[i].Actions = rules[n].Actions
+		updatedRules = append(updatedRules, cloneReactionRule(existing[i]))
+	}
+
+	return updatedRules, nil
+}
+
+func (m *Memory) ReadReactionRoleMessages(gId string) ([]rule.ReactionRoleMessage, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	messages := make([]rule.ReactionRoleMessage, 0, len(m.reactionRoles[gId]))
+
+	for _, msg := range m.reactionRoles[gId] {
+		messages = append(messages, cloneReactionRoleMessage(msg))
+	}
+
+	return messages, nil
+}
+
+func (m *Memory) SaveReactionRoleMessage(msg rule.ReactionRoleMessage) (rule.ReactionRoleMessage, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if !m.hasGuild(msg.GuildId) {
+		return rule.ReactionRoleMessage{}, common.ErrNotFound
+	}
+
+	msg = cloneReactionRoleMessage(msg)
+	messages := m.reactionRoles[msg.GuildId]
+
+	i, found := slices.BinarySearchFunc(messages, msg.MessageId, func(e rule.ReactionRoleMessage, id string) int {
+		return strings.Compare(e.MessageId, id)
+	})
+
+	if found {
+		messages[i] = msg
+	} else {
+		messages = slices.Insert(messages, i, msg)
+	}
+
+	m.reactionRoles[msg.GuildId] = messages
+
+	return cloneReactionRoleMessage(msg), nil
+}
+
+func (m *Memory) DeleteReactionRoleMessage(gId string, messageId string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	messages := m.reactionRoles[gId]
+	i := slices.IndexFunc(messages, func(msg rule.ReactionRoleMessage) bool {
+		return msg.MessageId == messageId
+	})
+
+	if i == -1 {
+		return common.ErrNotFound
+	}
+
+	m.reactionRoles[gId] = slices.Delete(messages, i, i+1)
+
+	return nil
+}
+
+func (m *Memory) CreateInfraction(ic infraction.InfractionCreate) (infraction.Infraction, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if !m.hasGuild(ic.GuildId) {
+		return infraction.Infraction{}, common.ErrNotFound
+	}
+
+	m.lastId++
+
+	i := infraction.Infraction{
+		Id:          m.lastId,
+		GuildId:     ic.GuildId,
+		UserId:      ic.UserId,
+		ModeratorId: ic.ModeratorId,
+		Source:      ic.Source,
+		Action:      ic.Action,
+		Reason:      ic.Reason,
+		EmojiName:   ic.EmojiName,
+		EmojiId:     ic.EmojiId,
+		ChannelId:   ic.ChannelId,
+		MessageId:   ic.MessageId,
+		Step:        ic.Step,
+		CreatedAt:   ic.CreatedAt,
+	}
+
+	m.infractions = append(m.infractions, i)
+
+	return i, nil
+}
+
+func (m *Memory) ReadInfraction(gId string, id int64) (infraction.Infraction, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	i := m.infractionIndex(gId, id)
+
+	if i == -1 {
+		return infraction.Infraction{}, common.ErrNotFound
+	}
+
+	return m.infractions[i], nil
+}
+
+func (m *Memory) ReadInfractions(q infraction.InfractionQuery) ([]infraction.Infraction, int, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	matching := make([]infraction.Infraction, 0)
+
+	for _, i := range m.infractions {
+		if i.GuildId == q.GuildId && (q.UserId == "" || i.UserId == q.UserId) {
+			matching = append(matching, i)
+		}
+	}
+
+	// newest first, infractions created at the same time by id
+	slices.SortStableFunc(matching, func(a, b infraction.Infraction) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+
+		return int(b.Id - a.Id)
+	})
+
+	start := min(q.Offset, len(matching))
+	end := min(start+q.Limit, len(matching))
+
+	return slices.Clone(matching[start:end]), len(matching), nil
+}
+
+func (m *Memory) UpdateInfraction(gId string, id int64, u infraction.InfractionUpdate) (infraction.Infraction, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	i := m.infractionIndex(gId, id)
+
+	if i == -1 {
+		return infraction.Infraction{}, common.ErrNotFound
+	}
+
+	m.infractions[i].Reason = u.Reason
+
+	return m.infractions[i], nil
+}
+
+func (m *Memory) DeleteInfraction(gId string, id int64) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	i := m.infractionIndex(gId, id)
+
+	if i == -1 {
+		return common.ErrNotFound
+	}
+
+	m.infractions = slices.Delete(m.infractions, i, i+1)
+
+	return nil
+}
+
+// infractionIndex returns the index of the infraction or -1. Must be called with lock held.
+func (m *Memory) infractionIndex(gId string, id int64) int {
+	return slices.IndexFunc(m.infractions, func(i infraction.Infraction) bool {
+		return i.GuildId == gId && i.Id == id
+	})
+}
+
+func (m *Memory) ReadEscalationPolicy(gId string) (escalation.Policy, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	steps := slices.Clone(m.escalations[gId])
+
+	if steps == nil {
+		steps = []escalation.Step{}
+	}
+
+	return escalation.Policy{GuildId: gId, Steps: steps}, nil
+}
+
+func (m *Memory) UpdateEscalationPolicy(gId string, steps []escalation.Step) (escalation.Policy, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if !m.hasGuild(gId) {
+		return escalation.Policy{}, common.ErrNotFound
+	}
+
+	steps = slices.Clone(steps)
+
+	slices.SortFunc(steps, func(a, b escalation.Step) int {
+		return a.Warns - b.Warns
+	})
+
+	if len(steps) == 0 {
+		delete(m.escalations, gId)
+		return escalation.Policy{GuildId: gId, Steps: []escalation.Step{}}, nil
+	}
+
+	m.escalations[gId] = steps
+
+	return escalation.Policy{GuildId: gId, Steps: slices.Clone(steps)}, nil
+}
+
+func (m *Memory) ReadReactionFloodRule(gId string) (rule.ReactionFloodRule, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	f, ok := m.reactionFlood[gId]
+
+	if !ok {
+		return rule.ReactionFloodRule{}, common.ErrNotFound
+	}
+
+	f.Actions = slices.Clone(f.Actions)
+
+	return f, nil
+}
+
+func (m *Memory) SaveReactionFloodRule(f rule.ReactionFloodRule) (rule.ReactionFloodRule, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if !m.hasGuild(f.GuildId) {
+		return rule.ReactionFloodRule{}, common.ErrNotFound
+	}
+
+	f.Actions = slices.Clone(f.Actions)
+	m.reactionFlood[f.GuildId] = f
+	f.Actions = slices.Clone(f.Actions)
+
+	return f, nil
+}
+
+func (m *Memory) DeleteReactionFloodRule(gId string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.reactionFlood[gId]; !ok {
+		return common.ErrNotFound
+	}
+
+	delete(m.reactionFlood, gId)
+
+	return nil
+}
+
+func cloneGuild(g guild.Guild) guild.Guild {
+	g.ExemptRoles = cloneStrings(g.ExemptRoles)
+	g.JoinedAt = cloneTime(g.JoinedAt)
+	return g
+}
+
+func cloneTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+
+	c := *t
+	return &c
+}
+
+// cloneReactionRule copies slices of the rule, so callers can't modify stored rules.
+// nil slices become empty like in the reactionRules table.
+func cloneReactionRule(r rule.ReactionRule) rule.ReactionRule {
+	r.IncludeChannels = cloneStrings(r.IncludeChannels)
+	r.ExcludeChannels = cloneStrings(r.ExcludeChannels)
+	r.ExemptRoles = cloneStrings(r.ExemptRoles)
+
+	if r.Threshold != nil {
+		threshold := *r.Threshold
+		r.Threshold = &threshold
+	}
+
+	return r
+}
+
+// cloneReactionRoleMessage copies roles of the message, nil roles become empty.
+func cloneReactionRoleMessage(msg rule.ReactionRoleMessage) rule.ReactionRoleMessage {
+	if msg.Roles == nil {
+		msg.Roles = []rule.ReactionRole{}
+	} else {
+		msg.Roles = slices.Clone(msg.Roles)
+	}
+
+	return msg
+}
+
+func cloneStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+
+	return slices.Clone(s)
+}
@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/finkabaj/hyde-bot/internals/db"
+	"github.com/finkabaj/hyde-bot/internals/db/dbtest"
+)
+
+func TestMemory(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T) db.Database {
+		return NewMemory()
+	})
+}
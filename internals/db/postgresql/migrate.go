@@ -0,0 +1,211 @@
+package postgresql
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"time"
+
+	"github.com/finkabaj/hyde-bot/internals/db"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+//go:embed migrations/*.sql
+var migrationsFS embed.FS
+
+// migrationLockKey is the advisory lock held while migrating, so concurrent instances wait for each other.
+const migrationLockKey int64 = 0x68796465 // "hyde"
+
+// withMigrationLock runs f on a single connection holding the migration advisory lock.
+func (p *Postgresql) withMigrationLock(ctx context.Context, f func(conn *pgxpool.Conn) error) error {
+	conn, err := p.pool.Acquire(ctx)
+
+	if err != nil {
+		return fmt.Errorf("error acquiring connection: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err = conn.Exec(ctx, `SELECT pg_advisory_lock($1)`, migrationLockKey); err != nil {
+		return fmt.Errorf("error acquiring migration lock: %w", err)
+	}
+
+	defer func() {
+		if _, err := conn.Exec(context.Background(), `SELECT pg_advisory_unlock($1)`, migrationLockKey); err != nil {
+			p.logger.Error(err, map[string]any{"details": "error releasing migration lock"})
+		}
+	}()
+
+	_, err = conn.Exec(ctx, `
+    CREATE TABLE IF NOT EXISTS "schema_migrations" (
+      "version" INTEGER PRIMARY KEY,
+      "name" VARCHAR(255) NOT NULL,
+      "appliedAt" TIMESTAMPTZ NOT NULL DEFAULT NOW()
+    )
+  `)
+
+	if err != nil {
+		return fmt.Errorf("error creating schema_migrations table: %w", err)
+	}
+
+	return f(conn)
+}
+
+// appliedMigrations returns applied versions with their apply time.
+func appliedMigrations(ctx context.Context, conn *pgxpool.Conn) (map[int]time.Time, error) {
+	rows, err := conn.Query(ctx, `SELECT "version", "appliedAt" FROM "schema_migrations"`)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make(map[int]time.Time)
+
+	for rows.Next() {
+		var version int
+		var appliedAt time.Time
+
+		if err = rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+
+		applied[version] = appliedAt
+	}
+
+	return applied, rows.Err()
+}
+
+// runMigration runs script and records the change in schema_migrations in one transaction.
+func runMigration(ctx context.Context, conn *pgxpool.Conn, script, record string, args ...any) (err error) {
+	tx, err := conn.Begin(ctx)
+
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	if _, err = tx.Exec(ctx, script); err != nil {
+		return
+	}
+
+	_, err = tx.Exec(ctx, record, args...)
+
+	return
+}
+
+func (p *Postgresql) MigrateUp() error {
+	migrations, err := db.LoadMigrations(migrationsFS, "migrations")
+
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	return p.withMigrationLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := appliedMigrations(ctx, conn)
+
+		if err != nil {
+			return fmt.Errorf("error reading applied migrations: %w", err)
+		}
+
+		for _, m := range migrations {
+			if _, ok := applied[m.Version]; ok {
+				continue
+			}
+
+			err = runMigration(ctx, conn, m.Up, `INSERT INTO "schema_migrations" ("version", "name") VALUES ($1, $2)`, m.Version, m.Name)
+
+			if err != nil {
+				return fmt.Errorf("error applying migration %d_%s: %w", m.Version, m.Name, err)
+			}
+
+			p.logger.Info("Migration applied", map[string]any{"version": m.Version, "name": m.Name})
+		}
+
+		return nil
+	})
+}
+
+func (p *Postgresql) MigrateDown(steps int) error {
+	migrations, err := db.LoadMigrations(migrationsFS, "migrations")
+
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	return p.withMigrationLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := appliedMigrations(ctx, conn)
+
+		if err != nil {
+			return fmt.Errorf("error reading applied migrations: %w", err)
+		}
+
+		for i := len(migrations) - 1; i >= 0 && steps > 0; i-- {
+			m := migrations[i]
+
+			if _, ok := applied[m.Version]; !ok {
+				continue
+			}
+
+			if m.Down == "" {
+				return fmt.Errorf("%w: %d_%s", db.ErrMissingDownMigration, m.Version, m.Name)
+			}
+
+			err = runMigration(ctx, conn, m.Down, `DELETE FROM "schema_migrations" WHERE "version" = $1`, m.Version)
+
+			if err != nil {
+				return fmt.Errorf("error reverting migration %d_%s: %w", m.Version, m.Name, err)
+			}
+
+			p.logger.Info("Migration reverted", map[string]any{"version": m.Version, "name": m.Name})
+
+			steps--
+		}
+
+		return nil
+	})
+}
+
+func (p *Postgresql) MigrationStatus() ([]db.MigrationStatus, error) {
+	migrations, err := db.LoadMigrations(migrationsFS, "migrations")
+
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	status := make([]db.MigrationStatus, 0, len(migrations))
+
+	err = p.withMigrationLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := appliedMigrations(ctx, conn)
+
+		if err != nil {
+			return fmt.Errorf("error reading applied migrations: %w", err)
+		}
+
+		for _, m := range migrations {
+			appliedAt, ok := applied[m.Version]
+
+			status = append(status, db.MigrationStatus{
+				Version:   m.Version,
+				Name:      m.Name,
+				Applied:   ok,
+				AppliedAt: appliedAt,
+			})
+		}
+
+		return nil
+	})
+
+	return status, err
+}
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "reactionRules";
+DROP TABLE IF EXISTS "refreshTokens";
+DROP TABLE IF EXISTS "guilds";
+DROP TABLE IF EXISTS "users";
@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS "users" (
+  "userId" VARCHAR(255) PRIMARY KEY,
+  "name" VARCHAR(255) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS "guilds" (
+  "guildId" VARCHAR(255) PRIMARY KEY,
+  "ownerId" VARCHAR(255) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS "refreshTokens" (
+  "userId" VARCHAR(255) PRIMARY KEY,
+  "token" TEXT NOT NULL,
+  "expires" DATE NOT NULL,
+  CONSTRAINT "fkUser"
+    FOREIGN KEY("userId")
+      REFERENCES users("userId") ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS "reactionRules" (
+  "emojiId" VARCHAR(255),
+  "emojiName" VARCHAR(255),
+  "isCustom" BOOLEAN NOT NULL DEFAULT FALSE,
+  "guildId" VARCHAR(255) NOT NULL,
+  "ruleAuthor" VARCHAR(255) NOT NULL,
+  "actions" INTEGER[] NOT NULL,
+  PRIMARY KEY ("guildId", "emojiId", "emojiName"),
+  FOREIGN KEY ("guildId") REFERENCES guilds("guildId") ON DELETE CASCADE
+);
@@ -0,0 +1,3 @@
+ALTER TABLE "reactionRules"
+  DROP COLUMN IF EXISTS "includeChannels",
+  DROP COLUMN IF EXISTS "excludeChannels";
@@ -0,0 +1,3 @@
+ALTER TABLE "reactionRules"
+  ADD COLUMN IF NOT EXISTS "includeChannels" VARCHAR(255)[] NOT NULL DEFAULT '{}',
+  ADD COLUMN IF NOT EXISTS "excludeChannels" VARCHAR(255)[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,6 @@
+ALTER TABLE "guilds"
+  DROP COLUMN IF EXISTS "exemptRoles",
+  DROP COLUMN IF EXISTS "exemptAdmins";
+
+ALTER TABLE "reactionRules"
+  DROP COLUMN IF EXISTS "exemptRoles";
@@ -0,0 +1,6 @@
+ALTER TABLE "reactionRules"
+  ADD COLUMN IF NOT EXISTS "exemptRoles" VARCHAR(255)[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE "guilds"
+  ADD COLUMN IF NOT EXISTS "exemptRoles" VARCHAR(255)[] NOT NULL DEFAULT '{}',
+  ADD COLUMN IF NOT EXISTS "exemptAdmins" BOOLEAN NOT NULL DEFAULT TRUE;
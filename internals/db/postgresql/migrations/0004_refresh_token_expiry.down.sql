@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "refreshTokensToken";
+
+ALTER TABLE "refreshTokens"
+  ALTER COLUMN "expires" TYPE DATE;
@@ -0,0 +1,4 @@
+ALTER TABLE "refreshTokens"
+  ALTER COLUMN "expires" TYPE TIMESTAMPTZ;
+
+CREATE UNIQUE INDEX IF NOT EXISTS "refreshTokensToken" ON "refreshTokens" ("token");
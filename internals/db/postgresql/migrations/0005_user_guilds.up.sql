@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS "userGuilds" (
+  "userId" VARCHAR(255) NOT NULL,
+  "guildId" VARCHAR(255) NOT NULL,
+  "owner" BOOLEAN NOT NULL DEFAULT FALSE,
+  "permissions" BIGINT NOT NULL DEFAULT 0,
+  PRIMARY KEY ("userId", "guildId"),
+  CONSTRAINT "fkUserGuildsUser"
+    FOREIGN KEY("userId")
+      REFERENCES users("userId") ON DELETE CASCADE
+);
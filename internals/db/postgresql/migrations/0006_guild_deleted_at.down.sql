@@ -0,0 +1,4 @@
+DELETE FROM "guilds" WHERE "deletedAt" IS NOT NULL;
+
+ALTER TABLE "guilds"
+  DROP COLUMN IF EXISTS "deletedAt";
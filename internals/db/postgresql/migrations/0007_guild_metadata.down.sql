@@ -0,0 +1,5 @@
+ALTER TABLE "guilds"
+  DROP COLUMN IF EXISTS "name",
+  DROP COLUMN IF EXISTS "icon",
+  DROP COLUMN IF EXISTS "memberCount",
+  DROP COLUMN IF EXISTS "joinedAt";
@@ -0,0 +1,5 @@
+ALTER TABLE "guilds"
+  ADD COLUMN IF NOT EXISTS "name" VARCHAR(255) NOT NULL DEFAULT '',
+  ADD COLUMN IF NOT EXISTS "icon" VARCHAR(255) NOT NULL DEFAULT '',
+  ADD COLUMN IF NOT EXISTS "memberCount" INTEGER NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS "joinedAt" TIMESTAMPTZ;
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS "infractions" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "guildId" VARCHAR(255) NOT NULL,
+  "userId" VARCHAR(255) NOT NULL,
+  "moderatorId" VARCHAR(255) NOT NULL DEFAULT '',
+  "source" VARCHAR(32) NOT NULL,
+  "action" VARCHAR(32) NOT NULL,
+  "reason" TEXT NOT NULL DEFAULT '',
+  "emojiName" VARCHAR(255) NOT NULL DEFAULT '',
+  "emojiId" VARCHAR(255) NOT NULL DEFAULT '',
+  "channelId" VARCHAR(255) NOT NULL DEFAULT '',
+  "messageId" VARCHAR(255) NOT NULL DEFAULT '',
+  "createdAt" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  CONSTRAINT "fkInfractionsGuild"
+    FOREIGN KEY("guildId")
+      REFERENCES guilds("guildId") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS "infractionsGuildUser" ON "infractions" ("guildId", "userId", "createdAt" DESC);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS "escalationSteps" (
+  "guildId" VARCHAR(255) NOT NULL,
+  "warns" INTEGER NOT NULL,
+  "windowSeconds" INTEGER NOT NULL,
+  "action" VARCHAR(32) NOT NULL,
+  "timeoutSeconds" INTEGER NOT NULL DEFAULT 0,
+  PRIMARY KEY ("guildId", "warns"),
+  CONSTRAINT "fkEscalationStepsGuild"
+    FOREIGN KEY("guildId")
+      REFERENCES guilds("guildId") ON DELETE CASCADE
+);
@@ -0,0 +1,14 @@
+-- timeouts have no number in the old format and are dropped
+ALTER TABLE "reactionRules"
+  ADD COLUMN IF NOT EXISTS "actionNumbers" INTEGER[] NOT NULL DEFAULT '{}';
+
+UPDATE "reactionRules" SET "actionNumbers" = ARRAY(
+  SELECT ("action"->>'type')::INTEGER
+  FROM jsonb_array_elements("actions") WITH ORDINALITY AS "a"("action", "position")
+  WHERE ("action"->>'type')::INTEGER <= 4
+  ORDER BY "position"
+);
+
+ALTER TABLE "reactionRules" DROP COLUMN "actions";
+ALTER TABLE "reactionRules" RENAME COLUMN "actionNumbers" TO "actions";
+ALTER TABLE "reactionRules" ALTER COLUMN "actions" DROP DEFAULT;
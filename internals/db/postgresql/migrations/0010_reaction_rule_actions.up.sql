@@ -0,0 +1,13 @@
+-- actions were an array of action numbers padded with zeros, now they are objects with parameters
+ALTER TABLE "reactionRules"
+  ADD COLUMN IF NOT EXISTS "actionList" JSONB NOT NULL DEFAULT '[]';
+
+UPDATE "reactionRules" SET "actionList" = COALESCE((
+  SELECT jsonb_agg(jsonb_build_object('type', "action") ORDER BY "position")
+  FROM unnest("actions") WITH ORDINALITY AS "a"("action", "position")
+  WHERE "action" <> 0
+), '[]');
+
+ALTER TABLE "reactionRules" DROP COLUMN "actions";
+ALTER TABLE "reactionRules" RENAME COLUMN "actionList" TO "actions";
+ALTER TABLE "reactionRules" ALTER COLUMN "actions" DROP DEFAULT;
@@ -0,0 +1,2 @@
+ALTER TABLE "guilds"
+  DROP COLUMN IF EXISTS "modLogChannelId";
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "reactionRoles";
+DROP TABLE IF EXISTS "reactionRoleMessages";
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS "reactionRoleMessages" (
+  "guildId" VARCHAR(255) NOT NULL,
+  "messageId" VARCHAR(255) NOT NULL,
+  "channelId" VARCHAR(255) NOT NULL,
+  "mode" VARCHAR(32) NOT NULL,
+  PRIMARY KEY ("guildId", "messageId"),
+  CONSTRAINT "fkReactionRoleMessagesGuild"
+    FOREIGN KEY("guildId")
+      REFERENCES guilds("guildId") ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS "reactionRoles" (
+  "guildId" VARCHAR(255) NOT NULL,
+  "messageId" VARCHAR(255) NOT NULL,
+  "position" INTEGER NOT NULL,
+  "emojiName" VARCHAR(255) NOT NULL,
+  "emojiId" VARCHAR(255) NOT NULL DEFAULT '',
+  "roleId" VARCHAR(255) NOT NULL,
+  PRIMARY KEY ("guildId", "messageId", "position"),
+  CONSTRAINT "fkReactionRolesMessage"
+    FOREIGN KEY("guildId", "messageId")
+      REFERENCES "reactionRoleMessages"("guildId", "messageId") ON DELETE CASCADE
+);
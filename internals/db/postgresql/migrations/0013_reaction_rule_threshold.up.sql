@@ -0,0 +1,3 @@
+-- NULL for rules that act on every reaction
+ALTER TABLE "reactionRules"
+  ADD COLUMN IF NOT EXISTS "threshold" JSONB;
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS "reactionFloodRules" (
+  "guildId" VARCHAR(255) PRIMARY KEY,
+  "maxReactions" INTEGER NOT NULL,
+  "windowSeconds" INTEGER NOT NULL,
+  "actions" JSONB NOT NULL,
+  CONSTRAINT "fkReactionFloodRulesGuild"
+    FOREIGN KEY("guildId")
+      REFERENCES guilds("guildId") ON DELETE CASCADE
+);
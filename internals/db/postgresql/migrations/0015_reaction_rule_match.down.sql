@@ -0,0 +1,2 @@
+ALTER TABLE "reactionRules"
+  DROP COLUMN IF EXISTS "match";
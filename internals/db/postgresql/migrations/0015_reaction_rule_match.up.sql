@@ -0,0 +1,3 @@
+-- empty for rules that match one exact emoji
+ALTER TABLE "reactionRules"
+  ADD COLUMN IF NOT EXISTS "match" TEXT NOT NULL DEFAULT '';
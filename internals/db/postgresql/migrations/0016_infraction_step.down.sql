@@ -0,0 +1,2 @@
+ALTER TABLE "infractions"
+  DROP COLUMN IF EXISTS "step";
@@ -0,0 +1,2 @@
+-- key of the escalation step that issued the infraction
+ALTER TABLE "infractions" ADD COLUMN IF NOT EXISTS "step" VARCHAR(64) NOT NULL DEFAULT '';
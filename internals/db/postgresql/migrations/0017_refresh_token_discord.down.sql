@@ -0,0 +1,2 @@
+ALTER TABLE "refreshTokens"
+  DROP COLUMN IF EXISTS "discordToken";
@@ -0,0 +1,2 @@
+-- encrypted Discord refresh token, used to fetch the guilds of the user again on refresh
+ALTER TABLE "refreshTokens" ADD COLUMN IF NOT EXISTS "discordToken" TEXT NOT NULL DEFAULT '';
@@ -2,6 +2,7 @@ package postgresql
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -9,13 +10,39 @@ import (
 	"github.com/finkabaj/hyde-bot/internals/db"
 	"github.com/finkabaj/hyde-bot/internals/logger"
 	"github.com/finkabaj/hyde-bot/internals/utils/common"
+	"github.com/finkabaj/hyde-bot/internals/utils/escalation"
 	"github.com/finkabaj/hyde-bot/internals/utils/guild"
+	"github.com/finkabaj/hyde-bot/internals/utils/infraction"
 	"github.com/finkabaj/hyde-bot/internals/utils/rule"
+	"github.com/finkabaj/hyde-bot/internals/utils/user"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// postgres error codes, see https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	codeUniqueViolation     = "23505"
+	codeForeignKeyViolation = "23503"
+)
+
+// guildColumns are the columns of guild.Guild, "deletedAt" is internal to the database.
+const guildColumns = `"guildId", "ownerId", "exemptRoles", "exemptAdmins", "name", "icon", "memberCount", "joinedAt", "modLogChannelId"`
+
+const infractionColumns = `"id", "guildId", "userId", "moderatorId", "source", "action", "reason", "emojiName", "emojiId", "channelId", "messageId", "step", "createdAt"`
+
+// pgErrorCode returns the code of the postgres error or an empty string.
+func pgErrorCode(err error) string {
+	var pgErr *pgconn.PgError
+
+	if errors.As(err, &pgErr) {
+		return pgErr.Code
+	}
+
+	return ""
+}
+
 type Postgresql struct {
 	pool   *pgxpool.Pool
 	logger logger.ILogger
@@ -27,131 +54,250 @@ func NewPostgresql(logger logger.ILogger) *Postgresql {
 	}
 }
 
-func (p *Postgresql) setup() (err error) {
-	if err = p.Status(); err != nil {
-		p.logger.Error(err, map[string]any{"details": "error while creating tables"})
+func (p *Postgresql) Connect(credentials db.DatabaseCredentials) (err error) {
+	connStr := fmt.Sprintf("user=%s password=%s host=%s port=%s dbname=%s sslmode=disable", credentials.User, credentials.Password, credentials.Host, credentials.Port, credentials.Database)
+	p.pool, err = pgxpool.New(context.Background(), connStr)
+
+	if err != nil {
 		return
 	}
 
-	ctx := context.Background()
+	// schema is managed by migrations, see MigrateUp
+	err = p.Status()
+
+	return
+}
+
+func (p *Postgresql) Close() {
+	p.pool.Close()
+}
+
+func (p *Postgresql) Status() (err error) {
+	err = p.pool.Ping(context.Background())
+
+	return
+}
+
+func (p *Postgresql) UpsertUser(u user.User) (user.User, error) {
+	query := `
+    INSERT INTO users ("userId", "name")
+    VALUES ($1, $2)
+    ON CONFLICT ("userId") DO UPDATE SET "name" = EXCLUDED."name"
+    RETURNING "userId", "name"
+  `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
 
-	transaction, err := p.pool.Begin(ctx)
+	var savedUser user.User
+	err := p.pool.QueryRow(ctx, query, u.UserId, u.Name).Scan(&savedUser.UserId, &savedUser.Name)
 
 	if err != nil {
-		return
+		p.logger.Error(err, map[string]any{"details": "error in UpsertUser query"})
+		return user.User{}, common.ErrInternal
 	}
 
-	defer func() {
-		if err != nil {
-			transaction.Rollback(ctx)
-		} else {
-			transaction.Commit(ctx)
-		}
-	}()
+	return savedUser, nil
+}
 
-	_, err = transaction.Exec(ctx, `
-    CREATE TABLE IF NOT EXISTS "users" (
-      "userId" VARCHAR(255) PRIMARY KEY,
-      "name" VARCHAR(255) NOT NULL
-    )
-  `)
+func (p *Postgresql) ReadUser(userId string) (user.User, error) {
+	query := `
+    SELECT "userId", "name" FROM users WHERE "userId" = $1
+  `
 
-	if err != nil {
-		p.logger.Debug("error creating users table")
-		return
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+
+	var foundUser user.User
+	err := p.pool.QueryRow(ctx, query, userId).Scan(&foundUser.UserId, &foundUser.Name)
+
+	if err == pgx.ErrNoRows {
+		return user.User{}, common.ErrNotFound
+	} else if err != nil {
+		p.logger.Error(err, map[string]any{"details": "error in ReadUser query"})
+		return user.User{}, common.ErrInternal
 	}
 
-	_, err = transaction.Exec(ctx, `
-    CREATE TABLE IF NOT EXISTS "guilds" (
-      "guildId" VARCHAR(255) PRIMARY KEY,
-      "ownerId" VARCHAR(255) NOT NULL
-    )
-  `)
+	return foundUser, nil
+}
 
-	if err != nil {
-		p.logger.Debug("error creating guilds table")
-		return
+func (p *Postgresql) SaveRefreshToken(t user.RefreshToken) error {
+	query := `
+    INSERT INTO "refreshTokens" ("userId", "token", "expires", "discordToken")
+    VALUES ($1, $2, $3, $4)
+    ON CONFLICT ("userId") DO UPDATE SET "token" = EXCLUDED."token", "expires" = EXCLUDED."expires", "discordToken" = EXCLUDED."discordToken"
+  `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+
+	_, err := p.pool.Exec(ctx, query, t.UserId, t.TokenHash, t.Expires, t.DiscordToken)
+
+	if pgErrorCode(err) == codeForeignKeyViolation {
+		return common.ErrNotFound
+	} else if err != nil {
+		p.logger.Error(err, map[string]any{"details": "error in SaveRefreshToken query"})
+		return common.ErrInternal
 	}
 
-	_, err = transaction.Exec(ctx, `
-    CREATE TABLE IF NOT EXISTS "refreshTokens" (
-      "userId" VARCHAR(255) PRIMARY KEY,
-      "token" TEXT NOT NULL,
-      "expires" DATE NOT NULL,
-      CONSTRAINT "fkUser"
-        FOREIGN KEY("userId")
-          REFERENCES users("userId") ON DELETE CASCADE
-    )
-  `)
+	return nil
+}
+
+func (p *Postgresql) ReadRefreshToken(tokenHash string) (user.RefreshToken, error) {
+	query := `
+    SELECT "userId", "token", "expires", "discordToken" FROM "refreshTokens" WHERE "token" = $1
+  `
 
-	if err != nil {
-		p.logger.Debug("error creating refreshTokens table")
-		return
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+
+	var t user.RefreshToken
+	err := p.pool.QueryRow(ctx, query, tokenHash).Scan(&t.UserId, &t.TokenHash, &t.Expires, &t.DiscordToken)
+
+	if err == pgx.ErrNoRows {
+		return user.RefreshToken{}, common.ErrNotFound
+	} else if err != nil {
+		p.logger.Error(err, map[string]any{"details": "error in ReadRefreshToken query"})
+		return user.RefreshToken{}, common.ErrInternal
 	}
 
-	_, err = transaction.Exec(ctx, `
-    CREATE TABLE IF NOT EXISTS "reactionRules" (
-      "emojiId" VARCHAR(255),
-      "emojiName" VARCHAR(255),
-      "isCustom" BOOLEAN NOT NULL DEFAULT FALSE,
-      "guildId" VARCHAR(255) NOT NULL,
-      "ruleAuthor" VARCHAR(255) NOT NULL,
-      "actions" INTEGER[] NOT NULL,
-      PRIMARY KEY ("guildId", "emojiId", "emojiName"),
-      FOREIGN KEY ("guildId") REFERENCES guilds("guildId") ON DELETE CASCADE
-    )
-  `)
+	return t, nil
+}
 
-	if err != nil {
-		p.logger.Debug("error creating reactionRules table")
-		return
+func (p *Postgresql) DeleteRefreshToken(userId string) error {
+	query := `
+    DELETE FROM "refreshTokens" WHERE "userId" = $1
+  `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+
+	if _, err := p.pool.Exec(ctx, query, userId); err != nil {
+		p.logger.Error(err, map[string]any{"details": "error in DeleteRefreshToken query"})
+		return common.ErrInternal
 	}
 
-	return
+	return nil
 }
 
-func (p *Postgresql) Connect(credentials db.DatabaseCredentials) (err error) {
-	connStr := fmt.Sprintf("user=%s password=%s host=%s port=%s dbname=%s sslmode=disable", credentials.User, credentials.Password, credentials.Host, credentials.Port, credentials.Database)
-	p.pool, err = pgxpool.New(context.Background(), connStr)
+func (p *Postgresql) SaveUserGuilds(userId string, guilds []user.UserGuild) (err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+
+	tx, err := p.pool.Begin(ctx)
 
 	if err != nil {
-		return
+		p.logger.Error(err, map[string]any{"details": "transaction begin in SaveUserGuilds"})
+		return common.ErrInternal
 	}
 
-	err = p.setup()
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
 
-	return
-}
+	if _, err = tx.Exec(ctx, `DELETE FROM "userGuilds" WHERE "userId" = $1`, userId); err != nil {
+		p.logger.Error(err, map[string]any{"details": "error while deleting from userGuilds"})
+		return common.ErrInternal
+	}
 
-func (p *Postgresql) Close() {
-	p.pool.Close()
+	rows := make([][]any, 0, len(guilds))
+
+	for _, g := range guilds {
+		rows = append(rows, []any{userId, g.GuildId, g.Owner, g.Permissions})
+	}
+
+	_, err = tx.CopyFrom(ctx,
+		pgx.Identifier{"userGuilds"},
+		[]string{"userId", "guildId", "owner", "permissions"},
+		pgx.CopyFromRows(rows),
+	)
+
+	if pgErrorCode(err) == codeForeignKeyViolation {
+		return common.ErrNotFound
+	} else if err != nil {
+		p.logger.Error(err, map[string]any{"details": "error while inserting to userGuilds"})
+		return common.ErrInternal
+	}
+
+	return nil
 }
 
-func (p *Postgresql) Status() (err error) {
-	err = p.pool.Ping(context.Background())
+func (p *Postgresql) ReadUserGuild(userId, guildId string) (user.UserGuild, error) {
+	query := `
+    SELECT "userId", "guildId", "owner", "permissions" FROM "userGuilds" WHERE "userId" = $1 AND "guildId" = $2
+  `
 
-	return
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+
+	rows, err := p.pool.Query(ctx, query, userId, guildId)
+
+	if err != nil {
+		p.logger.Error(err, map[string]any{"details": "error in ReadUserGuild query"})
+		return user.UserGuild{}, common.ErrInternal
+	}
+
+	g, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[user.UserGuild])
+
+	if err == pgx.ErrNoRows {
+		return user.UserGuild{}, common.ErrNotFound
+	} else if err != nil {
+		p.logger.Error(err, map[string]any{"details": "error while collecting user guild"})
+		return user.UserGuild{}, common.ErrInternal
+	}
+
+	return g, nil
 }
 
-func (p *Postgresql) CreateGuild(gc guild.GuildCreate) (guild.Guild, error) {
+func (p *Postgresql) CreateGuild(gc guild.GuildCreate) (newGuild guild.Guild, err error) {
 	query := `
-    INSERT INTO guilds ("guildId", "ownerId") 
-    VALUES ($1, $2) 
-    RETURNING *
+    INSERT INTO guilds ("guildId", "ownerId", "name", "icon", "memberCount", "joinedAt") 
+    VALUES ($1, $2, $3, $4, $5, $6) 
+    RETURNING ` + guildColumns + `
   `
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
 	defer cancel()
-	row, err := p.pool.Query(ctx, query, gc.GuildId, gc.OwnerId)
+
+	tx, err := p.pool.Begin(ctx)
+
 	if err != nil {
+		p.logger.Error(err, map[string]any{"details": "transaction begin in CreateGuild"})
+		return guild.Guild{}, common.ErrInternal
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	// rules of a deleted guild are purged with it
+	if _, err = tx.Exec(ctx, `DELETE FROM guilds WHERE "guildId" = $1 AND "deletedAt" IS NOT NULL`, gc.GuildId); err != nil {
+		p.logger.Error(err, map[string]any{"details": "error while purging deleted guild in CreateGuild"})
+		return guild.Guild{}, common.ErrInternal
+	}
+
+	row, err := tx.Query(ctx, query, gc.GuildId, gc.OwnerId, gc.Name, gc.Icon, gc.MemberCount, gc.JoinedAt)
+	if pgErrorCode(err) == codeUniqueViolation {
+		return guild.Guild{}, guild.ErrGuildConflict
+	} else if err != nil {
 		p.logger.Warn(err, map[string]any{"details": "error in CreateGuild query"})
 		return guild.Guild{}, common.ErrInternal
 	}
 	defer row.Close()
 
-	newGuild, err := pgx.CollectExactlyOneRow(row, pgx.RowToStructByName[guild.Guild])
+	newGuild, err = pgx.CollectExactlyOneRow(row, pgx.RowToStructByName[guild.Guild])
 
-	if err != nil {
+	if pgErrorCode(err) == codeUniqueViolation {
+		return guild.Guild{}, guild.ErrGuildConflict
+	} else if err != nil {
 		p.logger.Error(err, map[string]any{"details": "error when collecting rows in CreateGuild"})
 		return guild.Guild{}, common.ErrInternal
 	}
@@ -161,7 +307,7 @@ func (p *Postgresql) CreateGuild(gc guild.GuildCreate) (guild.Guild, error) {
 
 func (p *Postgresql) ReadGuild(guildId string) (guild.Guild, error) {
 	query := `
-    SELECT * FROM guilds WHERE "guildId" = $1
+    SELECT ` + guildColumns + ` FROM guilds WHERE "guildId" = $1 AND "deletedAt" IS NULL
   `
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
@@ -186,102 +332,313 @@ func (p *Postgresql) ReadGuild(guildId string) (guild.Guild, error) {
 	return foundGuild, nil
 }
 
-func (p *Postgresql) CreateReactionRules(rules []rule.ReactionRule) ([]rule.ReactionRule, error) {
+func (p *Postgresql) UpdateGuildExemptions(guildId string, exemptions guild.GuildExemptions) (guild.Guild, error) {
+	query := `
+    UPDATE guilds SET "exemptRoles" = $1, "exemptAdmins" = $2
+    WHERE "guildId" = $3 AND "deletedAt" IS NULL
+    RETURNING ` + guildColumns + `
+  `
+
+	exemptRoles := exemptions.ExemptRoles
+
+	// nil slice would be stored as NULL
+	if exemptRoles == nil {
+		exemptRoles = []string{}
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
 	defer cancel()
-
-	tx, err := p.pool.Begin(ctx)
+	row, err := p.pool.Query(ctx, query, exemptRoles, *exemptions.ExemptAdmins, guildId)
 
 	if err != nil {
-		p.logger.Error(err, map[string]any{"details": "transaction begin in CreateReactionRules"})
-		return []rule.ReactionRule{}, common.ErrInternal
+		p.logger.Error(err, map[string]any{"details": "error in UpdateGuildExemptions query"})
+		return guild.Guild{}, common.ErrInternal
 	}
+	defer row.Close()
 
-	defer func() {
-		if err != nil {
-			tx.Rollback(ctx)
-		} else {
-			tx.Commit(ctx)
-		}
-	}()
+	updatedGuild, err := pgx.CollectExactlyOneRow(row, pgx.RowToStructByName[guild.Guild])
 
-	rows := common.DestructureStructSlice(rules)
+	if err == pgx.ErrNoRows {
+		return guild.Guild{}, common.ErrNotFound
+	} else if err != nil {
+		p.logger.Error(err, map[string]any{"details": "error while collecting rows in UpdateGuildExemptions"})
+		return guild.Guild{}, common.ErrInternal
+	}
 
-	copyCount, err := p.pool.CopyFrom(ctx,
-		pgx.Identifier{"reactionRules"},
-		[]string{"emojiName", "emojiId", "isCustom", "guildId", "ruleAuthor", "actions"},
-		pgx.CopyFromRows(rows),
-	)
+	return updatedGuild, nil
+}
+
+func (p *Postgresql) UpdateGuildModLog(guildId string, modLog guild.GuildModLog) (guild.Guild, error) {
+	query := `
+    UPDATE guilds SET "modLogChannelId" = $1
+    WHERE "guildId" = $2 AND "deletedAt" IS NULL
+    RETURNING ` + guildColumns + `
+  `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+	row, err := p.pool.Query(ctx, query, modLog.ChannelId, guildId)
 
 	if err != nil {
-		p.logger.Error(err, map[string]any{"details": "error while inserting to reactionRules"})
-		return []rule.ReactionRule{}, common.ErrInternal
+		p.logger.Error(err, map[string]any{"details": "error in UpdateGuildModLog query"})
+		return guild.Guild{}, common.ErrInternal
 	}
+	defer row.Close()
 
-	if int(copyCount) != len(rows) {
-		err = common.ErrInternal
-		p.logger.Error(err, map[string]any{"details": fmt.Sprintf("Expected %d but got %d at CreateReactionRules", len(rows), int(copyCount))})
-		return []rule.ReactionRule{}, err
+	updatedGuild, err := pgx.CollectExactlyOneRow(row, pgx.RowToStructByName[guild.Guild])
+
+	if err == pgx.ErrNoRows {
+		return guild.Guild{}, common.ErrNotFound
+	} else if err != nil {
+		p.logger.Error(err, map[string]any{"details": "error while collecting rows in UpdateGuildModLog"})
+		return guild.Guild{}, common.ErrInternal
 	}
 
-	return rules, nil
+	return updatedGuild, nil
 }
 
-func (p *Postgresql) DeleteReactionRules(rules []rule.DeleteReactionRuleQuery, gId string) error {
-	placeholder1 := make([]string, len(rules))
-	placeholder2 := make([]string, len(rules))
-	values := []any{gId}
+func (p *Postgresql) UpdateGuild(guildId string, update guild.GuildUpdate) (guild.Guild, error) {
+	query := `
+    UPDATE guilds SET
+      "ownerId" = COALESCE($1, "ownerId"),
+      "name" = COALESCE($2, "name"),
+      "icon" = COALESCE($3, "icon"),
+      "memberCount" = COALESCE($4, "memberCount"),
+      "joinedAt" = COALESCE($5, "joinedAt")
+    WHERE "guildId" = $6 AND "deletedAt" IS NULL
+    RETURNING ` + guildColumns + `
+  `
 
-	for i := range placeholder1 {
-		placeholder1[i] = fmt.Sprintf("$%d", i+2)
-		values = append(values, rules[i].EmojiId)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+	row, err := p.pool.Query(ctx, query, update.OwnerId, update.Name, update.Icon, update.MemberCount, update.JoinedAt, guildId)
+
+	if err != nil {
+		p.logger.Error(err, map[string]any{"details": "error in UpdateGuild query"})
+		return guild.Guild{}, common.ErrInternal
 	}
+	defer row.Close()
 
-	for i := range placeholder2 {
-		placeholder2[i] = fmt.Sprintf("$%d", i+2+len(rules))
-		values = append(values, rules[i].EmojiName)
+	updatedGuild, err := pgx.CollectExactlyOneRow(row, pgx.RowToStructByName[guild.Guild])
+
+	if err == pgx.ErrNoRows {
+		return guild.Guild{}, common.ErrNotFound
+	} else if err != nil {
+		p.logger.Error(err, map[string]any{"details": "error while collecting rows in UpdateGuild"})
+		return guild.Guild{}, common.ErrInternal
 	}
 
-	query := fmt.Sprintf(`
-    DELETE FROM "reactionRules" WHERE "guildId" = $1 AND "emojiId" in (%s) AND "emojiName" in (%s) 
-  `, strings.Join(placeholder1, ","), strings.Join(placeholder2, ","))
+	return updatedGuild, nil
+}
+
+func (p *Postgresql) DeleteGuild(guildId string, deletedAt time.Time) error {
+	query := `
+    UPDATE guilds SET "deletedAt" = $1
+    WHERE "guildId" = $2 AND "deletedAt" IS NULL
+  `
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
 	defer cancel()
-	_, err := p.pool.Query(ctx, query, values...)
+
+	tag, err := p.pool.Exec(ctx, query, deletedAt, guildId)
 
 	if err != nil {
+		p.logger.Error(err, map[string]any{"details": "error in DeleteGuild query"})
 		return common.ErrInternal
 	}
 
+	if tag.RowsAffected() == 0 {
+		return common.ErrNotFound
+	}
+
 	return nil
 }
 
-func (p *Postgresql) ReadReactionRules(gId string) ([]rule.ReactionRule, error) {
+func (p *Postgresql) RestoreGuild(gc guild.GuildCreate, deletedAfter time.Time) (guild.Guild, error) {
 	query := `
-    SELECT * FROM "reactionRules" WHERE "guildId" = $1
+    UPDATE guilds SET "ownerId" = $1, "name" = $2, "icon" = $3, "memberCount" = $4, "joinedAt" = $5, "deletedAt" = NULL
+    WHERE "guildId" = $6 AND "deletedAt" >= $7
+    RETURNING ` + guildColumns + `
   `
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
 	defer cancel()
-	rows, err := p.pool.Query(ctx, query, gId)
+	row, err := p.pool.Query(ctx, query, gc.OwnerId, gc.Name, gc.Icon, gc.MemberCount, gc.JoinedAt, gc.GuildId, deletedAfter)
 
 	if err != nil {
-		p.logger.Error(err, map[string]any{"details": "error in GetReactionRules query"})
-		return []rule.ReactionRule{}, common.ErrInternal
+		p.logger.Error(err, map[string]any{"details": "error in RestoreGuild query"})
+		return guild.Guild{}, common.ErrInternal
 	}
+	defer row.Close()
 
-	var foundRules []rule.ReactionRule
-	for rows.Next() {
-		var foundRule rule.ReactionRule
-		var actions []rule.ReactAction
-		err = rows.Scan(&foundRule.EmojiId, &foundRule.EmojiName, &foundRule.IsCustom, &foundRule.GuildId, &foundRule.RuleAuthor, &actions)
-		if err != nil {
-			p.logger.Error(err, map[string]any{"details": "error while scanning rows in GetReactionRules"})
-			return []rule.ReactionRule{}, common.ErrInternal
-		}
-		copy(foundRule.Actions[:], actions)
-		foundRules = append(foundRules, foundRule)
+	restoredGuild, err := pgx.CollectExactlyOneRow(row, pgx.RowToStructByName[guild.Guild])
+
+	if err == pgx.ErrNoRows {
+		return guild.Guild{}, common.ErrNotFound
+	} else if err != nil {
+		p.logger.Error(err, map[string]any{"details": "error while collecting rows in RestoreGuild"})
+		return guild.Guild{}, common.ErrInternal
+	}
+
+	return restoredGuild, nil
+}
+
+func (p *Postgresql) PurgeGuilds(deletedBefore time.Time) (int, error) {
+	query := `
+    DELETE FROM guilds WHERE "deletedAt" < $1
+  `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	tag, err := p.pool.Exec(ctx, query, deletedBefore)
+
+	if err != nil {
+		p.logger.Error(err, map[string]any{"details": "error in PurgeGuilds query"})
+		return 0, common.ErrInternal
+	}
+
+	return int(tag.RowsAffected()), nil
+}
+
+func (p *Postgresql) CreateReactionRules(rules []rule.ReactionRule) ([]rule.ReactionRule, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+
+	tx, err := p.pool.Begin(ctx)
+
+	if err != nil {
+		p.logger.Error(err, map[string]any{"details": "transaction begin in CreateReactionRules"})
+		return []rule.ReactionRule{}, common.ErrInternal
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		} else {
+			tx.Commit(ctx)
+		}
+	}()
+
+	// nil slices would be copied as NULL
+	for i := range rules {
+		if rules[i].IncludeChannels == nil {
+			rules[i].IncludeChannels = []string{}
+		}
+
+		if rules[i].ExcludeChannels == nil {
+			rules[i].ExcludeChannels = []string{}
+		}
+
+		if rules[i].ExemptRoles == nil {
+			rules[i].ExemptRoles = []string{}
+		}
+	}
+
+	guildIds := make([]string, 0, len(rules))
+
+	for _, r := range rules {
+		guildIds = append(guildIds, r.GuildId)
+	}
+
+	// rules of deleted guilds satisfy the foreign key
+	var deleted bool
+	err = tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM guilds WHERE "guildId" = ANY($1) AND "deletedAt" IS NOT NULL)`, guildIds).Scan(&deleted)
+
+	if err != nil {
+		p.logger.Error(err, map[string]any{"details": "error while checking deleted guilds in CreateReactionRules"})
+		return []rule.ReactionRule{}, common.ErrInternal
+	} else if deleted {
+		return []rule.ReactionRule{}, common.ErrNotFound
+	}
+
+	rows := common.DestructureStructSlice(rules)
+
+	copyCount, err := tx.CopyFrom(ctx,
+		pgx.Identifier{"reactionRules"},
+		[]string{"emojiName", "emojiId", "isCustom", "guildId", "ruleAuthor", "actions", "includeChannels", "excludeChannels", "exemptRoles", "threshold", "match"},
+		pgx.CopyFromRows(rows),
+	)
+
+	switch pgErrorCode(err) {
+	case "":
+	case codeUniqueViolation:
+		return []rule.ReactionRule{}, rule.ErrRuleReactionConflict
+	case codeForeignKeyViolation:
+		return []rule.ReactionRule{}, common.ErrNotFound
+	}
+
+	if err != nil {
+		p.logger.Error(err, map[string]any{"details": "error while inserting to reactionRules"})
+		return []rule.ReactionRule{}, common.ErrInternal
+	}
+
+	if int(copyCount) != len(rows) {
+		err = common.ErrInternal
+		p.logger.Error(err, map[string]any{"details": fmt.Sprintf("Expected %d but got %d at CreateReactionRules", len(rows), int(copyCount))})
+		return []rule.ReactionRule{}, err
+	}
+
+	return rules, nil
+}
+
+func (p *Postgresql) DeleteReactionRules(rules []rule.DeleteReactionRuleQuery, gId string) error {
+	placeholder1 := make([]string, len(rules))
+	placeholder2 := make([]string, len(rules))
+	values := []any{gId}
+
+	for i := range placeholder1 {
+		placeholder1[i] = fmt.Sprintf("$%d", i+2)
+		values = append(values, rules[i].EmojiId)
+	}
+
+	for i := range placeholder2 {
+		placeholder2[i] = fmt.Sprintf("$%d", i+2+len(rules))
+		values = append(values, rules[i].EmojiName)
+	}
+
+	query := fmt.Sprintf(`
+    DELETE FROM "reactionRules" WHERE "guildId" = $1 AND "emojiId" in (%s) AND "emojiName" in (%s)
+    AND "guildId" IN (SELECT "guildId" FROM guilds WHERE "deletedAt" IS NULL)
+  `, strings.Join(placeholder1, ","), strings.Join(placeholder2, ","))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+	_, err := p.pool.Exec(ctx, query, values...)
+
+	if err != nil {
+		p.logger.Error(err, map[string]any{"details": "error in DeleteReactionRules query"})
+		return common.ErrInternal
+	}
+
+	return nil
+}
+
+func (p *Postgresql) ReadReactionRules(gId string) ([]rule.ReactionRule, error) {
+	query := `
+    SELECT "emojiId", "emojiName", "isCustom", "guildId", "ruleAuthor", "actions", "includeChannels", "excludeChannels", "exemptRoles", "threshold", "match"
+    FROM "reactionRules" WHERE "guildId" = $1
+    AND "guildId" IN (SELECT "guildId" FROM guilds WHERE "deletedAt" IS NULL)
+  `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+	rows, err := p.pool.Query(ctx, query, gId)
+
+	if err != nil {
+		p.logger.Error(err, map[string]any{"details": "error in GetReactionRules query"})
+		return []rule.ReactionRule{}, common.ErrInternal
+	}
+
+	var foundRules []rule.ReactionRule
+	for rows.Next() {
+		var foundRule rule.ReactionRule
+		err = rows.Scan(&foundRule.EmojiId, &foundRule.EmojiName, &foundRule.IsCustom, &foundRule.GuildId, &foundRule.RuleAuthor, &foundRule.Actions, &foundRule.IncludeChannels, &foundRule.ExcludeChannels, &foundRule.ExemptRoles, &foundRule.Threshold, &foundRule.Match)
+		if err != nil {
+			p.logger.Error(err, map[string]any{"details": "error while scanning rows in GetReactionRules"})
+			return []rule.ReactionRule{}, common.ErrInternal
+		}
+		foundRules = append(foundRules, foundRule)
 	}
 
 	//foundRules, err := pgx.CollectRows(rows, pgx.RowToStructByName[rule.ReactionRule])
@@ -295,3 +652,487 @@ func (p *Postgresql) ReadReactionRules(gId string) ([]rule.ReactionRule, error)
 
 	return foundRules, nil
 }
+
+func (p *Postgresql) UpdateReactionRules(rules []rule.ReactionRuleUpdate, gId string) ([]rule.ReactionRule, error) {
+	query := `
+    UPDATE "reactionRules" SET "actions" = $1
+    WHERE "guildId" = $2 AND "emojiId" = $3 AND "emojiName" = $4
+    AND "guildId" IN (SELECT "guildId" FROM guilds WHERE "deletedAt" IS NULL)
+    RETURNING "emojiId", "emojiName", "isCustom", "guildId", "ruleAuthor", "actions", "includeChannels", "excludeChannels", "exemptRoles", "threshold", "match"
+  `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+
+	tx, err := p.pool.Begin(ctx)
+
+	if err != nil {
+		p.logger.Error(err, map[string]any{"details": "transaction begin in UpdateReactionRules"})
+		return []rule.ReactionRule{}, common.ErrInternal
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		} else {
+			tx.Commit(ctx)
+		}
+	}()
+
+	updatedRules := make([]rule.ReactionRule, 0, len(rules))
+
+	for _, r := range rules {
+		var updatedRule rule.ReactionRule
+
+		err = tx.QueryRow(ctx, query, r.Actions, gId, r.EmojiId, r.EmojiName).
+			Scan(&updatedRule.EmojiId, &updatedRule.EmojiName, &updatedRule.IsCustom, &updatedRule.GuildId, &updatedRule.RuleAuthor, &updatedRule.Actions, &updatedRule.IncludeChannels, &updatedRule.ExcludeChannels, &updatedRule.ExemptRoles, &updatedRule.Threshold, &updatedRule.Match)
+
+		if err == pgx.ErrNoRows {
+			return []rule.ReactionRule{}, common.ErrNotFound
+		} else if err != nil {
+			p.logger.Error(err, map[string]any{"details": "error in UpdateReactionRules query"})
+			return []rule.ReactionRule{}, common.ErrInternal
+		}
+
+		updatedRules = append(updatedRules, updatedRule)
+	}
+
+	return updatedRules, nil
+}
+
+func (p *Postgresql) ReadReactionRoleMessages(gId string) ([]rule.ReactionRoleMessage, error) {
+	query := `
+    SELECT m."messageId", m."channelId", m."mode", r."emojiName", r."emojiId", r."roleId"
+    FROM "reactionRoleMessages" m
+    LEFT JOIN "reactionRoles" r ON r."guildId" = m."guildId" AND r."messageId" = m."messageId"
+    WHERE m."guildId" = $1 ORDER BY m."messageId", r."position"
+  `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+	rows, err := p.pool.Query(ctx, query, gId)
+
+	if err != nil {
+		p.logger.Error(err, map[string]any{"details": "error in ReadReactionRoleMessages query"})
+		return nil, common.ErrInternal
+	}
+
+	defer rows.Close()
+
+	messages := []rule.ReactionRoleMessage{}
+
+	for rows.Next() {
+		m := rule.ReactionRoleMessage{GuildId: gId}
+		var emojiName, emojiId, roleId *string
+
+		if err := rows.Scan(&m.MessageId, &m.ChannelId, &m.Mode, &emojiName, &emojiId, &roleId); err != nil {
+			p.logger.Error(err, map[string]any{"details": "error while scanning rows in ReadReactionRoleMessages"})
+			return nil, common.ErrInternal
+		}
+
+		if n := len(messages); n == 0 || messages[n-1].MessageId != m.MessageId {
+			m.Roles = []rule.ReactionRole{}
+			messages = append(messages, m)
+		}
+
+		// messages without roles have a row of nulls
+		if roleId != nil {
+			last := &messages[len(messages)-1]
+			last.Roles = append(last.Roles, rule.ReactionRole{EmojiName: *emojiName, EmojiId: *emojiId, RoleId: *roleId})
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		p.logger.Error(err, map[string]any{"details": "error while iterating rows in ReadReactionRoleMessages"})
+		return nil, common.ErrInternal
+	}
+
+	return messages, nil
+}
+
+func (p *Postgresql) SaveReactionRoleMessage(m rule.ReactionRoleMessage) (saved rule.ReactionRoleMessage, err error) {
+	query := `
+    INSERT INTO "reactionRoleMessages" ("guildId", "messageId", "channelId", "mode") VALUES ($1, $2, $3, $4)
+    ON CONFLICT ("guildId", "messageId") DO UPDATE SET "channelId" = EXCLUDED."channelId", "mode" = EXCLUDED."mode"
+  `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+
+	tx, err := p.pool.Begin(ctx)
+
+	if err != nil {
+		p.logger.Error(err, map[string]any{"details": "transaction begin in SaveReactionRoleMessage"})
+		return rule.ReactionRoleMessage{}, common.ErrInternal
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	var exists bool
+	err = tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM guilds WHERE "guildId" = $1 AND "deletedAt" IS NULL)`, m.GuildId).Scan(&exists)
+
+	if err != nil {
+		p.logger.Error(err, map[string]any{"details": "error while checking guild in SaveReactionRoleMessage"})
+		return rule.ReactionRoleMessage{}, common.ErrInternal
+	} else if !exists {
+		return rule.ReactionRoleMessage{}, common.ErrNotFound
+	}
+
+	if _, err = tx.Exec(ctx, query, m.GuildId, m.MessageId, m.ChannelId, m.Mode); err != nil {
+		p.logger.Error(err, map[string]any{"details": "error while upserting to reactionRoleMessages"})
+		return rule.ReactionRoleMessage{}, common.ErrInternal
+	}
+
+	if _, err = tx.Exec(ctx, `DELETE FROM "reactionRoles" WHERE "guildId" = $1 AND "messageId" = $2`, m.GuildId, m.MessageId); err != nil {
+		p.logger.Error(err, map[string]any{"details": "error while deleting from reactionRoles"})
+		return rule.ReactionRoleMessage{}, common.ErrInternal
+	}
+
+	rows := make([][]any, 0, len(m.Roles))
+
+	for i, r := range m.Roles {
+		rows = append(rows, []any{m.GuildId, m.MessageId, i, r.EmojiName, r.EmojiId, r.RoleId})
+	}
+
+	_, err = tx.CopyFrom(ctx,
+		pgx.Identifier{"reactionRoles"},
+		[]string{"guildId", "messageId", "position", "emojiName", "emojiId", "roleId"},
+		pgx.CopyFromRows(rows),
+	)
+
+	if err != nil {
+		p.logger.Error(err, map[string]any{"details": "error while inserting to reactionRoles"})
+		return rule.ReactionRoleMessage{}, common.ErrInternal
+	}
+
+	if m.Roles == nil {
+		m.Roles = []rule.ReactionRole{}
+	}
+
+	return m, nil
+}
+
+func (p *Postgresql) DeleteReactionRoleMessage(gId string, messageId string) error {
+	query := `
+    DELETE FROM "reactionRoleMessages" WHERE "guildId" = $1 AND "messageId" = $2
+  `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+
+	tag, err := p.pool.Exec(ctx, query, gId, messageId)
+
+	if err != nil {
+		p.logger.Error(err, map[string]any{"details": "error in DeleteReactionRoleMessage query"})
+		return common.ErrInternal
+	}
+
+	if tag.RowsAffected() == 0 {
+		return common.ErrNotFound
+	}
+
+	return nil
+}
+
+func (p *Postgresql) ReadReactionFloodRule(gId string) (rule.ReactionFloodRule, error) {
+	query := `
+    SELECT "guildId", "maxReactions", "windowSeconds", "actions" FROM "reactionFloodRules" WHERE "guildId" = $1
+  `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+
+	var f rule.ReactionFloodRule
+	err := p.pool.QueryRow(ctx, query, gId).Scan(&f.GuildId, &f.MaxReactions, &f.WindowSeconds, &f.Actions)
+
+	if err == pgx.ErrNoRows {
+		return rule.ReactionFloodRule{}, common.ErrNotFound
+	} else if err != nil {
+		p.logger.Error(err, map[string]any{"details": "error in ReadReactionFloodRule query"})
+		return rule.ReactionFloodRule{}, common.ErrInternal
+	}
+
+	return f, nil
+}
+
+func (p *Postgresql) SaveReactionFloodRule(f rule.ReactionFloodRule) (rule.ReactionFloodRule, error) {
+	// nothing is inserted for deleted guilds
+	query := `
+    INSERT INTO "reactionFloodRules" ("guildId", "maxReactions", "windowSeconds", "actions")
+    SELECT "guildId", $2, $3, $4 FROM guilds WHERE "guildId" = $1 AND "deletedAt" IS NULL
+    ON CONFLICT ("guildId") DO UPDATE SET
+      "maxReactions" = EXCLUDED."maxReactions", "windowSeconds" = EXCLUDED."windowSeconds", "actions" = EXCLUDED."actions"
+    RETURNING "guildId", "maxReactions", "windowSeconds", "actions"
+  `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+
+	var saved rule.ReactionFloodRule
+	err := p.pool.QueryRow(ctx, query, f.GuildId, f.MaxReactions, f.WindowSeconds, f.Actions).
+		Scan(&saved.GuildId, &saved.MaxReactions, &saved.WindowSeconds, &saved.Actions)
+
+	if err == pgx.ErrNoRows {
+		return rule.ReactionFloodRule{}, common.ErrNotFound
+	} else if err != nil {
+		p.logger.Error(err, map[string]any{"details": "error in SaveReactionFloodRule query"})
+		return rule.ReactionFloodRule{}, common.ErrInternal
+	}
+
+	return saved, nil
+}
+
+func (p *Postgresql) DeleteReactionFloodRule(gId string) error {
+	query := `
+    DELETE FROM "reactionFloodRules" WHERE "guildId" = $1
+  `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+
+	tag, err := p.pool.Exec(ctx, query, gId)
+
+	if err != nil {
+		p.logger.Error(err, map[string]any{"details": "error in DeleteReactionFloodRule query"})
+		return common.ErrInternal
+	}
+
+	if tag.RowsAffected() == 0 {
+		return common.ErrNotFound
+	}
+
+	return nil
+}
+
+func (p *Postgresql) CreateInfraction(i infraction.InfractionCreate) (infraction.Infraction, error) {
+	// nothing is inserted for deleted guilds
+	query := `
+    INSERT INTO "infractions" ("guildId", "userId", "moderatorId", "source", "action", "reason", "emojiName", "emojiId", "channelId", "messageId", "step", "createdAt")
+    SELECT "guildId", $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12 FROM guilds WHERE "guildId" = $1 AND "deletedAt" IS NULL
+    RETURNING ` + infractionColumns + `
+  `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+	row, err := p.pool.Query(ctx, query, i.GuildId, i.UserId, i.ModeratorId, i.Source, i.Action, i.Reason, i.EmojiName, i.EmojiId, i.ChannelId, i.MessageId, i.Step, i.CreatedAt)
+
+	if err != nil {
+		p.logger.Error(err, map[string]any{"details": "error in CreateInfraction query"})
+		return infraction.Infraction{}, common.ErrInternal
+	}
+	defer row.Close()
+
+	created, err := pgx.CollectExactlyOneRow(row, pgx.RowToStructByName[infraction.Infraction])
+
+	if err == pgx.ErrNoRows {
+		return infraction.Infraction{}, common.ErrNotFound
+	} else if err != nil {
+		p.logger.Error(err, map[string]any{"details": "error while collecting rows in CreateInfraction"})
+		return infraction.Infraction{}, common.ErrInternal
+	}
+
+	return created, nil
+}
+
+func (p *Postgresql) ReadInfraction(gId string, id int64) (infraction.Infraction, error) {
+	query := `
+    SELECT ` + infractionColumns + ` FROM "infractions" WHERE "guildId" = $1 AND "id" = $2
+  `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+	row, err := p.pool.Query(ctx, query, gId, id)
+
+	if err != nil {
+		p.logger.Error(err, map[string]any{"details": "error in ReadInfraction query"})
+		return infraction.Infraction{}, common.ErrInternal
+	}
+	defer row.Close()
+
+	found, err := pgx.CollectExactlyOneRow(row, pgx.RowToStructByName[infraction.Infraction])
+
+	if err == pgx.ErrNoRows {
+		return infraction.Infraction{}, common.ErrNotFound
+	} else if err != nil {
+		p.logger.Error(err, map[string]any{"details": "error while collecting rows in ReadInfraction"})
+		return infraction.Infraction{}, common.ErrInternal
+	}
+
+	return found, nil
+}
+
+func (p *Postgresql) ReadInfractions(q infraction.InfractionQuery) ([]infraction.Infraction, int, error) {
+	where := `WHERE "guildId" = $1 AND ($2 = '' OR "userId" = $2)`
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+
+	var total int
+
+	if err := p.pool.QueryRow(ctx, `SELECT COUNT(*) FROM "infractions" `+where, q.GuildId, q.UserId).Scan(&total); err != nil {
+		p.logger.Error(err, map[string]any{"details": "error in ReadInfractions count query"})
+		return []infraction.Infraction{}, 0, common.ErrInternal
+	}
+
+	query := `
+    SELECT ` + infractionColumns + ` FROM "infractions" ` + where + `
+    ORDER BY "createdAt" DESC, "id" DESC
+    LIMIT $3 OFFSET $4
+  `
+
+	rows, err := p.pool.Query(ctx, query, q.GuildId, q.UserId, q.Limit, q.Offset)
+
+	if err != nil {
+		p.logger.Error(err, map[string]any{"details": "error in ReadInfractions query"})
+		return []infraction.Infraction{}, 0, common.ErrInternal
+	}
+
+	found, err := pgx.CollectRows(rows, pgx.RowToStructByName[infraction.Infraction])
+
+	if err != nil {
+		p.logger.Error(err, map[string]any{"details": "error while collecting rows in ReadInfractions"})
+		return []infraction.Infraction{}, 0, common.ErrInternal
+	}
+
+	return found, total, nil
+}
+
+func (p *Postgresql) UpdateInfraction(gId string, id int64, u infraction.InfractionUpdate) (infraction.Infraction, error) {
+	query := `
+    UPDATE "infractions" SET "reason" = $1
+    WHERE "guildId" = $2 AND "id" = $3
+    RETURNING ` + infractionColumns + `
+  `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+	row, err := p.pool.Query(ctx, query, u.Reason, gId, id)
+
+	if err != nil {
+		p.logger.Error(err, map[string]any{"details": "error in UpdateInfraction query"})
+		return infraction.Infraction{}, common.ErrInternal
+	}
+	defer row.Close()
+
+	updated, err := pgx.CollectExactlyOneRow(row, pgx.RowToStructByName[infraction.Infraction])
+
+	if err == pgx.ErrNoRows {
+		return infraction.Infraction{}, common.ErrNotFound
+	} else if err != nil {
+		p.logger.Error(err, map[string]any{"details": "error while collecting rows in UpdateInfraction"})
+		return infraction.Infraction{}, common.ErrInternal
+	}
+
+	return updated, nil
+}
+
+func (p *Postgresql) DeleteInfraction(gId string, id int64) error {
+	query := `
+    DELETE FROM "infractions" WHERE "guildId" = $1 AND "id" = $2
+  `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+
+	tag, err := p.pool.Exec(ctx, query, gId, id)
+
+	if err != nil {
+		p.logger.Error(err, map[string]any{"details": "error in DeleteInfraction query"})
+		return common.ErrInternal
+	}
+
+	if tag.RowsAffected() == 0 {
+		return common.ErrNotFound
+	}
+
+	return nil
+}
+
+func (p *Postgresql) ReadEscalationPolicy(gId string) (escalation.Policy, error) {
+	query := `
+    SELECT "warns", "windowSeconds", "action", "timeoutSeconds" FROM "escalationSteps"
+    WHERE "guildId" = $1 ORDER BY "warns"
+  `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+	rows, err := p.pool.Query(ctx, query, gId)
+
+	if err != nil {
+		p.logger.Error(err, map[string]any{"details": "error in ReadEscalationPolicy query"})
+		return escalation.Policy{}, common.ErrInternal
+	}
+
+	steps, err := pgx.CollectRows(rows, pgx.RowToStructByName[escalation.Step])
+
+	if err != nil {
+		p.logger.Error(err, map[string]any{"details": "error while collecting rows in ReadEscalationPolicy"})
+		return escalation.Policy{}, common.ErrInternal
+	}
+
+	return escalation.Policy{GuildId: gId, Steps: steps}, nil
+}
+
+func (p *Postgresql) UpdateEscalationPolicy(gId string, steps []escalation.Step) (policy escalation.Policy, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+
+	tx, err := p.pool.Begin(ctx)
+
+	if err != nil {
+		p.logger.Error(err, map[string]any{"details": "transaction begin in UpdateEscalationPolicy"})
+		return escalation.Policy{}, common.ErrInternal
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	var exists bool
+	err = tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM guilds WHERE "guildId" = $1 AND "deletedAt" IS NULL)`, gId).Scan(&exists)
+
+	if err != nil {
+		p.logger.Error(err, map[string]any{"details": "error while checking guild in UpdateEscalationPolicy"})
+		return escalation.Policy{}, common.ErrInternal
+	} else if !exists {
+		return escalation.Policy{}, common.ErrNotFound
+	}
+
+	if _, err = tx.Exec(ctx, `DELETE FROM "escalationSteps" WHERE "guildId" = $1`, gId); err != nil {
+		p.logger.Error(err, map[string]any{"details": "error while deleting from escalationSteps"})
+		return escalation.Policy{}, common.ErrInternal
+	}
+
+	rows := make([][]any, 0, len(steps))
+
+	for _, s := range steps {
+		rows = append(rows, []any{gId, s.Warns, s.WindowSeconds, s.Action, s.TimeoutSeconds})
+	}
+
+	_, err = tx.CopyFrom(ctx,
+		pgx.Identifier{"escalationSteps"},
+		[]string{"guildId", "warns", "windowSeconds", "action", "timeoutSeconds"},
+		pgx.CopyFromRows(rows),
+	)
+
+	if err != nil {
+		p.logger.Error(err, map[string]any{"details": "error while inserting to escalationSteps"})
+		return escalation.Policy{}, common.ErrInternal
+	}
+
+	if steps == nil {
+		steps = []escalation.Step{}
+	}
+
+	return escalation.Policy{GuildId: gId, Steps: steps}, nil
+}
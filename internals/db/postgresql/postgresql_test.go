@@ -0,0 +1,45 @@
+package postgresql
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	mogs "github.com/finkabaj/hyde-bot/internals/backend/mocks"
+	"github.com/finkabaj/hyde-bot/internals/db"
+	"github.com/finkabaj/hyde-bot/internals/db/dbtest"
+)
+
+// TestPostgresql runs the conformance suite against TEST_POSTGRES_DB. All data of the database is deleted.
+func TestPostgresql(t *testing.T) {
+	credentials := db.DatabaseCredentials{
+		Host:     os.Getenv("TEST_POSTGRES_HOST"),
+		Port:     os.Getenv("TEST_POSTGRES_PORT"),
+		User:     os.Getenv("TEST_POSTGRES_USER"),
+		Password: os.Getenv("TEST_POSTGRES_PASSWORD"),
+		Database: os.Getenv("TEST_POSTGRES_DB"),
+	}
+
+	if credentials.Database == "" {
+		t.Skip("TEST_POSTGRES_DB is not set")
+	}
+
+	p := NewPostgresql(mogs.NewMockLogger())
+
+	if err := p.Connect(credentials); err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+
+	if err := p.MigrateUp(); err != nil {
+		t.Fatal(err)
+	}
+
+	dbtest.Run(t, func(t *testing.T) db.Database {
+		if _, err := p.pool.Exec(context.Background(), `TRUNCATE "guilds", "users" CASCADE`); err != nil {
+			t.Fatal(err)
+		}
+
+		return p
+	})
+}
@@ -0,0 +1,173 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"time"
+
+	"github.com/finkabaj/hyde-bot/internals/db"
+)
+
+//go:embed migrations/*.sql
+var migrationsFS embed.FS
+
+// withMigrationTx runs f in one immediate transaction. It holds the sqlite write lock,
+// so concurrent instances wait for each other and a failed run leaves the schema untouched.
+func (s *Sqlite) withMigrationTx(f func(ctx context.Context, tx *sql.Tx, applied map[int]time.Time) error) (err error) {
+	ctx := context.Background()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+
+	if err != nil {
+		return fmt.Errorf("error beginning migration transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	_, err = tx.ExecContext(ctx, `
+    CREATE TABLE IF NOT EXISTS "schema_migrations" (
+      "version" INTEGER PRIMARY KEY,
+      "name" TEXT NOT NULL,
+      "appliedAt" TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ', 'now'))
+    )
+  `)
+
+	if err != nil {
+		return fmt.Errorf("error creating schema_migrations table: %w", err)
+	}
+
+	applied, err := appliedMigrations(ctx, tx)
+
+	if err != nil {
+		return fmt.Errorf("error reading applied migrations: %w", err)
+	}
+
+	return f(ctx, tx, applied)
+}
+
+func appliedMigrations(ctx context.Context, tx *sql.Tx) (map[int]time.Time, error) {
+	rows, err := tx.QueryContext(ctx, `SELECT "version", "appliedAt" FROM "schema_migrations"`)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make(map[int]time.Time)
+
+	for rows.Next() {
+		var version int
+		var appliedAt string
+
+		if err = rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+
+		applied[version], err = time.Parse(time.RFC3339, appliedAt)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return applied, rows.Err()
+}
+
+func (s *Sqlite) MigrateUp() error {
+	migrations, err := db.LoadMigrations(migrationsFS, "migrations")
+
+	if err != nil {
+		return err
+	}
+
+	return s.withMigrationTx(func(ctx context.Context, tx *sql.Tx, applied map[int]time.Time) error {
+		for _, m := range migrations {
+			if _, ok := applied[m.Version]; ok {
+				continue
+			}
+
+			if _, err := tx.ExecContext(ctx, m.Up); err != nil {
+				return fmt.Errorf("error applying migration %d_%s: %w", m.Version, m.Name, err)
+			}
+
+			if _, err := tx.ExecContext(ctx, `INSERT INTO "schema_migrations" ("version", "name") VALUES (?, ?)`, m.Version, m.Name); err != nil {
+				return fmt.Errorf("error recording migration %d_%s: %w", m.Version, m.Name, err)
+			}
+
+			s.logger.Info("Migration applied", map[string]any{"version": m.Version, "name": m.Name})
+		}
+
+		return nil
+	})
+}
+
+func (s *Sqlite) MigrateDown(steps int) error {
+	migrations, err := db.LoadMigrations(migrationsFS, "migrations")
+
+	if err != nil {
+		return err
+	}
+
+	return s.withMigrationTx(func(ctx context.Context, tx *sql.Tx, applied map[int]time.Time) error {
+		for i := len(migrations) - 1; i >= 0 && steps > 0; i-- {
+			m := migrations[i]
+
+			if _, ok := applied[m.Version]; !ok {
+				continue
+			}
+
+			if m.Down == "" {
+				return fmt.Errorf("%w: %d_%s", db.ErrMissingDownMigration, m.Version, m.Name)
+			}
+
+			if _, err := tx.ExecContext(ctx, m.Down); err != nil {
+				return fmt.Errorf("error reverting migration %d_%s: %w", m.Version, m.Name, err)
+			}
+
+			if _, err := tx.ExecContext(ctx, `DELETE FROM "schema_migrations" WHERE "version" = ?`, m.Version); err != nil {
+				return fmt.Errorf("error recording migration %d_%s: %w", m.Version, m.Name, err)
+			}
+
+			s.logger.Info("Migration reverted", map[string]any{"version": m.Version, "name": m.Name})
+
+			steps--
+		}
+
+		return nil
+	})
+}
+
+func (s *Sqlite) MigrationStatus() ([]db.MigrationStatus, error) {
+	migrations, err := db.LoadMigrations(migrationsFS, "migrations")
+
+	if err != nil {
+		return nil, err
+	}
+
+	status := make([]db.MigrationStatus, 0, len(migrations))
+
+	err = s.withMigrationTx(func(ctx context.Context, tx *sql.Tx, applied map[int]time.Time) error {
+		for _, m := range migrations {
+			appliedAt, ok := applied[m.Version]
+
+			status = append(status, db.MigrationStatus{
+				Version:   m.Version,
+				Name:      m.Name,
+				Applied:   ok,
+				AppliedAt: appliedAt,
+			})
+		}
+
+		return nil
+	})
+
+	return status, err
+}
@@ -0,0 +1,33 @@
+-- list columns are JSON arrays, sqlite has no array type
+CREATE TABLE IF NOT EXISTS "users" (
+  "userId" TEXT PRIMARY KEY,
+  "name" TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS "guilds" (
+  "guildId" TEXT PRIMARY KEY,
+  "ownerId" TEXT NOT NULL,
+  "exemptRoles" TEXT NOT NULL DEFAULT '[]',
+  "exemptAdmins" INTEGER NOT NULL DEFAULT 1
+);
+
+CREATE TABLE IF NOT EXISTS "refreshTokens" (
+  "userId" TEXT PRIMARY KEY,
+  "token" TEXT NOT NULL,
+  "expires" TEXT NOT NULL,
+  FOREIGN KEY ("userId") REFERENCES "users"("userId") ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS "reactionRules" (
+  "emojiId" TEXT NOT NULL DEFAULT '',
+  "emojiName" TEXT NOT NULL DEFAULT '',
+  "isCustom" INTEGER NOT NULL DEFAULT 0,
+  "guildId" TEXT NOT NULL,
+  "ruleAuthor" TEXT NOT NULL,
+  "actions" TEXT NOT NULL,
+  "includeChannels" TEXT NOT NULL DEFAULT '[]',
+  "excludeChannels" TEXT NOT NULL DEFAULT '[]',
+  "exemptRoles" TEXT NOT NULL DEFAULT '[]',
+  PRIMARY KEY ("guildId", "emojiId", "emojiName"),
+  FOREIGN KEY ("guildId") REFERENCES "guilds"("guildId") ON DELETE CASCADE
+);
@@ -0,0 +1 @@
+CREATE UNIQUE INDEX IF NOT EXISTS "refreshTokensToken" ON "refreshTokens" ("token");
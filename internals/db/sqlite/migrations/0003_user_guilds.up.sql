@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS "userGuilds" (
+  "userId" TEXT NOT NULL,
+  "guildId" TEXT NOT NULL,
+  "owner" INTEGER NOT NULL DEFAULT 0,
+  "permissions" INTEGER NOT NULL DEFAULT 0,
+  PRIMARY KEY ("userId", "guildId"),
+  FOREIGN KEY ("userId") REFERENCES "users"("userId") ON DELETE CASCADE
+);
@@ -0,0 +1,3 @@
+DELETE FROM "guilds" WHERE "deletedAt" IS NOT NULL;
+
+ALTER TABLE "guilds" DROP COLUMN "deletedAt";
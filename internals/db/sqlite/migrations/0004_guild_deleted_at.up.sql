@@ -0,0 +1,2 @@
+-- RFC 3339 UTC timestamp, NULL while the guild isn't deleted
+ALTER TABLE "guilds" ADD COLUMN "deletedAt" TEXT;
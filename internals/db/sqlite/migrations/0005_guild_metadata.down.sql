@@ -0,0 +1,4 @@
+ALTER TABLE "guilds" DROP COLUMN "joinedAt";
+ALTER TABLE "guilds" DROP COLUMN "memberCount";
+ALTER TABLE "guilds" DROP COLUMN "icon";
+ALTER TABLE "guilds" DROP COLUMN "name";
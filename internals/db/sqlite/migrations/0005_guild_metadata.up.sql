@@ -0,0 +1,5 @@
+ALTER TABLE "guilds" ADD COLUMN "name" TEXT NOT NULL DEFAULT '';
+ALTER TABLE "guilds" ADD COLUMN "icon" TEXT NOT NULL DEFAULT '';
+ALTER TABLE "guilds" ADD COLUMN "memberCount" INTEGER NOT NULL DEFAULT 0;
+-- RFC 3339 UTC timestamp, NULL for guilds created before it was recorded
+ALTER TABLE "guilds" ADD COLUMN "joinedAt" TEXT;
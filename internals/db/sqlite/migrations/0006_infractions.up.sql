@@ -0,0 +1,18 @@
+-- "createdAt" is a fixed width RFC 3339 UTC timestamp, so it sorts as a string
+CREATE TABLE IF NOT EXISTS "infractions" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "guildId" TEXT NOT NULL,
+  "userId" TEXT NOT NULL,
+  "moderatorId" TEXT NOT NULL DEFAULT '',
+  "source" TEXT NOT NULL,
+  "action" TEXT NOT NULL,
+  "reason" TEXT NOT NULL DEFAULT '',
+  "emojiName" TEXT NOT NULL DEFAULT '',
+  "emojiId" TEXT NOT NULL DEFAULT '',
+  "channelId" TEXT NOT NULL DEFAULT '',
+  "messageId" TEXT NOT NULL DEFAULT '',
+  "createdAt" TEXT NOT NULL,
+  FOREIGN KEY ("guildId") REFERENCES "guilds"("guildId") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS "infractionsGuildUser" ON "infractions" ("guildId", "userId", "createdAt" DESC);
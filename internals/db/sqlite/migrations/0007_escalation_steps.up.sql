@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS "escalationSteps" (
+  "guildId" TEXT NOT NULL,
+  "warns" INTEGER NOT NULL,
+  "windowSeconds" INTEGER NOT NULL,
+  "action" TEXT NOT NULL,
+  "timeoutSeconds" INTEGER NOT NULL DEFAULT 0,
+  PRIMARY KEY ("guildId", "warns"),
+  FOREIGN KEY ("guildId") REFERENCES "guilds"("guildId") ON DELETE CASCADE
+);
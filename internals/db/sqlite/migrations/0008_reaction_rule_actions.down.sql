@@ -0,0 +1,6 @@
+-- timeouts have no number in the old format and are dropped
+UPDATE "reactionRules" SET "actions" = (
+  SELECT json_group_array(json_extract("value", '$.type'))
+  FROM json_each("reactionRules"."actions")
+  WHERE json_extract("value", '$.type') <= 4
+);
@@ -0,0 +1,6 @@
+-- actions were an array of action numbers padded with zeros, now they are objects with parameters
+UPDATE "reactionRules" SET "actions" = (
+  SELECT json_group_array(json_object('type', "value"))
+  FROM json_each("reactionRules"."actions")
+  WHERE "value" <> 0
+);
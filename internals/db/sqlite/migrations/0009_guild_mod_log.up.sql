@@ -0,0 +1,2 @@
+-- empty if the mod log is disabled
+ALTER TABLE "guilds" ADD COLUMN "modLogChannelId" TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS "reactionRoleMessages" (
+  "guildId" TEXT NOT NULL,
+  "messageId" TEXT NOT NULL,
+  "channelId" TEXT NOT NULL,
+  "mode" TEXT NOT NULL,
+  PRIMARY KEY ("guildId", "messageId"),
+  FOREIGN KEY ("guildId") REFERENCES "guilds"("guildId") ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS "reactionRoles" (
+  "guildId" TEXT NOT NULL,
+  "messageId" TEXT NOT NULL,
+  "position" INTEGER NOT NULL,
+  "emojiName" TEXT NOT NULL,
+  "emojiId" TEXT NOT NULL DEFAULT '',
+  "roleId" TEXT NOT NULL,
+  PRIMARY KEY ("guildId", "messageId", "position"),
+  FOREIGN KEY ("guildId", "messageId") REFERENCES "reactionRoleMessages"("guildId", "messageId") ON DELETE CASCADE
+);
@@ -0,0 +1,2 @@
+-- NULL for rules that act on every reaction
+ALTER TABLE "reactionRules" ADD COLUMN "threshold" TEXT;
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS "reactionFloodRules" (
+  "guildId" TEXT PRIMARY KEY,
+  "maxReactions" INTEGER NOT NULL,
+  "windowSeconds" INTEGER NOT NULL,
+  "actions" TEXT NOT NULL,
+  FOREIGN KEY ("guildId") REFERENCES "guilds"("guildId") ON DELETE CASCADE
+);
@@ -0,0 +1 @@
+ALTER TABLE "reactionRules" DROP COLUMN "match";
@@ -0,0 +1,2 @@
+-- empty for rules that match one exact emoji
+ALTER TABLE "reactionRules" ADD COLUMN "match" TEXT NOT NULL DEFAULT '';
@@ -0,0 +1 @@
+ALTER TABLE "infractions" DROP COLUMN "step";
@@ -0,0 +1,2 @@
+-- key of the escalation step that issued the infraction
+ALTER TABLE "infractions" ADD COLUMN "step" TEXT NOT NULL DEFAULT '';
@@ -0,0 +1 @@
+ALTER TABLE "refreshTokens" DROP COLUMN "discordToken";
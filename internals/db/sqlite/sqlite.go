@@ -0,0 +1,1287 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/finkabaj/hyde-bot/internals/db"
+	"github.com/finkabaj/hyde-bot/internals/logger"
+	"github.com/finkabaj/hyde-bot/internals/utils/common"
+	"github.com/finkabaj/hyde-bot/internals/utils/escalation"
+	"github.com/finkabaj/hyde-bot/internals/utils/guild"
+	"github.com/finkabaj/hyde-bot/internals/utils/infraction"
+	"github.com/finkabaj/hyde-bot/internals/utils/rule"
+	"github.com/finkabaj/hyde-bot/internals/utils/user"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// Sqlite stores data in a single file. credentials.Database is the path of the file.
+type Sqlite struct {
+	db     *sql.DB
+	logger logger.ILogger
+}
+
+func NewSqlite(logger logger.ILogger) *Sqlite {
+	return &Sqlite{
+		logger: logger,
+	}
+}
+
+// errorCode returns the extended code of the sqlite error or 0.
+func errorCode(err error) int {
+	var sqliteErr *sqlite.Error
+
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.Code()
+	}
+
+	return 0
+}
+
+func (s *Sqlite) Connect(credentials db.DatabaseCredentials) (err error) {
+	if credentials.Database == "" {
+		return errors.New("sqlite database path is empty")
+	}
+
+	// foreign keys are disabled by default in sqlite,
+	// immediate transactions take the write lock on begin, so concurrent writers wait for busy_timeout instead of failing
+	dsn := fmt.Sprintf("file:%s?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_txlock=immediate", url.PathEscape(credentials.Database))
+
+	s.db, err = sql.Open("sqlite", dsn)
+
+	if err != nil {
+		return
+	}
+
+	// sqlite allows a single writer, more connections only add busy errors
+	s.db.SetMaxOpenConns(1)
+
+	err = s.Status()
+
+	return
+}
+
+func (s *Sqlite) Close() {
+	s.db.Close()
+}
+
+func (s *Sqlite) Status() error {
+	return s.db.Ping()
+}
+
+func (s *Sqlite) UpsertUser(u user.User) (user.User, error) {
+	query := `
+    INSERT INTO "users" ("userId", "name")
+    VALUES (?, ?)
+    ON CONFLICT ("userId") DO UPDATE SET "name" = excluded."name"
+    RETURNING "userId", "name"
+  `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+
+	var savedUser user.User
+	err := s.db.QueryRowContext(ctx, query, u.UserId, u.Name).Scan(&savedUser.UserId, &savedUser.Name)
+
+	if err != nil {
+		s.logger.Error(err, map[string]any{"details": "error in UpsertUser query"})
+		return user.User{}, common.ErrInternal
+	}
+
+	return savedUser, nil
+}
+
+func (s *Sqlite) ReadUser(userId string) (user.User, error) {
+	query := `
+    SELECT "userId", "name" FROM "users" WHERE "userId" = ?
+  `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+
+	var foundUser user.User
+	err := s.db.QueryRowContext(ctx, query, userId).Scan(&foundUser.UserId, &foundUser.Name)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return user.User{}, common.ErrNotFound
+	case err != nil:
+		s.logger.Error(err, map[string]any{"details": "error in ReadUser query"})
+		return user.User{}, common.ErrInternal
+	}
+
+	return foundUser, nil
+}
+
+func (s *Sqlite) SaveRefreshToken(t user.RefreshToken) error {
+	query := `
+    INSERT INTO "refreshTokens" ("userId", "token", "expires", "discordToken")
+    VALUES (?, ?, ?, ?)
+    ON CONFLICT ("userId") DO UPDATE SET "token" = excluded."token", "expires" = excluded."expires", "discordToken" = excluded."discordToken"
+  `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+
+	_, err := s.db.ExecContext(ctx, query, t.UserId, t.TokenHash, t.Expires.UTC().Format(time.RFC3339Nano), t.DiscordToken)
+
+	switch {
+	case errorCode(err) == sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY:
+		return common.ErrNotFound
+	case err != nil:
+		s.logger.Error(err, map[string]any{"details": "error in SaveRefreshToken query"})
+		return common.ErrInternal
+	}
+
+	return nil
+}
+
+func (s *Sqlite) ReadRefreshToken(tokenHash string) (user.RefreshToken, error) {
+	query := `
+    SELECT "userId", "token", "expires", "discordToken" FROM "refreshTokens" WHERE "token" = ?
+  `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+
+	var t user.RefreshToken
+	var expires string
+	err := s.db.QueryRowContext(ctx, query, tokenHash).Scan(&t.UserId, &t.TokenHash, &expires, &t.DiscordToken)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return user.RefreshToken{}, common.ErrNotFound
+	case err != nil:
+		s.logger.Error(err, map[string]any{"details": "error in ReadRefreshToken query"})
+		return user.RefreshToken{}, common.ErrInternal
+	}
+
+	if t.Expires, err = time.Parse(time.RFC3339Nano, expires); err != nil {
+		s.logger.Error(err, map[string]any{"details": "error while parsing refresh token expiry"})
+		return user.RefreshToken{}, common.ErrInternal
+	}
+
+	return t, nil
+}
+
+func (s *Sqlite) DeleteRefreshToken(userId string) error {
+	query := `
+    DELETE FROM "refreshTokens" WHERE "userId" = ?
+  `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+
+	if _, err := s.db.ExecContext(ctx, query, userId); err != nil {
+		s.logger.Error(err, map[string]any{"details": "error in DeleteRefreshToken query"})
+		return common.ErrInternal
+	}
+
+	return nil
+}
+
+func (s *Sqlite) SaveUserGuilds(userId string, guilds []user.UserGuild) (err error) {
+	query := `
+    INSERT INTO "userGuilds" ("userId", "guildId", "owner", "permissions")
+    VALUES (?, ?, ?, ?)
+  `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+
+	if err != nil {
+		s.logger.Error(err, map[string]any{"details": "transaction begin in SaveUserGuilds"})
+		return common.ErrInternal
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	if _, err = tx.ExecContext(ctx, `DELETE FROM "userGuilds" WHERE "userId" = ?`, userId); err != nil {
+		s.logger.Error(err, map[string]any{"details": "error while deleting from userGuilds"})
+		return common.ErrInternal
+	}
+
+	for _, g := range guilds {
+		_, err = tx.ExecContext(ctx, query, userId, g.GuildId, g.Owner, g.Permissions)
+
+		switch {
+		case errorCode(err) == sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY:
+			return common.ErrNotFound
+		case err != nil:
+			s.logger.Error(err, map[string]any{"details": "error while inserting to userGuilds"})
+			return common.ErrInternal
+		}
+	}
+
+	return nil
+}
+
+func (s *Sqlite) ReadUserGuild(userId, guildId string) (user.UserGuild, error) {
+	query := `
+    SELECT "userId", "guildId", "owner", "permissions" FROM "userGuilds" WHERE "userId" = ? AND "guildId" = ?
+  `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+
+	var g user.UserGuild
+	err := s.db.QueryRowContext(ctx, query, userId, guildId).Scan(&g.UserId, &g.GuildId, &g.Owner, &g.Permissions)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return user.UserGuild{}, common.ErrNotFound
+	case err != nil:
+		s.logger.Error(err, map[string]any{"details": "error in ReadUserGuild query"})
+		return user.UserGuild{}, common.ErrInternal
+	}
+
+	return g, nil
+}
+
+func (s *Sqlite) CreateGuild(gc guild.GuildCreate) (newGuild guild.Guild, err error) {
+	query := `
+    INSERT INTO "guilds" ("guildId", "ownerId", "name", "icon", "memberCount", "joinedAt")
+    VALUES (?, ?, ?, ?, ?, ?)
+    RETURNING ` + guildColumns + `
+  `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+
+	if err != nil {
+		s.logger.Error(err, map[string]any{"details": "transaction begin in CreateGuild"})
+		return guild.Guild{}, common.ErrInternal
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	// rules of a deleted guild are purged with it
+	if _, err = tx.ExecContext(ctx, `DELETE FROM "guilds" WHERE "guildId" = ? AND "deletedAt" IS NOT NULL`, gc.GuildId); err != nil {
+		s.logger.Error(err, map[string]any{"details": "error while purging deleted guild in CreateGuild"})
+		return guild.Guild{}, common.ErrInternal
+	}
+
+	newGuild, err = scanGuild(tx.QueryRowContext(ctx, query, gc.GuildId, gc.OwnerId, gc.Name, gc.Icon, gc.MemberCount, formatJoinedAt(gc.JoinedAt)))
+
+	switch {
+	case errorCode(err) == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY:
+		return guild.Guild{}, guild.ErrGuildConflict
+	case err != nil:
+		s.logger.Error(err, map[string]any{"details": "error in CreateGuild query"})
+		return guild.Guild{}, common.ErrInternal
+	}
+
+	return newGuild, nil
+}
+
+func (s *Sqlite) ReadGuild(guildId string) (guild.Guild, error) {
+	query := `
+    SELECT ` + guildColumns + ` FROM "guilds" WHERE "guildId" = ? AND "deletedAt" IS NULL
+  `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+
+	foundGuild, err := scanGuild(s.db.QueryRowContext(ctx, query, guildId))
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return guild.Guild{}, common.ErrNotFound
+	case err != nil:
+		s.logger.Error(err, map[string]any{"details": "error in ReadGuild query"})
+		return guild.Guild{}, common.ErrInternal
+	}
+
+	return foundGuild, nil
+}
+
+func (s *Sqlite) UpdateGuildExemptions(guildId string, exemptions guild.GuildExemptions) (guild.Guild, error) {
+	query := `
+    UPDATE "guilds" SET "exemptRoles" = ?, "exemptAdmins" = ?
+    WHERE "guildId" = ? AND "deletedAt" IS NULL
+    RETURNING ` + guildColumns + `
+  `
+
+	exemptRoles, err := encodeList(exemptions.ExemptRoles)
+
+	if err != nil {
+		return guild.Guild{}, common.ErrInternal
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+
+	updatedGuild, err := scanGuild(s.db.QueryRowContext(ctx, query, exemptRoles, *exemptions.ExemptAdmins, guildId))
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return guild.Guild{}, common.ErrNotFound
+	case err != nil:
+		s.logger.Error(err, map[string]any{"details": "error in UpdateGuildExemptions query"})
+		return guild.Guild{}, common.ErrInternal
+	}
+
+	return updatedGuild, nil
+}
+
+func (s *Sqlite) UpdateGuildModLog(guildId string, modLog guild.GuildModLog) (guild.Guild, error) {
+	query := `
+    UPDATE "guilds" SET "modLogChannelId" = ?
+    WHERE "guildId" = ? AND "deletedAt" IS NULL
+    RETURNING ` + guildColumns + `
+  `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+
+	updatedGuild, err := scanGuild(s.db.QueryRowContext(ctx, query, modLog.ChannelId, guildId))
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return guild.Guild{}, common.ErrNotFound
+	case err != nil:
+		s.logger.Error(err, map[string]any{"details": "error in UpdateGuildModLog query"})
+		return guild.Guild{}, common.ErrInternal
+	}
+
+	return updatedGuild, nil
+}
+
+func (s *Sqlite) UpdateGuild(guildId string, update guild.GuildUpdate) (guild.Guild, error) {
+	query := `
+    UPDATE "guilds" SET
+      "ownerId" = COALESCE(?, "ownerId"),
+      "name" = COALESCE(?, "name"),
+      "icon" = COALESCE(?, "icon"),
+      "memberCount" = COALESCE(?, "memberCount"),
+      "joinedAt" = COALESCE(?, "joinedAt")
+    WHERE "guildId" = ? AND "deletedAt" IS NULL
+    RETURNING ` + guildColumns + `
+  `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+
+	updatedGuild, err := scanGuild(s.db.QueryRowContext(ctx, query, update.OwnerId, update.Name, update.Icon, update.MemberCount, formatJoinedAt(update.JoinedAt), guildId))
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return guild.Guild{}, common.ErrNotFound
+	case err != nil:
+		s.logger.Error(err, map[string]any{"details": "error in UpdateGuild query"})
+		return guild.Guild{}, common.ErrInternal
+	}
+
+	return updatedGuild, nil
+}
+
+func (s *Sqlite) DeleteGuild(guildId string, deletedAt time.Time) error {
+	query := `
+    UPDATE "guilds" SET "deletedAt" = ?
+    WHERE "guildId" = ? AND "deletedAt" IS NULL
+  `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+
+	res, err := s.db.ExecContext(ctx, query, formatTimestamp(deletedAt), guildId)
+
+	if err != nil {
+		s.logger.Error(err, map[string]any{"details": "error in DeleteGuild query"})
+		return common.ErrInternal
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		s.logger.Error(err, map[string]any{"details": "error while reading affected rows in DeleteGuild"})
+		return common.ErrInternal
+	} else if n == 0 {
+		return common.ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *Sqlite) RestoreGuild(gc guild.GuildCreate, deletedAfter time.Time) (guild.Guild, error) {
+	query := `
+    UPDATE "guilds" SET "ownerId" = ?, "name" = ?, "icon" = ?, "memberCount" = ?, "joinedAt" = ?, "deletedAt" = NULL
+    WHERE "guildId" = ? AND "deletedAt" >= ?
+    RETURNING ` + guildColumns + `
+  `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+
+	restoredGuild, err := scanGuild(s.db.QueryRowContext(ctx, query, gc.OwnerId, gc.Name, gc.Icon, gc.MemberCount, formatJoinedAt(gc.JoinedAt), gc.GuildId, formatTimestamp(deletedAfter)))
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return guild.Guild{}, common.ErrNotFound
+	case err != nil:
+		s.logger.Error(err, map[string]any{"details": "error in RestoreGuild query"})
+		return guild.Guild{}, common.ErrInternal
+	}
+
+	return restoredGuild, nil
+}
+
+func (s *Sqlite) PurgeGuilds(deletedBefore time.Time) (int, error) {
+	query := `
+    DELETE FROM "guilds" WHERE "deletedAt" < ?
+  `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	res, err := s.db.ExecContext(ctx, query, formatTimestamp(deletedBefore))
+
+	if err != nil {
+		s.logger.Error(err, map[string]any{"details": "error in PurgeGuilds query"})
+		return 0, common.ErrInternal
+	}
+
+	n, err := res.RowsAffected()
+
+	if err != nil {
+		s.logger.Error(err, map[string]any{"details": "error while reading affected rows in PurgeGuilds"})
+		return 0, common.ErrInternal
+	}
+
+	return int(n), nil
+}
+
+func (s *Sqlite) CreateReactionRules(rules []rule.ReactionRule) (created []rule.ReactionRule, err error) {
+	query := `
+    INSERT INTO "reactionRules" ("emojiName", "emojiId", "isCustom", "guildId", "ruleAuthor", "actions", "includeChannels", "excludeChannels", "exemptRoles", "threshold", "match")
+    VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+  `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+
+	if err != nil {
+		s.logger.Error(err, map[string]any{"details": "transaction begin in CreateReactionRules"})
+		return []rule.ReactionRule{}, common.ErrInternal
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	created = make([]rule.ReactionRule, 0, len(rules))
+
+	for _, r := range rules {
+		// rules of deleted guilds satisfy the foreign key
+		var deleted bool
+		err = tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM "guilds" WHERE "guildId" = ? AND "deletedAt" IS NOT NULL)`, r.GuildId).Scan(&deleted)
+
+		if err != nil {
+			s.logger.Error(err, map[string]any{"details": "error while checking deleted guild in CreateReactionRules"})
+			return []rule.ReactionRule{}, common.ErrInternal
+		} else if deleted {
+			return []rule.ReactionRule{}, common.ErrNotFound
+		}
+
+		var values []any
+		values, err = reactionRuleValues(r)
+
+		if err != nil {
+			return []rule.ReactionRule{}, common.ErrInternal
+		}
+
+		_, err = tx.ExecContext(ctx, query, values...)
+
+		switch errorCode(err) {
+		case 0:
+		case sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY:
+			return []rule.ReactionRule{}, rule.ErrRuleReactionConflict
+		case sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY:
+			return []rule.ReactionRule{}, common.ErrNotFound
+		}
+
+		if err != nil {
+			s.logger.Error(err, map[string]any{"details": "error while inserting to reactionRules"})
+			return []rule.ReactionRule{}, common.ErrInternal
+		}
+
+		created = append(created, normalizeReactionRule(r))
+	}
+
+	return created, nil
+}
+
+func (s *Sqlite) DeleteReactionRules(rules []rule.DeleteReactionRuleQuery, gId string) (err error) {
+	query := `
+    DELETE FROM "reactionRules" WHERE "guildId" = ? AND "emojiId" = ? AND "emojiName" = ?
+    AND "guildId" IN (SELECT "guildId" FROM "guilds" WHERE "deletedAt" IS NULL)
+  `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+
+	if err != nil {
+		s.logger.Error(err, map[string]any{"details": "transaction begin in DeleteReactionRules"})
+		return common.ErrInternal
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	for _, r := range rules {
+		if _, err = tx.ExecContext(ctx, query, gId, r.EmojiId, r.EmojiName); err != nil {
+			s.logger.Error(err, map[string]any{"details": "error in DeleteReactionRules query"})
+			return common.ErrInternal
+		}
+	}
+
+	return nil
+}
+
+func (s *Sqlite) ReadReactionRules(gId string) ([]rule.ReactionRule, error) {
+	query := `
+    SELECT "emojiId", "emojiName", "isCustom", "guildId", "ruleAuthor", "actions", "includeChannels", "excludeChannels", "exemptRoles", "threshold", "match"
+    FROM "reactionRules" WHERE "guildId" = ?
+    AND "guildId" IN (SELECT "guildId" FROM "guilds" WHERE "deletedAt" IS NULL) ORDER BY rowid
+  `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(ctx, query, gId)
+
+	if err != nil {
+		s.logger.Error(err, map[string]any{"details": "error in ReadReactionRules query"})
+		return []rule.ReactionRule{}, common.ErrInternal
+	}
+	defer rows.Close()
+
+	var foundRules []rule.ReactionRule
+
+	for rows.Next() {
+		foundRule, err := scanReactionRule(rows)
+
+		if err != nil {
+			s.logger.Error(err, map[string]any{"details": "error while scanning rows in ReadReactionRules"})
+			return []rule.ReactionRule{}, common.ErrInternal
+		}
+
+		foundRules = append(foundRules, foundRule)
+	}
+
+	if err = rows.Err(); err != nil {
+		s.logger.Error(err, map[string]any{"details": "error while collecting rows in ReadReactionRules"})
+		return []rule.ReactionRule{}, common.ErrInternal
+	}
+
+	return foundRules, nil
+}
+
+func (s *Sqlite) UpdateReactionRules(rules []rule.ReactionRuleUpdate, gId string) (updatedRules []rule.ReactionRule, err error) {
+	query := `
+    UPDATE "reactionRules" SET "actions" = ?
+    WHERE "guildId" = ? AND "emojiId" = ? AND "emojiName" = ?
+    AND "guildId" IN (SELECT "guildId" FROM "guilds" WHERE "deletedAt" IS NULL)
+    RETURNING "emojiId", "emojiName", "isCustom", "guildId", "ruleAuthor", "actions", "includeChannels", "excludeChannels", "exemptRoles", "threshold", "match"
+  `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+
+	if err != nil {
+		s.logger.Error(err, map[string]any{"details": "transaction begin in UpdateReactionRules"})
+		return []rule.ReactionRule{}, common.ErrInternal
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	updatedRules = make([]rule.ReactionRule, 0, len(rules))
+
+	for _, r := range rules {
+		var actions []byte
+		actions, err = json.Marshal(r.Actions)
+
+		if err != nil {
+			return []rule.ReactionRule{}, common.ErrInternal
+		}
+
+		var updatedRule rule.ReactionRule
+		updatedRule, err = scanReactionRule(tx.QueryRowContext(ctx, query, string(actions), gId, r.EmojiId, r.EmojiName))
+
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return []rule.ReactionRule{}, common.ErrNotFound
+		case err != nil:
+			s.logger.Error(err, map[string]any{"details": "error in UpdateReactionRules query"})
+			return []rule.ReactionRule{}, common.ErrInternal
+		}
+
+		updatedRules = append(updatedRules, updatedRule)
+	}
+
+	return updatedRules, nil
+}
+
+func (s *Sqlite) ReadReactionRoleMessages(gId string) ([]rule.ReactionRoleMessage, error) {
+	query := `
+    SELECT m."messageId", m."channelId", m."mode", r."emojiName", r."emojiId", r."roleId"
+    FROM "reactionRoleMessages" m
+    LEFT JOIN "reactionRoles" r ON r."guildId" = m."guildId" AND r."messageId" = m."messageId"
+    WHERE m."guildId" = ? ORDER BY m."messageId", r."position"
+  `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+	rows, err := s.db.QueryContext(ctx, query, gId)
+
+	if err != nil {
+		s.logger.Error(err, map[string]any{"details": "error in ReadReactionRoleMessages query"})
+		return nil, common.ErrInternal
+	}
+
+	defer rows.Close()
+
+	messages := []rule.ReactionRoleMessage{}
+
+	for rows.Next() {
+		m := rule.ReactionRoleMessage{GuildId: gId}
+		var emojiName, emojiId, roleId sql.NullString
+
+		if err := rows.Scan(&m.MessageId, &m.ChannelId, &m.Mode, &emojiName, &emojiId, &roleId); err != nil {
+			s.logger.Error(err, map[string]any{"details": "error while scanning rows in ReadReactionRoleMessages"})
+			return nil, common.ErrInternal
+		}
+
+		if n := len(messages); n == 0 || messages[n-1].MessageId != m.MessageId {
+			m.Roles = []rule.ReactionRole{}
+			messages = append(messages, m)
+		}
+
+		// messages without roles have a row of nulls
+		if roleId.Valid {
+			last := &messages[len(messages)-1]
+			last.Roles = append(last.Roles, rule.ReactionRole{EmojiName: emojiName.String, EmojiId: emojiId.String, RoleId: roleId.String})
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		s.logger.Error(err, map[string]any{"details": "error while iterating rows in ReadReactionRoleMessages"})
+		return nil, common.ErrInternal
+	}
+
+	return messages, nil
+}
+
+func (s *Sqlite) SaveReactionRoleMessage(m rule.ReactionRoleMessage) (saved rule.ReactionRoleMessage, err error) {
+	query := `
+    INSERT INTO "reactionRoleMessages" ("guildId", "messageId", "channelId", "mode") VALUES (?, ?, ?, ?)
+    ON CONFLICT ("guildId", "messageId") DO UPDATE SET "channelId" = excluded."channelId", "mode" = excluded."mode"
+  `
+	roleQuery := `
+    INSERT INTO "reactionRoles" ("guildId", "messageId", "position", "emojiName", "emojiId", "roleId")
+    VALUES (?, ?, ?, ?, ?, ?)
+  `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+
+	if err != nil {
+		s.logger.Error(err, map[string]any{"details": "transaction begin in SaveReactionRoleMessage"})
+		return rule.ReactionRoleMessage{}, common.ErrInternal
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	var exists bool
+	err = tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM "guilds" WHERE "guildId" = ? AND "deletedAt" IS NULL)`, m.GuildId).Scan(&exists)
+
+	if err != nil {
+		s.logger.Error(err, map[string]any{"details": "error while checking guild in SaveReactionRoleMessage"})
+		return rule.ReactionRoleMessage{}, common.ErrInternal
+	} else if !exists {
+		return rule.ReactionRoleMessage{}, common.ErrNotFound
+	}
+
+	if _, err = tx.ExecContext(ctx, query, m.GuildId, m.MessageId, m.ChannelId, m.Mode); err != nil {
+		s.logger.Error(err, map[string]any{"details": "error while upserting to reactionRoleMessages"})
+		return rule.ReactionRoleMessage{}, common.ErrInternal
+	}
+
+	if _, err = tx.ExecContext(ctx, `DELETE FROM "reactionRoles" WHERE "guildId" = ? AND "messageId" = ?`, m.GuildId, m.MessageId); err != nil {
+		s.logger.Error(err, map[string]any{"details": "error while deleting from reactionRoles"})
+		return rule.ReactionRoleMessage{}, common.ErrInternal
+	}
+
+	for i, r := range m.Roles {
+		if _, err = tx.ExecContext(ctx, roleQuery, m.GuildId, m.MessageId, i, r.EmojiName, r.EmojiId, r.RoleId); err != nil {
+			s.logger.Error(err, map[string]any{"details": "error while inserting to reactionRoles"})
+			return rule.ReactionRoleMessage{}, common.ErrInternal
+		}
+	}
+
+	if m.Roles == nil {
+		m.Roles = []rule.ReactionRole{}
+	}
+
+	return m, nil
+}
+
+func (s *Sqlite) DeleteReactionRoleMessage(gId string, messageId string) error {
+	query := `
+    DELETE FROM "reactionRoleMessages" WHERE "guildId" = ? AND "messageId" = ?
+  `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+
+	res, err := s.db.ExecContext(ctx, query, gId, messageId)
+
+	if err != nil {
+		s.logger.Error(err, map[string]any{"details": "error in DeleteReactionRoleMessage query"})
+		return common.ErrInternal
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		s.logger.Error(err, map[string]any{"details": "error while reading affected rows in DeleteReactionRoleMessage"})
+		return common.ErrInternal
+	} else if n == 0 {
+		return common.ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *Sqlite) ReadReactionFloodRule(gId string) (rule.ReactionFloodRule, error) {
+	query := `
+    SELECT "guildId", "maxReactions", "windowSeconds", "actions" FROM "reactionFloodRules" WHERE "guildId" = ?
+  `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+
+	f, err := scanReactionFloodRule(s.db.QueryRowContext(ctx, query, gId))
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return rule.ReactionFloodRule{}, common.ErrNotFound
+	case err != nil:
+		s.logger.Error(err, map[string]any{"details": "error in ReadReactionFloodRule query"})
+		return rule.ReactionFloodRule{}, common.ErrInternal
+	}
+
+	return f, nil
+}
+
+func (s *Sqlite) SaveReactionFloodRule(f rule.ReactionFloodRule) (rule.ReactionFloodRule, error) {
+	// nothing is inserted for deleted guilds
+	query := `
+    INSERT INTO "reactionFloodRules" ("guildId", "maxReactions", "windowSeconds", "actions")
+    SELECT "guildId", ?, ?, ? FROM "guilds" WHERE "guildId" = ? AND "deletedAt" IS NULL
+    ON CONFLICT ("guildId") DO UPDATE SET
+      "maxReactions" = excluded."maxReactions", "windowSeconds" = excluded."windowSeconds", "actions" = excluded."actions"
+    RETURNING "guildId", "maxReactions", "windowSeconds", "actions"
+  `
+
+	actions, err := json.Marshal(f.Actions)
+
+	if err != nil {
+		return rule.ReactionFloodRule{}, common.ErrInternal
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+
+	saved, err := scanReactionFloodRule(s.db.QueryRowContext(ctx, query, f.MaxReactions, f.WindowSeconds, string(actions), f.GuildId))
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return rule.ReactionFloodRule{}, common.ErrNotFound
+	case err != nil:
+		s.logger.Error(err, map[string]any{"details": "error in SaveReactionFloodRule query"})
+		return rule.ReactionFloodRule{}, common.ErrInternal
+	}
+
+	return saved, nil
+}
+
+func (s *Sqlite) DeleteReactionFloodRule(gId string) error {
+	query := `
+    DELETE FROM "reactionFloodRules" WHERE "guildId" = ?
+  `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+
+	res, err := s.db.ExecContext(ctx, query, gId)
+
+	if err != nil {
+		s.logger.Error(err, map[string]any{"details": "error in DeleteReactionFloodRule query"})
+		return common.ErrInternal
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		s.logger.Error(err, map[string]any{"details": "error while getting affected rows in DeleteReactionFloodRule"})
+		return common.ErrInternal
+	} else if n == 0 {
+		return common.ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *Sqlite) CreateInfraction(i infraction.InfractionCreate) (infraction.Infraction, error) {
+	// nothing is inserted for deleted guilds
+	query := `
+    INSERT INTO "infractions" ("guildId", "userId", "moderatorId", "source", "action", "reason", "emojiName", "emojiId", "channelId", "messageId", "step", "createdAt")
+    SELECT "guildId", ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ? FROM "guilds" WHERE "guildId" = ? AND "deletedAt" IS NULL
+    RETURNING ` + infractionColumns + `
+  `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+
+	created, err := scanInfraction(s.db.QueryRowContext(ctx, query, i.UserId, i.ModeratorId, i.Source, i.Action, i.Reason, i.EmojiName, i.EmojiId, i.ChannelId, i.MessageId, i.Step, formatTimestamp(i.CreatedAt), i.GuildId))
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return infraction.Infraction{}, common.ErrNotFound
+	case err != nil:
+		s.logger.Error(err, map[string]any{"details": "error in CreateInfraction query"})
+		return infraction.Infraction{}, common.ErrInternal
+	}
+
+	return created, nil
+}
+
+func (s *Sqlite) ReadInfraction(gId string, id int64) (infraction.Infraction, error) {
+	query := `
+    SELECT ` + infractionColumns + ` FROM "infractions" WHERE "guildId" = ? AND "id" = ?
+  `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+
+	found, err := scanInfraction(s.db.QueryRowContext(ctx, query, gId, id))
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return infraction.Infraction{}, common.ErrNotFound
+	case err != nil:
+		s.logger.Error(err, map[string]any{"details": "error in ReadInfraction query"})
+		return infraction.Infraction{}, common.ErrInternal
+	}
+
+	return found, nil
+}
+
+func (s *Sqlite) ReadInfractions(q infraction.InfractionQuery) (found []infraction.Infraction, total int, err error) {
+	where := `WHERE "guildId" = ? AND (? = '' OR "userId" = ?)`
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+
+	if err = s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM "infractions" `+where, q.GuildId, q.UserId, q.UserId).Scan(&total); err != nil {
+		s.logger.Error(err, map[string]any{"details": "error in ReadInfractions count query"})
+		return []infraction.Infraction{}, 0, common.ErrInternal
+	}
+
+	query := `
+    SELECT ` + infractionColumns + ` FROM "infractions" ` + where + `
+    ORDER BY "createdAt" DESC, "id" DESC
+    LIMIT ? OFFSET ?
+  `
+
+	rows, err := s.db.QueryContext(ctx, query, q.GuildId, q.UserId, q.UserId, q.Limit, q.Offset)
+
+	if err != nil {
+		s.logger.Error(err, map[string]any{"details": "error in ReadInfractions query"})
+		return []infraction.Infraction{}, 0, common.ErrInternal
+	}
+
+	defer rows.Close()
+
+	found = make([]infraction.Infraction, 0, q.Limit)
+
+	for rows.Next() {
+		i, err := scanInfraction(rows)
+
+		if err != nil {
+			s.logger.Error(err, map[string]any{"details": "error while scanning rows in ReadInfractions"})
+			return []infraction.Infraction{}, 0, common.ErrInternal
+		}
+
+		found = append(found, i)
+	}
+
+	if err = rows.Err(); err != nil {
+		s.logger.Error(err, map[string]any{"details": "error while iterating rows in ReadInfractions"})
+		return []infraction.Infraction{}, 0, common.ErrInternal
+	}
+
+	return found, total, nil
+}
+
+func (s *Sqlite) UpdateInfraction(gId string, id int64, u infraction.InfractionUpdate) (infraction.Infraction, error) {
+	query := `
+    UPDATE "infractions" SET "reason" = ?
+    WHERE "guildId" = ? AND "id" = ?
+    RETURNING ` + infractionColumns + `
+  `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+
+	updated, err := scanInfraction(s.db.QueryRowContext(ctx, query, u.Reason, gId, id))
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return infraction.Infraction{}, common.ErrNotFound
+	case err != nil:
+		s.logger.Error(err, map[string]any{"details": "error in UpdateInfraction query"})
+		return infraction.Infraction{}, common.ErrInternal
+	}
+
+	return updated, nil
+}
+
+func (s *Sqlite) DeleteInfraction(gId string, id int64) error {
+	query := `
+    DELETE FROM "infractions" WHERE "guildId" = ? AND "id" = ?
+  `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+
+	res, err := s.db.ExecContext(ctx, query, gId, id)
+
+	if err != nil {
+		s.logger.Error(err, map[string]any{"details": "error in DeleteInfraction query"})
+		return common.ErrInternal
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		s.logger.Error(err, map[string]any{"details": "error while reading affected rows in DeleteInfraction"})
+		return common.ErrInternal
+	} else if n == 0 {
+		return common.ErrNotFound
+	}
+
+	return nil
+}
+
+// timestampLayout has fixed width, so "deletedAt" and "createdAt" values compare as strings in chronological order.
+const timestampLayout = "2006-01-02T15:04:05.000000000Z"
+
+func formatTimestamp(t time.Time) string {
+	return t.UTC().Format(timestampLayout)
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// guildColumns are the columns of guild.Guild in the order scanned by scanGuild, "deletedAt" is internal to the database.
+const guildColumns = `"guildId", "ownerId", "exemptRoles", "exemptAdmins", "name", "icon", "memberCount", "joinedAt", "modLogChannelId"`
+
+func scanGuild(row scanner) (guild.Guild, error) {
+	var g guild.Guild
+	var exemptRoles string
+	var joinedAt sql.NullString
+
+	if err := row.Scan(&g.GuildId, &g.OwnerId, &exemptRoles, &g.ExemptAdmins, &g.Name, &g.Icon, &g.MemberCount, &joinedAt, &g.ModLogChannelId); err != nil {
+		return guild.Guild{}, err
+	}
+
+	if err := json.Unmarshal([]byte(exemptRoles), &g.ExemptRoles); err != nil {
+		return guild.Guild{}, err
+	}
+
+	if joinedAt.Valid {
+		t, err := time.Parse(time.RFC3339Nano, joinedAt.String)
+
+		if err != nil {
+			return guild.Guild{}, err
+		}
+
+		g.JoinedAt = &t
+	}
+
+	return g, nil
+}
+
+// formatJoinedAt returns nil for nil t, so it's stored as NULL.
+func formatJoinedAt(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+func scanReactionRule(row scanner) (rule.ReactionRule, error) {
+	var r rule.ReactionRule
+	var actions, includeChannels, excludeChannels, exemptRoles string
+	var threshold sql.NullString
+
+	err := row.Scan(&r.EmojiId, &r.EmojiName, &r.IsCustom, &r.GuildId, &r.RuleAuthor, &actions, &includeChannels, &excludeChannels, &exemptRoles, &threshold, &r.Match)
+
+	if err != nil {
+		return rule.ReactionRule{}, err
+	}
+
+	if threshold.Valid {
+		if err = json.Unmarshal([]byte(threshold.String), &r.Threshold); err != nil {
+			return rule.ReactionRule{}, err
+		}
+	}
+
+	lists := []struct {
+		src string
+		dst any
+	}{
+		{actions, &r.Actions},
+		{includeChannels, &r.IncludeChannels},
+		{excludeChannels, &r.ExcludeChannels},
+		{exemptRoles, &r.ExemptRoles},
+	}
+
+	for _, l := range lists {
+		if err = json.Unmarshal([]byte(l.src), l.dst); err != nil {
+			return rule.ReactionRule{}, err
+		}
+	}
+
+	return r, nil
+}
+
+func scanReactionFloodRule(row scanner) (rule.ReactionFloodRule, error) {
+	var f rule.ReactionFloodRule
+	var actions string
+
+	if err := row.Scan(&f.GuildId, &f.MaxReactions, &f.WindowSeconds, &actions); err != nil {
+		return rule.ReactionFloodRule{}, err
+	}
+
+	if err := json.Unmarshal([]byte(actions), &f.Actions); err != nil {
+		return rule.ReactionFloodRule{}, err
+	}
+
+	return f, nil
+}
+
+func reactionRuleValues(r rule.ReactionRule) ([]any, error) {
+	actions, err := json.Marshal(r.Actions)
+
+	if err != nil {
+		return nil, err
+	}
+
+	values := []any{r.EmojiName, r.EmojiId, r.IsCustom, r.GuildId, r.RuleAuthor, string(actions)}
+
+	for _, l := range [][]string{r.IncludeChannels, r.ExcludeChannels, r.ExemptRoles} {
+		encoded, err := encodeList(l)
+
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, encoded)
+	}
+
+	var threshold sql.NullString
+
+	if r.Threshold != nil {
+		b, err := json.Marshal(r.Threshold)
+
+		if err != nil {
+			return nil, err
+		}
+
+		threshold = sql.NullString{String: string(b), Valid: true}
+	}
+
+	return append(values, threshold, r.Match), nil
+}
+
+// encodeList encodes l as JSON array. nil is encoded as empty array.
+func encodeList(l []string) (string, error) {
+	if l == nil {
+		l = []string{}
+	}
+
+	b, err := json.Marshal(l)
+
+	return string(b), err
+}
+
+// normalizeReactionRule replaces nil lists with empty ones like they are read from the table.
+func normalizeReactionRule(r rule.ReactionRule) rule.ReactionRule {
+	for _, l := range []*[]string{&r.IncludeChannels, &r.ExcludeChannels, &r.ExemptRoles} {
+		if *l == nil {
+			*l = []string{}
+		}
+	}
+
+	return r
+}
+
+// infractionColumns are the columns of infraction.Infraction in the order scanned by scanInfraction.
+const infractionColumns = `"id", "guildId", "userId", "moderatorId", "source", "action", "reason", "emojiName", "emojiId", "channelId", "messageId", "step", "createdAt"`
+
+func scanInfraction(row scanner) (infraction.Infraction, error) {
+	var i infraction.Infraction
+	var createdAt string
+
+	err := row.Scan(&i.Id, &i.GuildId, &i.UserId, &i.ModeratorId, &i.Source, &i.Action, &i.Reason, &i.EmojiName, &i.EmojiId, &i.ChannelId, &i.MessageId, &i.Step, &createdAt)
+
+	if err != nil {
+		return infraction.Infraction{}, err
+	}
+
+	if i.CreatedAt, err = time.Parse(timestampLayout, createdAt); err != nil {
+		return infraction.Infraction{}, err
+	}
+
+	return i, nil
+}
+
+func (s *Sqlite) ReadEscalationPolicy(gId string) (escalation.Policy, error) {
+	query := `
+    SELECT "warns", "windowSeconds", "action", "timeoutSeconds" FROM "escalationSteps"
+    WHERE "guildId" = ? ORDER BY "warns"
+  `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+	rows, err := s.db.QueryContext(ctx, query, gId)
+
+	if err != nil {
+		s.logger.Error(err, map[string]any{"details": "error in ReadEscalationPolicy query"})
+		return escalation.Policy{}, common.ErrInternal
+	}
+
+	defer rows.Close()
+
+	steps := []escalation.Step{}
+
+	for rows.Next() {
+		var step escalation.Step
+
+		if err := rows.Scan(&step.Warns, &step.WindowSeconds, &step.Action, &step.TimeoutSeconds); err != nil {
+			s.logger.Error(err, map[string]any{"details": "error while scanning rows in ReadEscalationPolicy"})
+			return escalation.Policy{}, common.ErrInternal
+		}
+
+		steps = append(steps, step)
+	}
+
+	if err := rows.Err(); err != nil {
+		s.logger.Error(err, map[string]any{"details": "error while iterating rows in ReadEscalationPolicy"})
+		return escalation.Policy{}, common.ErrInternal
+	}
+
+	return escalation.Policy{GuildId: gId, Steps: steps}, nil
+}
+
+func (s *Sqlite) UpdateEscalationPolicy(gId string, steps []escalation.Step) (policy escalation.Policy, err error) {
+	query := `
+    INSERT INTO "escalationSteps" ("guildId", "warns", "windowSeconds", "action", "timeoutSeconds")
+    VALUES (?, ?, ?, ?, ?)
+  `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+
+	if err != nil {
+		s.logger.Error(err, map[string]any{"details": "transaction begin in UpdateEscalationPolicy"})
+		return escalation.Policy{}, common.ErrInternal
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	var exists bool
+	err = tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM "guilds" WHERE "guildId" = ? AND "deletedAt" IS NULL)`, gId).Scan(&exists)
+
+	if err != nil {
+		s.logger.Error(err, map[string]any{"details": "error while checking guild in UpdateEscalationPolicy"})
+		return escalation.Policy{}, common.ErrInternal
+	} else if !exists {
+		return escalation.Policy{}, common.ErrNotFound
+	}
+
+	if _, err = tx.ExecContext(ctx, `DELETE FROM "escalationSteps" WHERE "guildId" = ?`, gId); err != nil {
+		s.logger.Error(err, map[string]any{"details": "error while deleting from escalationSteps"})
+		return escalation.Policy{}, common.ErrInternal
+	}
+
+	for _, step := range steps {
+		if _, err = tx.ExecContext(ctx, query, gId, step.Warns, step.WindowSeconds, step.Action, step.TimeoutSeconds); err != nil {
+			s.logger.Error(err, map[string]any{"details": "error while inserting to escalationSteps"})
+			return escalation.Policy{}, common.ErrInternal
+		}
+	}
+
+	if steps == nil {
+		steps = []escalation.Step{}
+	}
+
+	return escalation.Policy{GuildId: gId, Steps: steps}, nil
+}
@@ -0,0 +1,61 @@
+package sqlite
+
+import (
+	"path/filepath"
+	"testing"
+
+	mogs "github.com/finkabaj/hyde-bot/internals/backend/mocks"
+	"github.com/finkabaj/hyde-bot/internals/db"
+	"github.com/finkabaj/hyde-bot/internals/db/dbtest"
+)
+
+func TestSqlite(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T) db.Database {
+		s := NewSqlite(mogs.NewMockLogger())
+
+		if err := s.Connect(db.DatabaseCredentials{Database: filepath.Join(t.TempDir(), "hyde.db")}); err != nil {
+			t.Fatal(err)
+		}
+
+		t.Cleanup(s.Close)
+
+		if err := s.MigrateUp(); err != nil {
+			t.Fatal(err)
+		}
+
+		return s
+	})
+}
+
+func TestSqliteMigrateDown(t *testing.T) {
+	s := NewSqlite(mogs.NewMockLogger())
+
+	if err := s.Connect(db.DatabaseCredentials{Database: filepath.Join(t.TempDir(), "hyde.db")}); err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	if err := s.MigrateUp(); err != nil {
+		t.Fatal(err)
+	}
+
+	status, err := s.MigrationStatus()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.MigrateDown(len(status)); err != nil {
+		t.Fatal(err)
+	}
+
+	if status, err = s.MigrationStatus(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, m := range status {
+		if m.Applied {
+			t.Errorf("migration %d_%s is still applied", m.Version, m.Name)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+// Package escalation takes the steps of guild escalation policies when members accumulate warnings.
+package escalation
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/finkabaj/hyde-bot/internals/actions"
+	"github.com/finkabaj/hyde-bot/internals/logger"
+	"github.com/finkabaj/hyde-bot/internals/modlog"
+	"github.com/finkabaj/hyde-bot/internals/rules"
+	escalationUtils "github.com/finkabaj/hyde-bot/internals/utils/escalation"
+	"github.com/finkabaj/hyde-bot/internals/utils/infraction"
+)
+
+type memberLock struct {
+	sync.Mutex
+	refs int
+}
+
+// Evaluator takes the escalation step reached by a member after a warning. Each step is taken
+// at most once within its window. Evaluations of the same member run one at a time, so concurrent
+// warnings don't take the same step twice.
+type Evaluator struct {
+	rm       *rules.RuleManager
+	executor *actions.Executor
+	modLog   *modlog.Publisher
+	members  map[string]*memberLock // members[guildID+":"+userID]
+	taken    map[string]time.Time   // taken[guildID+":"+userID+":"+step.Key()] is when the taken step expires
+	lock     sync.Mutex
+}
+
+var evaluator *Evaluator
+
+func NewEvaluator(rm *rules.RuleManager, executor *actions.Executor, modLog *modlog.Publisher) *Evaluator {
+	if evaluator == nil {
+		evaluator = &Evaluator{
+			rm:       rm,
+			executor: executor,
+			modLog:   modLog,
+			members:  make(map[string]*memberLock),
+			taken:    make(map[string]time.Time),
+		}
+	}
+	return evaluator
+}
+
+// Evaluate takes the step reached by warnings of the member of t and records it in the ledger.
+// It must be called after the warning is recorded. Returns the taken step, false if no step is taken.
+func (e *Evaluator) Evaluate(s *discordgo.Session, t actions.Target) (escalationUtils.Step, bool) {
+	unlock := e.lockMember(t.GuildID, t.UserID)
+	defer unlock()
+
+	policy, err := e.rm.FetchEscalationPolicy(t.GuildID)
+
+	if err != nil {
+		logger.Error(err, map[string]any{"details": "failed to get escalation policy", "guildId": t.GuildID, "userId": t.UserID})
+		return escalationUtils.Step{}, false
+	}
+
+	if len(policy.Steps) == 0 {
+		return escalationUtils.Step{}, false
+	}
+
+	now := time.Now()
+	infractions, err := fetchInfractions(e.rm.FetchInfractions, t.GuildID, t.UserID, now.Add(-maxWindow(policy.Steps)))
+
+	if err != nil {
+		logger.Error(err, map[string]any{"details": "failed to get infractions for escalation", "guildId": t.GuildID, "userId": t.UserID})
+		return escalationUtils.Step{}, false
+	}
+
+	step, ok := reachedStep(policy.Steps, infractions, now)
+
+	if !ok || e.isTaken(t, step, now) {
+		return escalationUtils.Step{}, false
+	}
+
+	if err := e.executor.Escalate(s, t, step); err != nil {
+		logger.Error(err, map[string]any{"details": "failed to take escalation step", "guildId": t.GuildID, "userId": t.UserID, "warns": step.Warns, "action": step.Action})
+		return escalationUtils.Step{}, false
+	}
+
+	e.setTaken(t, step, now)
+
+	err = e.rm.RecordInfraction(infraction.InfractionCreate{
+		GuildId:   t.GuildID,
+		UserId:    t.UserID,
+		Source:    infraction.SourceEscalation,
+		Action:    step.Action,
+		Reason:    step.Reason(),
+		Step:      step.Key(),
+		ChannelId: t.ChannelID,
+		MessageId: t.MessageID,
+	})
+
+	if err != nil && !errors.Is(err, rules.ErrQueued) {
+		logger.Error(err, map[string]any{"details": "failed to record escalation", "guildId": t.GuildID, "userId": t.UserID, "warns": step.Warns})
+	}
+
+	e.modLog.Publish(t.GuildID, modlog.EscalationEmbed(t, step))
+
+	logger.Info("Escalation step taken", map[string]any{"guildId": t.GuildID, "userId": t.UserID, "warns": step.Warns, "action": step.Action})
+
+	return step, true
+}
+
+// fetchInfractions returns infractions of the member newest first, at least those created after since.
+// The ledger is read page by page, so members with many infractions are counted in full.
+func fetchInfractions(fetch func(infraction.InfractionQuery) (infraction.InfractionPage, error),
+	guildID, userID string, since time.Time) ([]infraction.Infraction, error) {
+	var infractions []infraction.Infraction
+
+	for {
+		page, err := fetch(infraction.InfractionQuery{
+			GuildId: guildID,
+			UserId:  userID,
+			Limit:   infraction.MaxPageLimit,
+			Offset:  len(infractions),
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		infractions = append(infractions, page.Infractions...)
+
+		if len(page.Infractions) < infraction.MaxPageLimit || len(infractions) >= page.Total ||
+			page.Infractions[len(page.Infractions)-1].CreatedAt.Before(since) {
+			return infractions, nil
+		}
+	}
+}
+
+// maxWindow returns the longest window of the steps.
+func maxWindow(steps []escalationUtils.Step) time.Duration {
+	var window time.Duration
+
+	for _, step := range steps {
+		window = max(window, step.Window())
+	}
+
+	return window
+}
+
+// reachedStep returns the step with the most warns that the member reached within its window.
+// Steps already recorded in the ledger within their window are not reached again.
+// Steps must be sorted by warns, infractions are the newest infractions of the member.
+func reachedStep(steps []escalationUtils.Step, infractions []infraction.Infraction, now time.Time) (escalationUtils.Step, bool) {
+	for i := len(steps) - 1; i >= 0; i-- {
+		step := steps[i]
+		since := now.Add(-step.Window())
+		warns := 0
+		taken := false
+
+		for _, inf := range infractions {
+			if inf.CreatedAt.Before(since) {
+				continue
+			}
+
+			switch {
+			case inf.Action == infraction.Warn:
+				warns++
+			case inf.Source == infraction.SourceEscalation && inf.Step == step.Key():
+				taken = true
+			case inf.Source == infraction.SourceEscalation && inf.Step == "" && inf.Reason == step.Reason():
+				// recorded before steps were keyed
+				taken = true
+			}
+		}
+
+		if warns < step.Warns {
+			continue
+		}
+
+		if taken {
+			return escalationUtils.Step{}, false
+		}
+
+		return step, true
+	}
+
+	return escalationUtils.Step{}, false
+}
+
+// isTaken reports whether the step was taken within its window. It covers steps which are
+// not in the ledger yet, because recording them was queued.
+func (e *Evaluator) isTaken(t actions.Target, step escalationUtils.Step, now time.Time) bool {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	expires, ok := e.taken[stepKey(t, step)]
+
+	return ok && now.Before(expires)
+}
+
+func (e *Evaluator) setTaken(t actions.Target, step escalationUtils.Step, now time.Time) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	for k, expires := range e.taken {
+		if !now.Before(expires) {
+			delete(e.taken, k)
+		}
+	}
+
+	e.taken[stepKey(t, step)] = now.Add(step.Window())
+}
+
+// lockMember locks evaluations of the member and returns the unlock function.
+func (e *Evaluator) lockMember(guildID, userID string) func() {
+	k := guildID + ":" + userID
+
+	e.lock.Lock()
+	l, ok := e.members[k]
+
+	if !ok {
+		l = &memberLock{}
+		e.members[k] = l
+	}
+
+	l.refs++
+	e.lock.Unlock()
+
+	l.Lock()
+
+	return func() {
+		l.Unlock()
+
+		e.lock.Lock()
+		defer e.lock.Unlock()
+
+		l.refs--
+
+		if l.refs == 0 {
+			delete(e.members, k)
+		}
+	}
+}
+
+func stepKey(t actions.Target, step escalationUtils.Step) string {
+	return t.GuildID + ":" + t.UserID + ":" + step.Key()
+}
@@ -0,0 +1,193 @@
+package escalation
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/finkabaj/hyde-bot/internals/actions"
+	escalationUtils "github.com/finkabaj/hyde-bot/internals/utils/escalation"
+	"github.com/finkabaj/hyde-bot/internals/utils/infraction"
+	"github.com/stretchr/testify/assert"
+)
+
+var testSteps = []escalationUtils.Step{
+	{Warns: 3, WindowSeconds: 7 * 24 * 3600, Action: infraction.Timeout, TimeoutSeconds: 3600},
+	{Warns: 5, WindowSeconds: 7 * 24 * 3600, Action: infraction.Kick},
+}
+
+func TestReachedStep(t *testing.T) {
+	t.Run("NotReached", testReachedStepNotReached)
+	t.Run("Highest", testReachedStepHighest)
+	t.Run("OutsideWindow", testReachedStepOutsideWindow)
+	t.Run("AlreadyTaken", testReachedStepAlreadyTaken)
+	t.Run("LegacyTaken", testReachedStepLegacyTaken)
+}
+
+func TestFetchInfractions(t *testing.T) {
+	t.Run("Pages", testFetchInfractionsPages)
+	t.Run("StopsAtWindow", testFetchInfractionsStopsAtWindow)
+}
+
+func TestEvaluatorLocks(t *testing.T) {
+	t.Run("Taken", testEvaluatorTaken)
+	t.Run("MemberLock", testEvaluatorMemberLock)
+}
+
+func warns(n int, createdAt time.Time) []infraction.Infraction {
+	infractions := make([]infraction.Infraction, 0, n)
+
+	for range n {
+		infractions = append(infractions, infraction.Infraction{Action: infraction.Warn, Source: infraction.SourceRule, CreatedAt: createdAt})
+	}
+
+	return infractions
+}
+
+func testReachedStepNotReached(t *testing.T) {
+	now := time.Now()
+
+	_, ok := reachedStep(testSteps, warns(2, now), now)
+
+	assert.False(t, ok)
+}
+
+func testReachedStepHighest(t *testing.T) {
+	now := time.Now()
+
+	step, ok := reachedStep(testSteps, warns(6, now), now)
+
+	assert.True(t, ok)
+	assert.Equal(t, testSteps[1], step)
+}
+
+func testReachedStepOutsideWindow(t *testing.T) {
+	now := time.Now()
+	infractions := append(warns(2, now), warns(3, now.Add(-8*24*time.Hour))...)
+
+	_, ok := reachedStep(testSteps, infractions, now)
+
+	assert.False(t, ok, "warnings older than the window don't count")
+}
+
+func testReachedStepAlreadyTaken(t *testing.T) {
+	now := time.Now()
+	infractions := append(warns(4, now), infraction.Infraction{
+		Action:    infraction.Timeout,
+		Source:    infraction.SourceEscalation,
+		Reason:    "edited by a moderator",
+		Step:      testSteps[0].Key(),
+		CreatedAt: now,
+	})
+
+	_, ok := reachedStep(testSteps, infractions, now)
+
+	assert.False(t, ok, "step taken within its window isn't taken again")
+
+	infractions = append(infractions, warns(1, now)...)
+	step, ok := reachedStep(testSteps, infractions, now)
+
+	assert.True(t, ok)
+	assert.Equal(t, testSteps[1], step)
+}
+
+func testReachedStepLegacyTaken(t *testing.T) {
+	now := time.Now()
+	infractions := append(warns(3, now), infraction.Infraction{
+		Action:    infraction.Timeout,
+		Source:    infraction.SourceEscalation,
+		Reason:    testSteps[0].Reason(),
+		CreatedAt: now,
+	})
+
+	_, ok := reachedStep(testSteps, infractions, now)
+
+	assert.False(t, ok, "infractions recorded before steps were keyed are matched by reason")
+}
+
+// ledger returns a fetch function serving infractions page by page and counts the requests.
+func ledger(infractions []infraction.Infraction, requests *int) func(infraction.InfractionQuery) (infraction.InfractionPage, error) {
+	return func(q infraction.InfractionQuery) (infraction.InfractionPage, error) {
+		*requests++
+		end := min(q.Offset+q.Limit, len(infractions))
+
+		return infraction.InfractionPage{Infractions: infractions[q.Offset:end], Total: len(infractions)}, nil
+	}
+}
+
+func testFetchInfractionsPages(t *testing.T) {
+	now := time.Now()
+	requests := 0
+
+	found, err := fetchInfractions(ledger(warns(250, now), &requests), "guild", "user", now.Add(-time.Hour))
+
+	assert.NoError(t, err)
+	assert.Len(t, found, 250)
+	assert.Equal(t, 3, requests)
+
+	step, ok := reachedStep([]escalationUtils.Step{{Warns: 200, WindowSeconds: 3600, Action: infraction.Ban}}, found, now)
+
+	assert.True(t, ok, "warnings beyond the first page are counted")
+	assert.Equal(t, 200, step.Warns)
+}
+
+func testFetchInfractionsStopsAtWindow(t *testing.T) {
+	now := time.Now()
+	requests := 0
+	infractions := append(warns(infraction.MaxPageLimit, now), warns(2*infraction.MaxPageLimit, now.Add(-2*time.Hour))...)
+
+	found, err := fetchInfractions(ledger(infractions, &requests), "guild", "user", now.Add(-time.Hour))
+
+	assert.NoError(t, err)
+	assert.Len(t, found, 2*infraction.MaxPageLimit)
+	assert.Equal(t, 2, requests, "pages older than the window aren't read")
+}
+
+func testEvaluatorTaken(t *testing.T) {
+	e := &Evaluator{members: make(map[string]*memberLock), taken: make(map[string]time.Time)}
+	target := actions.Target{GuildID: "guild", UserID: "user"}
+	now := time.Now()
+
+	assert.False(t, e.isTaken(target, testSteps[0], now))
+
+	e.setTaken(target, testSteps[0], now)
+
+	assert.True(t, e.isTaken(target, testSteps[0], now))
+	assert.False(t, e.isTaken(target, testSteps[1], now))
+	assert.False(t, e.isTaken(target, testSteps[0], now.Add(testSteps[0].Window())), "taken step expires after its window")
+}
+
+func testEvaluatorMemberLock(t *testing.T) {
+	e := &Evaluator{members: make(map[string]*memberLock), taken: make(map[string]time.Time)}
+	running := 0
+	maxRunning := 0
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+
+	for range 10 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			unlock := e.lockMember("guild", "user")
+			defer unlock()
+
+			lock.Lock()
+			running++
+			maxRunning = max(maxRunning, running)
+			lock.Unlock()
+
+			time.Sleep(time.Millisecond)
+
+			lock.Lock()
+			running--
+			lock.Unlock()
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, 1, maxRunning, "evaluations of a member run one at a time")
+	assert.Empty(t, e.members, "locks are removed when unused")
+}
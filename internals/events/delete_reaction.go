@@ -5,13 +5,20 @@ import (
 	"slices"
 
 	"github.com/bwmarrin/discordgo"
-	"github.com/enescakir/emoji"
+	"github.com/finkabaj/hyde-bot/internals/actions"
+	"github.com/finkabaj/hyde-bot/internals/escalation"
+	"github.com/finkabaj/hyde-bot/internals/flood"
 	"github.com/finkabaj/hyde-bot/internals/logger"
+	"github.com/finkabaj/hyde-bot/internals/members"
+	"github.com/finkabaj/hyde-bot/internals/modlog"
 	"github.com/finkabaj/hyde-bot/internals/rules"
+	"github.com/finkabaj/hyde-bot/internals/threshold"
+	"github.com/finkabaj/hyde-bot/internals/utils/infraction"
 	"github.com/finkabaj/hyde-bot/internals/utils/rule"
 )
 
-func HandleDeleteReaction(rm *rules.RuleManager) EventHandler {
+func HandleDeleteReaction(rm *rules.RuleManager, executor *actions.Executor, evaluator *escalation.Evaluator, mc *members.Cache,
+	counter *threshold.Counter, limiter *flood.Limiter, modLog *modlog.Publisher) EventHandler {
 	return func(s *discordgo.Session, event any) {
 		typedEvent, ok := event.(*discordgo.MessageReactionAdd)
 
@@ -20,49 +27,202 @@ func HandleDeleteReaction(rm *rules.RuleManager) EventHandler {
 			return
 		}
 
-		reactionRules, err := rm.GetReactionRules(typedEvent.GuildID, false)
+		if s.State.User != nil && typedEvent.UserID == s.State.User.ID {
+			return
+		}
+
+		guildRules, err := rm.GetRules(typedEvent.GuildID, false)
 
-		if err != nil && !errors.Is(err, rules.ErrRulesNotFound) {
+		if err != nil {
 			logger.Debug("Failed to get reaction rules:" + err.Error())
 			return
-		} else if errors.Is(err, rules.ErrRulesNotFound) {
+		}
+
+		// every reaction counts towards the flood limit, including reaction roles
+		if limitReactionFlood(s, rm, executor, evaluator, mc, limiter, modLog, guildRules, typedEvent) {
 			return
 		}
 
-		if slices.ContainsFunc(reactionRules, func(rule rule.ReactionRule) bool {
-			return rule.EmojiId != "" && typedEvent.Emoji.ID != "" && rule.EmojiId == typedEvent.Emoji.ID
-		}) {
-			e := ""
-			if typedEvent.Emoji.ID != "" {
-				e = typedEvent.Emoji.Name + ":" + typedEvent.Emoji.ID
-			} else {
-				e = typedEvent.Emoji.Name
-			}
+		if grantReactionRole(s, rm, mc, typedEvent) {
+			return
+		}
 
-			err = s.MessageReactionsRemoveEmoji(typedEvent.ChannelID, typedEvent.MessageID, e)
+		if !guildRules.HaveReactionRules {
+			return
+		}
 
-			if err != nil {
-				logger.Error(err, map[string]any{"emojiName": typedEvent.Emoji.Name, "emojiId": typedEvent})
-			}
+		r, ok := findReactionRule(guildRules.ReactionRules, typedEvent.Emoji)
 
+		if !ok {
 			return
 		}
 
-		if slices.ContainsFunc(reactionRules, func(rule rule.ReactionRule) bool {
-			return rule.EmojiName != "" && typedEvent.Emoji.Name != "" && emoji.Parse(rule.EmojiName) == typedEvent.Emoji.Name
-		}) {
-			e := ""
-			if typedEvent.Emoji.ID != "" {
-				e = typedEvent.Emoji.Name + ":" + typedEvent.Emoji.ID
-			} else {
-				e = typedEvent.Emoji.Name
+		// the channel may have to be fetched, rules enforced everywhere don't need the parent
+		if len(r.IncludeChannels) > 0 || len(r.ExcludeChannels) > 0 {
+			if !r.AppliesToChannel(typedEvent.ChannelID, threadParentID(s, typedEvent.ChannelID)) {
+				return
 			}
+		}
 
-			err = s.MessageReactionsRemoveEmoji(typedEvent.ChannelID, typedEvent.MessageID, e)
+		if r.Threshold != nil {
+			countThresholdReaction(s, rm, executor, evaluator, mc, counter, modLog, guildRules, r, typedEvent)
+			return
+		}
 
-			if err != nil {
-				logger.Error(err, map[string]any{"emojiName": typedEvent.Emoji.Name, "emojiId": typedEvent.Emoji.ID})
-			}
+		target := actions.Target{
+			GuildID:   typedEvent.GuildID,
+			ChannelID: typedEvent.ChannelID,
+			MessageID: typedEvent.MessageID,
+			UserID:    typedEvent.UserID,
+			Emoji:     typedEvent.Emoji,
+			Member:    typedEvent.Member,
+		}
+
+		if isExempt(s, mc, guildRules, r, target) {
+			return
+		}
+
+		results := executor.Execute(s, r, target)
+		warned := recordResults(rm, results, target, "Reacted with "+actions.EmojiMention(typedEvent.Emoji))
+
+		modLog.Publish(typedEvent.GuildID, modlog.EnforcementEmbed(target, results))
+
+		if warned {
+			evaluator.Evaluate(s, target)
 		}
 	}
 }
+
+// infractionActions are the reaction rule actions recorded in the infraction ledger.
+var infractionActions = map[rule.ReactAction]infraction.Action{
+	rule.Warn:    infraction.Warn,
+	rule.Kick:    infraction.Kick,
+	rule.Ban:     infraction.Ban,
+	rule.Timeout: infraction.Timeout,
+}
+
+// findReactionRule returns the rule of the emoji. Custom emojis are matched by id first, then rules are matched by name.
+// Rules of exact emojis take precedence over base emoji, pattern and category rules, in that order.
+func findReactionRule(reactionRules []rule.ReactionRule, e discordgo.Emoji) (rule.ReactionRule, bool) {
+	// custom emojis are matched by id before any rule is matched by name
+	i := slices.IndexFunc(reactionRules, func(r rule.ReactionRule) bool {
+		return r.Match == rule.MatchExact && r.EmojiId != "" && e.ID != "" && r.Matches(e.Name, e.ID)
+	})
+
+	for _, kind := range []rule.MatchKind{rule.MatchExact, rule.MatchBase, rule.MatchPattern, rule.MatchCategory} {
+		if i != -1 {
+			break
+		}
+
+		i = slices.IndexFunc(reactionRules, func(r rule.ReactionRule) bool {
+			return r.Match == kind && r.Matches(e.Name, e.ID)
+		})
+	}
+
+	if i == -1 {
+		return rule.ReactionRule{}, false
+	}
+
+	return reactionRules[i], true
+}
+
+// recordResults logs failed actions and records the successful ones in the infraction ledger.
+// Returns true if the member of t was warned.
+func recordResults(rm *rules.RuleManager, results []actions.Result, t actions.Target, reason string) bool {
+	warned := false
+
+	for _, res := range results {
+		if res.Err != nil {
+			logger.Error(res.Err, map[string]any{
+				"details":   "failed to execute reaction rule action",
+				"action":    res.Action.Type.String(),
+				"guildId":   t.GuildID,
+				"userId":    t.UserID,
+				"emojiName": t.Emoji.Name,
+				"emojiId":   t.Emoji.ID,
+			})
+			continue
+		}
+
+		recordInfraction(rm, res.Action.Type, t, reason)
+		warned = warned || res.Action.Type == rule.Warn
+	}
+
+	return warned
+}
+
+// recordInfraction adds the action taken on the member of t to the ledger.
+func recordInfraction(rm *rules.RuleManager, a rule.ReactAction, t actions.Target, reason string) {
+	action, ok := infractionActions[a]
+
+	if !ok {
+		return
+	}
+
+	err := rm.RecordInfraction(infraction.InfractionCreate{
+		GuildId:   t.GuildID,
+		UserId:    t.UserID,
+		Source:    infraction.SourceRule,
+		Action:    action,
+		Reason:    reason,
+		EmojiName: t.Emoji.Name,
+		EmojiId:   t.Emoji.ID,
+		ChannelId: t.ChannelID,
+		MessageId: t.MessageID,
+	})
+
+	if err != nil && !errors.Is(err, rules.ErrQueued) {
+		logger.Error(err, map[string]any{"details": "failed to record infraction", "action": a.String(), "guildId": t.GuildID, "userId": t.UserID})
+	}
+}
+
+// threadParentID returns parent channel id if the channel is a thread. Otherwise returns empty string.
+func threadParentID(s *discordgo.Session, channelID string) string {
+	c, err := s.State.Channel(channelID)
+
+	if err != nil {
+		c, err = s.Channel(channelID)
+	}
+
+	if err != nil {
+		logger.Debug("Failed to get channel: "+err.Error(), map[string]any{"channelId": channelID})
+		return ""
+	}
+
+	if !c.IsThread() {
+		return ""
+	}
+
+	return c.ParentID
+}
+
+// isExempt reports whether the member of t is exempt from the rule.
+// If roles of the member can't be resolved, only guild wide privileges are checked.
+func isExempt(s *discordgo.Session, mc *members.Cache, guildRules rules.Rules, r rule.ReactionRule, t actions.Target) bool {
+	var roles []string
+
+	if t.Member != nil {
+		roles = t.Member.Roles
+		mc.Set(t.GuildID, t.UserID, roles)
+	} else {
+		var err error
+		roles, err = mc.Roles(s, t.GuildID, t.UserID)
+
+		if err != nil {
+			logger.Error(err, map[string]any{"details": "failed to get member roles", "guildId": t.GuildID, "userId": t.UserID})
+		}
+	}
+
+	privileged := false
+
+	if guildRules.ExemptAdmins {
+		var err error
+		privileged, err = actions.IsPrivileged(s, t.GuildID, t.UserID, roles)
+
+		if err != nil {
+			logger.Error(err, map[string]any{"details": "failed to check member privileges", "guildId": t.GuildID, "userId": t.UserID})
+		}
+	}
+
+	return guildRules.IsExempt(r, roles, privileged)
+}
@@ -0,0 +1,79 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/finkabaj/hyde-bot/internals/utils/rule"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindReactionRule(t *testing.T) {
+	t.Run("ExactBeforeBase", testFindReactionRuleExactBeforeBase)
+	t.Run("BaseBeforeCategory", testFindReactionRuleBaseBeforeCategory)
+	t.Run("ExactBeforePattern", testFindReactionRuleExactBeforePattern)
+	t.Run("PatternBeforeCategory", testFindReactionRulePatternBeforeCategory)
+	t.Run("ExactAlias", testFindReactionRuleExactAlias)
+	t.Run("NotFound", testFindReactionRuleNotFound)
+}
+
+var (
+	exactRule    = rule.ReactionRule{EmojiName: "\U0001f44d"}
+	baseRule     = rule.ReactionRule{EmojiName: "\U0001f44d", Match: rule.MatchBase}
+	categoryRule = rule.ReactionRule{EmojiName: "people-body", Match: rule.MatchCategory}
+	customRule   = rule.ReactionRule{EmojiName: "pepe", EmojiId: "1", IsCustom: true}
+	patternRule  = rule.ReactionRule{EmojiName: "^pepe", Match: rule.MatchPattern}
+)
+
+func testFindReactionRuleExactBeforeBase(t *testing.T) {
+	r, ok := findReactionRule([]rule.ReactionRule{categoryRule, baseRule, exactRule}, discordgo.Emoji{Name: "\U0001f44d"})
+
+	assert.True(t, ok)
+	assert.Equal(t, rule.MatchExact, r.Match)
+}
+
+func testFindReactionRuleBaseBeforeCategory(t *testing.T) {
+	r, ok := findReactionRule([]rule.ReactionRule{categoryRule, baseRule, exactRule}, discordgo.Emoji{Name: "\U0001f44d\U0001f3fd"})
+
+	assert.True(t, ok)
+	assert.Equal(t, rule.MatchBase, r.Match)
+
+	r, ok = findReactionRule([]rule.ReactionRule{categoryRule, exactRule}, discordgo.Emoji{Name: "\U0001f44d\U0001f3fd"})
+
+	assert.True(t, ok)
+	assert.Equal(t, rule.MatchCategory, r.Match)
+}
+
+func testFindReactionRuleExactBeforePattern(t *testing.T) {
+	r, ok := findReactionRule([]rule.ReactionRule{patternRule, customRule}, discordgo.Emoji{Name: "pepe", ID: "1"})
+
+	assert.True(t, ok)
+	assert.Equal(t, customRule, r)
+
+	r, ok = findReactionRule([]rule.ReactionRule{patternRule, customRule}, discordgo.Emoji{Name: "pepehands", ID: "2"})
+
+	assert.True(t, ok)
+	assert.Equal(t, rule.MatchPattern, r.Match)
+}
+
+func testFindReactionRulePatternBeforeCategory(t *testing.T) {
+	r, ok := findReactionRule([]rule.ReactionRule{categoryRule, patternRule}, discordgo.Emoji{Name: "pepe", ID: "2"})
+
+	assert.True(t, ok)
+	assert.Equal(t, rule.MatchPattern, r.Match)
+}
+
+func testFindReactionRuleExactAlias(t *testing.T) {
+	alias := rule.ReactionRule{EmojiName: ":thumbsup:"}
+
+	r, ok := findReactionRule([]rule.ReactionRule{baseRule, alias}, discordgo.Emoji{Name: "\U0001f44d"})
+
+	assert.True(t, ok)
+	assert.Equal(t, alias, r)
+}
+
+func testFindReactionRuleNotFound(t *testing.T) {
+	_, ok := findReactionRule([]rule.ReactionRule{exactRule, customRule, patternRule}, discordgo.Emoji{Name: "\U0001f600"})
+
+	assert.False(t, ok)
+}
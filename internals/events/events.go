@@ -1,13 +1,19 @@
 package events
 
 import (
-	"net/http"
 	"os"
 	"reflect"
+	"strings"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/finkabaj/hyde-bot/internals/actions"
 	"github.com/finkabaj/hyde-bot/internals/commands"
+	"github.com/finkabaj/hyde-bot/internals/escalation"
+	"github.com/finkabaj/hyde-bot/internals/flood"
+	"github.com/finkabaj/hyde-bot/internals/members"
+	"github.com/finkabaj/hyde-bot/internals/modlog"
 	"github.com/finkabaj/hyde-bot/internals/rules"
+	"github.com/finkabaj/hyde-bot/internals/threshold"
 	commandUtils "github.com/finkabaj/hyde-bot/internals/utils/command"
 )
 
@@ -21,23 +27,37 @@ type Event struct {
 
 type EventManager struct {
 	rm                  *rules.RuleManager
+	reconciler          *rules.Reconciler
 	cm                  *commands.CommandManager
+	executor            *actions.Executor
+	evaluator           *escalation.Evaluator
+	members             *members.Cache
+	counter             *threshold.Counter
+	limiter             *flood.Limiter
+	modLog              *modlog.Publisher
 	messageInteractions *commandUtils.MessageInteractions
+	pendingRules        *commandUtils.PendingReactionRules
 	Events              map[string]map[string]*Event // Events[type][guildID] = event
-	client              *http.Client
 }
 
 var em *EventManager
 
-func NewEventManager(rm *rules.RuleManager, cm *commands.CommandManager,
-	client *http.Client, messageInteractions *commandUtils.MessageInteractions) *EventManager {
+func NewEventManager(rm *rules.RuleManager, reconciler *rules.Reconciler, cm *commands.CommandManager, executor *actions.Executor,
+	evaluator *escalation.Evaluator, mc *members.Cache, counter *threshold.Counter, limiter *flood.Limiter, modLog *modlog.Publisher, messageInteractions *commandUtils.MessageInteractions, pendingRules *commandUtils.PendingReactionRules) *EventManager {
 	if em == nil {
 		return &EventManager{
 			rm:                  rm,
+			reconciler:          reconciler,
 			cm:                  cm,
+			executor:            executor,
+			evaluator:           evaluator,
+			members:             mc,
+			counter:             counter,
+			limiter:             limiter,
+			modLog:              modLog,
 			messageInteractions: messageInteractions,
+			pendingRules:        pendingRules,
 			Events:              make(map[string]map[string]*Event),
-			client:              client,
 		}
 	}
 	return em
@@ -50,11 +70,24 @@ func (em *EventManager) RegisterDefaultEvents() {
 		guildID = os.Getenv("DEV_GUILD_ID")
 	}
 
-	em.RegisterEventHandler("MessageReactionAdd", HandleDeleteReaction(em.rm), guildID)
-	em.RegisterEventHandler("InteractionCreate", HandleInteractionCreate(em.cm), guildID)
-	em.RegisterEventHandler("GuildCreate", HandleGuildCreate(em.rm, em.client), "")
-	em.RegisterEventHandler("ModalSubmitReaction", HandleSumbitModalReaction(em.rm), guildID)
+	em.RegisterEventHandler("MessageReactionAdd", HandleDeleteReaction(em.rm, em.executor, em.evaluator, em.members, em.counter, em.limiter, em.modLog), guildID)
+	em.RegisterEventHandler("MessageReactionRemove", HandleRemoveReaction(em.rm, em.counter), guildID)
+	// member events of every guild change its member count
+	counts := newMemberCounts(em.rm)
+
+	em.RegisterEventHandler("GuildMemberAdd", HandleGuildMemberAdd(counts), "")
+	em.RegisterEventHandler("GuildMemberUpdate", HandleGuildMemberUpdate(em.members), guildID)
+	em.RegisterEventHandler("GuildMemberRemove", HandleGuildMemberRemove(em.members, counts), "")
+	em.RegisterEventHandler("InteractionCreate", HandleInteractionCreate(em.cm, em.modLog), guildID)
+	em.RegisterEventHandler("GuildCreate", HandleGuildCreate(em.rm, em.reconciler), "")
+	em.RegisterEventHandler("GuildUpdate", HandleGuildUpdate(em.rm), "")
+	em.RegisterEventHandler("GuildDelete", HandleGuildDelete(em.rm, em.reconciler), "")
+	em.RegisterEventHandler("ModalSubmitReaction", HandleSumbitModalReaction(em.pendingRules), guildID)
 	em.RegisterEventHandler("MessageSubmitDeleteReactions", HandleSubmitDeleteReactionModal(em.rm, em.messageInteractions), guildID)
+	em.RegisterEventHandler("MessageSubmitReactionRuleConfig", HandleReactionRuleConfig(em.rm, em.pendingRules), guildID)
+	em.RegisterEventHandler("MessageSubmitEditReactionRule", HandleSubmitEditReactionRule(em.rm), guildID)
+	em.RegisterEventHandler("MessageSubmitReactionExemptions", HandleSubmitReactionExemptions(em.rm), guildID)
+	em.RegisterEventHandler("MessageSubmitInfractionsPage", HandleInfractionsPage(em.rm), guildID)
 }
 
 // RegisterEventHandler registers an event handler for a specific guild.
@@ -110,6 +143,18 @@ func getEventType(event interface{}) string {
 		case discordgo.InteractionModalSubmit:
 			return "ModalSubmitReaction"
 		case discordgo.InteractionMessageComponent:
+			customID := i.MessageComponentData().CustomID
+
+			switch {
+			case strings.HasPrefix(customID, commands.ReactionRuleCustomIDPrefix):
+				return "MessageSubmitReactionRuleConfig"
+			case strings.HasPrefix(customID, commands.EditReactionRuleCustomIDPrefix):
+				return "MessageSubmitEditReactionRule"
+			case strings.HasPrefix(customID, commands.ReactionExemptionsCustomIDPrefix):
+				return "MessageSubmitReactionExemptions"
+			case strings.HasPrefix(customID, commands.InfractionsPageCustomIDPrefix):
+				return "MessageSubmitInfractionsPage"
+			}
 			return "MessageSubmitDeleteReactions"
 		}
 	}
@@ -136,5 +181,13 @@ func getGuildID(event any) string {
 		return e.ID
 	}
 
+	if e, ok := event.(*discordgo.GuildUpdate); ok {
+		return e.ID
+	}
+
+	if e, ok := event.(*discordgo.GuildDelete); ok {
+		return e.ID
+	}
+
 	return ""
 }
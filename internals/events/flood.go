@@ -0,0 +1,64 @@
+package events
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/finkabaj/hyde-bot/internals/actions"
+	"github.com/finkabaj/hyde-bot/internals/escalation"
+	"github.com/finkabaj/hyde-bot/internals/flood"
+	"github.com/finkabaj/hyde-bot/internals/members"
+	"github.com/finkabaj/hyde-bot/internals/modlog"
+	"github.com/finkabaj/hyde-bot/internals/rules"
+	"github.com/finkabaj/hyde-bot/internals/utils/common"
+	"github.com/finkabaj/hyde-bot/internals/utils/rule"
+)
+
+// limitReactionFlood counts the reaction against the flood rule of the guild. Once the member exceeds it,
+// actions of the rule are taken on the member, unless the member is exempt, and true is returned.
+// Bots are never limited, they add many reactions at once, e.g. the emojis of reaction role messages.
+func limitReactionFlood(s *discordgo.Session, rm *rules.RuleManager, executor *actions.Executor, evaluator *escalation.Evaluator,
+	mc *members.Cache, limiter *flood.Limiter, modLog *modlog.Publisher, guildRules rules.Rules, e *discordgo.MessageReactionAdd) bool {
+	f := guildRules.ReactionFlood
+
+	if f == nil || (e.Member != nil && e.Member.User != nil && e.Member.User.Bot) {
+		return false
+	}
+
+	reactions, exceeded := limiter.Add(e.GuildID, e.UserID, flood.Reaction{ChannelID: e.ChannelID, MessageID: e.MessageID, Emoji: e.Emoji}, *f)
+
+	if !exceeded {
+		return false
+	}
+
+	target := actions.Target{
+		GuildID:   e.GuildID,
+		ChannelID: e.ChannelID,
+		MessageID: e.MessageID,
+		UserID:    e.UserID,
+		Emoji:     e.Emoji,
+		Member:    e.Member,
+	}
+
+	// the flood rule has no exemptions of its own, guild wide ones apply
+	if isExempt(s, mc, guildRules, rule.ReactionRule{}, target) {
+		return false
+	}
+
+	flooded := make([]actions.Target, 0, len(reactions))
+
+	for _, r := range reactions {
+		flooded = append(flooded, actions.Target{GuildID: e.GuildID, ChannelID: r.ChannelID, MessageID: r.MessageID, UserID: e.UserID, Emoji: r.Emoji})
+	}
+
+	results := executor.ExecuteFlood(s, *f, target, flooded)
+	warned := recordResults(rm, results, target, fmt.Sprintf("Reacted more than %d times in %s", f.MaxReactions, common.FormatDuration(f.Window())))
+
+	modLog.Publish(e.GuildID, modlog.FloodEmbed(target, *f, results))
+
+	if warned {
+		evaluator.Evaluate(s, target)
+	}
+
+	return true
+}
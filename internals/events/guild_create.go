@@ -1,21 +1,15 @@
 package events
 
 import (
-	"bytes"
-	"encoding/json"
 	"errors"
-	"io"
-	"net/http"
-	"os"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/finkabaj/hyde-bot/internals/logger"
 	"github.com/finkabaj/hyde-bot/internals/rules"
-	"github.com/finkabaj/hyde-bot/internals/utils/common"
 	"github.com/finkabaj/hyde-bot/internals/utils/guild"
 )
 
-func HandleGuildCreate(rm *rules.RuleManager, client *http.Client) EventHandler {
+func HandleGuildCreate(rm *rules.RuleManager, reconciler *rules.Reconciler) EventHandler {
 	return func(s *discordgo.Session, event any) {
 		typedEvent, ok := event.(*discordgo.GuildCreate)
 
@@ -24,84 +18,33 @@ func HandleGuildCreate(rm *rules.RuleManager, client *http.Client) EventHandler
 			return
 		}
 
-		info := &guild.GuildCreate{
-			GuildId: typedEvent.ID,
-			OwnerId: typedEvent.OwnerID,
+		info := guild.GuildCreate{
+			GuildId:     typedEvent.ID,
+			OwnerId:     typedEvent.OwnerID,
+			Name:        typedEvent.Name,
+			Icon:        typedEvent.Icon,
+			MemberCount: typedEvent.MemberCount,
 		}
 
-		jsonInfo, err := json.Marshal(&info)
-
-		if err != nil {
-			logger.Error(err, map[string]any{"details": "error while marshalling guild info"})
-			return
+		if joinedAt := typedEvent.JoinedAt; !joinedAt.IsZero() {
+			info.JoinedAt = &joinedAt
 		}
 
-		url := common.GetApiUrl(os.Getenv("API_HOST"), os.Getenv("API_PORT"), "/guild")
-
-		bodyReader := bytes.NewReader(jsonInfo)
-		res, err := client.Post(url, "application/json", bodyReader)
+		// existing guilds get the owner and metadata of the event
+		err := rm.CreateGuild(info)
 
-		if err != nil {
-			logger.Error(err, map[string]any{"details": "error while sending post request on guild create"})
+		if errors.Is(err, rules.ErrQueued) {
+			// the reconciler keeps retrying the guild until the queued create is replayed
+			logger.Info("Guild create queued", map[string]any{"guildId": info.GuildId})
+		} else if err != nil {
+			logger.Error(err, map[string]any{"details": "error while creating guild", "guildId": info.GuildId})
 			return
 		}
 
-		body := res.Body
-		defer body.Close()
-		b, err := io.ReadAll(body)
-
-		if err != nil {
-			logger.Fatal(err, map[string]any{"details": "the bot cannot continue to work correctly", "at": "guild_create"})
-		}
-
-		var result guild.Guild
-
-		if err := common.UnmarshalBodyBytes(b, &result); err != nil {
-			logger.Error(errors.New("error while unmarshaling guild create"))
-		}
-
-		var errRes common.ErrorResponse
-
-		if err := common.UnmarshalBodyBytes(b, &errRes); err != nil {
-			logger.Error(errors.New("error while unmarshaling guild create error"))
-		}
-
-		if errRes.Error == guild.ErrGuildConflict.Error() {
-			logger.Info("Guild already exists", map[string]any{"guildId": info.GuildId})
-		} else if errRes.Error != "" {
-			logger.Error(errors.New(errRes.Error), logger.ToMap(errRes.ValidationErrors))
+		// on error the reconciler retries the guild with backoff
+		if err := reconciler.SyncGuild(info.GuildId); err != nil {
+			logger.Error(err, map[string]any{"details": "error on fetching rules", "at": "guild_create", "guildId": info.GuildId})
 			return
 		}
-
-		if result.GuildId == info.GuildId || errRes.Error == guild.ErrGuildConflict.Error() {
-			rules, err := fetchRules(info.GuildId, rm)
-
-			if err != nil {
-				logger.Error(err, map[string]any{"details": "error on fetching rules", "at": "guild_create", "guildId": info.GuildId})
-				return
-			}
-
-			rm.AddRules(info.GuildId, rules)
-		}
 	}
 }
-
-func fetchRules(guildId string, rm *rules.RuleManager) (rules.Rules, error) {
-	rRules, err := rm.FetchReactionRules(guildId)
-
-	if err != nil {
-		return rules.Rules{}, err
-	}
-
-	if len(rRules) == 0 {
-		return rules.Rules{
-			ReactionRules:     nil,
-			HaveReactionRules: false,
-		}, nil
-	}
-
-	return rules.Rules{
-		ReactionRules:     rRules,
-		HaveReactionRules: true,
-	}, nil
-}
@@ -0,0 +1,40 @@
+package events
+
+import (
+	"errors"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/finkabaj/hyde-bot/internals/logger"
+	"github.com/finkabaj/hyde-bot/internals/rules"
+)
+
+// HandleGuildDelete deletes the guild when the bot leaves it. Guilds that are unavailable
+// because of a discord outage are sent as GuildDelete too, they are kept.
+func HandleGuildDelete(rm *rules.RuleManager, reconciler *rules.Reconciler) EventHandler {
+	return func(s *discordgo.Session, event any) {
+		typedEvent, ok := event.(*discordgo.GuildDelete)
+
+		if !ok {
+			logger.Warn(errors.New("incorect type in HandleGuildDelete"))
+			return
+		}
+
+		if typedEvent.Unavailable {
+			logger.Info("Guild is unavailable", map[string]any{"guildId": typedEvent.ID})
+			return
+		}
+
+		reconciler.Forget(typedEvent.ID)
+
+		err := rm.DeleteGuild(typedEvent.ID)
+
+		if errors.Is(err, rules.ErrQueued) {
+			logger.Info("Guild delete queued", map[string]any{"guildId": typedEvent.ID})
+		} else if err != nil {
+			logger.Error(err, map[string]any{"details": "error while deleting guild", "guildId": typedEvent.ID})
+			return
+		}
+
+		logger.Info("Left guild", map[string]any{"guildId": typedEvent.ID})
+	}
+}
@@ -0,0 +1,133 @@
+package events
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/finkabaj/hyde-bot/internals/logger"
+	"github.com/finkabaj/hyde-bot/internals/members"
+	"github.com/finkabaj/hyde-bot/internals/rules"
+	"github.com/finkabaj/hyde-bot/internals/utils/guild"
+)
+
+// memberCountDelay is how long member count changes of a guild are collected before the count is saved,
+// so a wave of joins is saved once.
+const memberCountDelay = time.Minute
+
+// memberCounts saves member counts of guilds kept by the state, which counts member events.
+type memberCounts struct {
+	rm      *rules.RuleManager
+	pending map[string]bool // pending[guildID] is true while a save of the guild is scheduled
+	lock    sync.Mutex
+}
+
+func newMemberCounts(rm *rules.RuleManager) *memberCounts {
+	return &memberCounts{
+		rm:      rm,
+		pending: make(map[string]bool),
+	}
+}
+
+// schedule saves the member count of the guild after memberCountDelay, unless a save is scheduled already.
+func (mc *memberCounts) schedule(s *discordgo.Session, guildID string) {
+	mc.lock.Lock()
+	defer mc.lock.Unlock()
+
+	if mc.pending[guildID] {
+		return
+	}
+
+	mc.pending[guildID] = true
+
+	time.AfterFunc(memberCountDelay, func() {
+		mc.lock.Lock()
+		delete(mc.pending, guildID)
+		mc.lock.Unlock()
+
+		mc.save(s, guildID)
+	})
+}
+
+func (mc *memberCounts) save(s *discordgo.Session, guildID string) {
+	g, err := s.State.Guild(guildID)
+
+	if err != nil {
+		logger.Debug("Failed to get guild of member count: "+err.Error(), map[string]any{"guildId": guildID})
+		return
+	}
+
+	// the state changes the count of the guild under its lock
+	s.State.RLock()
+	count := g.MemberCount
+	s.State.RUnlock()
+
+	err = mc.rm.UpdateGuild(guildID, guild.GuildUpdate{MemberCount: &count})
+
+	if errors.Is(err, rules.ErrQueued) {
+		logger.Info("Member count update queued", map[string]any{"guildId": guildID})
+	} else if err != nil {
+		logger.Error(err, map[string]any{"details": "error while updating member count", "guildId": guildID})
+	}
+}
+
+// HandleGuildMemberAdd saves the member count of the guild. Like other member events it's only sent
+// with the guild members intent, without it the count is saved when the guild is created.
+func HandleGuildMemberAdd(counts *memberCounts) EventHandler {
+	return func(s *discordgo.Session, event any) {
+		typedEvent, ok := event.(*discordgo.GuildMemberAdd)
+
+		if !ok {
+			logger.Debug("Failed to cast event to *discordgo.GuildMemberAdd")
+			return
+		}
+
+		if typedEvent.Member == nil {
+			logger.Debug("GuildMemberAdd event without member")
+			return
+		}
+
+		counts.schedule(s, typedEvent.GuildID)
+	}
+}
+
+// HandleGuildMemberUpdate refreshes cached roles of the member.
+// Member events are only sent with the guild members intent, without it cached roles expire after ttl.
+func HandleGuildMemberUpdate(mc *members.Cache) EventHandler {
+	return func(s *discordgo.Session, event any) {
+		typedEvent, ok := event.(*discordgo.GuildMemberUpdate)
+
+		if !ok {
+			logger.Debug("Failed to cast event to *discordgo.GuildMemberUpdate")
+			return
+		}
+
+		if typedEvent.Member == nil || typedEvent.User == nil {
+			logger.Debug("GuildMemberUpdate event without member")
+			return
+		}
+
+		mc.Set(typedEvent.GuildID, typedEvent.User.ID, typedEvent.Roles)
+	}
+}
+
+// HandleGuildMemberRemove forgets cached roles of the member and saves the member count of the guild.
+func HandleGuildMemberRemove(mc *members.Cache, counts *memberCounts) EventHandler {
+	return func(s *discordgo.Session, event any) {
+		typedEvent, ok := event.(*discordgo.GuildMemberRemove)
+
+		if !ok {
+			logger.Debug("Failed to cast event to *discordgo.GuildMemberRemove")
+			return
+		}
+
+		if typedEvent.Member == nil || typedEvent.User == nil {
+			logger.Debug("GuildMemberRemove event without member")
+			return
+		}
+
+		mc.Delete(typedEvent.GuildID, typedEvent.User.ID)
+		counts.schedule(s, typedEvent.GuildID)
+	}
+}
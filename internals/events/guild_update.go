@@ -0,0 +1,40 @@
+package events
+
+import (
+	"errors"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/finkabaj/hyde-bot/internals/logger"
+	"github.com/finkabaj/hyde-bot/internals/rules"
+	"github.com/finkabaj/hyde-bot/internals/utils/guild"
+)
+
+// HandleGuildUpdate saves ownership transfers and metadata changes of the guild.
+// Member count and join time aren't sent with guild updates, so they are kept.
+func HandleGuildUpdate(rm *rules.RuleManager) EventHandler {
+	return func(s *discordgo.Session, event any) {
+		typedEvent, ok := event.(*discordgo.GuildUpdate)
+
+		if !ok {
+			logger.Warn(errors.New("incorect type in HandleGuildUpdate"))
+			return
+		}
+
+		// the event guild is shared with the state, queued writes must not point into it
+		ownerId, name, icon := typedEvent.OwnerID, typedEvent.Name, typedEvent.Icon
+
+		update := guild.GuildUpdate{
+			OwnerId: &ownerId,
+			Name:    &name,
+			Icon:    &icon,
+		}
+
+		err := rm.UpdateGuild(typedEvent.ID, update)
+
+		if errors.Is(err, rules.ErrQueued) {
+			logger.Info("Guild update queued", map[string]any{"guildId": typedEvent.ID})
+		} else if err != nil {
+			logger.Error(err, map[string]any{"details": "error while updating guild", "guildId": typedEvent.ID})
+		}
+	}
+}
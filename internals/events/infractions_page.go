@@ -0,0 +1,48 @@
+package events
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/finkabaj/hyde-bot/internals/commands"
+	"github.com/finkabaj/hyde-bot/internals/logger"
+	"github.com/finkabaj/hyde-bot/internals/rules"
+	commandUtils "github.com/finkabaj/hyde-bot/internals/utils/command"
+)
+
+// HandleInfractionsPage shows the page of the /infractions message opened by the previous or next button.
+func HandleInfractionsPage(rm *rules.RuleManager) EventHandler {
+	return func(s *discordgo.Session, event any) {
+		i, ok := event.(*discordgo.InteractionCreate)
+
+		if !ok || i.Type != discordgo.InteractionMessageComponent {
+			return
+		}
+
+		userId, rawOffset, ok := strings.Cut(strings.TrimPrefix(i.MessageComponentData().CustomID, commands.InfractionsPageCustomIDPrefix), ":")
+		offset, err := strconv.Atoi(rawOffset)
+
+		if !ok || err != nil || offset < 0 {
+			logger.Debug("Invalid infractions page custom id", commandUtils.FillFields(i))
+			return
+		}
+
+		data, err := commands.InfractionsPage(rm, i.GuildID, userId, offset)
+
+		if err != nil {
+			logger.Error(err, commandUtils.FillFields(i))
+			updateReactionRuleConfigMessage(s, i, "Failed to get infractions")
+			return
+		}
+
+		err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseUpdateMessage,
+			Data: data,
+		})
+
+		if err != nil {
+			logger.Error(err, commandUtils.FillFields(i))
+		}
+	}
+}
@@ -4,10 +4,11 @@ import (
 	"github.com/bwmarrin/discordgo"
 	"github.com/finkabaj/hyde-bot/internals/commands"
 	"github.com/finkabaj/hyde-bot/internals/logger"
+	"github.com/finkabaj/hyde-bot/internals/modlog"
 	commandUtils "github.com/finkabaj/hyde-bot/internals/utils/command"
 )
 
-func HandleInteractionCreate(cm *commands.CommandManager) EventHandler {
+func HandleInteractionCreate(cm *commands.CommandManager, modLog *modlog.Publisher) EventHandler {
 	return func(s *discordgo.Session, event interface{}) {
 		i := event.(*discordgo.InteractionCreate)
 
@@ -29,10 +30,13 @@ func HandleInteractionCreate(cm *commands.CommandManager) EventHandler {
 					if err != nil {
 						logger.Error(err, commandUtils.FillFields(i))
 					}
+
+					return
 				}
 			}
 
 			cmd.Handler(s, i)
+			modLog.Publish(i.GuildID, modlog.CommandEmbed(i))
 			logger.Info("Command executed", commandUtils.FillFields(i))
 		}
 	}
@@ -0,0 +1,99 @@
+package events
+
+import (
+	"slices"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/finkabaj/hyde-bot/internals/logger"
+	"github.com/finkabaj/hyde-bot/internals/members"
+	"github.com/finkabaj/hyde-bot/internals/rules"
+	"github.com/finkabaj/hyde-bot/internals/utils/rule"
+)
+
+// grantReactionRole grants the reaction role of the added reaction. In unique mode other roles of the message
+// are revoked and their reactions removed. Returns false if the emoji has no reaction role on the message,
+// otherwise reaction rules aren't enforced on the reaction.
+func grantReactionRole(s *discordgo.Session, rm *rules.RuleManager, mc *members.Cache, e *discordgo.MessageReactionAdd) bool {
+	m, ok := rm.ReactionRoles(e.GuildID, e.MessageID)
+
+	if !ok {
+		return false
+	}
+
+	rr, ok := m.Role(e.Emoji.Name, e.Emoji.ID)
+
+	if !ok {
+		return false
+	}
+
+	if e.Member != nil && e.Member.User != nil && e.Member.User.Bot {
+		return true
+	}
+
+	if err := s.GuildMemberRoleAdd(e.GuildID, e.UserID, rr.RoleId); err != nil {
+		logger.Error(err, map[string]any{"details": "failed to grant reaction role", "guildId": e.GuildID, "userId": e.UserID, "roleId": rr.RoleId})
+		return true
+	}
+
+	if m.Mode != rule.ReactionRoleUnique {
+		return true
+	}
+
+	var roles []string
+
+	if e.Member != nil {
+		roles = e.Member.Roles
+	} else {
+		var err error
+		roles, err = mc.Roles(s, e.GuildID, e.UserID)
+
+		if err != nil {
+			logger.Error(err, map[string]any{"details": "failed to get member roles", "guildId": e.GuildID, "userId": e.UserID})
+			return true
+		}
+	}
+
+	for _, other := range m.Roles {
+		if other.SameEmoji(rr) || other.RoleId == rr.RoleId || !slices.Contains(roles, other.RoleId) {
+			continue
+		}
+
+		if err := s.GuildMemberRoleRemove(e.GuildID, e.UserID, other.RoleId); err != nil {
+			logger.Error(err, map[string]any{"details": "failed to revoke unique reaction role", "guildId": e.GuildID, "userId": e.UserID, "roleId": other.RoleId})
+			continue
+		}
+
+		emoji := discordgo.Emoji{Name: other.EmojiName, ID: other.EmojiId}
+
+		if err := s.MessageReactionRemove(e.ChannelID, e.MessageID, emoji.APIName(), e.UserID); err != nil {
+			logger.Error(err, map[string]any{"details": "failed to remove reaction of revoked role", "guildId": e.GuildID, "userId": e.UserID, "messageId": e.MessageID})
+		}
+	}
+
+	return true
+}
+
+// revokeReactionRole revokes the reaction role of the removed reaction, unless the message is in verify mode.
+func revokeReactionRole(s *discordgo.Session, rm *rules.RuleManager, e *discordgo.MessageReactionRemove) {
+	m, ok := rm.ReactionRoles(e.GuildID, e.MessageID)
+
+	if !ok || m.Mode == rule.ReactionRoleVerify {
+		return
+	}
+
+	rr, ok := m.Role(e.Emoji.Name, e.Emoji.ID)
+
+	if !ok {
+		return
+	}
+
+	if err := s.GuildMemberRoleRemove(e.GuildID, e.UserID, rr.RoleId); err != nil {
+		logger.Error(err, map[string]any{
+			"details":   "failed to revoke reaction role",
+			"guildId":   e.GuildID,
+			"userId":    e.UserID,
+			"messageId": e.MessageID,
+			"roleId":    rr.RoleId,
+		})
+	}
+}
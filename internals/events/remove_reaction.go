@@ -0,0 +1,36 @@
+package events
+
+import (
+	"github.com/bwmarrin/discordgo"
+	"github.com/finkabaj/hyde-bot/internals/logger"
+	"github.com/finkabaj/hyde-bot/internals/rules"
+	"github.com/finkabaj/hyde-bot/internals/threshold"
+)
+
+// HandleRemoveReaction revokes reaction roles and stops counting the reaction for threshold rules.
+func HandleRemoveReaction(rm *rules.RuleManager, counter *threshold.Counter) EventHandler {
+	return func(s *discordgo.Session, event any) {
+		typedEvent, ok := event.(*discordgo.MessageReactionRemove)
+
+		if !ok {
+			logger.Debug("Failed to cast event to *discordgo.MessageReactionRemove")
+			return
+		}
+
+		if s.State.User != nil && typedEvent.UserID == s.State.User.ID {
+			return
+		}
+
+		revokeReactionRole(s, rm, typedEvent)
+
+		guildRules, err := rm.GetRules(typedEvent.GuildID, false)
+
+		if err != nil || !guildRules.HaveReactionRules {
+			return
+		}
+
+		if r, ok := findReactionRule(guildRules.ReactionRules, typedEvent.Emoji); ok && r.Threshold != nil {
+			counter.Remove(typedEvent.GuildID, typedEvent.MessageID, threshold.RuleKey(r), typedEvent.UserID)
+		}
+	}
+}
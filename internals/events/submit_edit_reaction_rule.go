@@ -0,0 +1,60 @@
+package events
+
+import (
+	"errors"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/finkabaj/hyde-bot/internals/commands"
+	"github.com/finkabaj/hyde-bot/internals/logger"
+	"github.com/finkabaj/hyde-bot/internals/rules"
+	"github.com/finkabaj/hyde-bot/internals/utils/common"
+	"github.com/finkabaj/hyde-bot/internals/utils/rule"
+)
+
+func HandleSubmitEditReactionRule(rm *rules.RuleManager) EventHandler {
+	return func(s *discordgo.Session, event any) {
+		i, ok := event.(*discordgo.InteractionCreate)
+
+		if !ok || i.Type != discordgo.InteractionMessageComponent {
+			return
+		}
+
+		data := i.MessageComponentData()
+
+		emojiName, emojiId, ok := commands.ParseEditReactionRuleCustomID(data.CustomID)
+
+		if !ok {
+			return
+		}
+
+		updateReactionRuleConfigMessage(s, i, editReactionRule(rm, i.GuildID, emojiName, emojiId, data.Values, commands.WriteOrigin(i)))
+	}
+}
+
+func editReactionRule(rm *rules.RuleManager, guildId, emojiName, emojiId string, values []string, origin *rules.WriteOrigin) string {
+	actions, err := commands.ParseReactActions(values)
+
+	if err != nil {
+		return "Invalid actions selected"
+	}
+
+	err = rm.UpdateReactionRulesApi(guildId, []rule.ReactionRuleUpdate{{
+		EmojiName: emojiName,
+		EmojiId:   emojiId,
+		Actions:   actions,
+	}}, origin)
+
+	switch {
+	case errors.Is(err, rules.ErrInvalidActions):
+		return "Invalid actions selected"
+	case errors.Is(err, common.ErrNotFound):
+		return "Reaction rule not found, it may have been deleted"
+	case errors.Is(err, rules.ErrQueued):
+		return commands.QueuedMessage
+	case err != nil:
+		logger.Error(err, map[string]any{"details": "failed to update reaction rule", "guildId": guildId})
+		return "Failed to update reaction rule"
+	}
+
+	return "Reaction rule updated successfully!"
+}
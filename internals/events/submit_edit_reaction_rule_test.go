@@ -0,0 +1,44 @@
+package events
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/finkabaj/hyde-bot/internals/apiclient"
+	mogs "github.com/finkabaj/hyde-bot/internals/backend/mocks"
+	"github.com/finkabaj/hyde-bot/internals/rules"
+	"github.com/finkabaj/hyde-bot/internals/utils/common"
+	"github.com/finkabaj/hyde-bot/internals/utils/rule"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEditReactionRule(t *testing.T) {
+	t.Run("NotFound", testEditReactionRuleNotFound)
+	t.Run("InvalidActions", testEditReactionRuleInvalidActions)
+}
+
+// newNotFoundRuleManager returns a rule manager whose API responds to every request with not found.
+func newNotFoundRuleManager(t *testing.T) *rules.RuleManager {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		common.NewErrorResponseBuilder(common.ErrNotFound).SetStatus(http.StatusNotFound).Send(w)
+	}))
+	t.Cleanup(s.Close)
+
+	client := apiclient.NewClient(mogs.NewMockLogger(), s.URL, s.Client(), apiclient.RetryPolicy{}, nil)
+
+	return rules.NewRuleManager(client, rules.NewWriteQueue(mogs.NewMockLogger()))
+}
+
+func testEditReactionRuleNotFound(t *testing.T) {
+	rm := newNotFoundRuleManager(t)
+	values := []string{strconv.Itoa(int(rule.Delete))}
+
+	assert.Equal(t, "Reaction rule not found, it may have been deleted", editReactionRule(rm, "guild", "pepe", "1", values, nil))
+}
+
+func testEditReactionRuleInvalidActions(t *testing.T) {
+	// actions are validated before the rule manager is used
+	assert.Equal(t, "Invalid actions selected", editReactionRule(nil, "guild", "pepe", "1", []string{"delete"}, nil))
+}
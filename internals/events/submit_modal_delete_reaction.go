@@ -1,6 +1,7 @@
 package events
 
 import (
+	"errors"
 	"strings"
 
 	"github.com/bwmarrin/discordgo"
@@ -30,9 +31,10 @@ func HandleSubmitDeleteReactionModal(rm *rules.RuleManager, messageInteractions
 
 		deleteRulesDto := make([]rules.RulesDeleteDto, 0, len(data.Values))
 		for _, v := range data.Values {
-			vSplit := strings.Split(v, ":")
-			emojiName := vSplit[0]
-			emojiID := vSplit[1]
+			// patterns may contain colons, ids never do
+			sep := strings.LastIndex(v, ":")
+			emojiName := v[:sep]
+			emojiID := v[sep+1:]
 
 			if emojiID == "NULL" {
 				emojiID = ""
@@ -44,9 +46,10 @@ func HandleSubmitDeleteReactionModal(rm *rules.RuleManager, messageInteractions
 			})
 		}
 
-		err := rm.DeleteReactionRulesApi(i.GuildID, deleteRulesDto)
+		err := rm.DeleteReactionRulesApi(i.GuildID, deleteRulesDto, commands.WriteOrigin(i))
+		queued := errors.Is(err, rules.ErrQueued)
 
-		if err != nil {
+		if err != nil && !queued {
 			logger.Error(err, map[string]any{"details": "failed to delete reaction rules"})
 
 			i, ok := messageInteractions.GetMessageInteraction(i.Member.User.ID)
@@ -78,6 +81,11 @@ func HandleSubmitDeleteReactionModal(rm *rules.RuleManager, messageInteractions
 			commandUtils.SendDefaultResponse(s, i, "Failed to delete message")
 		}
 
+		if queued {
+			commandUtils.SendDefaultResponse(s, i, commands.QueuedMessage)
+			return
+		}
+
 		commandUtils.SendDefaultResponse(s, i, "Successfully deleted reaction rules")
 	}
 }
@@ -1,21 +1,22 @@
 package events
 
 import (
-	"errors"
 	"fmt"
 	"slices"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/enescakir/emoji"
+	"github.com/finkabaj/hyde-bot/internals/commands"
 	"github.com/finkabaj/hyde-bot/internals/logger"
-	"github.com/finkabaj/hyde-bot/internals/rules"
 	commandUtils "github.com/finkabaj/hyde-bot/internals/utils/command"
+	"github.com/finkabaj/hyde-bot/internals/utils/common"
 	"github.com/finkabaj/hyde-bot/internals/utils/rule"
 )
 
-func HandleSumbitModalReaction(rm *rules.RuleManager) EventHandler {
+func HandleSumbitModalReaction(pending *commandUtils.PendingReactionRules) EventHandler {
 	return func(s *discordgo.Session, event any) {
 		data, i, err := commandUtils.GetDataFromModalSubmit(event)
 
@@ -44,25 +45,65 @@ func HandleSumbitModalReaction(rm *rules.RuleManager) EventHandler {
 			return
 		}
 
-		rRules, err := rm.PostReactionRules(i.GuildID, r)
+		var threshold *rule.ReactionThreshold
 
-		if err != nil {
-			if errors.Is(err, rules.ErrIntersectingRules) {
-				commandUtils.SendDefaultResponse(s, i, "Reaction rules already exist")
+		if len(data.Components) > 1 {
+			threshold, err = commands.ParseReactionThreshold(data.Components[1].(*discordgo.ActionsRow).Components[0].(*discordgo.TextInput).Value)
 
+			if err != nil {
+				commandUtils.SendDefaultResponse(s, i, fmt.Sprintf("Threshold must be like 5 in 10m, from %d to %d members within at most %s",
+					rule.MinThresholdCount, rule.MaxThresholdCount, common.FormatDuration(rule.MaxThresholdWindow)))
 				return
 			}
+		}
 
-			commandUtils.SendDefaultResponse(s, i, "Failed to post reaction rules")
+		content := "Select actions for the reaction rules"
 
-			logger.Error(err)
+		if threshold != nil {
+			content = fmt.Sprintf("Select actions for the reaction rules, they are taken once %d members react to a message within %s. "+
+				"Delete removes the message, the other actions are taken on its author", threshold.Count, common.FormatDuration(threshold.Window()))
+		}
 
+		defaultActions := rule.Actions{{Type: rule.Delete}}
+
+		err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content:    content,
+				Flags:      1 << 6,
+				Components: commands.ReactionRuleConfigComponents(defaultActions),
+			},
+		})
+
+		if err != nil {
+			logger.Error(err, map[string]any{"details": "failed to respond to reaction rules modal"})
 			return
 		}
 
-		rm.AddReactionRules(i.GuildID, rRules)
+		pending.Set(i.GuildID, i.Member.User.ID, commandUtils.PendingReactionRule{
+			Interaction: i,
+			Rules:       r,
+			Actions:     defaultActions,
+			Threshold:   threshold,
+		})
+
+		go func() {
+			<-time.After(commands.ReactionRuleConfigTimeout)
+
+			if !pending.DeleteIfInteraction(i.GuildID, i.Member.User.ID, i) {
+				return
+			}
+
+			content := "You took too long to respond, please try again."
+			_, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+				Content:    &content,
+				Components: &[]discordgo.MessageComponent{},
+			})
 
-		commandUtils.SendDefaultResponse(s, i, "Modal submitted successfully!")
+			if err != nil {
+				logger.Error(err, map[string]any{"details": "failed to edit expired reaction rules configuration"})
+			}
+		}()
 	}
 }
 
@@ -79,16 +120,19 @@ func parseModalReactionInput(text string, ruleAuthor string, guildId string, emo
 
 	result := make([]rule.ReactionRule, 0, len(textSplited))
 
-	const rac = rule.ReactActionCount
-
 	for _, v := range textSplited {
-		if emoji.Exist(v) {
+		if r, ok := parseModalMatcher(v, ruleAuthor, guildId); ok {
+			if !r.IsValidMatcher() {
+				return nil
+			}
+
+			result = append(result, r)
+		} else if emoji.Exist(v) {
 			result = append(result, rule.ReactionRule{
 				GuildId:    guildId,
 				RuleAuthor: ruleAuthor,
 				EmojiName:  emoji.Parse(v),
 				IsCustom:   false,
-				Actions:    [rac]rule.ReactAction{rule.Delete},
 			})
 		} else if i := slices.IndexFunc(emojies, func(e *discordgo.Emoji) bool {
 			return v != "" && e.ID == v
@@ -99,7 +143,6 @@ func parseModalReactionInput(text string, ruleAuthor string, guildId string, emo
 				EmojiId:    v,
 				EmojiName:  emojies[i].Name,
 				IsCustom:   true,
-				Actions:    [rac]rule.ReactAction{rule.Delete},
 			})
 		} else if strings.HasPrefix(v, ":") && strings.HasSuffix(v, ":") {
 			eId := ""
@@ -119,7 +162,6 @@ func parseModalReactionInput(text string, ruleAuthor string, guildId string, emo
 				EmojiName:  eParsed,
 				EmojiId:    eId,
 				IsCustom:   true,
-				Actions:    [rac]rule.ReactAction{rule.Delete},
 			})
 		} else {
 			var emojiSequence string
@@ -138,7 +180,6 @@ func parseModalReactionInput(text string, ruleAuthor string, guildId string, emo
 							RuleAuthor: ruleAuthor,
 							EmojiName:  emojiSequence,
 							IsCustom:   false,
-							Actions:    [rac]rule.ReactAction{rule.Delete},
 						})
 						emojiSequence = ""
 					}
@@ -153,7 +194,6 @@ func parseModalReactionInput(text string, ruleAuthor string, guildId string, emo
 					RuleAuthor: ruleAuthor,
 					EmojiName:  v,
 					IsCustom:   false,
-					Actions:    [rac]rule.ReactAction{rule.Delete},
 				})
 			}
 		}
@@ -161,3 +201,29 @@ func parseModalReactionInput(text string, ruleAuthor string, guildId string, emo
 
 	return result
 }
+
+// parseModalMatcher parses a matcher token like base:👍, category:flags or pattern:^nsfw_.
+// Returns false if the token isn't a matcher, the returned rule may still describe no valid emojis.
+func parseModalMatcher(v string, ruleAuthor string, guildId string) (rule.ReactionRule, bool) {
+	kind, value, ok := strings.Cut(v, ":")
+
+	if !ok || value == "" {
+		return rule.ReactionRule{}, false
+	}
+
+	r := rule.ReactionRule{GuildId: guildId, RuleAuthor: ruleAuthor, Match: rule.MatchKind(kind)}
+
+	switch r.Match {
+	case rule.MatchBase:
+		r.EmojiName = rule.BaseEmoji(emoji.Parse(value))
+	case rule.MatchCategory:
+		r.EmojiName = strings.ToLower(value)
+	case rule.MatchPattern:
+		r.EmojiName = value
+		r.IsCustom = true
+	default:
+		return rule.ReactionRule{}, false
+	}
+
+	return r, true
+}
@@ -0,0 +1,76 @@
+package events
+
+import (
+	"errors"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/finkabaj/hyde-bot/internals/commands"
+	"github.com/finkabaj/hyde-bot/internals/logger"
+	"github.com/finkabaj/hyde-bot/internals/rules"
+	commandUtils "github.com/finkabaj/hyde-bot/internals/utils/command"
+	"github.com/finkabaj/hyde-bot/internals/utils/guild"
+)
+
+// HandleSubmitReactionExemptions saves guild wide exemptions selected in the /reaction-exemptions message.
+// The other exemption is taken from the cache, so every selection is saved right away.
+func HandleSubmitReactionExemptions(rm *rules.RuleManager) EventHandler {
+	return func(s *discordgo.Session, event any) {
+		i, ok := event.(*discordgo.InteractionCreate)
+
+		if !ok || i.Type != discordgo.InteractionMessageComponent {
+			return
+		}
+
+		data := i.MessageComponentData()
+
+		guildRules, err := rm.GetRules(i.GuildID, false)
+
+		if err != nil {
+			logger.Error(err, commandUtils.FillFields(i))
+			updateReactionRuleConfigMessage(s, i, "Failed to get reaction exemptions")
+			return
+		}
+
+		exemptions := guild.GuildExemptions{
+			ExemptRoles:  guildRules.ExemptRoles,
+			ExemptAdmins: &guildRules.ExemptAdmins,
+		}
+
+		switch data.CustomID {
+		case commands.ReactionExemptionsRolesCustomID:
+			exemptions.ExemptRoles = data.Values
+		case commands.ReactionExemptionsAdminsCustomID:
+			exemptAdmins := len(data.Values) > 0 && data.Values[0] == "true"
+			exemptions.ExemptAdmins = &exemptAdmins
+		default:
+			return
+		}
+
+		err = rm.UpdateExemptionsApi(i.GuildID, exemptions, commands.WriteOrigin(i))
+		queued := errors.Is(err, rules.ErrQueued)
+
+		if err != nil && !queued {
+			logger.Error(err, map[string]any{"details": "failed to update reaction exemptions", "guildId": i.GuildID})
+			updateReactionRuleConfigMessage(s, i, "Failed to update reaction exemptions")
+			return
+		}
+
+		content := commands.ReactionExemptionsContent(exemptions.ExemptRoles, *exemptions.ExemptAdmins)
+
+		if queued {
+			content += "\n\n" + commands.QueuedMessage
+		}
+
+		err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseUpdateMessage,
+			Data: &discordgo.InteractionResponseData{
+				Content:    content,
+				Components: commands.ReactionExemptionsComponents(*exemptions.ExemptAdmins),
+			},
+		})
+
+		if err != nil {
+			logger.Error(err, commandUtils.FillFields(i))
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package events
+
+import (
+	"errors"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/finkabaj/hyde-bot/internals/commands"
+	"github.com/finkabaj/hyde-bot/internals/logger"
+	"github.com/finkabaj/hyde-bot/internals/rules"
+	commandUtils "github.com/finkabaj/hyde-bot/internals/utils/command"
+	"github.com/finkabaj/hyde-bot/internals/utils/rule"
+)
+
+// HandleReactionRuleConfig handles components of the message sent after the create reaction rules modal.
+func HandleReactionRuleConfig(rm *rules.RuleManager, pending *commandUtils.PendingReactionRules) EventHandler {
+	return func(s *discordgo.Session, event any) {
+		i, ok := event.(*discordgo.InteractionCreate)
+
+		if !ok || i.Type != discordgo.InteractionMessageComponent {
+			return
+		}
+
+		data := i.MessageComponentData()
+		userID := i.Member.User.ID
+
+		switch data.CustomID {
+		case commands.ReactionRuleActionsCustomID:
+			actions, err := commands.ParseReactActions(data.Values)
+
+			if err != nil {
+				logger.Error(err, commandUtils.FillFields(i))
+				updateReactionRuleConfigMessage(s, i, "Invalid actions selected")
+				return
+			}
+
+			updatePendingReactionRule(s, i, pending, func(pr *commandUtils.PendingReactionRule) {
+				pr.Actions = actions
+			})
+		case commands.ReactionRuleIncludeChannelsCustomID:
+			updatePendingReactionRule(s, i, pending, func(pr *commandUtils.PendingReactionRule) {
+				pr.IncludeChannels = data.Values
+			})
+		case commands.ReactionRuleExcludeChannelsCustomID:
+			updatePendingReactionRule(s, i, pending, func(pr *commandUtils.PendingReactionRule) {
+				pr.ExcludeChannels = data.Values
+			})
+		case commands.ReactionRuleExemptRolesCustomID:
+			updatePendingReactionRule(s, i, pending, func(pr *commandUtils.PendingReactionRule) {
+				pr.ExemptRoles = data.Values
+			})
+		case commands.ReactionRuleCancelCustomID:
+			pending.Take(i.GuildID, userID)
+			updateReactionRuleConfigMessage(s, i, "Reaction rules creation cancelled")
+		case commands.ReactionRuleCreateCustomID:
+			pr, ok := pending.Take(i.GuildID, userID)
+
+			if !ok {
+				updateReactionRuleConfigMessage(s, i, "Nothing to create, please submit the rules again")
+				return
+			}
+
+			updateReactionRuleConfigMessage(s, i, createPendingReactionRules(rm, i.GuildID, pr, commands.WriteOrigin(i)))
+		}
+	}
+}
+
+func createPendingReactionRules(rm *rules.RuleManager, guildId string, pr commandUtils.PendingReactionRule, origin *rules.WriteOrigin) string {
+	for idx := range pr.Rules {
+		pr.Rules[idx].Actions = pr.Actions
+		pr.Rules[idx].IncludeChannels = pr.IncludeChannels
+		pr.Rules[idx].ExcludeChannels = pr.ExcludeChannels
+		pr.Rules[idx].ExemptRoles = pr.ExemptRoles
+
+		if pr.Threshold != nil {
+			threshold := *pr.Threshold
+			pr.Rules[idx].Threshold = &threshold
+		}
+	}
+
+	err := rm.PostReactionRules(guildId, pr.Rules, origin)
+
+	switch {
+	case errors.Is(err, rules.ErrIntersectingRules), errors.Is(err, rule.ErrRuleReactionConflict):
+		return "Reaction rules already exist"
+	case errors.Is(err, rules.ErrInvalidActions):
+		return "Invalid actions selected"
+	case errors.Is(err, rules.ErrIntersectingChannels):
+		return "A channel can't be both included and excluded"
+	case errors.Is(err, rules.ErrInvalidThreshold):
+		return "Invalid threshold"
+	case errors.Is(err, rule.ErrReactionRoleConflict):
+		return "An emoji of the rules is a reaction role in the channels of the rules, remove the reaction role or exclude its channel first"
+	case errors.Is(err, rules.ErrInvalidMatcher):
+		return "Invalid emoji category, base emoji or pattern"
+	case errors.Is(err, rules.ErrQueued):
+		return commands.QueuedMessage
+	case err != nil:
+		logger.Error(err, map[string]any{"details": "failed to post reaction rules", "guildId": guildId})
+		return "Failed to post reaction rules"
+	}
+
+	return "Reaction rules created successfully!"
+}
+
+// updatePendingReactionRule applies f to the pending rule and acknowledges the component without changing the message.
+func updatePendingReactionRule(s *discordgo.Session, i *discordgo.InteractionCreate,
+	pending *commandUtils.PendingReactionRules, f func(pr *commandUtils.PendingReactionRule)) {
+	if !pending.Update(i.GuildID, i.Member.User.ID, f) {
+		updateReactionRuleConfigMessage(s, i, "Nothing to configure, please submit the rules again")
+		return
+	}
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredMessageUpdate,
+	})
+
+	if err != nil {
+		logger.Error(err, commandUtils.FillFields(i))
+	}
+}
+
+func updateReactionRuleConfigMessage(s *discordgo.Session, i *discordgo.InteractionCreate, content string) {
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    content,
+			Components: []discordgo.MessageComponent{},
+		},
+	})
+
+	if err != nil {
+		logger.Error(err, commandUtils.FillFields(i))
+	}
+}
@@ -0,0 +1,101 @@
+package events
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/finkabaj/hyde-bot/internals/actions"
+	"github.com/finkabaj/hyde-bot/internals/escalation"
+	"github.com/finkabaj/hyde-bot/internals/logger"
+	"github.com/finkabaj/hyde-bot/internals/members"
+	"github.com/finkabaj/hyde-bot/internals/modlog"
+	"github.com/finkabaj/hyde-bot/internals/rules"
+	"github.com/finkabaj/hyde-bot/internals/threshold"
+	"github.com/finkabaj/hyde-bot/internals/utils/rule"
+)
+
+// countThresholdReaction counts the reaction for the threshold rule. Once the message reaches the threshold,
+// actions of the rule are taken on the message and its author, unless the author is exempt from the rule.
+// Reactions of bots and of the author of the message aren't counted. Without a mod log channel the guild owner is notified instead.
+func countThresholdReaction(s *discordgo.Session, rm *rules.RuleManager, executor *actions.Executor, evaluator *escalation.Evaluator,
+	mc *members.Cache, counter *threshold.Counter, modLog *modlog.Publisher, guildRules rules.Rules, r rule.ReactionRule, e *discordgo.MessageReactionAdd) {
+	if e.Member != nil && e.Member.User != nil && e.Member.User.Bot {
+		return
+	}
+
+	if !counter.Add(e.GuildID, e.MessageID, threshold.RuleKey(r), e.UserID, *r.Threshold) {
+		return
+	}
+
+	m, err := s.ChannelMessage(e.ChannelID, e.MessageID)
+
+	if err != nil {
+		logger.Error(err, map[string]any{"details": "failed to get message that reached reaction threshold", "guildId": e.GuildID, "messageId": e.MessageID})
+		return
+	}
+
+	if m.Author == nil || (s.State.User != nil && m.Author.ID == s.State.User.ID) {
+		return
+	}
+
+	// the author is only known now, their own reaction may have been counted toward the threshold
+	if !counter.ExcludeAuthor(e.GuildID, e.MessageID, threshold.RuleKey(r), m.Author.ID, *r.Threshold) {
+		return
+	}
+
+	target := actions.Target{
+		GuildID:   e.GuildID,
+		ChannelID: e.ChannelID,
+		MessageID: e.MessageID,
+		UserID:    m.Author.ID,
+		Emoji:     e.Emoji,
+		Member:    m.Member,
+	}
+
+	// webhooks have no member to be exempt
+	if m.WebhookID == "" && isExempt(s, mc, guildRules, r, target) {
+		return
+	}
+
+	results := executor.ExecuteOnMessage(s, r, target)
+	warned := recordResults(rm, results, target, fmt.Sprintf("Message reported with %s by %d members", actions.EmojiMention(e.Emoji), r.Threshold.Count))
+
+	embed := modlog.ThresholdEmbed(target, *r.Threshold, results)
+
+	// actions taken on reports of members must reach moderators, the owner is told when there's no mod log
+	if rm.ModLogChannel(e.GuildID) != "" {
+		modLog.Publish(e.GuildID, embed)
+	} else {
+		notifyOwner(s, e.GuildID, embed)
+	}
+
+	if warned {
+		evaluator.Evaluate(s, target)
+	}
+}
+
+// notifyOwner sends the mod log entry to the guild owner in direct messages.
+func notifyOwner(s *discordgo.Session, guildID string, embed *discordgo.MessageEmbed) {
+	g, err := s.State.Guild(guildID)
+
+	if err != nil {
+		g, err = s.Guild(guildID)
+	}
+
+	if err != nil {
+		logger.Error(err, map[string]any{"details": "failed to get guild to notify owner", "guildId": guildID})
+		return
+	}
+
+	embed.Footer = &discordgo.MessageEmbedFooter{Text: fmt.Sprintf("Sent to you as the owner of %s, set a mod log channel to get these in the server", g.Name)}
+
+	channel, err := s.UserChannelCreate(g.OwnerID)
+
+	if err == nil {
+		_, err = s.ChannelMessageSendEmbed(channel.ID, embed)
+	}
+
+	if err != nil {
+		logger.Error(err, map[string]any{"details": "failed to notify owner", "guildId": guildID, "userId": g.OwnerID})
+	}
+}
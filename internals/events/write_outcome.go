@@ -0,0 +1,192 @@
+package events
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/finkabaj/hyde-bot/internals/actions"
+	"github.com/finkabaj/hyde-bot/internals/logger"
+	"github.com/finkabaj/hyde-bot/internals/modlog"
+	"github.com/finkabaj/hyde-bot/internals/rules"
+	"github.com/finkabaj/hyde-bot/internals/utils/rule"
+)
+
+// NotifyWriteOutcome tells the admin who made a queued write whether the API saved it.
+// The message is a follow-up of the interaction while its token is valid, a direct message otherwise.
+func NotifyWriteOutcome(s *discordgo.Session) rules.WriteNotifier {
+	return func(w rules.Write, err error) {
+		if w.Origin == nil {
+			return
+		}
+
+		content := fmt.Sprintf("Your queued change is saved: %s", describeWrite(w))
+
+		if err != nil {
+			content = fmt.Sprintf("Your queued change was rejected and is reverted: %s", describeWrite(w))
+		}
+
+		_, followupErr := s.FollowupMessageCreate(&discordgo.Interaction{
+			AppID: w.Origin.AppId,
+			Token: w.Origin.InteractionToken,
+		}, false, &discordgo.WebhookParams{
+			Content: content,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		})
+
+		if followupErr == nil {
+			return
+		}
+
+		channel, dmErr := s.UserChannelCreate(w.Origin.UserId)
+
+		if dmErr == nil {
+			_, dmErr = s.ChannelMessageSend(channel.ID, content)
+		}
+
+		if dmErr != nil {
+			logger.Error(dmErr, map[string]any{"details": "failed to notify about queued write", "guildId": w.GuildId, "userId": w.Origin.UserId, "op": w.Op})
+		}
+	}
+}
+
+// ruleChangeOps are the writes posted to the mod log as rule changes.
+var ruleChangeOps = map[rules.WriteOp]bool{
+	rules.WritePostReactionRules:   true,
+	rules.WriteUpdateReactionRules: true,
+	rules.WriteDeleteReactionRules: true,
+	rules.WriteUpdateExemptions:    true,
+	rules.WriteUpdateEscalation:    true,
+	rules.WriteUpdateModLog:        true,
+	rules.WriteSaveReactionRoles:   true,
+	rules.WriteDeleteReactionRoles: true,
+	rules.WriteSaveReactionFlood:   true,
+	rules.WriteDeleteReactionFlood: true,
+}
+
+// ModLogWrites posts rule changes made by admins to the mod log of the guild.
+func ModLogWrites(p *modlog.Publisher) rules.WriteObserver {
+	return func(w rules.Write, status rules.WriteStatus) {
+		if !ruleChangeOps[w.Op] {
+			return
+		}
+
+		userID := ""
+
+		if w.Origin != nil {
+			userID = w.Origin.UserId
+		}
+
+		if status == rules.WriteRejected {
+			p.Publish(w.GuildId, modlog.RejectedChangeEmbed(userID, describeWrite(w)))
+			return
+		}
+
+		p.Publish(w.GuildId, modlog.RuleChangeEmbed(userID, describeWrite(w), status == rules.WriteQueued))
+	}
+}
+
+// ModLogRuleEvents posts rule changes made through the API, e.g. in the dashboard, to the mod log of the guild.
+func ModLogRuleEvents(p *modlog.Publisher) rules.RuleEventObserver {
+	return func(e rule.RuleEvent) {
+		p.Publish(e.GuildId, modlog.ExternalRuleChangeEmbed(describeRuleEvent(e)))
+	}
+}
+
+func describeRuleEvent(e rule.RuleEvent) string {
+	switch e.Op {
+	case rule.RuleEventCreated:
+		return "create reaction rules for " + describeReactionRules(e.ReactionRules)
+	case rule.RuleEventUpdated:
+		return "update reaction rules for " + describeReactionRules(e.ReactionRules)
+	case rule.RuleEventDeleted:
+		emojis := make([]string, 0, len(e.DeletedReactionRules))
+
+		for _, d := range e.DeletedReactionRules {
+			emojis = append(emojis, actions.EmojiMention(discordgo.Emoji{Name: d.EmojiName, ID: d.EmojiId}))
+		}
+
+		return "delete reaction rules for " + strings.Join(emojis, " ")
+	case rule.RuleEventExemptionsUpdated:
+		return "update reaction exemptions"
+	case rule.RuleEventModLogUpdated:
+		return describeWrite(rules.Write{Op: rules.WriteUpdateModLog, GuildId: e.GuildId, ModLog: e.ModLog})
+	case rule.RuleEventReactionRolesUpdated:
+		changes := make([]string, 0, len(e.ReactionRoles))
+
+		for _, m := range e.ReactionRoles {
+			changes = append(changes, describeWrite(rules.Write{Op: rules.WriteSaveReactionRoles, GuildId: e.GuildId, ReactionRoles: &m}))
+		}
+
+		return strings.Join(changes, "\n")
+	case rule.RuleEventReactionRolesDeleted:
+		return "delete reaction roles of messages " + strings.Join(e.DeletedReactionRoleMessages, ", ")
+	case rule.RuleEventReactionFloodUpdated:
+		return describeWrite(rules.Write{Op: rules.WriteSaveReactionFlood, GuildId: e.GuildId, ReactionFlood: e.ReactionFlood})
+	case rule.RuleEventReactionFloodDeleted:
+		return "disable reaction flood limit"
+	default:
+		return string(e.Op)
+	}
+}
+
+func describeReactionRules(reactionRules []rule.ReactionRule) string {
+	emojis := make([]string, 0, len(reactionRules))
+
+	for _, r := range reactionRules {
+		if r.Match != rule.MatchExact {
+			emojis = append(emojis, r.Describe())
+			continue
+		}
+
+		emojis = append(emojis, actions.EmojiMention(discordgo.Emoji{Name: r.EmojiName, ID: r.EmojiId}))
+	}
+
+	return strings.Join(emojis, " ")
+}
+
+func describeWrite(w rules.Write) string {
+	switch w.Op {
+	case rules.WritePostReactionRules:
+		return "create reaction rules for " + describeReactionRules(w.ReactionRules)
+	case rules.WriteUpdateReactionRules:
+		emojis := make([]string, 0, len(w.ReactionRuleUpdates))
+
+		for _, u := range w.ReactionRuleUpdates {
+			emojis = append(emojis, actions.EmojiMention(discordgo.Emoji{Name: u.EmojiName, ID: u.EmojiId}))
+		}
+
+		return "update reaction rules for " + strings.Join(emojis, " ")
+	case rules.WriteDeleteReactionRules:
+		emojis := make([]string, 0, len(w.DeletedReactionRules))
+
+		for _, d := range w.DeletedReactionRules {
+			emojis = append(emojis, actions.EmojiMention(discordgo.Emoji{Name: d.EmojiName, ID: d.EmojiId}))
+		}
+
+		return "delete reaction rules for " + strings.Join(emojis, " ")
+	case rules.WriteUpdateExemptions:
+		return "update reaction exemptions"
+	case rules.WriteUpdateEscalation:
+		return "update escalation policy"
+	case rules.WriteUpdateModLog:
+		if w.ModLog.ChannelId == "" {
+			return "disable mod log"
+		}
+
+		return fmt.Sprintf("set mod log channel to <#%s>", w.ModLog.ChannelId)
+	case rules.WriteSaveReactionRoles:
+		return fmt.Sprintf("set %s reaction roles of https://discord.com/channels/%s/%s/%s",
+			w.ReactionRoles.Mode, w.GuildId, w.ReactionRoles.ChannelId, w.ReactionRoles.MessageId)
+	case rules.WriteDeleteReactionRoles:
+		return "delete reaction roles of message " + w.DeletedReactionRoleMessage
+	case rules.WriteSaveReactionFlood:
+		return fmt.Sprintf("limit reactions to %d in %s per member", w.ReactionFlood.MaxReactions, w.ReactionFlood.Window())
+	case rules.WriteDeleteReactionFlood:
+		return "disable reaction flood limit"
+	case rules.WriteCreateInfraction:
+		return fmt.Sprintf("record %s of <@%s>", w.Infraction.Action, w.Infraction.UserId)
+	default:
+		return string(w.Op)
+	}
+}
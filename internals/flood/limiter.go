@@ -0,0 +1,99 @@
+// Package flood limits how fast members react for reaction flood rules.
+package flood
+
+import (
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/finkabaj/hyde-bot/internals/utils/rule"
+)
+
+// Reaction is a reaction a member made, kept so flood reactions can be removed.
+type Reaction struct {
+	ChannelID string
+	MessageID string
+	Emoji     discordgo.Emoji
+}
+
+type key struct {
+	guildID string
+	userID  string
+}
+
+type entry struct {
+	reaction Reaction
+	at       time.Time
+}
+
+// Limiter keeps a sliding window of recent reactions per member of a guild. A window holds at most
+// MaxReactions+1 reactions of the flood rule, members are dropped once their reactions expired.
+type Limiter struct {
+	windows map[key][]entry
+	now     func() time.Time
+	lock    sync.Mutex
+}
+
+var limiter *Limiter
+
+func NewLimiter() *Limiter {
+	if limiter == nil {
+		limiter = &Limiter{
+			windows: make(map[key][]entry),
+			now:     time.Now,
+		}
+	}
+	return limiter
+}
+
+// Add records the reaction of the member. Once the member made more than MaxReactions reactions within the window
+// of the flood rule, Add returns them and true. The window of the member is reset then, so a flood is reported once.
+func (l *Limiter) Add(guildID, userID string, r Reaction, f rule.ReactionFloodRule) ([]Reaction, bool) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := l.now()
+	k := key{guildID: guildID, userID: userID}
+	window, ok := l.windows[k]
+
+	if !ok {
+		// windows of members who stopped reacting are never looked at again, they're swept as new members come in
+		if len(l.windows)%1024 == 0 {
+			l.evictExpired(now)
+		}
+	}
+
+	// reactions are in order, so expired ones are at the start
+	expired := 0
+
+	for expired < len(window) && !now.Before(window[expired].at.Add(f.Window())) {
+		expired++
+	}
+
+	window = append(window[expired:], entry{reaction: r, at: now})
+
+	if len(window) <= f.MaxReactions {
+		l.windows[k] = window
+		return nil, false
+	}
+
+	delete(l.windows, k)
+
+	reactions := make([]Reaction, 0, len(window))
+
+	for _, e := range window {
+		reactions = append(reactions, e.reaction)
+	}
+
+	return reactions, true
+}
+
+// evictExpired drops windows whose last reaction is older than any flood window, no rule can count them anymore.
+// The guild may have changed its rule since, so the longest allowed window is used. Must be called with lock held.
+func (l *Limiter) evictExpired(now time.Time) {
+	for k, window := range l.windows {
+		if len(window) == 0 || !now.Before(window[len(window)-1].at.Add(rule.MaxFloodWindow)) {
+			delete(l.windows, k)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package flood
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/finkabaj/hyde-bot/internals/utils/rule"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiter(t *testing.T) {
+	t.Run("Exceeded", testLimiterExceeded)
+	t.Run("Window", testLimiterWindow)
+	t.Run("Reset", testLimiterReset)
+	t.Run("Members", testLimiterMembers)
+	t.Run("Concurrent", testLimiterConcurrent)
+}
+
+var testFlood = rule.ReactionFloodRule{GuildId: "1", MaxReactions: 3, WindowSeconds: 10, Actions: rule.Actions{{Type: rule.Delete}}}
+
+// newTestLimiter returns a limiter with a clock the test moves forward.
+func newTestLimiter() (*Limiter, func(d time.Duration)) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := &Limiter{
+		windows: make(map[key][]entry),
+		now:     func() time.Time { return now },
+	}
+
+	return l, func(d time.Duration) { now = now.Add(d) }
+}
+
+func reaction(messageID string) Reaction {
+	return Reaction{ChannelID: "100", MessageID: messageID, Emoji: discordgo.Emoji{Name: "👍"}}
+}
+
+func testLimiterExceeded(t *testing.T) {
+	l, _ := newTestLimiter()
+
+	for _, m := range []string{"10", "11", "12"} {
+		_, exceeded := l.Add("1", "a", reaction(m), testFlood)
+		assert.False(t, exceeded)
+	}
+
+	reactions, exceeded := l.Add("1", "a", reaction("13"), testFlood)
+
+	assert.True(t, exceeded)
+	assert.Equal(t, []Reaction{reaction("10"), reaction("11"), reaction("12"), reaction("13")}, reactions)
+}
+
+func testLimiterWindow(t *testing.T) {
+	l, advance := newTestLimiter()
+
+	l.Add("1", "a", reaction("10"), testFlood)
+	advance(6 * time.Second)
+	l.Add("1", "a", reaction("11"), testFlood)
+	l.Add("1", "a", reaction("12"), testFlood)
+	advance(4 * time.Second)
+
+	_, exceeded := l.Add("1", "a", reaction("13"), testFlood)
+	assert.False(t, exceeded, "the first reaction is older than the window")
+
+	reactions, exceeded := l.Add("1", "a", reaction("14"), testFlood)
+
+	assert.True(t, exceeded)
+	assert.Equal(t, []Reaction{reaction("11"), reaction("12"), reaction("13"), reaction("14")}, reactions)
+}
+
+func testLimiterReset(t *testing.T) {
+	l, _ := newTestLimiter()
+
+	for _, m := range []string{"10", "11", "12", "13"} {
+		l.Add("1", "a", reaction(m), testFlood)
+	}
+
+	_, exceeded := l.Add("1", "a", reaction("14"), testFlood)
+
+	assert.False(t, exceeded, "the window is reset once the flood is reported")
+}
+
+func testLimiterMembers(t *testing.T) {
+	l, _ := newTestLimiter()
+
+	for _, m := range []string{"10", "11", "12"} {
+		l.Add("1", "a", reaction(m), testFlood)
+	}
+
+	_, exceeded := l.Add("1", "b", reaction("13"), testFlood)
+	assert.False(t, exceeded)
+
+	_, exceeded = l.Add("2", "a", reaction("13"), testFlood)
+	assert.False(t, exceeded)
+}
+
+func testLimiterConcurrent(t *testing.T) {
+	l, _ := newTestLimiter()
+
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	floods := 0
+
+	for range 100 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if _, exceeded := l.Add("1", "a", reaction("10"), testFlood); exceeded {
+				lock.Lock()
+				floods++
+				lock.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, 25, floods, "every 4th reaction exceeds the limit")
+}
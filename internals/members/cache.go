@@ -0,0 +1,104 @@
+package members
+
+import (
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// DefaultTTL is used when the cache is created with non positive ttl.
+const DefaultTTL = 5 * time.Minute
+
+type entry struct {
+	roles   []string
+	expires time.Time
+}
+
+// Cache keeps roles of guild members, so handlers don't call the REST api on every event.
+// Entries are refreshed from member events and expire after ttl.
+type Cache struct {
+	ttl     time.Duration
+	entries map[string]entry // entries[guildID+":"+userID]
+	lock    sync.RWMutex
+}
+
+var cache *Cache
+
+func NewCache(ttl time.Duration) *Cache {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+
+	if cache == nil {
+		cache = &Cache{
+			ttl:     ttl,
+			entries: make(map[string]entry),
+		}
+	}
+	return cache
+}
+
+// Roles returns role ids of the member. Cache is used first, then the session state, REST api is a fallback.
+func (c *Cache) Roles(s *discordgo.Session, guildID, userID string) ([]string, error) {
+	c.lock.RLock()
+	e, ok := c.entries[key(guildID, userID)]
+	c.lock.RUnlock()
+
+	if ok && time.Now().Before(e.expires) {
+		return e.roles, nil
+	}
+
+	m, err := s.State.Member(guildID, userID)
+
+	if err != nil {
+		m, err = s.GuildMember(guildID, userID)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	c.Set(guildID, userID, m.Roles)
+
+	return m.Roles, nil
+}
+
+// Set caches roles of the member.
+func (c *Cache) Set(guildID, userID string, roles []string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.entries[key(guildID, userID)] = entry{
+		roles:   roles,
+		expires: time.Now().Add(c.ttl),
+	}
+
+	// expired entries are dropped lazily, so the map doesn't grow with every member ever seen
+	if len(c.entries)%1024 == 0 {
+		c.evictExpired()
+	}
+}
+
+// Delete removes the member from the cache.
+func (c *Cache) Delete(guildID, userID string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	delete(c.entries, key(guildID, userID))
+}
+
+// evictExpired must be called with lock held.
+func (c *Cache) evictExpired() {
+	now := time.Now()
+
+	for k, e := range c.entries {
+		if !now.Before(e.expires) {
+			delete(c.entries, k)
+		}
+	}
+}
+
+func key(guildID, userID string) string {
+	return guildID + ":" + userID
+}
@@ -0,0 +1,189 @@
+package modlog
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/finkabaj/hyde-bot/internals/actions"
+	"github.com/finkabaj/hyde-bot/internals/utils/common"
+	"github.com/finkabaj/hyde-bot/internals/utils/escalation"
+	"github.com/finkabaj/hyde-bot/internals/utils/rule"
+)
+
+// Colors of the entries by their kind.
+const (
+	ColorEnforcement = 0xe74c3c
+	ColorEscalation  = 0xe67e22
+	ColorRuleChange  = 0x3498db
+	ColorCommand     = 0x95a5a6
+	ColorNotice      = 0xf1c40f
+)
+
+// discord limits of embed texts
+const (
+	maxDescriptionLength = 4096
+	maxFieldValueLength  = 1024
+)
+
+// EnforcementEmbed describes actions taken on the member who reacted with a forbidden emoji.
+func EnforcementEmbed(t actions.Target, results []actions.Result) *discordgo.MessageEmbed {
+	return &discordgo.MessageEmbed{
+		Title: "Reaction rule enforced",
+		Description: fmt.Sprintf("<@%s> reacted with %s in <#%s>\nhttps://discord.com/channels/%s/%s/%s",
+			t.UserID, actions.EmojiMention(t.Emoji), t.ChannelID, t.GuildID, t.ChannelID, t.MessageID),
+		Color: ColorEnforcement,
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: "Actions", Value: resultsValue(results)},
+		},
+	}
+}
+
+// ThresholdEmbed describes actions taken on the message that reached the threshold of a reaction rule.
+// UserID of t is the author of the message.
+func ThresholdEmbed(t actions.Target, threshold rule.ReactionThreshold, results []actions.Result) *discordgo.MessageEmbed {
+	return &discordgo.MessageEmbed{
+		Title: "Reaction threshold reached",
+		Description: fmt.Sprintf("Message of <@%s> in <#%s> got %s from %d members within %s\nhttps://discord.com/channels/%s/%s/%s",
+			t.UserID, t.ChannelID, actions.EmojiMention(t.Emoji), threshold.Count, common.FormatDuration(threshold.Window()),
+			t.GuildID, t.ChannelID, t.MessageID),
+		Color: ColorEnforcement,
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: "Actions", Value: resultsValue(results)},
+		},
+	}
+}
+
+// FloodEmbed describes actions taken on the member who exceeded the reaction flood rule.
+func FloodEmbed(t actions.Target, f rule.ReactionFloodRule, results []actions.Result) *discordgo.MessageEmbed {
+	return &discordgo.MessageEmbed{
+		Title: "Reaction flood limit exceeded",
+		Description: fmt.Sprintf("<@%s> reacted more than %d times within %s, last in <#%s>\nhttps://discord.com/channels/%s/%s/%s",
+			t.UserID, f.MaxReactions, common.FormatDuration(f.Window()), t.ChannelID, t.GuildID, t.ChannelID, t.MessageID),
+		Color: ColorEnforcement,
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: "Actions", Value: resultsValue(results)},
+		},
+	}
+}
+
+func resultsValue(results []actions.Result) string {
+	lines := make([]string, 0, len(results))
+
+	for _, res := range results {
+		line := "✅ " + actionLabel(res.Action)
+
+		if res.Err != nil {
+			line = fmt.Sprintf("❌ %s: %s", actionLabel(res.Action), res.Err)
+		}
+
+		lines = append(lines, line)
+	}
+
+	return truncate(strings.Join(lines, "\n"), maxFieldValueLength)
+}
+
+// EscalationEmbed describes the escalation step taken on the member.
+func EscalationEmbed(t actions.Target, step escalation.Step) *discordgo.MessageEmbed {
+	return &discordgo.MessageEmbed{
+		Title:       "Escalation step taken",
+		Description: fmt.Sprintf("<@%s>: %s", t.UserID, step.Reason()),
+		Color:       ColorEscalation,
+	}
+}
+
+// RuleChangeEmbed describes a change of the guild configuration made by the user.
+// Queued changes are sent to the API once it's available, they may still be rejected.
+func RuleChangeEmbed(userID, change string, queued bool) *discordgo.MessageEmbed {
+	e := &discordgo.MessageEmbed{
+		Title:       "Rules changed",
+		Description: truncate(fmt.Sprintf("<@%s>: %s", userID, change), maxDescriptionLength),
+		Color:       ColorRuleChange,
+	}
+
+	if userID == "" {
+		e.Description = truncate(change, maxDescriptionLength)
+	}
+
+	if queued {
+		e.Footer = &discordgo.MessageEmbedFooter{Text: "Queued, the API is unavailable"}
+	}
+
+	return e
+}
+
+// RejectedChangeEmbed describes a queued change made by the user that the API rejected once it was back.
+// The change is reverted.
+func RejectedChangeEmbed(userID, change string) *discordgo.MessageEmbed {
+	e := RuleChangeEmbed(userID, change, false)
+	e.Title = "Queued rule change rejected"
+	e.Color = ColorNotice
+	e.Footer = &discordgo.MessageEmbedFooter{Text: "The change is reverted"}
+
+	return e
+}
+
+// ExternalRuleChangeEmbed describes a change of the guild configuration made outside of discord, e.g. in the dashboard.
+func ExternalRuleChangeEmbed(change string) *discordgo.MessageEmbed {
+	e := RuleChangeEmbed("", change, false)
+	e.Footer = &discordgo.MessageEmbedFooter{Text: "Changed through the API"}
+
+	return e
+}
+
+// CommandEmbed describes an application command executed in the guild.
+func CommandEmbed(i *discordgo.InteractionCreate) *discordgo.MessageEmbed {
+	userID := ""
+
+	if i.Member != nil && i.Member.User != nil {
+		userID = i.Member.User.ID
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       "Command executed",
+		Description: truncate(fmt.Sprintf("<@%s> in <#%s>\n%s", userID, i.ChannelID, CommandLine(i.ApplicationCommandData())), maxDescriptionLength),
+		Color:       ColorCommand,
+	}
+}
+
+// CommandLine formats the command as typed, e.g. "/escalation set warns:3 window:7d".
+func CommandLine(data discordgo.ApplicationCommandInteractionData) string {
+	return strings.Join(appendOptions([]string{"/" + data.Name}, data.Options), " ")
+}
+
+func appendOptions(parts []string, options []*discordgo.ApplicationCommandInteractionDataOption) []string {
+	for _, o := range options {
+		switch o.Type {
+		case discordgo.ApplicationCommandOptionSubCommand, discordgo.ApplicationCommandOptionSubCommandGroup:
+			parts = appendOptions(append(parts, o.Name), o.Options)
+		case discordgo.ApplicationCommandOptionUser:
+			parts = append(parts, fmt.Sprintf("%s:<@%v>", o.Name, o.Value))
+		case discordgo.ApplicationCommandOptionChannel:
+			parts = append(parts, fmt.Sprintf("%s:<#%v>", o.Name, o.Value))
+		case discordgo.ApplicationCommandOptionRole:
+			parts = append(parts, fmt.Sprintf("%s:<@&%v>", o.Name, o.Value))
+		default:
+			parts = append(parts, fmt.Sprintf("%s:%v", o.Name, o.Value))
+		}
+	}
+
+	return parts
+}
+
+func actionLabel(a rule.Action) string {
+	if a.Type == rule.Timeout {
+		return a.Type.String() + " " + common.FormatDuration(a.Duration())
+	}
+
+	return a.Type.String()
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+
+	if len(r) <= n {
+		return s
+	}
+
+	return string(r[:n-1]) + "…"
+}
@@ -0,0 +1,192 @@
+// Package modlog posts moderation log entries to the mod log channels of guilds.
+// Entries are batched, so bursts of enforcement don't hit the rate limits of discord.
+package modlog
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/finkabaj/hyde-bot/internals/logger"
+	"github.com/finkabaj/hyde-bot/internals/rules"
+)
+
+const (
+	// FlushInterval is how often pending entries are posted.
+	FlushInterval = 2 * time.Second
+	// MaxEmbedsPerMessage is the discord limit of embeds in a message.
+	MaxEmbedsPerMessage = 10
+	// MaxMessagesPerFlush limits messages posted to a channel per flush. The channel rate limit
+	// is 5 messages per 5 seconds, the rest of the entries waits for the next flush.
+	MaxMessagesPerFlush = 2
+	// MaxPending is the number of entries kept per guild, the oldest entries are dropped when it's exceeded.
+	MaxPending = 200
+
+	// maxEmbedsSize is the discord limit of characters of all embeds in a message.
+	maxEmbedsSize = 6000
+)
+
+// sendFunc posts embeds as one message to the channel.
+type sendFunc func(channelID string, embeds []*discordgo.MessageEmbed) error
+
+type guildLog struct {
+	pending []*discordgo.MessageEmbed
+	dropped int
+}
+
+// Publisher collects mod log entries of guilds and posts them in batches.
+type Publisher struct {
+	channel func(guildID string) string // channel returns the mod log channel of the guild, empty if it's disabled.
+	guilds  map[string]*guildLog
+	lock    sync.Mutex
+}
+
+var publisher *Publisher
+
+func NewPublisher(rm *rules.RuleManager) *Publisher {
+	if publisher == nil {
+		publisher = &Publisher{
+			channel: rm.ModLogChannel,
+			guilds:  make(map[string]*guildLog),
+		}
+	}
+	return publisher
+}
+
+// Publish queues the entry for the mod log of the guild. Entries of guilds without mod log are ignored.
+func (p *Publisher) Publish(guildID string, e *discordgo.MessageEmbed) {
+	if p == nil || guildID == "" || p.channel(guildID) == "" {
+		return
+	}
+
+	if e.Timestamp == "" {
+		e.Timestamp = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	gl, ok := p.guilds[guildID]
+
+	if !ok {
+		gl = &guildLog{}
+		p.guilds[guildID] = gl
+	}
+
+	gl.pending = append(gl.pending, e)
+
+	if n := len(gl.pending) - MaxPending; n > 0 {
+		gl.pending = gl.pending[n:]
+		gl.dropped += n
+	}
+}
+
+// Run posts pending entries every FlushInterval until ctx is done.
+func (p *Publisher) Run(ctx context.Context, s *discordgo.Session) {
+	ticker := time.NewTicker(FlushInterval)
+	defer ticker.Stop()
+
+	send := func(channelID string, embeds []*discordgo.MessageEmbed) error {
+		_, err := s.ChannelMessageSendEmbeds(channelID, embeds)
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.flush(send)
+		}
+	}
+}
+
+// flush posts the messages taken from pending entries. Messages of a guild after a failed one are dropped.
+func (p *Publisher) flush(send sendFunc) {
+	for guildID, messages := range p.take() {
+		channelID := p.channel(guildID)
+
+		if channelID == "" {
+			continue
+		}
+
+		for _, embeds := range messages {
+			if err := send(channelID, embeds); err != nil {
+				logger.Error(err, map[string]any{"details": "failed to post mod log", "guildId": guildID, "channelId": channelID, "entries": len(embeds)})
+				break
+			}
+		}
+	}
+}
+
+// take removes up to MaxMessagesPerFlush messages of entries from every guild.
+func (p *Publisher) take() map[string][][]*discordgo.MessageEmbed {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	taken := make(map[string][][]*discordgo.MessageEmbed, len(p.guilds))
+
+	for guildID, gl := range p.guilds {
+		if gl.dropped > 0 {
+			gl.pending = append([]*discordgo.MessageEmbed{droppedEmbed(gl.dropped)}, gl.pending...)
+			gl.dropped = 0
+		}
+
+		var messages [][]*discordgo.MessageEmbed
+
+		for len(messages) < MaxMessagesPerFlush && len(gl.pending) > 0 {
+			n, size := 0, 0
+
+			for n < len(gl.pending) && n < MaxEmbedsPerMessage {
+				size += embedSize(gl.pending[n])
+
+				if n > 0 && size > maxEmbedsSize {
+					break
+				}
+
+				n++
+			}
+
+			messages = append(messages, gl.pending[:n])
+			gl.pending = gl.pending[n:]
+		}
+
+		if len(gl.pending) == 0 {
+			delete(p.guilds, guildID)
+		}
+
+		taken[guildID] = messages
+	}
+
+	return taken
+}
+
+func droppedEmbed(n int) *discordgo.MessageEmbed {
+	return &discordgo.MessageEmbed{
+		Title:       "Entries dropped",
+		Description: fmt.Sprintf("%d older entries were dropped, there were too many at once", n),
+		Color:       ColorNotice,
+		Timestamp:   time.Now().UTC().Format(time.RFC3339),
+	}
+}
+
+// embedSize returns the number of characters discord counts towards the limit of a message.
+func embedSize(e *discordgo.MessageEmbed) int {
+	size := len([]rune(e.Title)) + len([]rune(e.Description))
+
+	for _, f := range e.Fields {
+		size += len([]rune(f.Name)) + len([]rune(f.Value))
+	}
+
+	if e.Footer != nil {
+		size += len([]rune(e.Footer.Text))
+	}
+
+	if e.Author != nil {
+		size += len([]rune(e.Author.Name))
+	}
+
+	return size
+}
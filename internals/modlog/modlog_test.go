@@ -0,0 +1,148 @@
+package modlog
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublisher(t *testing.T) {
+	t.Run("NoChannel", testPublisherNoChannel)
+	t.Run("Batches", testPublisherBatches)
+	t.Run("EmbedsSize", testPublisherEmbedsSize)
+	t.Run("Dropped", testPublisherDropped)
+	t.Run("Flush", testPublisherFlush)
+}
+
+func TestCommandLine(t *testing.T) {
+	t.Run("Subcommand", testCommandLineSubcommand)
+	t.Run("Mentions", testCommandLineMentions)
+}
+
+func newTestPublisher(channels map[string]string) *Publisher {
+	return &Publisher{
+		channel: func(guildID string) string { return channels[guildID] },
+		guilds:  make(map[string]*guildLog),
+	}
+}
+
+func entries(n int) []*discordgo.MessageEmbed {
+	embeds := make([]*discordgo.MessageEmbed, 0, n)
+
+	for i := range n {
+		embeds = append(embeds, &discordgo.MessageEmbed{Description: fmt.Sprint(i)})
+	}
+
+	return embeds
+}
+
+func testPublisherNoChannel(t *testing.T) {
+	p := newTestPublisher(map[string]string{})
+
+	p.Publish("1", &discordgo.MessageEmbed{})
+
+	assert.Empty(t, p.take())
+
+	var nilPublisher *Publisher
+	assert.NotPanics(t, func() { nilPublisher.Publish("1", &discordgo.MessageEmbed{}) })
+}
+
+func testPublisherBatches(t *testing.T) {
+	p := newTestPublisher(map[string]string{"1": "10"})
+
+	for _, e := range entries(25) {
+		p.Publish("1", e)
+	}
+
+	taken := p.take()["1"]
+
+	assert.Len(t, taken, MaxMessagesPerFlush)
+	assert.Len(t, taken[0], MaxEmbedsPerMessage)
+	assert.Len(t, taken[1], MaxEmbedsPerMessage)
+	assert.Equal(t, "0", taken[0][0].Description)
+	assert.NotEmpty(t, taken[0][0].Timestamp)
+
+	taken = p.take()["1"]
+
+	assert.Len(t, taken, 1, "the rest waits for the next flush")
+	assert.Len(t, taken[0], 5)
+	assert.Equal(t, "20", taken[0][0].Description)
+	assert.Empty(t, p.guilds)
+}
+
+func testPublisherEmbedsSize(t *testing.T) {
+	p := newTestPublisher(map[string]string{"1": "10"})
+
+	for range 3 {
+		p.Publish("1", &discordgo.MessageEmbed{Description: strings.Repeat("a", 2500)})
+	}
+
+	taken := p.take()["1"]
+
+	assert.Len(t, taken, 2)
+	assert.Len(t, taken[0], 2)
+	assert.Len(t, taken[1], 1)
+}
+
+func testPublisherDropped(t *testing.T) {
+	p := newTestPublisher(map[string]string{"1": "10"})
+
+	for _, e := range entries(MaxPending + 5) {
+		p.Publish("1", e)
+	}
+
+	taken := p.take()["1"]
+
+	assert.Contains(t, taken[0][0].Description, "5 older entries were dropped")
+	assert.Equal(t, "5", taken[0][1].Description)
+}
+
+func testPublisherFlush(t *testing.T) {
+	p := newTestPublisher(map[string]string{"1": "10", "2": "20"})
+	sent := make(map[string]int)
+
+	p.Publish("1", &discordgo.MessageEmbed{})
+	p.Publish("2", &discordgo.MessageEmbed{})
+	p.Publish("2", &discordgo.MessageEmbed{})
+
+	p.flush(func(channelID string, embeds []*discordgo.MessageEmbed) error {
+		sent[channelID] += len(embeds)
+		return nil
+	})
+
+	assert.Equal(t, map[string]int{"10": 1, "20": 2}, sent)
+}
+
+func testCommandLineSubcommand(t *testing.T) {
+	line := CommandLine(discordgo.ApplicationCommandInteractionData{
+		Name: "escalation",
+		Options: []*discordgo.ApplicationCommandInteractionDataOption{
+			{
+				Name: "set",
+				Type: discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandInteractionDataOption{
+					{Name: "warns", Type: discordgo.ApplicationCommandOptionInteger, Value: float64(3)},
+					{Name: "window", Type: discordgo.ApplicationCommandOptionString, Value: "7d"},
+				},
+			},
+		},
+	})
+
+	assert.Equal(t, "/escalation set warns:3 window:7d", line)
+}
+
+func testCommandLineMentions(t *testing.T) {
+	line := CommandLine(discordgo.ApplicationCommandInteractionData{
+		Name: "warn",
+		Options: []*discordgo.ApplicationCommandInteractionDataOption{
+			{Name: "user", Type: discordgo.ApplicationCommandOptionUser, Value: "1"},
+			{Name: "channel", Type: discordgo.ApplicationCommandOptionChannel, Value: "2"},
+			{Name: "role", Type: discordgo.ApplicationCommandOptionRole, Value: "3"},
+		},
+	})
+
+	assert.Equal(t, "/warn user:<@1> channel:<#2> role:<@&3>", line)
+}
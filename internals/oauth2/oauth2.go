@@ -1,3 +1,216 @@
+// Package oauth2 implements the client side of the Discord OAuth2 authorization code flow.
 package oauth2
 
-import ()
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	DiscordAuthURL  = "https://discord.com/oauth2/authorize"
+	DiscordTokenURL = "https://discord.com/api/oauth2/token"
+	DiscordAPIURL   = "https://discord.com/api"
+)
+
+var DefaultScopes = []string{"identify", "guilds"}
+
+var (
+	ErrExchange = errors.New("error exchanging oauth2 grant")
+	ErrAPI      = errors.New("discord api request failed")
+)
+
+// Config of the Discord application. Empty urls and scopes fall back to the Discord defaults.
+type Config struct {
+	ClientId     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	AuthURL      string
+	TokenURL     string
+	APIURL       string
+}
+
+type Token struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+	Scope        string `json:"scope"`
+}
+
+type DiscordUser struct {
+	Id         string `json:"id"`
+	Username   string `json:"username"`
+	GlobalName string `json:"global_name"`
+}
+
+// DiscordGuild is a partial guild from the guilds of the user. Permissions are the permissions
+// of the user in the guild without channel overwrites.
+type DiscordGuild struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	Owner       bool   `json:"owner"`
+	Permissions int64  `json:"permissions,string"`
+}
+
+// DisplayName returns the global name of the user or the username if it isn't set.
+func (u DiscordUser) DisplayName() string {
+	if u.GlobalName != "" {
+		return u.GlobalName
+	}
+
+	return u.Username
+}
+
+type IClient interface {
+	AuthCodeURL(state string) string
+	Exchange(ctx context.Context, code string) (Token, error)
+	Refresh(ctx context.Context, refreshToken string) (Token, error)
+	FetchUser(ctx context.Context, accessToken string) (DiscordUser, error)
+	FetchGuilds(ctx context.Context, accessToken string) ([]DiscordGuild, error)
+}
+
+type Client struct {
+	config Config
+	client *http.Client
+}
+
+func NewClient(c Config, client *http.Client) *Client {
+	if c.AuthURL == "" {
+		c.AuthURL = DiscordAuthURL
+	}
+
+	if c.TokenURL == "" {
+		c.TokenURL = DiscordTokenURL
+	}
+
+	if c.APIURL == "" {
+		c.APIURL = DiscordAPIURL
+	}
+
+	if len(c.Scopes) == 0 {
+		c.Scopes = DefaultScopes
+	}
+
+	return &Client{
+		config: c,
+		client: client,
+	}
+}
+
+// AuthCodeURL returns the url of the Discord consent page. state is sent back to the redirect url unchanged.
+func (c *Client) AuthCodeURL(state string) string {
+	v := url.Values{
+		"response_type": {"code"},
+		"client_id":     {c.config.ClientId},
+		"redirect_uri":  {c.config.RedirectURL},
+		"scope":         {strings.Join(c.config.Scopes, " ")},
+		"state":         {state},
+	}
+
+	return c.config.AuthURL + "?" + v.Encode()
+}
+
+// Exchange trades the authorization code from the redirect for a Discord access token.
+func (c *Client) Exchange(ctx context.Context, code string) (Token, error) {
+	return c.token(ctx, url.Values{
+		"grant_type":   {"authorization_code"},
+		"code":         {code},
+		"redirect_uri": {c.config.RedirectURL},
+	})
+}
+
+// Refresh trades the refresh token of an earlier exchange for a new Discord access token.
+// Discord rotates refresh tokens, the one in the returned token replaces refreshToken.
+func (c *Client) Refresh(ctx context.Context, refreshToken string) (Token, error) {
+	return c.token(ctx, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+	})
+}
+
+// token posts the grant in form to the token endpoint. Rejected grants return ErrExchange.
+func (c *Client) token(ctx context.Context, form url.Values) (Token, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.config.TokenURL, strings.NewReader(form.Encode()))
+
+	if err != nil {
+		return Token{}, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(c.config.ClientId, c.config.ClientSecret)
+
+	res, err := c.client.Do(req)
+
+	if err != nil {
+		return Token{}, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return Token{}, fmt.Errorf("%w: token endpoint responded with %d", ErrExchange, res.StatusCode)
+	}
+
+	var token Token
+
+	if err := json.NewDecoder(res.Body).Decode(&token); err != nil {
+		return Token{}, err
+	}
+
+	if token.AccessToken == "" {
+		return Token{}, fmt.Errorf("%w: empty access token", ErrExchange)
+	}
+
+	return token, nil
+}
+
+// FetchUser returns the user that authorized accessToken.
+func (c *Client) FetchUser(ctx context.Context, accessToken string) (DiscordUser, error) {
+	var u DiscordUser
+
+	if err := c.get(ctx, accessToken, "/users/@me", &u); err != nil {
+		return DiscordUser{}, err
+	}
+
+	return u, nil
+}
+
+// FetchGuilds returns the guilds of the user that authorized accessToken. Requires the guilds scope.
+func (c *Client) FetchGuilds(ctx context.Context, accessToken string) ([]DiscordGuild, error) {
+	var guilds []DiscordGuild
+
+	if err := c.get(ctx, accessToken, "/users/@me/guilds", &guilds); err != nil {
+		return nil, err
+	}
+
+	return guilds, nil
+}
+
+// get decodes the response of the api endpoint at path to v.
+func (c *Client) get(ctx context.Context, accessToken string, path string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.config.APIURL+path, nil)
+
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	res, err := c.client.Do(req)
+
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: %s responded with %d", ErrAPI, path, res.StatusCode)
+	}
+
+	return json.NewDecoder(res.Body).Decode(v)
+}
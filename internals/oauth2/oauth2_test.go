@@ -0,0 +1,179 @@
+package oauth2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeDiscord is a local token endpoint and api that accepts only the code "valid", the refresh
+// token "refresh" and the access token it issues for them.
+func fakeDiscord(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+		id, secret, _ := r.BasicAuth()
+
+		if r.Method != http.MethodPost || id != "id" || secret != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.FormValue("grant_type") {
+		case "authorization_code":
+			if r.FormValue("code") != "valid" || r.FormValue("redirect_uri") != "http://localhost/auth/callback" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			json.NewEncoder(w).Encode(Token{AccessToken: "access", TokenType: "Bearer", ExpiresIn: 604800, RefreshToken: "refresh", Scope: "identify guilds"})
+		case "refresh_token":
+			if r.FormValue("refresh_token") != "refresh" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			json.NewEncoder(w).Encode(Token{AccessToken: "access", TokenType: "Bearer", ExpiresIn: 604800, RefreshToken: "rotated", Scope: "identify guilds"})
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	mux.HandleFunc("/api/users/@me", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer access" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		json.NewEncoder(w).Encode(DiscordUser{Id: "1", Username: "user"})
+	})
+
+	mux.HandleFunc("/api/users/@me/guilds", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer access" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Write([]byte(`[{"id": "1", "name": "guild", "owner": false, "permissions": "2147483679"}]`))
+	})
+
+	s := httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func newTestClient(s *httptest.Server) *Client {
+	return NewClient(Config{
+		ClientId:     "id",
+		ClientSecret: "secret",
+		RedirectURL:  "http://localhost/auth/callback",
+		AuthURL:      s.URL + "/oauth2/authorize",
+		TokenURL:     s.URL + "/oauth2/token",
+		APIURL:       s.URL + "/api",
+	}, s.Client())
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	c := NewClient(Config{ClientId: "id", RedirectURL: "http://localhost/auth/callback"}, http.DefaultClient)
+
+	u, err := url.Parse(c.AuthCodeURL("state"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, DiscordAuthURL, u.Scheme+"://"+u.Host+u.Path)
+	assert.Equal(t, url.Values{
+		"response_type": {"code"},
+		"client_id":     {"id"},
+		"redirect_uri":  {"http://localhost/auth/callback"},
+		"scope":         {"identify guilds"},
+		"state":         {"state"},
+	}, u.Query())
+}
+
+func TestExchange(t *testing.T) {
+	t.Run("Positive", testExchangePositive)
+	t.Run("InvalidCode", testExchangeInvalidCode)
+}
+
+func TestRefresh(t *testing.T) {
+	t.Run("Positive", testRefreshPositive)
+	t.Run("InvalidToken", testRefreshInvalidToken)
+}
+
+func TestFetchUser(t *testing.T) {
+	t.Run("Positive", testFetchUserPositive)
+	t.Run("InvalidToken", testFetchUserInvalidToken)
+}
+
+func TestFetchGuilds(t *testing.T) {
+	c := newTestClient(fakeDiscord(t))
+
+	guilds, err := c.FetchGuilds(context.Background(), "access")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []DiscordGuild{{Id: "1", Name: "guild", Permissions: 2147483679}}, guilds)
+
+	_, err = c.FetchGuilds(context.Background(), "invalid")
+
+	assert.True(t, errors.Is(err, ErrAPI))
+}
+
+func testExchangePositive(t *testing.T) {
+	c := newTestClient(fakeDiscord(t))
+
+	token, err := c.Exchange(context.Background(), "valid")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "access", token.AccessToken)
+	assert.Equal(t, "refresh", token.RefreshToken)
+}
+
+func testExchangeInvalidCode(t *testing.T) {
+	c := newTestClient(fakeDiscord(t))
+
+	_, err := c.Exchange(context.Background(), "invalid")
+
+	assert.True(t, errors.Is(err, ErrExchange))
+}
+
+func testRefreshPositive(t *testing.T) {
+	c := newTestClient(fakeDiscord(t))
+
+	token, err := c.Refresh(context.Background(), "refresh")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "access", token.AccessToken)
+	assert.Equal(t, "rotated", token.RefreshToken)
+}
+
+func testRefreshInvalidToken(t *testing.T) {
+	c := newTestClient(fakeDiscord(t))
+
+	_, err := c.Refresh(context.Background(), "invalid")
+
+	assert.True(t, errors.Is(err, ErrExchange))
+}
+
+func testFetchUserPositive(t *testing.T) {
+	c := newTestClient(fakeDiscord(t))
+
+	u, err := c.FetchUser(context.Background(), "access")
+
+	assert.NoError(t, err)
+	assert.Equal(t, DiscordUser{Id: "1", Username: "user"}, u)
+	assert.Equal(t, "user", u.DisplayName())
+}
+
+func testFetchUserInvalidToken(t *testing.T) {
+	c := newTestClient(fakeDiscord(t))
+
+	_, err := c.FetchUser(context.Background(), "invalid")
+
+	assert.True(t, errors.Is(err, ErrAPI))
+}
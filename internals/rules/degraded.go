@@ -0,0 +1,293 @@
+package rules
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/finkabaj/hyde-bot/internals/apiclient"
+	"github.com/finkabaj/hyde-bot/internals/logger"
+	"github.com/finkabaj/hyde-bot/internals/utils/common"
+	"github.com/finkabaj/hyde-bot/internals/utils/guild"
+)
+
+// WriteReplayInterval is how often queued writes are retried.
+const WriteReplayInterval = 5 * time.Second
+
+var (
+	// ErrQueued is returned by writes made while the API is unavailable. The write is applied to the cache
+	// and sent to the API when it's back.
+	ErrQueued = errors.New("api is unavailable, the change is queued")
+	// ErrWritesPending is returned by SyncGuild while the guild has queued writes, the API doesn't know them yet.
+	ErrWritesPending = errors.New("guild has queued writes")
+	// ErrSyncInterrupted is returned by SyncGuild when a write or rule event was applied to the guild while its
+	// rules were fetched. The cache keeps the newer rules.
+	ErrSyncInterrupted = errors.New("guild rules changed while they were synced")
+)
+
+// Degraded reports whether the API is unavailable or writes made while it was aren't replayed yet.
+// Cached rules are enforced either way.
+func (rm *RuleManager) Degraded() bool {
+	return !rm.api.Available() || rm.queue.Len() > 0
+}
+
+// CreateGuild registers the guild in the API. Guilds that already exist get the owner and metadata of g.
+func (rm *RuleManager) CreateGuild(g guild.GuildCreate) error {
+	return rm.write(Write{Op: WriteCreateGuild, GuildId: g.GuildId, Guild: &g})
+}
+
+// UpdateGuild sets the owner and metadata of the guild in the API, nil fields of u are kept.
+func (rm *RuleManager) UpdateGuild(guildId string, u guild.GuildUpdate) error {
+	return rm.write(Write{Op: WriteUpdateGuild, GuildId: guildId, GuildUpdate: &u})
+}
+
+// DeleteGuild removes the guild from the API and the cache when the bot leaves it.
+// The API keeps its rules for the retention, so they are restored if the bot rejoins.
+func (rm *RuleManager) DeleteGuild(guildId string) error {
+	return rm.write(Write{Op: WriteDeleteGuild, GuildId: guildId})
+}
+
+// WriteStatus is the outcome of a write reported to the WriteObserver.
+type WriteStatus int
+
+const (
+	WriteSaved    WriteStatus = iota // WriteSaved writes are saved by the API.
+	WriteQueued                      // WriteQueued writes are queued while the API is unavailable.
+	WriteRejected                    // WriteRejected writes were queued and rejected by the API on replay, they are reverted.
+)
+
+// WriteObserver is called with every write made through the RuleManager once the API saved it or it's queued,
+// and again if a queued write is rejected.
+type WriteObserver func(w Write, status WriteStatus)
+
+// ObserveWrites sets the observer of writes, replacing the previous one. nil removes it.
+func (rm *RuleManager) ObserveWrites(o WriteObserver) {
+	rm.lock.Lock()
+	defer rm.lock.Unlock()
+
+	rm.observer = o
+}
+
+// WriteNotifier is called with the outcome of a replayed write, err is nil if the API saved it.
+type WriteNotifier func(w Write, err error)
+
+// ReplayWrites sends queued writes in order until ctx is done. notify can be nil.
+func (rm *RuleManager) ReplayWrites(ctx context.Context, notify WriteNotifier) {
+	ticker := time.NewTicker(WriteReplayInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			rm.replayWrites(ctx, notify)
+		}
+	}
+}
+
+// replayWrites sends queued writes until the queue is empty or the API is unavailable.
+// Writes rejected by the API are dropped and the guild is synced, so the cache drops them too.
+func (rm *RuleManager) replayWrites(ctx context.Context, notify WriteNotifier) {
+	for {
+		w, ok := rm.queue.Peek()
+
+		if !ok {
+			return
+		}
+
+		err := rm.sendWrite(ctx, w)
+
+		if errors.Is(err, apiclient.ErrUnavailable) || ctx.Err() != nil {
+			return
+		}
+
+		if popErr := rm.queue.Pop(); popErr != nil {
+			// the write stays in the file and is replayed again after restart
+			logger.Error(popErr, map[string]any{"details": "error while removing replayed write", "guildId": w.GuildId, "op": w.Op})
+		}
+
+		if notify != nil {
+			notify(w, err)
+		}
+
+		if err == nil {
+			logger.Info("Queued write replayed", map[string]any{"guildId": w.GuildId, "op": w.Op, "pending": rm.queue.Len()})
+			continue
+		}
+
+		logger.Error(err, map[string]any{"details": "queued write rejected by the api", "guildId": w.GuildId, "op": w.Op})
+		rm.observe(w, WriteRejected)
+
+		if rm.queue.HasGuild(w.GuildId) {
+			continue
+		}
+
+		if err := rm.SyncGuild(w.GuildId); err != nil {
+			logger.Error(err, map[string]any{"details": "error while syncing guild after rejected write", "guildId": w.GuildId})
+		}
+	}
+}
+
+// write sends w to the API and applies it to the cache. While the API is unavailable, or earlier writes
+// of the guild are still queued, w is queued and applied to the cache right away, then ErrQueued is returned.
+func (rm *RuleManager) write(w Write) error {
+	// a later write of the guild could reach the API first or be sent while this one is queued
+	unlock := rm.lockGuildWrites(w.GuildId)
+	defer unlock()
+
+	if !rm.queue.HasGuild(w.GuildId) {
+		err := rm.sendWrite(context.Background(), w)
+
+		if !errors.Is(err, apiclient.ErrUnavailable) {
+			if err == nil {
+				rm.applyWrite(w)
+				rm.observe(w, WriteSaved)
+			}
+
+			return err
+		}
+	}
+
+	w, err := rm.queue.Push(w)
+
+	if err != nil {
+		return err
+	}
+
+	rm.applyWrite(w)
+	rm.observe(w, WriteQueued)
+
+	logger.Warn(ErrQueued, map[string]any{"guildId": w.GuildId, "op": w.Op, "pending": rm.queue.Len()})
+
+	return ErrQueued
+}
+
+// lockGuildWrites waits for writes of the guild in progress and returns the function that unlocks them.
+func (rm *RuleManager) lockGuildWrites(guildId string) func() {
+	rm.writesLock.Lock()
+
+	if rm.guildWrites == nil {
+		rm.guildWrites = make(map[string]*sync.Mutex)
+	}
+
+	l, ok := rm.guildWrites[guildId]
+
+	if !ok {
+		l = &sync.Mutex{}
+		rm.guildWrites[guildId] = l
+	}
+
+	rm.writesLock.Unlock()
+
+	l.Lock()
+
+	return l.Unlock
+}
+
+func (rm *RuleManager) observe(w Write, status WriteStatus) {
+	rm.lock.RLock()
+	o := rm.observer
+	rm.lock.RUnlock()
+
+	if o != nil {
+		o(w, status)
+	}
+}
+
+func (rm *RuleManager) sendWrite(ctx context.Context, w Write) (err error) {
+	// the API streams the change back before it responds, the event isn't observed as a change made elsewhere
+	rm.expectEcho(w)
+
+	defer func() {
+		if err != nil {
+			rm.cancelEcho(w)
+		}
+	}()
+
+	switch w.Op {
+	case WriteCreateGuild:
+		// guilds the API already knows may have a new owner since the bot saw them
+		if _, err = rm.api.CreateGuild(ctx, *w.Guild); errors.Is(err, guild.ErrGuildConflict) {
+			_, err = rm.api.UpdateGuild(ctx, w.GuildId, w.Guild.Update())
+		}
+	case WriteUpdateGuild:
+		_, err = rm.api.UpdateGuild(ctx, w.GuildId, *w.GuildUpdate)
+	case WriteDeleteGuild:
+		// the guild is already deleted
+		if err = rm.api.DeleteGuild(ctx, w.GuildId); errors.Is(err, common.ErrNotFound) {
+			err = nil
+		}
+	case WriteUpdateExemptions:
+		_, err = rm.api.UpdateGuildExemptions(ctx, w.GuildId, *w.Exemptions)
+	case WritePostReactionRules:
+		_, err = rm.api.CreateReactionRules(ctx, w.ReactionRules)
+	case WriteUpdateReactionRules:
+		_, err = rm.api.UpdateReactionRules(ctx, w.GuildId, w.ReactionRuleUpdates)
+	case WriteDeleteReactionRules:
+		err = rm.api.DeleteReactionRules(ctx, w.GuildId, w.DeletedReactionRules)
+	case WriteCreateInfraction:
+		_, err = rm.api.CreateInfraction(ctx, *w.Infraction)
+	case WriteUpdateEscalation:
+		_, err = rm.api.UpdateEscalationPolicy(ctx, w.GuildId, *w.Escalation)
+	case WriteUpdateModLog:
+		_, err = rm.api.UpdateGuildModLog(ctx, w.GuildId, *w.ModLog)
+	case WriteSaveReactionRoles:
+		_, err = rm.api.SaveReactionRoles(ctx, w.GuildId, w.ReactionRoles.MessageId, w.ReactionRoles.Update())
+	case WriteDeleteReactionRoles:
+		// reaction roles of the message are already deleted
+		if err = rm.api.DeleteReactionRoles(ctx, w.GuildId, w.DeletedReactionRoleMessage); errors.Is(err, common.ErrNotFound) {
+			err = nil
+		}
+	case WriteSaveReactionFlood:
+		_, err = rm.api.SaveReactionFlood(ctx, w.GuildId, w.ReactionFlood.Update())
+	case WriteDeleteReactionFlood:
+		// the flood rule is already deleted
+		if err = rm.api.DeleteReactionFlood(ctx, w.GuildId); errors.Is(err, common.ErrNotFound) {
+			err = nil
+		}
+	default:
+		return fmt.Errorf("unknown write op: %s", w.Op)
+	}
+
+	if err != nil {
+		return fmt.Errorf("error sending %s: %w", w.Op, err)
+	}
+
+	return nil
+}
+
+func (rm *RuleManager) applyWrite(w Write) {
+	rm.nextGeneration(w.GuildId)
+
+	switch w.Op {
+	case WriteDeleteGuild:
+		rm.RemoveGuild(w.GuildId)
+	case WriteUpdateExemptions:
+		rm.SetExemptions(w.GuildId, *w.Exemptions)
+	case WriteUpdateModLog:
+		rm.SetModLog(w.GuildId, *w.ModLog)
+	case WriteSaveReactionRoles:
+		rm.SetReactionRoles(w.GuildId, *w.ReactionRoles)
+	case WriteDeleteReactionRoles:
+		rm.RemoveReactionRoles(w.GuildId, w.DeletedReactionRoleMessage)
+	case WriteSaveReactionFlood:
+		rm.SetReactionFlood(w.GuildId, w.ReactionFlood)
+	case WriteDeleteReactionFlood:
+		rm.SetReactionFlood(w.GuildId, nil)
+	case WritePostReactionRules:
+		rm.AddReactionRules(w.GuildId, w.ReactionRules)
+	case WriteUpdateReactionRules:
+		rm.UpdateReactionRuleActions(w.GuildId, w.ReactionRuleUpdates)
+	case WriteDeleteReactionRules:
+		deleteDto := make([]RulesDeleteDto, 0, len(w.DeletedReactionRules))
+
+		for _, d := range w.DeletedReactionRules {
+			deleteDto = append(deleteDto, RulesDeleteDto{EmojiName: d.EmojiName, EmojiId: d.EmojiId})
+		}
+
+		rm.DeleteReactionRules(w.GuildId, deleteDto)
+	}
+}
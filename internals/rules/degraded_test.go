@@ -0,0 +1,290 @@
+package rules
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/finkabaj/hyde-bot/internals/apiclient"
+	mogs "github.com/finkabaj/hyde-bot/internals/backend/mocks"
+	"github.com/finkabaj/hyde-bot/internals/logger"
+	"github.com/finkabaj/hyde-bot/internals/utils/common"
+	"github.com/finkabaj/hyde-bot/internals/utils/rule"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	// the rule manager logs with the global logger
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+
+	if err != nil {
+		panic(err)
+	}
+
+	logger.NewLogger(devNull)
+
+	os.Exit(m.Run())
+}
+
+func TestWrite(t *testing.T) {
+	t.Run("Saved", testWriteSaved)
+	t.Run("QueuedWhenUnavailable", testWriteQueuedWhenUnavailable)
+	t.Run("QueuedBehindPendingWrites", testWriteQueuedBehindPendingWrites)
+	t.Run("Rejected", testWriteRejected)
+	t.Run("BreakerOpen", testWriteBreakerOpen)
+	t.Run("ConcurrentKeepOrder", testWriteConcurrentKeepOrder)
+}
+
+func TestReplayWrites(t *testing.T) {
+	t.Run("PopFailingFile", testReplayWritesPopFailingFile)
+	t.Run("RejectedObserved", testReplayWritesRejectedObserved)
+}
+
+// stubApi responds to reaction rules creation with status and counts the requests.
+type stubApi struct {
+	status   atomic.Int32
+	requests atomic.Int32
+}
+
+func newTestRuleManager(t *testing.T, queue *WriteQueue) (*RuleManager, *stubApi) {
+	return newTestRuleManagerWithBreaker(t, queue, nil)
+}
+
+func newTestRuleManagerWithBreaker(t *testing.T, queue *WriteQueue, breaker *apiclient.Breaker) (*RuleManager, *stubApi) {
+	api := &stubApi{}
+	api.status.Store(http.StatusCreated)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		api.requests.Add(1)
+
+		if status := int(api.status.Load()); status != http.StatusCreated {
+			common.NewErrorResponseBuilder(common.ErrInternal).SetStatus(status).Send(w)
+			return
+		}
+
+		common.MarshalBody(w, http.StatusCreated, []rule.ReactionRule{})
+	}))
+	t.Cleanup(s.Close)
+
+	client := apiclient.NewClient(mogs.NewMockLogger(), s.URL, s.Client(), apiclient.RetryPolicy{}, breaker)
+
+	return &RuleManager{rm: make(map[string]Rules), api: client, queue: queue}, api
+}
+
+// observedWrites records the statuses of writes observed by rm.
+func observedWrites(rm *RuleManager) *[]WriteStatus {
+	var statuses []WriteStatus
+
+	rm.ObserveWrites(func(w Write, status WriteStatus) {
+		statuses = append(statuses, status)
+	})
+
+	return &statuses
+}
+
+func cachedEmojis(rm *RuleManager, guildId string) []string {
+	rm.lock.RLock()
+	defer rm.lock.RUnlock()
+
+	var emojis []string
+
+	for _, r := range rm.rm[guildId].ReactionRules {
+		emojis = append(emojis, r.EmojiName)
+	}
+
+	return emojis
+}
+
+func testWriteSaved(t *testing.T) {
+	q := &WriteQueue{logger: mogs.NewMockLogger()}
+	rm, api := newTestRuleManager(t, q)
+	statuses := observedWrites(rm)
+
+	require.NoError(t, rm.write(testWrite("guild", "1")))
+
+	assert.Equal(t, int32(1), api.requests.Load())
+	assert.Equal(t, 0, q.Len())
+	assert.Equal(t, []string{"1"}, cachedEmojis(rm, "guild"))
+	assert.Equal(t, []WriteStatus{WriteSaved}, *statuses)
+}
+
+func testWriteQueuedWhenUnavailable(t *testing.T) {
+	q := &WriteQueue{logger: mogs.NewMockLogger()}
+	rm, api := newTestRuleManager(t, q)
+	api.status.Store(http.StatusServiceUnavailable)
+	statuses := observedWrites(rm)
+
+	err := rm.write(testWrite("guild", "1"))
+
+	assert.ErrorIs(t, err, ErrQueued)
+	assert.Equal(t, 1, q.Len())
+	assert.True(t, q.HasGuild("guild"))
+	assert.Equal(t, []string{"1"}, cachedEmojis(rm, "guild"), "queued writes are applied to the cache")
+	assert.Equal(t, []WriteStatus{WriteQueued}, *statuses)
+	assert.True(t, rm.Degraded())
+}
+
+func testWriteQueuedBehindPendingWrites(t *testing.T) {
+	q := &WriteQueue{logger: mogs.NewMockLogger()}
+	rm, api := newTestRuleManager(t, q)
+	api.status.Store(http.StatusServiceUnavailable)
+
+	require.ErrorIs(t, rm.write(testWrite("guild", "1")), ErrQueued)
+
+	api.status.Store(http.StatusCreated)
+	requests := api.requests.Load()
+
+	assert.ErrorIs(t, rm.write(testWrite("guild", "2")), ErrQueued, "writes keep their order")
+	assert.Equal(t, requests, api.requests.Load(), "the write isn't sent before the queued ones")
+	assert.Equal(t, 2, q.Len())
+
+	assert.NoError(t, rm.write(testWrite("other", "1")), "other guilds aren't queued")
+	assert.Equal(t, 2, q.Len())
+}
+
+func testWriteRejected(t *testing.T) {
+	q := &WriteQueue{logger: mogs.NewMockLogger()}
+	rm, api := newTestRuleManager(t, q)
+	api.status.Store(http.StatusBadRequest)
+	statuses := observedWrites(rm)
+
+	err := rm.write(testWrite("guild", "1"))
+
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrQueued)
+	assert.Equal(t, 0, q.Len())
+	assert.Empty(t, cachedEmojis(rm, "guild"))
+	assert.Empty(t, *statuses)
+}
+
+func testWriteBreakerOpen(t *testing.T) {
+	q := &WriteQueue{logger: mogs.NewMockLogger()}
+	rm, api := newTestRuleManagerWithBreaker(t, q, apiclient.NewBreaker(mogs.NewMockLogger(), 1, time.Hour))
+	api.status.Store(http.StatusServiceUnavailable)
+
+	require.ErrorIs(t, rm.write(testWrite("guild", "1")), ErrQueued)
+
+	api.status.Store(http.StatusCreated)
+	requests := api.requests.Load()
+
+	assert.ErrorIs(t, rm.write(testWrite("other", "1")), ErrQueued, "writes are queued while the breaker is open")
+	assert.Equal(t, requests, api.requests.Load(), "the open breaker keeps requests from the api")
+	assert.Equal(t, []string{"1"}, cachedEmojis(rm, "other"))
+	assert.True(t, rm.Degraded())
+
+	rm.replayWrites(context.Background(), nil)
+
+	assert.Equal(t, 2, q.Len(), "replay stops while the breaker is open")
+	assert.Equal(t, requests, api.requests.Load())
+}
+
+func testWriteConcurrentKeepOrder(t *testing.T) {
+	var lock sync.Mutex
+	var received []string
+	sending := make(chan struct{})
+	release := make(chan struct{})
+
+	// the first write is held in flight, then the api turns out to be unavailable
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var rules []rule.ReactionRule
+		json.NewDecoder(r.Body).Decode(&rules)
+
+		lock.Lock()
+		received = append(received, rules[0].EmojiName)
+		first := len(received) == 1
+		lock.Unlock()
+
+		if first {
+			close(sending)
+			<-release
+			common.NewErrorResponseBuilder(common.ErrInternal).SetStatus(http.StatusServiceUnavailable).Send(w)
+			return
+		}
+
+		common.MarshalBody(w, http.StatusCreated, []rule.ReactionRule{})
+	}))
+	t.Cleanup(s.Close)
+
+	q := &WriteQueue{logger: mogs.NewMockLogger()}
+	client := apiclient.NewClient(mogs.NewMockLogger(), s.URL, s.Client(), apiclient.RetryPolicy{}, nil)
+	rm := &RuleManager{rm: make(map[string]Rules), api: client, queue: q}
+
+	first := make(chan error)
+	second := make(chan error)
+
+	go func() { first <- rm.write(testWrite("guild", "1")) }()
+	<-sending
+	go func() { second <- rm.write(testWrite("guild", "2")) }()
+
+	// without serialized writes the second one reaches the api in the meantime
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+
+	assert.ErrorIs(t, <-first, ErrQueued)
+	assert.ErrorIs(t, <-second, ErrQueued, "the write waits for the earlier one and is queued behind it")
+
+	lock.Lock()
+	assert.Equal(t, []string{"1"}, received)
+	lock.Unlock()
+
+	w, ok := q.Peek()
+
+	require.True(t, ok)
+	assert.Equal(t, "1", w.ReactionRules[0].EmojiName)
+	assert.Equal(t, 2, q.Len())
+}
+
+func testReplayWritesPopFailingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.jsonl")
+	q := openTestWriteQueue(t, path)
+	rm, api := newTestRuleManager(t, q)
+
+	for _, emojiName := range []string{"1", "2"} {
+		_, err := q.Push(testWrite("guild", emojiName))
+		require.NoError(t, err)
+	}
+
+	readOnly, err := os.Open(path)
+	require.NoError(t, err)
+	require.NoError(t, q.file.Close())
+	q.file = readOnly
+
+	var notified []Write
+
+	rm.replayWrites(context.Background(), func(w Write, err error) {
+		assert.NoError(t, err)
+		notified = append(notified, w)
+	})
+
+	assert.Equal(t, int32(2), api.requests.Load(), "every write is sent once")
+	assert.Len(t, notified, 2)
+	assert.Equal(t, 0, q.Len())
+}
+
+func testReplayWritesRejectedObserved(t *testing.T) {
+	q := &WriteQueue{logger: mogs.NewMockLogger()}
+	rm, api := newTestRuleManager(t, q)
+	api.status.Store(http.StatusBadRequest)
+
+	_, err := q.Push(testWrite("guild", "1"))
+	require.NoError(t, err)
+
+	var statuses []WriteStatus
+
+	rm.ObserveWrites(func(w Write, status WriteStatus) {
+		statuses = append(statuses, status)
+	})
+
+	rm.replayWrites(context.Background(), nil)
+
+	assert.Equal(t, []WriteStatus{WriteRejected}, statuses)
+	assert.Equal(t, 0, q.Len())
+}
@@ -0,0 +1,284 @@
+package rules
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/finkabaj/hyde-bot/internals/apiclient"
+	"github.com/finkabaj/hyde-bot/internals/logger"
+	"github.com/finkabaj/hyde-bot/internals/utils/rule"
+)
+
+const (
+	minListenBackoff = time.Second
+	maxListenBackoff = 30 * time.Second
+	// echoTTL is how long the event of a write sent by the bot is expected, events are lost while disconnected.
+	echoTTL = 30 * time.Second
+)
+
+// writeEvents are the events the API streams for writes.
+var writeEvents = map[WriteOp]rule.RuleEventOp{
+	WritePostReactionRules:   rule.RuleEventCreated,
+	WriteUpdateReactionRules: rule.RuleEventUpdated,
+	WriteDeleteReactionRules: rule.RuleEventDeleted,
+	WriteUpdateExemptions:    rule.RuleEventExemptionsUpdated,
+	WriteUpdateModLog:        rule.RuleEventModLogUpdated,
+	WriteSaveReactionRoles:   rule.RuleEventReactionRolesUpdated,
+	WriteDeleteReactionRoles: rule.RuleEventReactionRolesDeleted,
+	WriteSaveReactionFlood:   rule.RuleEventReactionFloodUpdated,
+	WriteDeleteReactionFlood: rule.RuleEventReactionFloodDeleted,
+}
+
+type echoKey struct {
+	guildId string
+	op      rule.RuleEventOp
+}
+
+// RuleEventObserver is called with rule changes streamed by the API that weren't made through the RuleManager,
+// e.g. changes made in the dashboard.
+type RuleEventObserver func(e rule.RuleEvent)
+
+// ObserveRuleEvents sets the observer of rule events, replacing the previous one. nil removes it.
+func (rm *RuleManager) ObserveRuleEvents(o RuleEventObserver) {
+	rm.lock.Lock()
+	defer rm.lock.Unlock()
+
+	rm.eventObserver = o
+}
+
+// ListenRuleEvents applies rule changes streamed by the API to the cache until ctx is done.
+// The http client of api must not have a timeout, because the stream is long lived.
+// After a reconnect all cached guilds are synced, because events sent while disconnected are lost.
+func (rm *RuleManager) ListenRuleEvents(ctx context.Context, api *apiclient.Client) {
+	backoff := minListenBackoff
+	reconnect := false
+
+	for {
+		connected, err := rm.listenRuleEvents(ctx, api, reconnect)
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		if connected {
+			backoff = minListenBackoff
+			reconnect = true
+		}
+
+		logger.Warn(err, map[string]any{"details": "rule events stream closed", "retryIn": backoff.String()})
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff = min(backoff*2, maxListenBackoff)
+	}
+}
+
+// listenRuleEvents reads one stream connection. connected is true if the API accepted the connection.
+func (rm *RuleManager) listenRuleEvents(ctx context.Context, api *apiclient.Client, resync bool) (connected bool, err error) {
+	stream, err := api.RuleEvents(ctx)
+
+	if err != nil {
+		return false, fmt.Errorf("error connecting to rule events: %w", err)
+	}
+
+	defer stream.Close()
+
+	logger.Info("Listening to rule events")
+
+	if resync {
+		rm.syncAllGuilds()
+	}
+
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var data strings.Builder
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case line == "":
+			if data.Len() > 0 {
+				rm.handleRuleEventData(data.String())
+				data.Reset()
+			}
+		case strings.HasPrefix(line, "data:"):
+			data.WriteString(strings.TrimSpace(strings.TrimPrefix(line, "data:")))
+		}
+	}
+
+	if err = scanner.Err(); err == nil {
+		err = fmt.Errorf("rule events stream ended")
+	}
+
+	return true, err
+}
+
+func (rm *RuleManager) handleRuleEventData(data string) {
+	var e rule.RuleEvent
+
+	if err := json.Unmarshal([]byte(data), &e); err != nil {
+		logger.Error(err, map[string]any{"details": "error while unmarshaling rule event"})
+		return
+	}
+
+	rm.ApplyRuleEvent(e)
+}
+
+// ApplyRuleEvent patches the cache of the guild from the event. Events of unknown guilds are ignored.
+func (rm *RuleManager) ApplyRuleEvent(e rule.RuleEvent) {
+	if !rm.HasGuild(e.GuildId) {
+		return
+	}
+
+	rm.nextGeneration(e.GuildId)
+
+	switch e.Op {
+	case rule.RuleEventCreated, rule.RuleEventUpdated:
+		rm.AddReactionRules(e.GuildId, e.ReactionRules)
+	case rule.RuleEventDeleted:
+		deleteDto := make([]RulesDeleteDto, 0, len(e.DeletedReactionRules))
+
+		for _, d := range e.DeletedReactionRules {
+			deleteDto = append(deleteDto, RulesDeleteDto{EmojiName: d.EmojiName, EmojiId: d.EmojiId})
+		}
+
+		rm.DeleteReactionRules(e.GuildId, deleteDto)
+	case rule.RuleEventExemptionsUpdated:
+		if e.Exemptions == nil {
+			logger.Warn(errors.New("exemptions event without exemptions"), map[string]any{"guildId": e.GuildId})
+			return
+		}
+
+		rm.SetExemptions(e.GuildId, *e.Exemptions)
+	case rule.RuleEventModLogUpdated:
+		if e.ModLog == nil {
+			logger.Warn(errors.New("mod log event without mod log"), map[string]any{"guildId": e.GuildId})
+			return
+		}
+
+		rm.SetModLog(e.GuildId, *e.ModLog)
+	case rule.RuleEventReactionRolesUpdated:
+		for _, m := range e.ReactionRoles {
+			rm.SetReactionRoles(e.GuildId, m)
+		}
+	case rule.RuleEventReactionRolesDeleted:
+		for _, messageId := range e.DeletedReactionRoleMessages {
+			rm.RemoveReactionRoles(e.GuildId, messageId)
+		}
+	case rule.RuleEventReactionFloodUpdated:
+		if e.ReactionFlood == nil {
+			logger.Warn(errors.New("reaction flood event without flood rule"), map[string]any{"guildId": e.GuildId})
+			return
+		}
+
+		rm.SetReactionFlood(e.GuildId, e.ReactionFlood)
+	case rule.RuleEventReactionFloodDeleted:
+		rm.SetReactionFlood(e.GuildId, nil)
+	default:
+		if err := rm.SyncGuild(e.GuildId); err != nil {
+			logger.Error(err, map[string]any{"details": "error while syncing guild on unknown rule event", "guildId": e.GuildId})
+		}
+		return
+	}
+
+	logger.Debug("Rule event applied", map[string]any{"guildId": e.GuildId, "op": e.Op})
+
+	if rm.consumeEcho(e) {
+		return
+	}
+
+	rm.lock.RLock()
+	o := rm.eventObserver
+	rm.lock.RUnlock()
+
+	if o != nil {
+		o(e)
+	}
+}
+
+// expectEcho expects the event of w, so it isn't observed as a change made elsewhere.
+func (rm *RuleManager) expectEcho(w Write) {
+	op, ok := writeEvents[w.Op]
+
+	if !ok {
+		return
+	}
+
+	rm.echoLock.Lock()
+	defer rm.echoLock.Unlock()
+
+	if rm.echoes == nil {
+		rm.echoes = make(map[echoKey][]time.Time)
+	}
+
+	k := echoKey{guildId: w.GuildId, op: op}
+	rm.echoes[k] = append(rm.echoes[k], time.Now().Add(echoTTL))
+}
+
+// cancelEcho stops expecting the event of w, the API didn't save it.
+func (rm *RuleManager) cancelEcho(w Write) {
+	op, ok := writeEvents[w.Op]
+
+	if !ok {
+		return
+	}
+
+	rm.echoLock.Lock()
+	defer rm.echoLock.Unlock()
+
+	k := echoKey{guildId: w.GuildId, op: op}
+
+	if expiries := rm.echoes[k]; len(expiries) > 0 {
+		rm.echoes[k] = expiries[:len(expiries)-1]
+	}
+
+	if len(rm.echoes[k]) == 0 {
+		delete(rm.echoes, k)
+	}
+}
+
+// consumeEcho reports whether e is the expected event of a write of the bot and stops expecting it.
+func (rm *RuleManager) consumeEcho(e rule.RuleEvent) bool {
+	rm.echoLock.Lock()
+	defer rm.echoLock.Unlock()
+
+	k := echoKey{guildId: e.GuildId, op: e.Op}
+	now := time.Now()
+
+	expiries := slices.DeleteFunc(rm.echoes[k], func(expires time.Time) bool {
+		return !now.Before(expires)
+	})
+
+	if len(expiries) == 0 {
+		delete(rm.echoes, k)
+		return false
+	}
+
+	if len(expiries) == 1 {
+		delete(rm.echoes, k)
+	} else {
+		rm.echoes[k] = expiries[1:]
+	}
+
+	return true
+}
+
+func (rm *RuleManager) syncAllGuilds() {
+	for _, guildId := range rm.Guilds() {
+		if err := rm.SyncGuild(guildId); err != nil && !errors.Is(err, ErrWritesPending) && !errors.Is(err, ErrSyncInterrupted) {
+			logger.Error(err, map[string]any{"details": "error while syncing guild after reconnect", "guildId": guildId})
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package rules
+
+import (
+	"net/http"
+	"testing"
+
+	mogs "github.com/finkabaj/hyde-bot/internals/backend/mocks"
+	"github.com/finkabaj/hyde-bot/internals/utils/rule"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyRuleEvent(t *testing.T) {
+	t.Run("ExternalChangeObserved", testApplyRuleEventExternalChangeObserved)
+	t.Run("EchoOfWriteNotObserved", testApplyRuleEventEchoOfWriteNotObserved)
+	t.Run("FailedWriteNoEcho", testApplyRuleEventFailedWriteNoEcho)
+}
+
+func observedRuleEvents(rm *RuleManager) *[]rule.RuleEvent {
+	var observed []rule.RuleEvent
+
+	rm.ObserveRuleEvents(func(e rule.RuleEvent) {
+		observed = append(observed, e)
+	})
+
+	return &observed
+}
+
+func createdEvent(guildId, emojiName string) rule.RuleEvent {
+	return rule.RuleEvent{
+		Op:            rule.RuleEventCreated,
+		GuildId:       guildId,
+		ReactionRules: []rule.ReactionRule{{GuildId: guildId, EmojiName: emojiName, Actions: rule.Actions{{Type: rule.Delete}}}},
+	}
+}
+
+func testApplyRuleEventExternalChangeObserved(t *testing.T) {
+	rm, _ := newTestRuleManager(t, &WriteQueue{logger: mogs.NewMockLogger()})
+	rm.rm["guild"] = Rules{}
+	observed := observedRuleEvents(rm)
+
+	rm.ApplyRuleEvent(createdEvent("guild", "1"))
+	rm.ApplyRuleEvent(createdEvent("unknown", "1"))
+
+	require.Len(t, *observed, 1)
+	assert.Equal(t, "guild", (*observed)[0].GuildId)
+}
+
+func testApplyRuleEventEchoOfWriteNotObserved(t *testing.T) {
+	rm, _ := newTestRuleManager(t, &WriteQueue{logger: mogs.NewMockLogger()})
+	rm.rm["guild"] = Rules{}
+	observed := observedRuleEvents(rm)
+
+	require.NoError(t, rm.write(testWrite("guild", "1")))
+
+	rm.ApplyRuleEvent(createdEvent("guild", "1"))
+	assert.Empty(t, *observed, "the event of the write is expected")
+
+	rm.ApplyRuleEvent(createdEvent("guild", "2"))
+	assert.Len(t, *observed, 1, "only one event is expected per write")
+}
+
+func testApplyRuleEventFailedWriteNoEcho(t *testing.T) {
+	rm, api := newTestRuleManager(t, &WriteQueue{logger: mogs.NewMockLogger()})
+	rm.rm["guild"] = Rules{}
+	api.status.Store(http.StatusBadRequest)
+	observed := observedRuleEvents(rm)
+
+	require.Error(t, rm.write(testWrite("guild", "1")))
+
+	rm.ApplyRuleEvent(createdEvent("guild", "2"))
+	assert.Len(t, *observed, 1)
+}
@@ -0,0 +1,171 @@
+package rules
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/finkabaj/hyde-bot/internals/logger"
+)
+
+// DefaultReconcileInterval is used when the reconciler is created with non positive interval.
+const DefaultReconcileInterval = 10 * time.Minute
+
+const (
+	minReconcileBackoff = 5 * time.Second
+	maxReconcileBackoff = 10 * time.Minute
+	minReconcileTick    = 100 * time.Millisecond
+	maxReconcileTick    = 30 * time.Second
+)
+
+type guildSync struct {
+	lastSync time.Time // lastSync is zero if the guild was never synced successfully
+	failures int
+	next     time.Time
+}
+
+// Reconciler periodically replaces cached rules of every known guild with rules from the API,
+// so the cache recovers from missed rule events and failed fetches.
+type Reconciler struct {
+	rm       *RuleManager
+	interval time.Duration
+	guilds   map[string]*guildSync
+	lock     sync.Mutex
+}
+
+var reconciler *Reconciler
+
+func NewReconciler(rm *RuleManager, interval time.Duration) *Reconciler {
+	if interval <= 0 {
+		interval = DefaultReconcileInterval
+	}
+
+	if reconciler == nil {
+		reconciler = &Reconciler{
+			rm:       rm,
+			interval: interval,
+			guilds:   make(map[string]*guildSync),
+		}
+	}
+	return reconciler
+}
+
+// Run syncs guilds when they are due until ctx is done.
+func (r *Reconciler) Run(ctx context.Context) {
+	// short intervals would make the tick non positive, which panics
+	ticker := time.NewTicker(max(min(r.interval/10, maxReconcileTick), minReconcileTick))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, guildId := range r.dueGuilds(time.Now()) {
+				if ctx.Err() != nil {
+					return
+				}
+
+				r.SyncGuild(guildId)
+			}
+		}
+	}
+}
+
+// SyncGuild syncs the guild now. On failure the guild is retried with exponential backoff,
+// cached rules stay untouched until a sync succeeds.
+func (r *Reconciler) SyncGuild(guildId string) error {
+	err := r.rm.SyncGuild(guildId)
+	r.recordSync(guildId, err, time.Now())
+
+	return err
+}
+
+// recordSync schedules the next sync of the guild after the sync finished at now with err.
+func (r *Reconciler) recordSync(guildId string, err error, now time.Time) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	gs := r.guildSync(guildId)
+
+	if err != nil {
+		gs.failures++
+		backoff := min(minReconcileBackoff<<min(gs.failures-1, 16), maxReconcileBackoff)
+		gs.next = now.Add(backoff + jitter(backoff))
+
+		logger.Warn(err, map[string]any{"details": "error while syncing guild rules", "guildId": guildId, "failures": gs.failures, "retryAt": gs.next})
+
+		return
+	}
+
+	gs.failures = 0
+	gs.lastSync = now
+	gs.next = now.Add(r.interval + jitter(r.interval))
+}
+
+// LastSync returns the time of the last successful sync of the guild.
+func (r *Reconciler) LastSync(guildId string) (time.Time, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	gs, ok := r.guilds[guildId]
+
+	if !ok || gs.lastSync.IsZero() {
+		return time.Time{}, false
+	}
+
+	return gs.lastSync, true
+}
+
+// Forget stops syncing the guild until it's cached again.
+func (r *Reconciler) Forget(guildId string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	delete(r.guilds, guildId)
+}
+
+// dueGuilds returns known guilds whose next sync time has passed.
+// Guilds cached by the RuleManager but never seen by the reconciler are due immediately.
+func (r *Reconciler) dueGuilds(now time.Time) []string {
+	known := r.rm.Guilds()
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, guildId := range known {
+		r.guildSync(guildId)
+	}
+
+	due := make([]string, 0)
+
+	for guildId, gs := range r.guilds {
+		if !now.Before(gs.next) {
+			due = append(due, guildId)
+		}
+	}
+
+	return due
+}
+
+// guildSync must be called with lock held.
+func (r *Reconciler) guildSync(guildId string) *guildSync {
+	gs, ok := r.guilds[guildId]
+
+	if !ok {
+		gs = &guildSync{}
+		r.guilds[guildId] = gs
+	}
+
+	return gs
+}
+
+// jitter returns random duration up to a fifth of d.
+func jitter(d time.Duration) time.Duration {
+	if d < 5 {
+		return 0
+	}
+
+	return rand.N(d / 5)
+}
@@ -0,0 +1,139 @@
+package rules
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	mogs "github.com/finkabaj/hyde-bot/internals/backend/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconciler(t *testing.T) {
+	t.Run("NewGuildDue", testReconcilerNewGuildDue)
+	t.Run("Backoff", testReconcilerBackoff)
+	t.Run("BackoffCapped", testReconcilerBackoffCapped)
+	t.Run("SuccessResetsBackoff", testReconcilerSuccessResetsBackoff)
+	t.Run("LastSync", testReconcilerLastSync)
+	t.Run("SyncGuildFailure", testReconcilerSyncGuildFailure)
+	t.Run("RunShortInterval", testReconcilerRunShortInterval)
+}
+
+var errSync = errors.New("sync failed")
+
+func newTestReconciler(t *testing.T, interval time.Duration) (*Reconciler, *stubApi) {
+	rm, api := newTestRuleManager(t, &WriteQueue{logger: mogs.NewMockLogger()})
+
+	return &Reconciler{rm: rm, interval: interval, guilds: make(map[string]*guildSync)}, api
+}
+
+// assertNext asserts the next sync of the guild is after d plus up to a fifth of jitter.
+func assertNext(t *testing.T, r *Reconciler, guildId string, now time.Time, d time.Duration) {
+	t.Helper()
+
+	next := r.guilds[guildId].next
+
+	assert.False(t, next.Before(now.Add(d)), "next sync %s is before %s", next, now.Add(d))
+	assert.True(t, next.Before(now.Add(d+d/5+1)), "next sync %s is after the jitter of %s", next, now.Add(d))
+}
+
+func testReconcilerNewGuildDue(t *testing.T) {
+	r, _ := newTestReconciler(t, time.Minute)
+	r.rm.rm["guild"] = Rules{}
+
+	assert.Equal(t, []string{"guild"}, r.dueGuilds(time.Now()))
+}
+
+func testReconcilerBackoff(t *testing.T) {
+	r, _ := newTestReconciler(t, time.Minute)
+	now := time.Now()
+
+	for failures, backoff := range []time.Duration{5 * time.Second, 10 * time.Second, 20 * time.Second} {
+		r.recordSync("guild", errSync, now)
+
+		assert.Equal(t, failures+1, r.guilds["guild"].failures)
+		assertNext(t, r, "guild", now, backoff)
+	}
+
+	assert.Empty(t, r.dueGuilds(now))
+	assert.Equal(t, []string{"guild"}, r.dueGuilds(r.guilds["guild"].next))
+}
+
+func testReconcilerBackoffCapped(t *testing.T) {
+	r, _ := newTestReconciler(t, time.Minute)
+	now := time.Now()
+
+	for range 100 {
+		r.recordSync("guild", errSync, now)
+	}
+
+	assertNext(t, r, "guild", now, maxReconcileBackoff)
+}
+
+func testReconcilerSuccessResetsBackoff(t *testing.T) {
+	r, _ := newTestReconciler(t, time.Minute)
+	now := time.Now()
+
+	r.recordSync("guild", errSync, now)
+	r.recordSync("guild", errSync, now)
+	r.recordSync("guild", nil, now)
+
+	assert.Zero(t, r.guilds["guild"].failures)
+	assertNext(t, r, "guild", now, time.Minute)
+
+	r.recordSync("guild", errSync, now)
+
+	assertNext(t, r, "guild", now, minReconcileBackoff)
+}
+
+func testReconcilerLastSync(t *testing.T) {
+	r, _ := newTestReconciler(t, time.Minute)
+	now := time.Now()
+
+	_, ok := r.LastSync("guild")
+	assert.False(t, ok, "unknown guild")
+
+	r.recordSync("guild", errSync, now)
+
+	_, ok = r.LastSync("guild")
+	assert.False(t, ok, "guild never synced successfully")
+
+	r.recordSync("guild", nil, now)
+
+	lastSync, ok := r.LastSync("guild")
+	assert.True(t, ok)
+	assert.Equal(t, now, lastSync)
+
+	r.recordSync("guild", errSync, now.Add(time.Minute))
+
+	lastSync, ok = r.LastSync("guild")
+	assert.True(t, ok, "failures keep the last successful sync")
+	assert.Equal(t, now, lastSync)
+
+	r.Forget("guild")
+
+	_, ok = r.LastSync("guild")
+	assert.False(t, ok)
+}
+
+func testReconcilerSyncGuildFailure(t *testing.T) {
+	r, api := newTestReconciler(t, time.Minute)
+	api.status.Store(http.StatusInternalServerError)
+
+	require.Error(t, r.SyncGuild("guild"))
+
+	_, ok := r.LastSync("guild")
+	assert.False(t, ok)
+	assert.Equal(t, 1, r.guilds["guild"].failures)
+}
+
+func testReconcilerRunShortInterval(t *testing.T) {
+	r, _ := newTestReconciler(t, time.Nanosecond)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.NotPanics(t, func() { r.Run(ctx) })
+}
@@ -1,28 +1,56 @@
 package rules
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
-	"io"
-	"net/http"
-	"os"
+	"slices"
 	"sync"
+	"time"
 
+	"github.com/finkabaj/hyde-bot/internals/apiclient"
 	"github.com/finkabaj/hyde-bot/internals/logger"
 	"github.com/finkabaj/hyde-bot/internals/utils/common"
+	"github.com/finkabaj/hyde-bot/internals/utils/escalation"
+	"github.com/finkabaj/hyde-bot/internals/utils/guild"
+	"github.com/finkabaj/hyde-bot/internals/utils/infraction"
 	"github.com/finkabaj/hyde-bot/internals/utils/rule"
 )
 
 var (
 	ErrIntersectingRules = errors.New("reaction rules already exist")
 	ErrRulesNotFound     = errors.New("rules not found")
+	ErrInvalidActions    = errors.New("reaction rules have invalid actions")
+	// ErrIntersectingChannels is returned when a channel is both included and excluded by a rule.
+	ErrIntersectingChannels = errors.New("reaction rules have intersecting channels")
+	// ErrInvalidThreshold is returned when the count or window of a threshold rule is out of limits.
+	ErrInvalidThreshold = errors.New("reaction rules have invalid threshold")
+	// ErrInvalidFlood is returned when the limits of a flood rule are out of bounds.
+	ErrInvalidFlood = errors.New("reaction flood rule has invalid limits")
+	// ErrInvalidMatcher is returned when a category, base emoji or pattern rule describes no valid emojis.
+	ErrInvalidMatcher = errors.New("reaction rules have invalid matcher")
 )
 
 type Rules struct {
 	ReactionRules     []rule.ReactionRule `json:"reactionRules"`
 	HaveReactionRules bool                `json:"haveReactionRules"`
+	ExemptRoles       []string            `json:"exemptRoles"`
+	ExemptAdmins      bool                `json:"exemptAdmins"`
+	ModLogChannelId   string              `json:"modLogChannelId"` // ModLogChannelId is empty if the mod log is disabled.
+	// ReactionRoles are messages with reaction roles. Reaction roles take precedence over reaction rules on their messages.
+	ReactionRoles []rule.ReactionRoleMessage `json:"reactionRoles"`
+	// ReactionFlood is nil if the guild has no flood rule.
+	ReactionFlood *rule.ReactionFloodRule `json:"reactionFlood,omitempty"`
+}
+
+// IsExempt reports whether a member with roles is exempt from the reaction rule.
+// privileged is true for the guild owner and administrators.
+func (r Rules) IsExempt(rr rule.ReactionRule, roles []string, privileged bool) bool {
+	if privileged && r.ExemptAdmins {
+		return true
+	}
+
+	return common.HaveIntersection(roles, r.ExemptRoles) || common.HaveIntersection(roles, rr.ExemptRoles)
 }
 
 type RulesDeleteDto struct {
@@ -31,18 +59,27 @@ type RulesDeleteDto struct {
 }
 
 type RuleManager struct {
-	rm     map[string]Rules
-	client *http.Client
-	lock   sync.RWMutex
+	rm            map[string]Rules
+	api           *apiclient.Client
+	queue         *WriteQueue
+	observer      WriteObserver
+	eventObserver RuleEventObserver
+	echoes        map[echoKey][]time.Time // echoes[key] are expiries of events expected for writes of the bot
+	generations   map[string]uint64       // generations[guildId] counts writes and rule events applied to the guild
+	guildWrites   map[string]*sync.Mutex  // guildWrites[guildId] serializes writes of the guild
+	lock          sync.RWMutex
+	echoLock      sync.Mutex
+	writesLock    sync.Mutex
 }
 
 var ruleManager *RuleManager
 
-func NewRuleManager(client *http.Client) *RuleManager {
+func NewRuleManager(api *apiclient.Client, queue *WriteQueue) *RuleManager {
 	if ruleManager == nil {
 		ruleManager = &RuleManager{
-			rm:     make(map[string]Rules),
-			client: client,
+			rm:    make(map[string]Rules),
+			api:   api,
+			queue: queue,
 		}
 	}
 	return ruleManager
@@ -52,6 +89,14 @@ func (rm *RuleManager) AddRules(guildId string, rules Rules) {
 	rm.lock.Lock()
 	defer rm.lock.Unlock()
 
+	rm.addRules(guildId, rules)
+}
+
+func (rm *RuleManager) addRules(guildId string, rules Rules) {
+	for i := range rules.ReactionRules {
+		rules.ReactionRules[i].Compile()
+	}
+
 	rm.rm[guildId] = rules
 }
 
@@ -86,13 +131,267 @@ func (rm *RuleManager) DeleteReactionRules(guildID string, deleteDto []RulesDele
 
 }
 
+// AddReactionRules adds reaction rules to the cache. Cached rules with the same emoji are replaced.
 func (rm *RuleManager) AddReactionRules(guildId string, reactionRules []rule.ReactionRule) {
 	rm.lock.Lock()
 	defer rm.lock.Unlock()
 
+	rules, ok := rm.rm[guildId]
+
+	if !ok {
+		// guilds are exempting admins by default
+		rules.ExemptAdmins = true
+	}
+
+	for _, r := range reactionRules {
+		r.Compile()
+		i := slices.IndexFunc(rules.ReactionRules, r.SameEmoji)
+
+		if i == -1 {
+			rules.ReactionRules = append(rules.ReactionRules, r)
+		} else {
+			rules.ReactionRules[i] = r
+		}
+	}
+
+	rules.HaveReactionRules = len(rules.ReactionRules) > 0
+	rm.rm[guildId] = rules
+}
+
+// RemoveGuild removes cached rules of the guild.
+func (rm *RuleManager) RemoveGuild(guildId string) {
+	rm.lock.Lock()
+	defer rm.lock.Unlock()
+
+	delete(rm.rm, guildId)
+}
+
+// HasGuild reports whether rules of the guild are cached.
+func (rm *RuleManager) HasGuild(guildId string) bool {
+	rm.lock.RLock()
+	defer rm.lock.RUnlock()
+
+	_, ok := rm.rm[guildId]
+
+	return ok
+}
+
+// Guilds returns ids of all guilds with cached rules.
+func (rm *RuleManager) Guilds() []string {
+	rm.lock.RLock()
+	defer rm.lock.RUnlock()
+
+	guilds := make([]string, 0, len(rm.rm))
+
+	for guildId := range rm.rm {
+		guilds = append(guilds, guildId)
+	}
+
+	return guilds
+}
+
+// SyncGuild fetches rules, reaction roles, exemptions and the mod log channel of the guild from the API and replaces cached ones.
+// Guilds with queued writes return ErrWritesPending and keep cached rules. If a write or rule event is applied to the guild
+// while the rules are fetched, the fetched rules may miss it, they are dropped and ErrSyncInterrupted is returned.
+func (rm *RuleManager) SyncGuild(guildId string) error {
+	if rm.queue.HasGuild(guildId) {
+		return ErrWritesPending
+	}
+
+	generation := rm.generation(guildId)
+
+	g, err := rm.FetchGuild(guildId)
+
+	if err != nil {
+		return err
+	}
+
+	rRules, err := rm.FetchReactionRules(guildId)
+
+	if err != nil {
+		return err
+	}
+
+	reactionRoles, err := rm.FetchReactionRoles(guildId)
+
+	if err != nil {
+		return err
+	}
+
+	flood, err := rm.FetchReactionFlood(guildId)
+
+	if err != nil {
+		return err
+	}
+
+	rm.lock.Lock()
+	defer rm.lock.Unlock()
+
+	if rm.generations[guildId] != generation {
+		return ErrSyncInterrupted
+	}
+
+	rm.addRules(guildId, Rules{
+		ReactionRules:     rRules,
+		HaveReactionRules: len(rRules) > 0,
+		ExemptRoles:       g.ExemptRoles,
+		ExemptAdmins:      g.ExemptAdmins,
+		ModLogChannelId:   g.ModLogChannelId,
+		ReactionRoles:     reactionRoles,
+		ReactionFlood:     flood,
+	})
+
+	return nil
+}
+
+// generation returns the number of writes and rule events applied to the guild so far.
+func (rm *RuleManager) generation(guildId string) uint64 {
+	rm.lock.RLock()
+	defer rm.lock.RUnlock()
+
+	return rm.generations[guildId]
+}
+
+// nextGeneration records that a write or rule event is applied to the guild,
+// so syncs that started before it drop their snapshot.
+func (rm *RuleManager) nextGeneration(guildId string) {
+	rm.lock.Lock()
+	defer rm.lock.Unlock()
+
+	if rm.generations == nil {
+		rm.generations = make(map[string]uint64)
+	}
+
+	rm.generations[guildId]++
+}
+
+// SetExemptions replaces cached guild wide exemptions. Guilds without cached rules are ignored.
+func (rm *RuleManager) SetExemptions(guildId string, exemptions guild.GuildExemptions) {
+	rm.lock.Lock()
+	defer rm.lock.Unlock()
+
+	rules, ok := rm.rm[guildId]
+
+	if !ok {
+		return
+	}
+
+	rules.ExemptRoles = exemptions.ExemptRoles
+
+	if exemptions.ExemptAdmins != nil {
+		rules.ExemptAdmins = *exemptions.ExemptAdmins
+	}
+
+	rm.rm[guildId] = rules
+}
+
+// SetModLog sets the cached mod log channel. Guilds without cached rules are ignored.
+func (rm *RuleManager) SetModLog(guildId string, modLog guild.GuildModLog) {
+	rm.lock.Lock()
+	defer rm.lock.Unlock()
+
+	rules, ok := rm.rm[guildId]
+
+	if !ok {
+		return
+	}
+
+	rules.ModLogChannelId = modLog.ChannelId
+	rm.rm[guildId] = rules
+}
+
+// ModLogChannel returns the cached mod log channel of the guild, empty if the mod log is disabled.
+func (rm *RuleManager) ModLogChannel(guildId string) string {
+	rm.lock.RLock()
+	defer rm.lock.RUnlock()
+
+	return rm.rm[guildId].ModLogChannelId
+}
+
+// SetReactionRoles adds the message with reaction roles to the cache or replaces the cached one.
+// Guilds without cached rules are ignored.
+func (rm *RuleManager) SetReactionRoles(guildId string, m rule.ReactionRoleMessage) {
+	rm.lock.Lock()
+	defer rm.lock.Unlock()
+
+	rules, ok := rm.rm[guildId]
+
+	if !ok {
+		return
+	}
+
+	// the slice may be shared with rules returned by GetRules
+	rules.ReactionRoles = slices.DeleteFunc(slices.Clone(rules.ReactionRoles), func(cached rule.ReactionRoleMessage) bool {
+		return cached.MessageId == m.MessageId
+	})
+	rules.ReactionRoles = append(rules.ReactionRoles, m)
+	rm.rm[guildId] = rules
+}
+
+// RemoveReactionRoles removes the cached message with reaction roles.
+func (rm *RuleManager) RemoveReactionRoles(guildId string, messageId string) {
+	rm.lock.Lock()
+	defer rm.lock.Unlock()
+
+	rules, ok := rm.rm[guildId]
+
+	if !ok {
+		return
+	}
+
+	rules.ReactionRoles = slices.DeleteFunc(slices.Clone(rules.ReactionRoles), func(cached rule.ReactionRoleMessage) bool {
+		return cached.MessageId == messageId
+	})
+	rm.rm[guildId] = rules
+}
+
+// ReactionRoles returns the cached message with reaction roles.
+func (rm *RuleManager) ReactionRoles(guildId string, messageId string) (rule.ReactionRoleMessage, bool) {
+	rm.lock.RLock()
+	defer rm.lock.RUnlock()
+
+	i := slices.IndexFunc(rm.rm[guildId].ReactionRoles, func(m rule.ReactionRoleMessage) bool {
+		return m.MessageId == messageId
+	})
+
+	if i == -1 {
+		return rule.ReactionRoleMessage{}, false
+	}
+
+	return rm.rm[guildId].ReactionRoles[i], true
+}
+
+// SetReactionFlood replaces the cached flood rule, nil removes it. Guilds without cached rules are ignored.
+func (rm *RuleManager) SetReactionFlood(guildId string, f *rule.ReactionFloodRule) {
+	rm.lock.Lock()
+	defer rm.lock.Unlock()
+
+	rules, ok := rm.rm[guildId]
+
+	if !ok {
+		return
+	}
+
+	rules.ReactionFlood = f
+	rm.rm[guildId] = rules
+}
+
+// UpdateReactionRuleActions sets actions of cached reaction rules that have the same emoji as updates.
+func (rm *RuleManager) UpdateReactionRuleActions(guildId string, updates []rule.ReactionRuleUpdate) {
+	rm.lock.Lock()
+	defer rm.lock.Unlock()
+
 	rules := rm.rm[guildId]
-	rules.ReactionRules = append(rules.ReactionRules, reactionRules...)
-	rules.HaveReactionRules = true
+
+	for i, r := range rules.ReactionRules {
+		for _, u := range updates {
+			if r.EmojiName == u.EmojiName && r.EmojiId == u.EmojiId {
+				rules.ReactionRules[i].Actions = u.Actions
+				break
+			}
+		}
+	}
+
 	rm.rm[guildId] = rules
 }
 
@@ -129,102 +428,260 @@ func (rm *RuleManager) GetReactionRules(guildId string, locked bool) ([]rule.Rea
 	return rules.ReactionRules, nil
 }
 
-func (rm *RuleManager) FetchReactionRules(guildId string) ([]rule.ReactionRule, error) {
-	reactionRulesUrl := common.GetApiUrl(os.Getenv("API_HOST"), os.Getenv("API_PORT"), "/rules/reaction/"+guildId)
-	res, err := rm.client.Get(reactionRulesUrl)
+func (rm *RuleManager) FetchGuild(guildId string) (guild.Guild, error) {
+	g, err := rm.api.GetGuild(context.Background(), guildId)
 
 	if err != nil {
-		return nil, err
+		return guild.Guild{}, fmt.Errorf("error fetching guild: %w", err)
+	}
+
+	return g, nil
+}
+
+// RecordInfraction adds the infraction to the ledger of the guild. Zero CreatedAt is set to now,
+// so infractions queued while the API is unavailable keep the time the action was taken.
+func (rm *RuleManager) RecordInfraction(i infraction.InfractionCreate) error {
+	if i.CreatedAt.IsZero() {
+		i.CreatedAt = time.Now().UTC()
+	}
+
+	if err := rm.write(Write{Op: WriteCreateInfraction, GuildId: i.GuildId, Infraction: &i}); err != nil {
+		return fmt.Errorf("error recording infraction: %w", err)
 	}
 
-	body := res.Body
-	defer body.Close()
-	b, err := io.ReadAll(body)
+	return nil
+}
+
+func (rm *RuleManager) FetchInfractions(q infraction.InfractionQuery) (infraction.InfractionPage, error) {
+	page, err := rm.api.GetInfractions(context.Background(), q)
 
 	if err != nil {
-		logger.Fatal(err, map[string]any{"details": "The bot cannot continue to work correctly", "at": "guild_create"})
+		return infraction.InfractionPage{}, fmt.Errorf("error fetching infractions: %w", err)
 	}
 
-	if res.StatusCode != http.StatusOK {
-		var errRes common.ErrorResponse
+	return page, nil
+}
 
-		if err = common.UnmarshalBodyBytes(b, &errRes); err != nil {
-			logger.Error(errors.New("error while unmarshaling reaction rules error"))
-			return nil, err
-		}
+func (rm *RuleManager) FetchEscalationPolicy(guildId string) (escalation.Policy, error) {
+	policy, err := rm.api.GetEscalationPolicy(context.Background(), guildId)
 
-		logger.Debug("Error response", map[string]any{"status": res.StatusCode, "error": errRes.Error, "validationErrors": errRes.ValidationErrors, "message": errRes.Message})
-		return nil, errors.New(errRes.Error)
+	if err != nil {
+		return escalation.Policy{}, fmt.Errorf("error fetching escalation policy: %w", err)
 	}
 
-	var reactionRules []rule.ReactionRule
+	return policy, nil
+}
 
-	if err = common.UnmarshalBodyBytes(b, &reactionRules); err != nil {
-		logger.Error(errors.New("error while unmarshaling reaction rules"))
-		return nil, err
+// UpdateEscalationPolicyApi replaces steps of the escalation policy through the API.
+// While the API is unavailable the change is queued and ErrQueued is returned.
+func (rm *RuleManager) UpdateEscalationPolicyApi(guildId string, steps []escalation.Step, origin *WriteOrigin) error {
+	if err := rm.write(Write{Op: WriteUpdateEscalation, GuildId: guildId, Origin: origin, Escalation: &escalation.PolicyUpdate{Steps: steps}}); err != nil {
+		return fmt.Errorf("error updating escalation policy: %w", err)
 	}
 
-	return reactionRules, nil
+	logger.Info("Escalation policy updated", map[string]any{"guildId": guildId, "steps": len(steps)})
+
+	return nil
 }
 
-func (rm *RuleManager) PostReactionRules(guildId string, reactionRules []rule.ReactionRule) ([]rule.ReactionRule, error) {
-	existingRules, err := rm.GetReactionRules(guildId, false)
+// UpdateExemptionsApi replaces guild wide exemptions through the API and updates the cache.
+// While the API is unavailable the change is queued and ErrQueued is returned.
+func (rm *RuleManager) UpdateExemptionsApi(guildId string, exemptions guild.GuildExemptions, origin *WriteOrigin) error {
+	if err := rm.write(Write{Op: WriteUpdateExemptions, GuildId: guildId, Origin: origin, Exemptions: &exemptions}); err != nil {
+		return fmt.Errorf("error updating guild exemptions: %w", err)
+	}
+
+	logger.Info("Guild exemptions updated", map[string]any{"guildId": guildId, "exemptRoles": exemptions.ExemptRoles, "exemptAdmins": exemptions.ExemptAdmins})
+
+	return nil
+}
+
+// UpdateModLogApi sets the mod log channel through the API and updates the cache, empty channelId disables the mod log.
+// While the API is unavailable the change is queued and ErrQueued is returned.
+func (rm *RuleManager) UpdateModLogApi(guildId string, channelId string, origin *WriteOrigin) error {
+	if err := rm.write(Write{Op: WriteUpdateModLog, GuildId: guildId, Origin: origin, ModLog: &guild.GuildModLog{ChannelId: channelId}}); err != nil {
+		return fmt.Errorf("error updating mod log: %w", err)
+	}
+
+	logger.Info("Mod log updated", map[string]any{"guildId": guildId, "channelId": channelId})
+
+	return nil
+}
+
+func (rm *RuleManager) FetchReactionRoles(guildId string) ([]rule.ReactionRoleMessage, error) {
+	messages, err := rm.api.GetReactionRoles(context.Background(), guildId)
+
+	if err != nil {
+		return nil, fmt.Errorf("error fetching reaction roles: %w", err)
+	}
+
+	return messages, nil
+}
+
+// SaveReactionRolesApi creates or replaces reaction roles of the message through the API and the cache.
+// Reaction rules enforced on an emoji of the message in its channel return rule.ErrReactionRoleConflict.
+// While the API is unavailable the change is queued and ErrQueued is returned.
+func (rm *RuleManager) SaveReactionRolesApi(m rule.ReactionRoleMessage, origin *WriteOrigin) error {
+	if rule.HaveDuplicateReactionRoles(m.Roles) {
+		return rule.ErrDuplicateReactionRoles
+	}
+
+	rRules, err := rm.GetReactionRules(m.GuildId, false)
 
 	if err != nil && !errors.Is(err, ErrRulesNotFound) {
-		return nil, fmt.Errorf("error posting reaction rules: %w", err)
+		return fmt.Errorf("error saving reaction roles: %w", err)
+	}
+
+	if slices.ContainsFunc(rRules, m.ConflictsWith) {
+		return rule.ErrReactionRoleConflict
 	}
 
-	if common.HaveIntersection(existingRules, reactionRules) {
-		return nil, ErrIntersectingRules
+	if err := rm.write(Write{Op: WriteSaveReactionRoles, GuildId: m.GuildId, Origin: origin, ReactionRoles: &m}); err != nil {
+		return fmt.Errorf("error saving reaction roles: %w", err)
 	}
 
-	b, err := json.Marshal(reactionRules)
+	logger.Info("Reaction roles saved", map[string]any{"guildId": m.GuildId, "messageId": m.MessageId, "mode": m.Mode, "roles": len(m.Roles)})
 
-	if err != nil {
-		return nil, fmt.Errorf("error marshaling reaction rules: %w", err)
+	return nil
+}
+
+// DeleteReactionRolesApi deletes reaction roles of the message through the API and from the cache.
+// While the API is unavailable the deletion is queued and ErrQueued is returned.
+func (rm *RuleManager) DeleteReactionRolesApi(guildId string, messageId string, origin *WriteOrigin) error {
+	if err := rm.write(Write{Op: WriteDeleteReactionRoles, GuildId: guildId, Origin: origin, DeletedReactionRoleMessage: messageId}); err != nil {
+		return fmt.Errorf("error deleting reaction roles: %w", err)
 	}
 
-	bb := bytes.NewReader(b)
+	logger.Info("Reaction roles deleted", map[string]any{"guildId": guildId, "messageId": messageId})
+
+	return nil
+}
 
-	rRulesApiUrl := common.GetApiUrl(os.Getenv("API_HOST"), os.Getenv("API_PORT"), "/rules/reaction")
-	res, err := rm.client.Post(rRulesApiUrl, "application/json", bb)
+// FetchReactionFlood returns the flood rule of the guild, nil if it has none.
+func (rm *RuleManager) FetchReactionFlood(guildId string) (*rule.ReactionFloodRule, error) {
+	f, err := rm.api.GetReactionFlood(context.Background(), guildId)
 
-	if err != nil {
-		return nil, fmt.Errorf("error posting reaction rules: %w", err)
+	if errors.Is(err, common.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error fetching reaction flood rule: %w", err)
 	}
 
-	body := res.Body
-	defer body.Close()
-	b, err = io.ReadAll(body)
+	return &f, nil
+}
+
+// SaveReactionFloodApi creates or replaces the flood rule of the guild through the API and the cache.
+// While the API is unavailable the change is queued and ErrQueued is returned.
+func (rm *RuleManager) SaveReactionFloodApi(f rule.ReactionFloodRule, origin *WriteOrigin) error {
+	if !f.Update().IsValid() {
+		return ErrInvalidFlood
+	}
+
+	if !common.HaveActions(f.Actions) || common.HaveInvalidActions(f.Actions) || common.HaveDuplicatesActions(f.Actions) {
+		return ErrInvalidActions
+	}
+
+	if err := rm.write(Write{Op: WriteSaveReactionFlood, GuildId: f.GuildId, Origin: origin, ReactionFlood: &f}); err != nil {
+		return fmt.Errorf("error saving reaction flood rule: %w", err)
+	}
+
+	logger.Info("Reaction flood rule saved", map[string]any{"guildId": f.GuildId, "maxReactions": f.MaxReactions, "windowSeconds": f.WindowSeconds})
+
+	return nil
+}
+
+// DeleteReactionFloodApi deletes the flood rule of the guild through the API and from the cache.
+// While the API is unavailable the deletion is queued and ErrQueued is returned.
+func (rm *RuleManager) DeleteReactionFloodApi(guildId string, origin *WriteOrigin) error {
+	if err := rm.write(Write{Op: WriteDeleteReactionFlood, GuildId: guildId, Origin: origin}); err != nil {
+		return fmt.Errorf("error deleting reaction flood rule: %w", err)
+	}
+
+	logger.Info("Reaction flood rule deleted", map[string]any{"guildId": guildId})
+
+	return nil
+}
+
+func (rm *RuleManager) FetchReactionRules(guildId string) ([]rule.ReactionRule, error) {
+	reactionRules, err := rm.api.GetReactionRules(context.Background(), guildId)
 
 	if err != nil {
-		logger.Fatal(err, map[string]any{"details": "The bot cannot continue to work correctly", "at": "guild_create"})
+		return nil, fmt.Errorf("error fetching reaction rules: %w", err)
+	}
+
+	return reactionRules, nil
+}
+
+// PostReactionRules creates reaction rules through the API and adds them to the cache.
+// While the API is unavailable the rules are queued and ErrQueued is returned.
+// origin is notified about the outcome of queued writes, it can be nil. Rules enforced on an emoji
+// of a reaction role message in its channel return rule.ErrReactionRoleConflict.
+func (rm *RuleManager) PostReactionRules(guildId string, reactionRules []rule.ReactionRule, origin *WriteOrigin) error {
+	existingRules, err := rm.GetReactionRules(guildId, false)
+
+	if err != nil && !errors.Is(err, ErrRulesNotFound) {
+		return fmt.Errorf("error posting reaction rules: %w", err)
 	}
 
-	if res.StatusCode != http.StatusCreated {
-		var errRes common.ErrorResponse
+	for _, r := range reactionRules {
+		if slices.ContainsFunc(existingRules, r.SameEmoji) {
+			return ErrIntersectingRules
+		}
+
+		if !common.HaveActions(r.Actions) || common.HaveInvalidActions(r.Actions) || common.HaveDuplicatesActions(r.Actions) {
+			return ErrInvalidActions
+		}
 
-		if err = common.UnmarshalBodyBytes(b, &errRes); err != nil {
-			logger.Error(errors.New("error posting unmarshaling reaction rules error"))
-			return nil, err
+		if common.HaveIntersection(r.IncludeChannels, r.ExcludeChannels) {
+			return ErrIntersectingChannels
 		}
 
-		logger.Debug("Error response", map[string]any{"status": res.StatusCode, "error": errRes.Error, "validationErrors": errRes.ValidationErrors, "message": errRes.Message})
+		if r.Threshold != nil && !r.Threshold.IsValid() {
+			return ErrInvalidThreshold
+		}
 
-		return nil, errors.New(errRes.Error)
+		if !r.IsValidMatcher() {
+			return ErrInvalidMatcher
+		}
 	}
 
-	var rRules []rule.ReactionRule
+	// reaction roles are granted before rules are matched, the rule would never be enforced
+	if guildRules, err := rm.GetRules(guildId, false); err == nil {
+		for _, m := range guildRules.ReactionRoles {
+			if slices.ContainsFunc(reactionRules, m.ConflictsWith) {
+				return rule.ErrReactionRoleConflict
+			}
+		}
+	}
+
+	if err := rm.write(Write{Op: WritePostReactionRules, GuildId: guildId, Origin: origin, ReactionRules: reactionRules}); err != nil {
+		return fmt.Errorf("error posting reaction rules: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateReactionRulesApi updates reaction rules through the API and the cache.
+// While the API is unavailable the updates are queued and ErrQueued is returned.
+func (rm *RuleManager) UpdateReactionRulesApi(guildId string, updates []rule.ReactionRuleUpdate, origin *WriteOrigin) error {
+	for _, u := range updates {
+		if !common.HaveActions(u.Actions) || common.HaveInvalidActions(u.Actions) || common.HaveDuplicatesActions(u.Actions) {
+			return ErrInvalidActions
+		}
+	}
 
-	if err = common.UnmarshalBodyBytes(b, &rRules); err != nil {
-		logger.Error(errors.New("error posting unmarshaling reaction rules"))
-		return nil, err
+	if err := rm.write(Write{Op: WriteUpdateReactionRules, GuildId: guildId, Origin: origin, ReactionRuleUpdates: updates}); err != nil {
+		return fmt.Errorf("error updating reaction rules: %w", err)
 	}
 
-	return rRules, nil
+	logger.Info("Reaction rules updated", map[string]any{"guildId": guildId, "rules": updates})
+
+	return nil
 }
 
-func (rm *RuleManager) DeleteReactionRulesApi(guildId string, deleteDto []RulesDeleteDto) error {
+// DeleteReactionRulesApi deletes reaction rules through the API and from the cache.
+// While the API is unavailable the deletion is queued and ErrQueued is returned.
+func (rm *RuleManager) DeleteReactionRulesApi(guildId string, deleteDto []RulesDeleteDto, origin *WriteOrigin) error {
 	rRules, err := rm.GetReactionRules(guildId, false)
 
 	if err != nil {
@@ -248,46 +705,10 @@ func (rm *RuleManager) DeleteReactionRulesApi(guildId string, deleteDto []RulesD
 		return errors.New("some rules are not found")
 	}
 
-	queryString := rule.EncodeDeleteReactQuery(query)
-
-	rRulesApiUrl := common.GetApiUrl(os.Getenv("API_HOST"), os.Getenv("API_PORT"), "/rules/reaction/"+guildId+"?"+queryString)
-
-	req, err := http.NewRequest(http.MethodDelete, rRulesApiUrl, nil)
-
-	if err != nil {
-		return fmt.Errorf("error deleting reaction rules: %w", err)
-	}
-
-	res, err := rm.client.Do(req)
-
-	if err != nil {
-		return fmt.Errorf("error deleting reaction rules: %w", err)
-	}
-
-	body := res.Body
-	defer body.Close()
-	b, err := io.ReadAll(body)
-
-	if err != nil {
-		logger.Error(err, map[string]any{"details": "error while reading response body"})
+	if err := rm.write(Write{Op: WriteDeleteReactionRules, GuildId: guildId, Origin: origin, DeletedReactionRules: query}); err != nil {
 		return fmt.Errorf("error deleting reaction rules: %w", err)
 	}
 
-	if res.StatusCode != http.StatusOK {
-		var errRes common.ErrorResponse
-
-		if err = common.UnmarshalBodyBytes(b, &errRes); err != nil {
-			logger.Error(errors.New("error unmarshaling errRes"))
-			return fmt.Errorf("error unmarshaling errRes: %w", err)
-		}
-
-		logger.Debug("Error response", map[string]any{"status": res.StatusCode, "error": errRes.Error, "validationErrors": errRes.ValidationErrors, "message": errRes.Message})
-
-		return errors.New(errRes.Error)
-	}
-
-	rm.DeleteReactionRules(guildId, deleteDto)
-
 	logger.Info("Reaction rules deleted", map[string]any{"guildId": guildId, "rules": deleteDto})
 
 	return nil
@@ -0,0 +1,107 @@
+package rules
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/finkabaj/hyde-bot/internals/apiclient"
+	mogs "github.com/finkabaj/hyde-bot/internals/backend/mocks"
+	"github.com/finkabaj/hyde-bot/internals/utils/common"
+	"github.com/finkabaj/hyde-bot/internals/utils/guild"
+	"github.com/finkabaj/hyde-bot/internals/utils/rule"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncGuild(t *testing.T) {
+	t.Run("Positive", testSyncGuildPositive)
+	t.Run("WriteDuringFetch", testSyncGuildWriteDuringFetch)
+	t.Run("RuleEventDuringFetch", testSyncGuildRuleEventDuringFetch)
+}
+
+// slowFetch holds the guild fetch of SyncGuild until release is closed. fetching is closed when it starts.
+type slowFetch struct {
+	fetching chan struct{}
+	release  chan struct{}
+}
+
+// newSyncTestRuleManager returns a rule manager whose API has the guild "guild" with the reaction rule "old".
+// Created reaction rules are accepted, but not returned by later fetches.
+func newSyncTestRuleManager(t *testing.T) (*RuleManager, *slowFetch) {
+	fetch := &slowFetch{fetching: make(chan struct{}), release: make(chan struct{})}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /guild/guild", func(w http.ResponseWriter, r *http.Request) {
+		close(fetch.fetching)
+		<-fetch.release
+
+		common.MarshalBody(w, http.StatusOK, guild.Guild{GuildId: "guild"})
+	})
+	mux.HandleFunc("GET /rules/reaction/guild", func(w http.ResponseWriter, r *http.Request) {
+		common.MarshalBody(w, http.StatusOK, []rule.ReactionRule{{GuildId: "guild", EmojiName: "old", Actions: rule.Actions{{Type: rule.Delete}}}})
+	})
+	mux.HandleFunc("GET /rules/reaction-roles/guild", func(w http.ResponseWriter, r *http.Request) {
+		common.MarshalBody(w, http.StatusOK, []rule.ReactionRoleMessage{})
+	})
+	mux.HandleFunc("GET /rules/reaction-flood/guild", func(w http.ResponseWriter, r *http.Request) {
+		common.NewErrorResponseBuilder(common.ErrNotFound).SetStatus(http.StatusNotFound).Send(w)
+	})
+	mux.HandleFunc("POST /rules/reaction", func(w http.ResponseWriter, r *http.Request) {
+		common.MarshalBody(w, http.StatusCreated, []rule.ReactionRule{})
+	})
+
+	s := httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+
+	client := apiclient.NewClient(mogs.NewMockLogger(), s.URL, s.Client(), apiclient.RetryPolicy{}, nil)
+	rm := &RuleManager{rm: map[string]Rules{"guild": {}}, api: client, queue: &WriteQueue{logger: mogs.NewMockLogger()}}
+
+	return rm, fetch
+}
+
+// syncDuring syncs the guild and calls change while the guild is fetched. Returns the error of the sync.
+func syncDuring(rm *RuleManager, fetch *slowFetch, change func()) error {
+	synced := make(chan error)
+
+	go func() {
+		synced <- rm.SyncGuild("guild")
+	}()
+
+	<-fetch.fetching
+	change()
+	close(fetch.release)
+
+	return <-synced
+}
+
+func testSyncGuildPositive(t *testing.T) {
+	rm, fetch := newSyncTestRuleManager(t)
+
+	err := syncDuring(rm, fetch, func() {})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"old"}, cachedEmojis(rm, "guild"))
+}
+
+func testSyncGuildWriteDuringFetch(t *testing.T) {
+	rm, fetch := newSyncTestRuleManager(t)
+
+	err := syncDuring(rm, fetch, func() {
+		require.NoError(t, rm.write(testWrite("guild", "new")))
+	})
+
+	assert.ErrorIs(t, err, ErrSyncInterrupted)
+	assert.Equal(t, []string{"new"}, cachedEmojis(rm, "guild"), "the snapshot fetched before the write is dropped")
+}
+
+func testSyncGuildRuleEventDuringFetch(t *testing.T) {
+	rm, fetch := newSyncTestRuleManager(t)
+
+	err := syncDuring(rm, fetch, func() {
+		rm.ApplyRuleEvent(createdEvent("guild", "new"))
+	})
+
+	assert.ErrorIs(t, err, ErrSyncInterrupted)
+	assert.Equal(t, []string{"new"}, cachedEmojis(rm, "guild"), "the snapshot fetched before the event is dropped")
+}
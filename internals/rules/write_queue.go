@@ -0,0 +1,326 @@
+package rules
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/finkabaj/hyde-bot/internals/logger"
+	"github.com/finkabaj/hyde-bot/internals/utils/escalation"
+	"github.com/finkabaj/hyde-bot/internals/utils/guild"
+	"github.com/finkabaj/hyde-bot/internals/utils/infraction"
+	"github.com/finkabaj/hyde-bot/internals/utils/rule"
+)
+
+// DefaultWriteQueuePath is used when the write queue is opened with empty path.
+const DefaultWriteQueuePath = "write_queue.jsonl"
+
+type WriteOp string
+
+const (
+	WriteCreateGuild         WriteOp = "createGuild"
+	WriteUpdateGuild         WriteOp = "updateGuild"
+	WriteDeleteGuild         WriteOp = "deleteGuild"
+	WriteUpdateExemptions    WriteOp = "updateExemptions"
+	WritePostReactionRules   WriteOp = "postReactionRules"
+	WriteUpdateReactionRules WriteOp = "updateReactionRules"
+	WriteDeleteReactionRules WriteOp = "deleteReactionRules"
+	WriteCreateInfraction    WriteOp = "createInfraction"
+	WriteUpdateEscalation    WriteOp = "updateEscalation"
+	WriteUpdateModLog        WriteOp = "updateModLog"
+	WriteSaveReactionRoles   WriteOp = "saveReactionRoles"
+	WriteDeleteReactionRoles WriteOp = "deleteReactionRoles"
+	WriteSaveReactionFlood   WriteOp = "saveReactionFlood"
+	WriteDeleteReactionFlood WriteOp = "deleteReactionFlood"
+)
+
+// WriteOrigin is the interaction of the admin who made a write, so the outcome of a queued write can be reported.
+type WriteOrigin struct {
+	UserId    string `json:"userId"`
+	ChannelId string `json:"channelId"`
+	AppId     string `json:"appId"`
+	// InteractionToken allows follow-up messages for 15 minutes after the interaction.
+	InteractionToken string `json:"interactionToken"`
+}
+
+// Write is an API mutation made while the API was unavailable. Only the field of the Op is set.
+type Write struct {
+	Id                   uint64                         `json:"id"`
+	Op                   WriteOp                        `json:"op"`
+	GuildId              string                         `json:"guildId"`
+	Origin               *WriteOrigin                   `json:"origin,omitempty"`
+	Guild                *guild.GuildCreate             `json:"guild,omitempty"`
+	GuildUpdate          *guild.GuildUpdate             `json:"guildUpdate,omitempty"`
+	Exemptions           *guild.GuildExemptions         `json:"exemptions,omitempty"`
+	ReactionRules        []rule.ReactionRule            `json:"reactionRules,omitempty"`
+	ReactionRuleUpdates  []rule.ReactionRuleUpdate      `json:"reactionRuleUpdates,omitempty"`
+	DeletedReactionRules []rule.DeleteReactionRuleQuery `json:"deletedReactionRules,omitempty"`
+	Infraction           *infraction.InfractionCreate   `json:"infraction,omitempty"`
+	Escalation           *escalation.PolicyUpdate       `json:"escalation,omitempty"`
+	ModLog               *guild.GuildModLog             `json:"modLog,omitempty"`
+	ReactionRoles        *rule.ReactionRoleMessage      `json:"reactionRoles,omitempty"`
+	// DeletedReactionRoleMessage is the id of the message whose reaction roles are deleted.
+	DeletedReactionRoleMessage string                  `json:"deletedReactionRoleMessage,omitempty"`
+	ReactionFlood              *rule.ReactionFloodRule `json:"reactionFlood,omitempty"`
+}
+
+// writeQueueEntry is a line of the queue file. A write is appended when it's queued
+// and its id is appended with Done when it's replayed or dropped.
+type writeQueueEntry struct {
+	Write *Write `json:"write,omitempty"`
+	Id    uint64 `json:"id,omitempty"`
+	Done  bool   `json:"done,omitempty"`
+}
+
+// WriteQueue holds writes in the order they were made until the API is back.
+// After Open the queue is kept in an append-only file, so queued writes survive restarts.
+type WriteQueue struct {
+	writes []Write
+	lastId uint64
+	file   *os.File
+	logger logger.ILogger
+	lock   sync.Mutex
+}
+
+var writeQueue *WriteQueue
+
+func NewWriteQueue(l logger.ILogger) *WriteQueue {
+	if writeQueue == nil {
+		writeQueue = &WriteQueue{logger: l}
+	}
+	return writeQueue
+}
+
+// Open loads writes queued in the file at path, creating it if needed, and persists the queue to it from now on.
+// The file is compacted to the pending writes.
+func (q *WriteQueue) Open(path string) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if q.file != nil {
+		return errors.New("write queue is already open")
+	}
+
+	if path == "" {
+		path = DefaultWriteQueuePath
+	}
+
+	writes, lastId, err := q.read(path)
+
+	if err != nil {
+		return err
+	}
+
+	// writes queued before Open are kept after the loaded ones
+	for _, w := range q.writes {
+		lastId++
+		w.Id = lastId
+		writes = append(writes, w)
+	}
+
+	if err := compactWriteQueue(path, writes); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+
+	if err != nil {
+		return fmt.Errorf("error opening write queue: %w", err)
+	}
+
+	q.writes = writes
+	q.lastId = lastId
+	q.file = f
+
+	if len(writes) > 0 {
+		q.logger.Info("Queued writes loaded", map[string]any{"pending": len(writes), "path": path})
+	}
+
+	return nil
+}
+
+func (q *WriteQueue) Close() error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if q.file == nil {
+		return nil
+	}
+
+	err := q.file.Close()
+	q.file = nil
+
+	return err
+}
+
+// Push queues w and returns it with the assigned id. The write is on disk when Push returns.
+func (q *WriteQueue) Push(w Write) (Write, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	w.Id = q.lastId + 1
+
+	if err := q.append(writeQueueEntry{Write: &w}); err != nil {
+		return Write{}, fmt.Errorf("error queuing write: %w", err)
+	}
+
+	q.lastId = w.Id
+	q.writes = append(q.writes, w)
+
+	return w, nil
+}
+
+// Peek returns the oldest write.
+func (q *WriteQueue) Peek() (Write, bool) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if len(q.writes) == 0 {
+		return Write{}, false
+	}
+
+	return q.writes[0], true
+}
+
+// Pop removes the oldest write. The file is truncated once the queue is empty.
+// The write is removed from memory even if the file can't be updated, so it isn't replayed again
+// until the queue is reopened.
+func (q *WriteQueue) Pop() error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if len(q.writes) == 0 {
+		return nil
+	}
+
+	var err error
+
+	if len(q.writes) == 1 && q.file != nil {
+		if err = q.file.Truncate(0); err != nil {
+			err = fmt.Errorf("error truncating write queue: %w", err)
+		}
+	} else if err = q.append(writeQueueEntry{Id: q.writes[0].Id, Done: true}); err != nil {
+		err = fmt.Errorf("error removing queued write: %w", err)
+	}
+
+	q.writes = q.writes[1:]
+
+	return err
+}
+
+func (q *WriteQueue) Len() int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	return len(q.writes)
+}
+
+// HasGuild reports whether the guild has queued writes.
+func (q *WriteQueue) HasGuild(guildId string) bool {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	return slices.ContainsFunc(q.writes, func(w Write) bool {
+		return w.GuildId == guildId
+	})
+}
+
+// append must be called with lock held. Queues that aren't open only live in memory.
+func (q *WriteQueue) append(e writeQueueEntry) error {
+	if q.file == nil {
+		return nil
+	}
+
+	b, err := json.Marshal(e)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err = q.file.Write(append(b, '\n')); err != nil {
+		return err
+	}
+
+	return q.file.Sync()
+}
+
+// read returns pending writes of the file in order. Missing file has no writes.
+// An incomplete last line, left by a crash while writing it, is skipped.
+func (q *WriteQueue) read(path string) ([]Write, uint64, error) {
+	f, err := os.Open(path)
+
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, 0, nil
+	} else if err != nil {
+		return nil, 0, fmt.Errorf("error reading write queue: %w", err)
+	}
+
+	defer f.Close()
+
+	var writes []Write
+	var lastId uint64
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	for line := 1; scanner.Scan(); line++ {
+		var e writeQueueEntry
+
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			q.logger.Warn(err, map[string]any{"details": "skipping invalid write queue entry", "path": path, "line": line})
+			continue
+		}
+
+		switch {
+		case e.Write != nil:
+			writes = append(writes, *e.Write)
+			lastId = max(lastId, e.Write.Id)
+		case e.Done:
+			writes = slices.DeleteFunc(writes, func(w Write) bool {
+				return w.Id == e.Id
+			})
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error reading write queue: %w", err)
+	}
+
+	return writes, lastId, nil
+}
+
+// compactWriteQueue atomically replaces the file with one that contains only writes.
+func compactWriteQueue(path string, writes []Write) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+
+	if err != nil {
+		return fmt.Errorf("error compacting write queue: %w", err)
+	}
+
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriter(tmp)
+	enc := json.NewEncoder(w)
+
+	for i := range writes {
+		if err := enc.Encode(writeQueueEntry{Write: &writes[i]}); err != nil {
+			tmp.Close()
+			return fmt.Errorf("error compacting write queue: %w", err)
+		}
+	}
+
+	if err := errors.Join(w.Flush(), tmp.Sync(), tmp.Close()); err != nil {
+		return fmt.Errorf("error compacting write queue: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("error compacting write queue: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,176 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	mogs "github.com/finkabaj/hyde-bot/internals/backend/mocks"
+	"github.com/finkabaj/hyde-bot/internals/utils/rule"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteQueue(t *testing.T) {
+	t.Run("Reopen", testWriteQueueReopen)
+	t.Run("TruncatesWhenEmpty", testWriteQueueTruncatesWhenEmpty)
+	t.Run("SkipsIncompleteLine", testWriteQueueSkipsIncompleteLine)
+	t.Run("KeepsWritesQueuedBeforeOpen", testWriteQueueKeepsWritesQueuedBeforeOpen)
+	t.Run("LoadsLegacyActions", testWriteQueueLoadsLegacyActions)
+	t.Run("PopFailingFile", testWriteQueuePopFailingFile)
+}
+
+func openTestWriteQueue(t *testing.T, path string) *WriteQueue {
+	q := &WriteQueue{logger: mogs.NewMockLogger()}
+	require.NoError(t, q.Open(path))
+	t.Cleanup(func() { q.Close() })
+
+	return q
+}
+
+func testWrite(guildId, emojiName string) Write {
+	return Write{
+		Op:            WritePostReactionRules,
+		GuildId:       guildId,
+		Origin:        &WriteOrigin{UserId: "user", ChannelId: "channel"},
+		ReactionRules: []rule.ReactionRule{{GuildId: guildId, EmojiName: emojiName, Actions: rule.Actions{{Type: rule.Delete}}}},
+	}
+}
+
+func testWriteQueueReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.jsonl")
+	q := openTestWriteQueue(t, path)
+
+	for _, emojiName := range []string{"1", "2", "3"} {
+		_, err := q.Push(testWrite("guild", emojiName))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, q.Pop())
+	require.NoError(t, q.Close())
+
+	reopened := openTestWriteQueue(t, path)
+
+	assert.Equal(t, 2, reopened.Len())
+	assert.True(t, reopened.HasGuild("guild"))
+
+	w, ok := reopened.Peek()
+
+	require.True(t, ok)
+	assert.Equal(t, uint64(2), w.Id)
+	assert.Equal(t, testWrite("guild", "2").ReactionRules, w.ReactionRules)
+	assert.Equal(t, &WriteOrigin{UserId: "user", ChannelId: "channel"}, w.Origin)
+
+	// ids continue after the loaded writes
+	pushed, err := reopened.Push(testWrite("other", "4"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4), pushed.Id)
+}
+
+func testWriteQueueTruncatesWhenEmpty(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.jsonl")
+	q := openTestWriteQueue(t, path)
+
+	_, err := q.Push(testWrite("guild", "1"))
+	require.NoError(t, err)
+	require.NoError(t, q.Pop())
+
+	info, err := os.Stat(path)
+
+	assert.NoError(t, err)
+	assert.Zero(t, info.Size())
+	assert.False(t, q.HasGuild("guild"))
+}
+
+func testWriteQueueSkipsIncompleteLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.jsonl")
+	q := openTestWriteQueue(t, path)
+
+	_, err := q.Push(testWrite("guild", "1"))
+	require.NoError(t, err)
+	require.NoError(t, q.Close())
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"write":{"id":2,"op":"postReac`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	reopened := openTestWriteQueue(t, path)
+
+	assert.Equal(t, 1, reopened.Len())
+
+	// the file is compacted, so new writes aren't appended to the incomplete line
+	_, err = reopened.Push(testWrite("guild", "2"))
+	require.NoError(t, err)
+	require.NoError(t, reopened.Close())
+
+	assert.Equal(t, 2, openTestWriteQueue(t, path).Len())
+}
+
+func testWriteQueueKeepsWritesQueuedBeforeOpen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.jsonl")
+	q := openTestWriteQueue(t, path)
+
+	_, err := q.Push(testWrite("guild", "1"))
+	require.NoError(t, err)
+	require.NoError(t, q.Close())
+
+	memory := &WriteQueue{logger: mogs.NewMockLogger()}
+	_, err = memory.Push(testWrite("guild", "2"))
+	require.NoError(t, err)
+	require.NoError(t, memory.Open(path))
+	defer memory.Close()
+
+	first, _ := memory.Peek()
+	require.NoError(t, memory.Pop())
+	second, _ := memory.Peek()
+
+	assert.Equal(t, "1", first.ReactionRules[0].EmojiName)
+	assert.Equal(t, "2", second.ReactionRules[0].EmojiName)
+	assert.Equal(t, uint64(2), second.Id)
+}
+
+func testWriteQueueLoadsLegacyActions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.jsonl")
+
+	// rules had a fixed size array of actions, zeros were unused slots
+	err := os.WriteFile(path, []byte(`{"write":{"id":1,"op":"postReactionRules","guildId":"guild","reactionRules":[{"emojiName":"1","guildId":"guild","isCustom":false,"ruleAuthor":"","actions":[1,2,0,0]}]}}`+"\n"), 0600)
+	require.NoError(t, err)
+
+	w, ok := openTestWriteQueue(t, path).Peek()
+
+	require.True(t, ok)
+	assert.Equal(t, rule.Actions{{Type: rule.Delete}, {Type: rule.Warn}}, w.ReactionRules[0].Actions)
+}
+
+func testWriteQueuePopFailingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.jsonl")
+	q := openTestWriteQueue(t, path)
+
+	for _, emojiName := range []string{"1", "2"} {
+		_, err := q.Push(testWrite("guild", emojiName))
+		require.NoError(t, err)
+	}
+
+	// writes to a read only file fail
+	readOnly, err := os.Open(path)
+	require.NoError(t, err)
+	require.NoError(t, q.file.Close())
+	q.file = readOnly
+
+	assert.Error(t, q.Pop())
+
+	w, ok := q.Peek()
+
+	require.True(t, ok, "the second write is still queued")
+	assert.Equal(t, "2", w.ReactionRules[0].EmojiName, "the popped write must not be returned again")
+
+	assert.Error(t, q.Pop())
+
+	_, ok = q.Peek()
+
+	assert.False(t, ok)
+	assert.Equal(t, 0, q.Len())
+}
@@ -0,0 +1,133 @@
+// Package threshold counts reactions to messages for reaction rules with a threshold.
+package threshold
+
+import (
+	"sync"
+	"time"
+
+	"github.com/finkabaj/hyde-bot/internals/utils/rule"
+)
+
+type key struct {
+	guildID   string
+	messageID string
+	rule      string
+}
+
+type tally struct {
+	reactions map[string]time.Time // reactions[userID] = when the member reacted
+	author    string               // author of the message once it's known, their reactions aren't counted
+	reached   bool
+	expires   time.Time
+}
+
+// Counter counts distinct members who reacted to a message with the emoji of a threshold rule.
+// Reactions expire after the window of the rule, tallies are dropped once all their reactions expired.
+type Counter struct {
+	tallies map[key]*tally
+	now     func() time.Time
+	lock    sync.Mutex
+}
+
+var counter *Counter
+
+func NewCounter() *Counter {
+	if counter == nil {
+		counter = &Counter{
+			tallies: make(map[key]*tally),
+			now:     time.Now,
+		}
+	}
+	return counter
+}
+
+// RuleKey identifies the rule in the counter, reactions to different rules are counted separately.
+func RuleKey(r rule.ReactionRule) string {
+	return r.EmojiName + ":" + r.EmojiId
+}
+
+// Add counts the reaction of the member and reports whether the message has just reached the threshold.
+// The threshold is reached once, later reactions don't report it again until the reactions of the message expire.
+// Reactions of an author excluded with ExcludeAuthor aren't counted.
+func (c *Counter) Add(guildID, messageID, ruleKey, userID string, t rule.ReactionThreshold) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	now := c.now()
+	k := key{guildID: guildID, messageID: messageID, rule: ruleKey}
+	tl, ok := c.tallies[k]
+
+	if !ok || !now.Before(tl.expires) {
+		tl = &tally{reactions: make(map[string]time.Time)}
+		c.tallies[k] = tl
+
+		// expired tallies are dropped lazily, so the map doesn't grow with every message ever reacted to
+		if len(c.tallies)%1024 == 0 {
+			c.evictExpired(now)
+		}
+	}
+
+	if userID == tl.author {
+		return false
+	}
+
+	for userID, at := range tl.reactions {
+		if !now.Before(at.Add(t.Window())) {
+			delete(tl.reactions, userID)
+		}
+	}
+
+	tl.reactions[userID] = now
+	tl.expires = now.Add(t.Window())
+
+	if tl.reached || len(tl.reactions) < t.Count {
+		return false
+	}
+
+	tl.reached = true
+
+	return true
+}
+
+// Remove stops counting the reaction of the member, e.g. when the member removes it.
+func (c *Counter) Remove(guildID, messageID, ruleKey, userID string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if tl, ok := c.tallies[key{guildID: guildID, messageID: messageID, rule: ruleKey}]; ok {
+		delete(tl.reactions, userID)
+	}
+}
+
+// ExcludeAuthor stops counting reactions of the author of the message, members don't report their own messages.
+// It reports whether the message is still at the threshold without the reaction of the author.
+// If it isn't, the threshold is reported again once other members reach it.
+func (c *Counter) ExcludeAuthor(guildID, messageID, ruleKey, authorID string, t rule.ReactionThreshold) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	tl, ok := c.tallies[key{guildID: guildID, messageID: messageID, rule: ruleKey}]
+
+	if !ok {
+		return false
+	}
+
+	tl.author = authorID
+	delete(tl.reactions, authorID)
+
+	if len(tl.reactions) < t.Count {
+		tl.reached = false
+		return false
+	}
+
+	return true
+}
+
+// evictExpired must be called with lock held.
+func (c *Counter) evictExpired(now time.Time) {
+	for k, tl := range c.tallies {
+		if !now.Before(tl.expires) {
+			delete(c.tallies, k)
+		}
+	}
+}
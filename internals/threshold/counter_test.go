@@ -0,0 +1,123 @@
+package threshold
+
+import (
+	"testing"
+	"time"
+
+	"github.com/finkabaj/hyde-bot/internals/utils/rule"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCounter(t *testing.T) {
+	t.Run("Reached", testCounterReached)
+	t.Run("DistinctMembers", testCounterDistinctMembers)
+	t.Run("Window", testCounterWindow)
+	t.Run("ReachedOnce", testCounterReachedOnce)
+	t.Run("Remove", testCounterRemove)
+	t.Run("ExcludeAuthor", testCounterExcludeAuthor)
+	t.Run("Rules", testCounterRules)
+}
+
+var testThreshold = rule.ReactionThreshold{Count: 3, WindowSeconds: 600}
+
+// newTestCounter returns a counter with a clock the test moves forward.
+func newTestCounter() (*Counter, func(d time.Duration)) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := &Counter{
+		tallies: make(map[key]*tally),
+		now:     func() time.Time { return now },
+	}
+
+	return c, func(d time.Duration) { now = now.Add(d) }
+}
+
+func testCounterReached(t *testing.T) {
+	c, _ := newTestCounter()
+
+	assert.False(t, c.Add("1", "10", "🚩:", "a", testThreshold))
+	assert.False(t, c.Add("1", "10", "🚩:", "b", testThreshold))
+	assert.True(t, c.Add("1", "10", "🚩:", "c", testThreshold))
+}
+
+func testCounterDistinctMembers(t *testing.T) {
+	c, _ := newTestCounter()
+
+	for range 5 {
+		assert.False(t, c.Add("1", "10", "🚩:", "a", testThreshold))
+	}
+
+	assert.False(t, c.Add("1", "10", "🚩:", "b", testThreshold))
+}
+
+func testCounterWindow(t *testing.T) {
+	c, advance := newTestCounter()
+
+	c.Add("1", "10", "🚩:", "a", testThreshold)
+	advance(6 * time.Minute)
+	c.Add("1", "10", "🚩:", "b", testThreshold)
+	advance(5 * time.Minute)
+
+	assert.False(t, c.Add("1", "10", "🚩:", "c", testThreshold), "reaction of a is older than the window")
+	assert.True(t, c.Add("1", "10", "🚩:", "d", testThreshold))
+}
+
+func testCounterReachedOnce(t *testing.T) {
+	c, advance := newTestCounter()
+
+	for _, u := range []string{"a", "b", "c"} {
+		c.Add("1", "10", "🚩:", u, testThreshold)
+	}
+
+	assert.False(t, c.Add("1", "10", "🚩:", "d", testThreshold))
+
+	advance(testThreshold.Window())
+
+	for _, u := range []string{"a", "b"} {
+		assert.False(t, c.Add("1", "10", "🚩:", u, testThreshold))
+	}
+
+	assert.True(t, c.Add("1", "10", "🚩:", "c", testThreshold), "threshold is reached again after the reactions expired")
+}
+
+func testCounterRemove(t *testing.T) {
+	c, _ := newTestCounter()
+
+	c.Add("1", "10", "🚩:", "a", testThreshold)
+	c.Add("1", "10", "🚩:", "b", testThreshold)
+	c.Remove("1", "10", "🚩:", "b")
+	c.Remove("1", "11", "🚩:", "b")
+
+	assert.False(t, c.Add("1", "10", "🚩:", "c", testThreshold))
+	assert.True(t, c.Add("1", "10", "🚩:", "b", testThreshold))
+}
+
+func testCounterExcludeAuthor(t *testing.T) {
+	c, _ := newTestCounter()
+
+	c.Add("1", "10", "🚩:", "author", testThreshold)
+	c.Add("1", "10", "🚩:", "a", testThreshold)
+	require.True(t, c.Add("1", "10", "🚩:", "b", testThreshold))
+
+	assert.False(t, c.ExcludeAuthor("1", "10", "🚩:", "author", testThreshold), "the reaction of the author doesn't count")
+	assert.False(t, c.Add("1", "10", "🚩:", "author", testThreshold), "the author reacting again isn't counted")
+	assert.True(t, c.Add("1", "10", "🚩:", "c", testThreshold), "the threshold is reported once other members reach it")
+	assert.True(t, c.ExcludeAuthor("1", "10", "🚩:", "author", testThreshold))
+
+	c.Add("1", "11", "🚩:", "a", testThreshold)
+	c.Add("1", "11", "🚩:", "b", testThreshold)
+	require.True(t, c.Add("1", "11", "🚩:", "c", testThreshold))
+
+	assert.True(t, c.ExcludeAuthor("1", "11", "🚩:", "author", testThreshold), "the author didn't react")
+}
+
+func testCounterRules(t *testing.T) {
+	c, _ := newTestCounter()
+
+	c.Add("1", "10", "🚩:", "a", testThreshold)
+	c.Add("1", "10", "🚩:", "b", testThreshold)
+
+	assert.False(t, c.Add("1", "10", "👎:", "c", testThreshold))
+	assert.False(t, c.Add("1", "11", "🚩:", "c", testThreshold))
+	assert.False(t, c.Add("2", "10", "🚩:", "c", testThreshold))
+}
@@ -0,0 +1,105 @@
+package commandUtils
+
+import (
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/finkabaj/hyde-bot/internals/utils/rule"
+)
+
+// PendingReactionRule is a reaction rule creation that waits for the admin to finish configuring it.
+type PendingReactionRule struct {
+	Interaction     *discordgo.InteractionCreate // Interaction is the modal submit that sent the configuration message.
+	Rules           []rule.ReactionRule
+	Actions         rule.Actions
+	IncludeChannels []string
+	ExcludeChannels []string
+	ExemptRoles     []string
+	Threshold       *rule.ReactionThreshold // Threshold is nil for rules that act on every reaction.
+}
+
+// pendingKey identifies a pending creation, admins may configure rules in several guilds at once.
+type pendingKey struct {
+	guildID string
+	userID  string
+}
+
+type PendingReactionRules struct {
+	pending map[pendingKey]*PendingReactionRule
+	lock    sync.RWMutex
+}
+
+func NewPendingReactionRules() *PendingReactionRules {
+	return &PendingReactionRules{
+		pending: make(map[pendingKey]*PendingReactionRule),
+	}
+}
+
+func (p *PendingReactionRules) Get(guildID, userID string) (PendingReactionRule, bool) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	pr, ok := p.pending[pendingKey{guildID, userID}]
+
+	if !ok {
+		return PendingReactionRule{}, false
+	}
+
+	return *pr, true
+}
+
+func (p *PendingReactionRules) Set(guildID, userID string, pr PendingReactionRule) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.pending[pendingKey{guildID, userID}] = &pr
+}
+
+// Update calls f with the pending rule of the user in the guild under lock. Returns false if there is no pending rule.
+func (p *PendingReactionRules) Update(guildID, userID string, f func(pr *PendingReactionRule)) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	pr, ok := p.pending[pendingKey{guildID, userID}]
+
+	if !ok {
+		return false
+	}
+
+	f(pr)
+
+	return true
+}
+
+// Take returns the pending rule and removes it, so it can be submitted only once.
+func (p *PendingReactionRules) Take(guildID, userID string) (PendingReactionRule, bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	pr, ok := p.pending[pendingKey{guildID, userID}]
+
+	if !ok {
+		return PendingReactionRule{}, false
+	}
+
+	delete(p.pending, pendingKey{guildID, userID})
+
+	return *pr, true
+}
+
+// DeleteIfInteraction removes the pending rule only if it was created by interaction i.
+// Returns true if the rule was removed.
+func (p *PendingReactionRules) DeleteIfInteraction(guildID, userID string, i *discordgo.InteractionCreate) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	pr, ok := p.pending[pendingKey{guildID, userID}]
+
+	if !ok || pr.Interaction != i {
+		return false
+	}
+
+	delete(p.pending, pendingKey{guildID, userID})
+
+	return true
+}
@@ -0,0 +1,46 @@
+package commandUtils
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/finkabaj/hyde-bot/internals/utils/rule"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPendingReactionRules(t *testing.T) {
+	t.Run("PerGuild", testPendingReactionRulesPerGuild)
+	t.Run("DeleteIfInteraction", testPendingReactionRulesDeleteIfInteraction)
+}
+
+func testPendingReactionRulesPerGuild(t *testing.T) {
+	p := NewPendingReactionRules()
+
+	p.Set("1", "user", PendingReactionRule{Rules: []rule.ReactionRule{{GuildId: "1"}}})
+	p.Set("2", "user", PendingReactionRule{Rules: []rule.ReactionRule{{GuildId: "2"}}})
+
+	pr, ok := p.Take("1", "user")
+
+	assert.True(t, ok)
+	assert.Equal(t, "1", pr.Rules[0].GuildId)
+
+	_, ok = p.Get("1", "user")
+	assert.False(t, ok)
+
+	pr, ok = p.Get("2", "user")
+
+	assert.True(t, ok, "creations in other guilds are kept")
+	assert.Equal(t, "2", pr.Rules[0].GuildId)
+}
+
+func testPendingReactionRulesDeleteIfInteraction(t *testing.T) {
+	p := NewPendingReactionRules()
+	first, second := &discordgo.InteractionCreate{}, &discordgo.InteractionCreate{}
+
+	p.Set("1", "user", PendingReactionRule{Interaction: first})
+	p.Set("2", "user", PendingReactionRule{Interaction: second})
+
+	assert.False(t, p.DeleteIfInteraction("1", "user", second))
+	assert.True(t, p.DeleteIfInteraction("1", "user", first))
+	assert.True(t, p.DeleteIfInteraction("2", "user", second))
+}
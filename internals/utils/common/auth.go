@@ -0,0 +1,27 @@
+package common
+
+import "net/http"
+
+// ServiceAuthScheme is the Authorization scheme of the bot credential.
+const ServiceAuthScheme = "Bot"
+
+// ServiceAuthTransport sends the bot credential with every request to the api.
+type ServiceAuthTransport struct {
+	Token string
+	// Base is used to send requests, http.DefaultTransport if nil.
+	Base http.RoundTripper
+}
+
+func (t *ServiceAuthTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	base := t.Base
+
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	// a RoundTripper must not modify the request
+	r = r.Clone(r.Context())
+	r.Header.Set("Authorization", ServiceAuthScheme+" "+t.Token)
+
+	return base.RoundTrip(r)
+}
@@ -142,14 +142,31 @@ func RemoveDuplicates[T comparable](slice []T) []T {
 	return result
 }
 
-func HaveDuplicatesActions(a [rule.ReactActionCount]rule.ReactAction) bool {
+// HaveDuplicatesActions reports whether a contains several actions of the same type, even with different parameters
+func HaveDuplicatesActions(a rule.Actions) bool {
 	seen := make(map[rule.ReactAction]bool)
 
 	for _, val := range a {
-		if val != 0 && seen[val] {
+		if seen[val.Type] {
+			return true
+		}
+		seen[val.Type] = true
+	}
+
+	return false
+}
+
+// HaveActions reports whether at least one action is set
+func HaveActions(a rule.Actions) bool {
+	return len(a) > 0
+}
+
+// HaveInvalidActions reports whether a contains an action that is not defined or has invalid parameters
+func HaveInvalidActions(a rule.Actions) bool {
+	for _, val := range a {
+		if !val.IsValid() {
 			return true
 		}
-		seen[val] = true
 	}
 
 	return false
@@ -0,0 +1,74 @@
+package common
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidDuration = errors.New("invalid duration")
+
+// ParseDuration parses durations like time.ParseDuration and also accepts days, e.g. "7d" or "1d12h".
+func ParseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	days, rest, hasDays := strings.Cut(s, "d")
+
+	if !hasDays {
+		d, err := time.ParseDuration(s)
+
+		if err != nil {
+			return 0, ErrInvalidDuration
+		}
+
+		return d, nil
+	}
+
+	n, err := strconv.Atoi(days)
+
+	if err != nil || n < 0 {
+		return 0, ErrInvalidDuration
+	}
+
+	d := time.Duration(n) * 24 * time.Hour
+
+	if rest == "" {
+		return d, nil
+	}
+
+	restDuration, err := time.ParseDuration(rest)
+
+	if err != nil || restDuration < 0 {
+		return 0, ErrInvalidDuration
+	}
+
+	return d + restDuration, nil
+}
+
+// FormatDuration formats d in the format accepted by ParseDuration, e.g. "7d", "1h30m" or "1d12h".
+func FormatDuration(d time.Duration) string {
+	if d <= 0 {
+		return "0s"
+	}
+
+	var b strings.Builder
+
+	units := []struct {
+		suffix string
+		size   time.Duration
+	}{
+		{"d", 24 * time.Hour},
+		{"h", time.Hour},
+		{"m", time.Minute},
+		{"s", time.Second},
+	}
+
+	for _, u := range units {
+		if n := d / u.size; n > 0 {
+			b.WriteString(strconv.FormatInt(int64(n), 10) + u.suffix)
+			d -= n * u.size
+		}
+	}
+
+	return b.String()
+}
@@ -6,9 +6,11 @@ import (
 )
 
 var (
-	ErrBadRequest = errors.New("bad request")
-	ErrNotFound   = errors.New("not found")
-	ErrInternal   = errors.New("internal error")
+	ErrBadRequest   = errors.New("bad request")
+	ErrNotFound     = errors.New("not found")
+	ErrInternal     = errors.New("internal error")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
 )
 
 type ErrorResponse struct {
@@ -102,3 +104,21 @@ func SendNotFoundError(w http.ResponseWriter, m ...string) {
 	}
 	b.Send(w)
 }
+
+func SendUnauthorizedError(w http.ResponseWriter, m ...string) {
+	b := NewErrorResponseBuilder(ErrUnauthorized).
+		SetStatus(http.StatusUnauthorized)
+	if len(m) == 1 {
+		b.SetMessage(m[0])
+	}
+	b.Send(w)
+}
+
+func SendForbiddenError(w http.ResponseWriter, m ...string) {
+	b := NewErrorResponseBuilder(ErrForbidden).
+		SetStatus(http.StatusForbidden)
+	if len(m) == 1 {
+		b.SetMessage(m[0])
+	}
+	b.Send(w)
+}
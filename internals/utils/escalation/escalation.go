@@ -0,0 +1,85 @@
+package escalation
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/finkabaj/hyde-bot/internals/utils/common"
+	"github.com/finkabaj/hyde-bot/internals/utils/infraction"
+	"github.com/finkabaj/hyde-bot/internals/utils/rule"
+)
+
+const (
+	MaxSteps   = 10
+	MaxTimeout = rule.MaxTimeout
+)
+
+// Step is taken when a member has at least Warns warnings within the window.
+type Step struct {
+	Warns          int               `json:"warns" validate:"min=1,max=100"`
+	WindowSeconds  int               `json:"windowSeconds" validate:"min=60,max=31536000"`
+	Action         infraction.Action `json:"action" validate:"required,oneof=timeout kick ban"`
+	TimeoutSeconds int               `json:"timeoutSeconds,omitempty" validate:"required_if=Action timeout,max=2419200"`
+}
+
+func (s Step) Window() time.Duration {
+	return time.Duration(s.WindowSeconds) * time.Second
+}
+
+func (s Step) Timeout() time.Duration {
+	return time.Duration(s.TimeoutSeconds) * time.Second
+}
+
+// Key identifies the step in infractions it recorded, so the same step isn't taken twice within its window.
+// Unlike the reason, it can't be edited.
+func (s Step) Key() string {
+	return fmt.Sprintf("%d/%d", s.Warns, s.WindowSeconds)
+}
+
+// Reason describes the step. It's the reason of infractions recorded by the step.
+func (s Step) Reason() string {
+	reason := fmt.Sprintf("Escalation: %d warnings in %s", s.Warns, common.FormatDuration(s.Window()))
+
+	if s.Action == infraction.Timeout {
+		reason += fmt.Sprintf(", timeout for %s", common.FormatDuration(s.Timeout()))
+	}
+
+	return reason
+}
+
+// Policy is the escalation policy of the guild. Steps are sorted by warns.
+type Policy struct {
+	GuildId string `json:"guildId"`
+	Steps   []Step `json:"steps"`
+}
+
+// PolicyUpdate replaces steps of the policy. Warns of the steps must be unique.
+type PolicyUpdate struct {
+	Steps []Step `json:"steps" validate:"max=10,dive"`
+}
+
+var ErrDuplicateSteps = errors.New("escalation steps have duplicate warns")
+
+// SortSteps sorts steps by warns and returns ErrDuplicateSteps if warns of two steps are equal.
+// Timeout of steps that aren't timeouts is cleared.
+func SortSteps(steps []Step) ([]Step, error) {
+	sorted := slices.Clone(steps)
+
+	slices.SortFunc(sorted, func(a, b Step) int {
+		return a.Warns - b.Warns
+	})
+
+	for i := range sorted {
+		if i > 0 && sorted[i].Warns == sorted[i-1].Warns {
+			return nil, ErrDuplicateSteps
+		}
+
+		if sorted[i].Action != infraction.Timeout {
+			sorted[i].TimeoutSeconds = 0
+		}
+	}
+
+	return sorted, nil
+}
@@ -1,15 +1,62 @@
 package guild
 
-import "errors"
+import (
+	"errors"
+	"time"
+)
 
 type GuildCreate struct {
-	GuildId string `json:"guildId,inline" validate:"required"`
-	OwnerId string `json:"ownerId,inline" validate:"required"`
+	GuildId     string     `json:"guildId,inline" validate:"required"`
+	OwnerId     string     `json:"ownerId,inline" validate:"required"`
+	Name        string     `json:"name"`
+	Icon        string     `json:"icon"` // Icon is the hash of the guild icon.
+	MemberCount int        `json:"memberCount" validate:"min=0"`
+	JoinedAt    *time.Time `json:"joinedAt"` // JoinedAt is when the bot joined the guild.
 }
 
 type Guild struct {
-	GuildId string `json:"guildId"`
-	OwnerId string `json:"ownerId"`
+	GuildId      string     `json:"guildId"`
+	OwnerId      string     `json:"ownerId"`
+	ExemptRoles  []string   `json:"exemptRoles"`  // ExemptRoles are exempt from every reaction rule of the guild.
+	ExemptAdmins bool       `json:"exemptAdmins"` // ExemptAdmins exempts the owner and administrators from reaction rules.
+	Name         string     `json:"name"`
+	Icon         string     `json:"icon"`
+	MemberCount  int        `json:"memberCount"`
+	JoinedAt     *time.Time `json:"joinedAt"` // JoinedAt is nil for guilds created before it was recorded.
+	// ModLogChannelId is the channel where the bot posts the moderation log. Empty if the mod log is disabled.
+	ModLogChannelId string `json:"modLogChannelId"`
+}
+
+// GuildUpdate changes the owner and metadata of the guild. Nil fields are kept.
+type GuildUpdate struct {
+	OwnerId     *string    `json:"ownerId" validate:"omitnil,min=1"`
+	Name        *string    `json:"name"`
+	Icon        *string    `json:"icon"`
+	MemberCount *int       `json:"memberCount" validate:"omitnil,min=0"`
+	JoinedAt    *time.Time `json:"joinedAt"`
+}
+
+// GuildExemptions replaces guild wide exemptions from reaction rules.
+// ExemptAdmins is a pointer so omitting it is a validation error instead of silently disabling it.
+type GuildExemptions struct {
+	ExemptRoles  []string `json:"exemptRoles" validate:"omitempty,dive,required"`
+	ExemptAdmins *bool    `json:"exemptAdmins" validate:"required"`
+}
+
+// GuildModLog sets the moderation log channel of the guild. Empty ChannelId disables the mod log.
+type GuildModLog struct {
+	ChannelId string `json:"channelId" validate:"omitempty,numeric"`
+}
+
+// Update returns an update that sets the owner and metadata of g. Nil JoinedAt is kept.
+func (g GuildCreate) Update() GuildUpdate {
+	return GuildUpdate{
+		OwnerId:     &g.OwnerId,
+		Name:        &g.Name,
+		Icon:        &g.Icon,
+		MemberCount: &g.MemberCount,
+		JoinedAt:    g.JoinedAt,
+	}
 }
 
 func (g GuildCreate) Compare(a GuildCreate) int {
@@ -0,0 +1,132 @@
+package infraction
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type Action string
+
+const (
+	Warn    Action = "warn"
+	Timeout Action = "timeout"
+	Kick    Action = "kick"
+	Ban     Action = "ban"
+)
+
+// Source is what issued the infraction.
+type Source string
+
+const (
+	SourceRule      Source = "rule"      // SourceRule infractions are issued by reaction rules.
+	SourceModerator Source = "moderator" // SourceModerator infractions are issued by a moderator.
+	// SourceEscalation infractions are issued by the escalation policy when a member accumulates warnings.
+	SourceEscalation Source = "escalation"
+)
+
+const (
+	DefaultPageLimit = 10
+	MaxPageLimit     = 100
+)
+
+type Infraction struct {
+	Id          int64  `json:"id"`
+	GuildId     string `json:"guildId"`
+	UserId      string `json:"userId"`                // UserId is the member the infraction is issued to.
+	ModeratorId string `json:"moderatorId,omitempty"` // ModeratorId is empty for infractions of reaction rules.
+	Source      Source `json:"source"`
+	Action      Action `json:"action"`
+	Reason      string `json:"reason"`
+	// EmojiName, EmojiId, ChannelId and MessageId describe the reaction that triggered the rule.
+	EmojiName string `json:"emojiName,omitempty"`
+	EmojiId   string `json:"emojiId,omitempty"`
+	ChannelId string `json:"channelId,omitempty"`
+	MessageId string `json:"messageId,omitempty"`
+	// Step is the key of the escalation step that issued the infraction, empty for other sources.
+	Step      string    `json:"step,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type InfractionCreate struct {
+	GuildId     string `json:"guildId" validate:"required"`
+	UserId      string `json:"userId" validate:"required"`
+	ModeratorId string `json:"moderatorId,omitempty" validate:"required_if=Source moderator"`
+	Source      Source `json:"source" validate:"required,oneof=rule moderator escalation"`
+	Action      Action `json:"action" validate:"required,oneof=warn timeout kick ban"`
+	Reason      string `json:"reason" validate:"max=1024"`
+	EmojiName   string `json:"emojiName,omitempty"`
+	EmojiId     string `json:"emojiId,omitempty"`
+	ChannelId   string `json:"channelId,omitempty"`
+	MessageId   string `json:"messageId,omitempty"`
+	Step        string `json:"step,omitempty" validate:"excluded_unless=Source escalation,max=64"`
+	// CreatedAt is when the action was taken, zero means now. Infractions queued by the bot are sent later.
+	CreatedAt time.Time `json:"createdAt,omitempty"`
+}
+
+// InfractionUpdate replaces the reason of the infraction.
+type InfractionUpdate struct {
+	Reason string `json:"reason" validate:"max=1024"`
+}
+
+// InfractionQuery selects infractions of the guild newest first. Empty UserId selects infractions of every member.
+type InfractionQuery struct {
+	GuildId string `json:"guildId"`
+	UserId  string `json:"userId"`
+	Limit   int    `json:"limit" validate:"min=0,max=100"`
+	Offset  int    `json:"offset" validate:"min=0"`
+}
+
+// InfractionPage is a page of infractions with the number of infractions matching the query.
+type InfractionPage struct {
+	Infractions []Infraction `json:"infractions"`
+	Total       int          `json:"total"`
+}
+
+var ErrInvalidId = errors.New("invalid infraction id")
+
+// DecodeInfractionQuery decodes userId, limit and offset of the query. Limit and offset that aren't numbers
+// are decoded as -1 so they fail validation. The guild comes from the url.
+func DecodeInfractionQuery(query string) InfractionQuery {
+	values, _ := url.ParseQuery(query)
+
+	return InfractionQuery{
+		UserId: values.Get("userId"),
+		Limit:  decodeInt(values.Get("limit")),
+		Offset: decodeInt(values.Get("offset")),
+	}
+}
+
+// EncodeInfractionQuery encodes the query for DecodeInfractionQuery.
+func EncodeInfractionQuery(q InfractionQuery) string {
+	values := url.Values{}
+
+	if q.UserId != "" {
+		values.Set("userId", q.UserId)
+	}
+
+	if q.Limit != 0 {
+		values.Set("limit", strconv.Itoa(q.Limit))
+	}
+
+	if q.Offset != 0 {
+		values.Set("offset", strconv.Itoa(q.Offset))
+	}
+
+	return values.Encode()
+}
+
+func decodeInt(v string) int {
+	if v == "" {
+		return 0
+	}
+
+	n, err := strconv.Atoi(v)
+
+	if err != nil {
+		return -1
+	}
+
+	return n
+}
@@ -0,0 +1,1819 @@
+// Code generated by gen_emoji_categories.go DO NOT EDIT.
+
+package rule
+
+// EmojiCategories are unicode emoji groups in the order of the unicode emoji list.
+var EmojiCategories = []string{"smileys-emotion", "people-body", "component", "animals-nature", "food-drink", "travel-places", "activities", "objects", "symbols", "flags"}
+
+// emojiCategories maps base emojis to their category.
+var emojiCategories = map[string]string{
+	"\U0001f600":                 "smileys-emotion",
+	"\U0001f603":                 "smileys-emotion",
+	"\U0001f604":                 "smileys-emotion",
+	"\U0001f601":                 "smileys-emotion",
+	"\U0001f606":                 "smileys-emotion",
+	"\U0001f605":                 "smileys-emotion",
+	"\U0001f923":                 "smileys-emotion",
+	"\U0001f602":                 "smileys-emotion",
+	"\U0001f642":                 "smileys-emotion",
+	"\U0001f643":                 "smileys-emotion",
+	"\U0001f609":                 "smileys-emotion",
+	"\U0001f60a":                 "smileys-emotion",
+	"\U0001f607":                 "smileys-emotion",
+	"\U0001f970":                 "smileys-emotion",
+	"\U0001f60d":                 "smileys-emotion",
+	"\U0001f929":                 "smileys-emotion",
+	"\U0001f618":                 "smileys-emotion",
+	"\U0001f617":                 "smileys-emotion",
+	"\u263a":                     "smileys-emotion",
+	"\U0001f61a":                 "smileys-emotion",
+	"\U0001f619":                 "smileys-emotion",
+	"\U0001f972":                 "smileys-emotion",
+	"\U0001f60b":                 "smileys-emotion",
+	"\U0001f61b":                 "smileys-emotion",
+	"\U0001f61c":                 "smileys-emotion",
+	"\U0001f92a":                 "smileys-emotion",
+	"\U0001f61d":                 "smileys-emotion",
+	"\U0001f911":                 "smileys-emotion",
+	"\U0001f917":                 "smileys-emotion",
+	"\U0001f92d":                 "smileys-emotion",
+	"\U0001f92b":                 "smileys-emotion",
+	"\U0001f914":                 "smileys-emotion",
+	"\U0001f910":                 "smileys-emotion",
+	"\U0001f928":                 "smileys-emotion",
+	"\U0001f610":                 "smileys-emotion",
+	"\U0001f611":                 "smileys-emotion",
+	"\U0001f636":                 "smileys-emotion",
+	"\U0001f60f":                 "smileys-emotion",
+	"\U0001f612":                 "smileys-emotion",
+	"\U0001f644":                 "smileys-emotion",
+	"\U0001f62c":                 "smileys-emotion",
+	"\U0001f925":                 "smileys-emotion",
+	"\U0001f60c":                 "smileys-emotion",
+	"\U0001f614":                 "smileys-emotion",
+	"\U0001f62a":                 "smileys-emotion",
+	"\U0001f924":                 "smileys-emotion",
+	"\U0001f634":                 "smileys-emotion",
+	"\U0001f637":                 "smileys-emotion",
+	"\U0001f912":                 "smileys-emotion",
+	"\U0001f915":                 "smileys-emotion",
+	"\U0001f922":                 "smileys-emotion",
+	"\U0001f92e":                 "smileys-emotion",
+	"\U0001f927":                 "smileys-emotion",
+	"\U0001f975":                 "smileys-emotion",
+	"\U0001f976":                 "smileys-emotion",
+	"\U0001f974":                 "smileys-emotion",
+	"\U0001f635":                 "smileys-emotion",
+	"\U0001f92f":                 "smileys-emotion",
+	"\U0001f920":                 "smileys-emotion",
+	"\U0001f973":                 "smileys-emotion",
+	"\U0001f978":                 "smileys-emotion",
+	"\U0001f60e":                 "smileys-emotion",
+	"\U0001f913":                 "smileys-emotion",
+	"\U0001f9d0":                 "smileys-emotion",
+	"\U0001f615":                 "smileys-emotion",
+	"\U0001f61f":                 "smileys-emotion",
+	"\U0001f641":                 "smileys-emotion",
+	"\u2639":                     "smileys-emotion",
+	"\U0001f62e":                 "smileys-emotion",
+	"\U0001f62f":                 "smileys-emotion",
+	"\U0001f632":                 "smileys-emotion",
+	"\U0001f633":                 "smileys-emotion",
+	"\U0001f97a":                 "smileys-emotion",
+	"\U0001f626":                 "smileys-emotion",
+	"\U0001f627":                 "smileys-emotion",
+	"\U0001f628":                 "smileys-emotion",
+	"\U0001f630":                 "smileys-emotion",
+	"\U0001f625":                 "smileys-emotion",
+	"\U0001f622":                 "smileys-emotion",
+	"\U0001f62d":                 "smileys-emotion",
+	"\U0001f631":                 "smileys-emotion",
+	"\U0001f616":                 "smileys-emotion",
+	"\U0001f623":                 "smileys-emotion",
+	"\U0001f61e":                 "smileys-emotion",
+	"\U0001f613":                 "smileys-emotion",
+	"\U0001f629":                 "smileys-emotion",
+	"\U0001f62b":                 "smileys-emotion",
+	"\U0001f971":                 "smileys-emotion",
+	"\U0001f624":                 "smileys-emotion",
+	"\U0001f621":                 "smileys-emotion",
+	"\U0001f620":                 "smileys-emotion",
+	"\U0001f92c":                 "smileys-emotion",
+	"\U0001f608":                 "smileys-emotion",
+	"\U0001f47f":                 "smileys-emotion",
+	"\U0001f480":                 "smileys-emotion",
+	"\u2620":                     "smileys-emotion",
+	"\U0001f4a9":                 "smileys-emotion",
+	"\U0001f921":                 "smileys-emotion",
+	"\U0001f479":                 "smileys-emotion",
+	"\U0001f47a":                 "smileys-emotion",
+	"\U0001f47b":                 "smileys-emotion",
+	"\U0001f47d":                 "smileys-emotion",
+	"\U0001f47e":                 "smileys-emotion",
+	"\U0001f916":                 "smileys-emotion",
+	"\U0001f63a":                 "smileys-emotion",
+	"\U0001f638":                 "smileys-emotion",
+	"\U0001f639":                 "smileys-emotion",
+	"\U0001f63b":                 "smileys-emotion",
+	"\U0001f63c":                 "smileys-emotion",
+	"\U0001f63d":                 "smileys-emotion",
+	"\U0001f640":                 "smileys-emotion",
+	"\U0001f63f":                 "smileys-emotion",
+	"\U0001f63e":                 "smileys-emotion",
+	"\U0001f648":                 "smileys-emotion",
+	"\U0001f649":                 "smileys-emotion",
+	"\U0001f64a":                 "smileys-emotion",
+	"\U0001f48b":                 "smileys-emotion",
+	"\U0001f48c":                 "smileys-emotion",
+	"\U0001f498":                 "smileys-emotion",
+	"\U0001f49d":                 "smileys-emotion",
+	"\U0001f496":                 "smileys-emotion",
+	"\U0001f497":                 "smileys-emotion",
+	"\U0001f493":                 "smileys-emotion",
+	"\U0001f49e":                 "smileys-emotion",
+	"\U0001f495":                 "smileys-emotion",
+	"\U0001f49f":                 "smileys-emotion",
+	"\u2763":                     "smileys-emotion",
+	"\U0001f494":                 "smileys-emotion",
+	"\u2764":                     "smileys-emotion",
+	"\U0001f9e1":                 "smileys-emotion",
+	"\U0001f49b":                 "smileys-emotion",
+	"\U0001f49a":                 "smileys-emotion",
+	"\U0001f499":                 "smileys-emotion",
+	"\U0001f49c":                 "smileys-emotion",
+	"\U0001f90e":                 "smileys-emotion",
+	"\U0001f5a4":                 "smileys-emotion",
+	"\U0001f90d":                 "smileys-emotion",
+	"\U0001f4af":                 "smileys-emotion",
+	"\U0001f4a2":                 "smileys-emotion",
+	"\U0001f4a5":                 "smileys-emotion",
+	"\U0001f4ab":                 "smileys-emotion",
+	"\U0001f4a6":                 "smileys-emotion",
+	"\U0001f4a8":                 "smileys-emotion",
+	"\U0001f573":                 "smileys-emotion",
+	"\U0001f4a3":                 "smileys-emotion",
+	"\U0001f4ac":                 "smileys-emotion",
+	"\U0001f441\u200d\U0001f5e8": "smileys-emotion",
+	"\U0001f5e8":                 "smileys-emotion",
+	"\U0001f5ef":                 "smileys-emotion",
+	"\U0001f4ad":                 "smileys-emotion",
+	"\U0001f4a4":                 "smileys-emotion",
+	"\U0001f44b":                 "people-body",
+	"\U0001f91a":                 "people-body",
+	"\U0001f590":                 "people-body",
+	"\u270b":                     "people-body",
+	"\U0001f596":                 "people-body",
+	"\U0001f44c":                 "people-body",
+	"\U0001f90c":                 "people-body",
+	"\U0001f90f":                 "people-body",
+	"\u270c":                     "people-body",
+	"\U0001f91e":                 "people-body",
+	"\U0001f91f":                 "people-body",
+	"\U0001f918":                 "people-body",
+	"\U0001f919":                 "people-body",
+	"\U0001f448":                 "people-body",
+	"\U0001f449":                 "people-body",
+	"\U0001f446":                 "people-body",
+	"\U0001f595":                 "people-body",
+	"\U0001f447":                 "people-body",
+	"\u261d":                     "people-body",
+	"\U0001f44d":                 "people-body",
+	"\U0001f44e":                 "people-body",
+	"\u270a":                     "people-body",
+	"\U0001f44a":                 "people-body",
+	"\U0001f91b":                 "people-body",
+	"\U0001f91c":                 "people-body",
+	"\U0001f44f":                 "people-body",
+	"\U0001f64c":                 "people-body",
+	"\U0001f450":                 "people-body",
+	"\U0001f932":                 "people-body",
+	"\U0001f91d":                 "people-body",
+	"\U0001f64f":                 "people-body",
+	"\u270d":                     "people-body",
+	"\U0001f485":                 "people-body",
+	"\U0001f933":                 "people-body",
+	"\U0001f4aa":                 "people-body",
+	"\U0001f9be":                 "people-body",
+	"\U0001f9bf":                 "people-body",
+	"\U0001f9b5":                 "people-body",
+	"\U0001f9b6":                 "people-body",
+	"\U0001f442":                 "people-body",
+	"\U0001f9bb":                 "people-body",
+	"\U0001f443":                 "people-body",
+	"\U0001f9e0":                 "people-body",
+	"\U0001fac0":                 "people-body",
+	"\U0001fac1":                 "people-body",
+	"\U0001f9b7":                 "people-body",
+	"\U0001f9b4":                 "people-body",
+	"\U0001f440":                 "people-body",
+	"\U0001f441":                 "people-body",
+	"\U0001f445":                 "people-body",
+	"\U0001f444":                 "people-body",
+	"\U0001f476":                 "people-body",
+	"\U0001f9d2":                 "people-body",
+	"\U0001f466":                 "people-body",
+	"\U0001f467":                 "people-body",
+	"\U0001f9d1":                 "people-body",
+	"\U0001f471":                 "people-body",
+	"\U0001f468":                 "people-body",
+	"\U0001f9d4":                 "people-body",
+	"\U0001f468\u200d\U0001f9b0": "people-body",
+	"\U0001f468\u200d\U0001f9b1": "people-body",
+	"\U0001f468\u200d\U0001f9b3": "people-body",
+	"\U0001f468\u200d\U0001f9b2": "people-body",
+	"\U0001f469":                 "people-body",
+	"\U0001f469\u200d\U0001f9b0": "people-body",
+	"\U0001f9d1\u200d\U0001f9b0": "people-body",
+	"\U0001f469\u200d\U0001f9b1": "people-body",
+	"\U0001f9d1\u200d\U0001f9b1": "people-body",
+	"\U0001f469\u200d\U0001f9b3": "people-body",
+	"\U0001f9d1\u200d\U0001f9b3": "people-body",
+	"\U0001f469\u200d\U0001f9b2": "people-body",
+	"\U0001f9d1\u200d\U0001f9b2": "people-body",
+	"\U0001f471\u200d\u2640":     "people-body",
+	"\U0001f471\u200d\u2642":     "people-body",
+	"\U0001f9d3":                 "people-body",
+	"\U0001f474":                 "people-body",
+	"\U0001f475":                 "people-body",
+	"\U0001f64d":                 "people-body",
+	"\U0001f64d\u200d\u2642":     "people-body",
+	"\U0001f64d\u200d\u2640":     "people-body",
+	"\U0001f64e":                 "people-body",
+	"\U0001f64e\u200d\u2642":     "people-body",
+	"\U0001f64e\u200d\u2640":     "people-body",
+	"\U0001f645":                 "people-body",
+	"\U0001f645\u200d\u2642":     "people-body",
+	"\U0001f645\u200d\u2640":     "people-body",
+	"\U0001f646":                 "people-body",
+	"\U0001f646\u200d\u2642":     "people-body",
+	"\U0001f646\u200d\u2640":     "people-body",
+	"\U0001f481":                 "people-body",
+	"\U0001f481\u200d\u2642":     "people-body",
+	"\U0001f481\u200d\u2640":     "people-body",
+	"\U0001f64b":                 "people-body",
+	"\U0001f64b\u200d\u2642":     "people-body",
+	"\U0001f64b\u200d\u2640":     "people-body",
+	"\U0001f9cf":                 "people-body",
+	"\U0001f9cf\u200d\u2642":     "people-body",
+	"\U0001f9cf\u200d\u2640":     "people-body",
+	"\U0001f647":                 "people-body",
+	"\U0001f647\u200d\u2642":     "people-body",
+	"\U0001f647\u200d\u2640":     "people-body",
+	"\U0001f926":                 "people-body",
+	"\U0001f926\u200d\u2642":     "people-body",
+	"\U0001f926\u200d\u2640":     "people-body",
+	"\U0001f937":                 "people-body",
+	"\U0001f937\u200d\u2642":     "people-body",
+	"\U0001f937\u200d\u2640":     "people-body",
+	"\U0001f9d1\u200d\u2695":     "people-body",
+	"\U0001f468\u200d\u2695":     "people-body",
+	"\U0001f469\u200d\u2695":     "people-body",
+	"\U0001f9d1\u200d\U0001f393": "people-body",
+	"\U0001f468\u200d\U0001f393": "people-body",
+	"\U0001f469\u200d\U0001f393": "people-body",
+	"\U0001f9d1\u200d\U0001f3eb": "people-body",
+	"\U0001f468\u200d\U0001f3eb": "people-body",
+	"\U0001f469\u200d\U0001f3eb": "people-body",
+	"\U0001f9d1\u200d\u2696":     "people-body",
+	"\U0001f468\u200d\u2696":     "people-body",
+	"\U0001f469\u200d\u2696":     "people-body",
+	"\U0001f9d1\u200d\U0001f33e": "people-body",
+	"\U0001f468\u200d\U0001f33e": "people-body",
+	"\U0001f469\u200d\U0001f33e": "people-body",
+	"\U0001f9d1\u200d\U0001f373": "people-body",
+	"\U0001f468\u200d\U0001f373": "people-body",
+	"\U0001f469\u200d\U0001f373": "people-body",
+	"\U0001f9d1\u200d\U0001f527": "people-body",
+	"\U0001f468\u200d\U0001f527": "people-body",
+	"\U0001f469\u200d\U0001f527": "people-body",
+	"\U0001f9d1\u200d\U0001f3ed": "people-body",
+	"\U0001f468\u200d\U0001f3ed": "people-body",
+	"\U0001f469\u200d\U0001f3ed": "people-body",
+	"\U0001f9d1\u200d\U0001f4bc": "people-body",
+	"\U0001f468\u200d\U0001f4bc": "people-body",
+	"\U0001f469\u200d\U0001f4bc": "people-body",
+	"\U0001f9d1\u200d\U0001f52c": "people-body",
+	"\U0001f468\u200d\U0001f52c": "people-body",
+	"\U0001f469\u200d\U0001f52c": "people-body",
+	"\U0001f9d1\u200d\U0001f4bb": "people-body",
+	"\U0001f468\u200d\U0001f4bb": "people-body",
+	"\U0001f469\u200d\U0001f4bb": "people-body",
+	"\U0001f9d1\u200d\U0001f3a4": "people-body",
+	"\U0001f468\u200d\U0001f3a4": "people-body",
+	"\U0001f469\u200d\U0001f3a4": "people-body",
+	"\U0001f9d1\u200d\U0001f3a8": "people-body",
+	"\U0001f468\u200d\U0001f3a8": "people-body",
+	"\U0001f469\u200d\U0001f3a8": "people-body",
+	"\U0001f9d1\u200d\u2708":     "people-body",
+	"\U0001f468\u200d\u2708":     "people-body",
+	"\U0001f469\u200d\u2708":     "people-body",
+	"\U0001f9d1\u200d\U0001f680": "people-body",
+	"\U0001f468\u200d\U0001f680": "people-body",
+	"\U0001f469\u200d\U0001f680": "people-body",
+	"\U0001f9d1\u200d\U0001f692": "people-body",
+	"\U0001f468\u200d\U0001f692": "people-body",
+	"\U0001f469\u200d\U0001f692": "people-body",
+	"\U0001f46e":                 "people-body",
+	"\U0001f46e\u200d\u2642":     "people-body",
+	"\U0001f46e\u200d\u2640":     "people-body",
+	"\U0001f575":                 "people-body",
+	"\U0001f575\u200d\u2642":     "people-body",
+	"\U0001f575\u200d\u2640":     "people-body",
+	"\U0001f482":                 "people-body",
+	"\U0001f482\u200d\u2642":     "people-body",
+	"\U0001f482\u200d\u2640":     "people-body",
+	"\U0001f977":                 "people-body",
+	"\U0001f477":                 "people-body",
+	"\U0001f477\u200d\u2642":     "people-body",
+	"\U0001f477\u200d\u2640":     "people-body",
+	"\U0001f934":                 "people-body",
+	"\U0001f478":                 "people-body",
+	"\U0001f473":                 "people-body",
+	"\U0001f473\u200d\u2642":     "people-body",
+	"\U0001f473\u200d\u2640":     "people-body",
+	"\U0001f472":                 "people-body",
+	"\U0001f9d5":                 "people-body",
+	"\U0001f935":                 "people-body",
+	"\U0001f935\u200d\u2642":     "people-body",
+	"\U0001f935\u200d\u2640":     "people-body",
+	"\U0001f470":                 "people-body",
+	"\U0001f470\u200d\u2642":     "people-body",
+	"\U0001f470\u200d\u2640":     "people-body",
+	"\U0001f930":                 "people-body",
+	"\U0001f931":                 "people-body",
+	"\U0001f469\u200d\U0001f37c": "people-body",
+	"\U0001f468\u200d\U0001f37c": "people-body",
+	"\U0001f9d1\u200d\U0001f37c": "people-body",
+	"\U0001f47c":                 "people-body",
+	"\U0001f385":                 "people-body",
+	"\U0001f936":                 "people-body",
+	"\U0001f9d1\u200d\U0001f384": "people-body",
+	"\U0001f9b8":                 "people-body",
+	"\U0001f9b8\u200d\u2642":     "people-body",
+	"\U0001f9b8\u200d\u2640":     "people-body",
+	"\U0001f9b9":                 "people-body",
+	"\U0001f9b9\u200d\u2642":     "people-body",
+	"\U0001f9b9\u200d\u2640":     "people-body",
+	"\U0001f9d9":                 "people-body",
+	"\U0001f9d9\u200d\u2642":     "people-body",
+	"\U0001f9d9\u200d\u2640":     "people-body",
+	"\U0001f9da":                 "people-body",
+	"\U0001f9da\u200d\u2642":     "people-body",
+	"\U0001f9da\u200d\u2640":     "people-body",
+	"\U0001f9db":                 "people-body",
+	"\U0001f9db\u200d\u2642":     "people-body",
+	"\U0001f9db\u200d\u2640":     "people-body",
+	"\U0001f9dc":                 "people-body",
+	"\U0001f9dc\u200d\u2642":     "people-body",
+	"\U0001f9dc\u200d\u2640":     "people-body",
+	"\U0001f9dd":                 "people-body",
+	"\U0001f9dd\u200d\u2642":     "people-body",
+	"\U0001f9dd\u200d\u2640":     "people-body",
+	"\U0001f9de":                 "people-body",
+	"\U0001f9de\u200d\u2642":     "people-body",
+	"\U0001f9de\u200d\u2640":     "people-body",
+	"\U0001f9df":                 "people-body",
+	"\U0001f9df\u200d\u2642":     "people-body",
+	"\U0001f9df\u200d\u2640":     "people-body",
+	"\U0001f486":                 "people-body",
+	"\U0001f486\u200d\u2642":     "people-body",
+	"\U0001f486\u200d\u2640":     "people-body",
+	"\U0001f487":                 "people-body",
+	"\U0001f487\u200d\u2642":     "people-body",
+	"\U0001f487\u200d\u2640":     "people-body",
+	"\U0001f6b6":                 "people-body",
+	"\U0001f6b6\u200d\u2642":     "people-body",
+	"\U0001f6b6\u200d\u2640":     "people-body",
+	"\U0001f9cd":                 "people-body",
+	"\U0001f9cd\u200d\u2642":     "people-body",
+	"\U0001f9cd\u200d\u2640":     "people-body",
+	"\U0001f9ce":                 "people-body",
+	"\U0001f9ce\u200d\u2642":     "people-body",
+	"\U0001f9ce\u200d\u2640":     "people-body",
+	"\U0001f9d1\u200d\U0001f9af": "people-body",
+	"\U0001f468\u200d\U0001f9af": "people-body",
+	"\U0001f469\u200d\U0001f9af": "people-body",
+	"\U0001f9d1\u200d\U0001f9bc": "people-body",
+	"\U0001f468\u200d\U0001f9bc": "people-body",
+	"\U0001f469\u200d\U0001f9bc": "people-body",
+	"\U0001f9d1\u200d\U0001f9bd": "people-body",
+	"\U0001f468\u200d\U0001f9bd": "people-body",
+	"\U0001f469\u200d\U0001f9bd": "people-body",
+	"\U0001f3c3":                 "people-body",
+	"\U0001f3c3\u200d\u2642":     "people-body",
+	"\U0001f3c3\u200d\u2640":     "people-body",
+	"\U0001f483":                 "people-body",
+	"\U0001f57a":                 "people-body",
+	"\U0001f574":                 "people-body",
+	"\U0001f46f":                 "people-body",
+	"\U0001f46f\u200d\u2642":     "people-body",
+	"\U0001f46f\u200d\u2640":     "people-body",
+	"\U0001f9d6":                 "people-body",
+	"\U0001f9d6\u200d\u2642":     "people-body",
+	"\U0001f9d6\u200d\u2640":     "people-body",
+	"\U0001f9d7":                 "people-body",
+	"\U0001f9d7\u200d\u2642":     "people-body",
+	"\U0001f9d7\u200d\u2640":     "people-body",
+	"\U0001f93a":                 "people-body",
+	"\U0001f3c7":                 "people-body",
+	"\u26f7":                     "people-body",
+	"\U0001f3c2":                 "people-body",
+	"\U0001f3cc":                 "people-body",
+	"\U0001f3cc\u200d\u2642":     "people-body",
+	"\U0001f3cc\u200d\u2640":     "people-body",
+	"\U0001f3c4":                 "people-body",
+	"\U0001f3c4\u200d\u2642":     "people-body",
+	"\U0001f3c4\u200d\u2640":     "people-body",
+	"\U0001f6a3":                 "people-body",
+	"\U0001f6a3\u200d\u2642":     "people-body",
+	"\U0001f6a3\u200d\u2640":     "people-body",
+	"\U0001f3ca":                 "people-body",
+	"\U0001f3ca\u200d\u2642":     "people-body",
+	"\U0001f3ca\u200d\u2640":     "people-body",
+	"\u26f9":                     "people-body",
+	"\u26f9\u200d\u2642":         "people-body",
+	"\u26f9\u200d\u2640":         "people-body",
+	"\U0001f3cb":                 "people-body",
+	"\U0001f3cb\u200d\u2642":     "people-body",
+	"\U0001f3cb\u200d\u2640":     "people-body",
+	"\U0001f6b4":                 "people-body",
+	"\U0001f6b4\u200d\u2642":     "people-body",
+	"\U0001f6b4\u200d\u2640":     "people-body",
+	"\U0001f6b5":                 "people-body",
+	"\U0001f6b5\u200d\u2642":     "people-body",
+	"\U0001f6b5\u200d\u2640":     "people-body",
+	"\U0001f938":                 "people-body",
+	"\U0001f938\u200d\u2642":     "people-body",
+	"\U0001f938\u200d\u2640":     "people-body",
+	"\U0001f93c":                 "people-body",
+	"\U0001f93c\u200d\u2642":     "people-body",
+	"\U0001f93c\u200d\u2640":     "people-body",
+	"\U0001f93d":                 "people-body",
+	"\U0001f93d\u200d\u2642":     "people-body",
+	"\U0001f93d\u200d\u2640":     "people-body",
+	"\U0001f93e":                 "people-body",
+	"\U0001f93e\u200d\u2642":     "people-body",
+	"\U0001f93e\u200d\u2640":     "people-body",
+	"\U0001f939":                 "people-body",
+	"\U0001f939\u200d\u2642":     "people-body",
+	"\U0001f939\u200d\u2640":     "people-body",
+	"\U0001f9d8":                 "people-body",
+	"\U0001f9d8\u200d\u2642":     "people-body",
+	"\U0001f9d8\u200d\u2640":     "people-body",
+	"\U0001f6c0":                 "people-body",
+	"\U0001f6cc":                 "people-body",
+	"\U0001f9d1\u200d\U0001f91d\u200d\U0001f9d1": "people-body",
+	"\U0001f46d": "people-body",
+	"\U0001f46b": "people-body",
+	"\U0001f46c": "people-body",
+	"\U0001f48f": "people-body",
+	"\U0001f469\u200d\u2764\u200d\U0001f48b\u200d\U0001f468": "people-body",
+	"\U0001f468\u200d\u2764\u200d\U0001f48b\u200d\U0001f468": "people-body",
+	"\U0001f469\u200d\u2764\u200d\U0001f48b\u200d\U0001f469": "people-body",
+	"\U0001f491":                                                 "people-body",
+	"\U0001f469\u200d\u2764\u200d\U0001f468":                     "people-body",
+	"\U0001f468\u200d\u2764\u200d\U0001f468":                     "people-body",
+	"\U0001f469\u200d\u2764\u200d\U0001f469":                     "people-body",
+	"\U0001f46a":                                                 "people-body",
+	"\U0001f468\u200d\U0001f469\u200d\U0001f466":                 "people-body",
+	"\U0001f468\u200d\U0001f469\u200d\U0001f467":                 "people-body",
+	"\U0001f468\u200d\U0001f469\u200d\U0001f467\u200d\U0001f466": "people-body",
+	"\U0001f468\u200d\U0001f469\u200d\U0001f466\u200d\U0001f466": "people-body",
+	"\U0001f468\u200d\U0001f469\u200d\U0001f467\u200d\U0001f467": "people-body",
+	"\U0001f468\u200d\U0001f468\u200d\U0001f466":                 "people-body",
+	"\U0001f468\u200d\U0001f468\u200d\U0001f467":                 "people-body",
+	"\U0001f468\u200d\U0001f468\u200d\U0001f467\u200d\U0001f466": "people-body",
+	"\U0001f468\u200d\U0001f468\u200d\U0001f466\u200d\U0001f466": "people-body",
+	"\U0001f468\u200d\U0001f468\u200d\U0001f467\u200d\U0001f467": "people-body",
+	"\U0001f469\u200d\U0001f469\u200d\U0001f466":                 "people-body",
+	"\U0001f469\u200d\U0001f469\u200d\U0001f467":                 "people-body",
+	"\U0001f469\u200d\U0001f469\u200d\U0001f467\u200d\U0001f466": "people-body",
+	"\U0001f469\u200d\U0001f469\u200d\U0001f466\u200d\U0001f466": "people-body",
+	"\U0001f469\u200d\U0001f469\u200d\U0001f467\u200d\U0001f467": "people-body",
+	"\U0001f468\u200d\U0001f466":                                 "people-body",
+	"\U0001f468\u200d\U0001f466\u200d\U0001f466":                 "people-body",
+	"\U0001f468\u200d\U0001f467":                                 "people-body",
+	"\U0001f468\u200d\U0001f467\u200d\U0001f466":                 "people-body",
+	"\U0001f468\u200d\U0001f467\u200d\U0001f467":                 "people-body",
+	"\U0001f469\u200d\U0001f466":                                 "people-body",
+	"\U0001f469\u200d\U0001f466\u200d\U0001f466":                 "people-body",
+	"\U0001f469\u200d\U0001f467":                                 "people-body",
+	"\U0001f469\u200d\U0001f467\u200d\U0001f466":                 "people-body",
+	"\U0001f469\u200d\U0001f467\u200d\U0001f467":                 "people-body",
+	"\U0001f5e3":                 "people-body",
+	"\U0001f464":                 "people-body",
+	"\U0001f465":                 "people-body",
+	"\U0001fac2":                 "people-body",
+	"\U0001f463":                 "people-body",
+	"\U0001f9b0":                 "component",
+	"\U0001f9b1":                 "component",
+	"\U0001f9b3":                 "component",
+	"\U0001f9b2":                 "component",
+	"\U0001f435":                 "animals-nature",
+	"\U0001f412":                 "animals-nature",
+	"\U0001f98d":                 "animals-nature",
+	"\U0001f9a7":                 "animals-nature",
+	"\U0001f436":                 "animals-nature",
+	"\U0001f415":                 "animals-nature",
+	"\U0001f9ae":                 "animals-nature",
+	"\U0001f415\u200d\U0001f9ba": "animals-nature",
+	"\U0001f429":                 "animals-nature",
+	"\U0001f43a":                 "animals-nature",
+	"\U0001f98a":                 "animals-nature",
+	"\U0001f99d":                 "animals-nature",
+	"\U0001f431":                 "animals-nature",
+	"\U0001f408":                 "animals-nature",
+	"\U0001f408\u200d\u2b1b":     "animals-nature",
+	"\U0001f981":                 "animals-nature",
+	"\U0001f42f":                 "animals-nature",
+	"\U0001f405":                 "animals-nature",
+	"\U0001f406":                 "animals-nature",
+	"\U0001f434":                 "animals-nature",
+	"\U0001f40e":                 "animals-nature",
+	"\U0001f984":                 "animals-nature",
+	"\U0001f993":                 "animals-nature",
+	"\U0001f98c":                 "animals-nature",
+	"\U0001f9ac":                 "animals-nature",
+	"\U0001f42e":                 "animals-nature",
+	"\U0001f402":                 "animals-nature",
+	"\U0001f403":                 "animals-nature",
+	"\U0001f404":                 "animals-nature",
+	"\U0001f437":                 "animals-nature",
+	"\U0001f416":                 "animals-nature",
+	"\U0001f417":                 "animals-nature",
+	"\U0001f43d":                 "animals-nature",
+	"\U0001f40f":                 "animals-nature",
+	"\U0001f411":                 "animals-nature",
+	"\U0001f410":                 "animals-nature",
+	"\U0001f42a":                 "animals-nature",
+	"\U0001f42b":                 "animals-nature",
+	"\U0001f999":                 "animals-nature",
+	"\U0001f992":                 "animals-nature",
+	"\U0001f418":                 "animals-nature",
+	"\U0001f9a3":                 "animals-nature",
+	"\U0001f98f":                 "animals-nature",
+	"\U0001f99b":                 "animals-nature",
+	"\U0001f42d":                 "animals-nature",
+	"\U0001f401":                 "animals-nature",
+	"\U0001f400":                 "animals-nature",
+	"\U0001f439":                 "animals-nature",
+	"\U0001f430":                 "animals-nature",
+	"\U0001f407":                 "animals-nature",
+	"\U0001f43f":                 "animals-nature",
+	"\U0001f9ab":                 "animals-nature",
+	"\U0001f994":                 "animals-nature",
+	"\U0001f987":                 "animals-nature",
+	"\U0001f43b":                 "animals-nature",
+	"\U0001f43b\u200d\u2744":     "animals-nature",
+	"\U0001f428":                 "animals-nature",
+	"\U0001f43c":                 "animals-nature",
+	"\U0001f9a5":                 "animals-nature",
+	"\U0001f9a6":                 "animals-nature",
+	"\U0001f9a8":                 "animals-nature",
+	"\U0001f998":                 "animals-nature",
+	"\U0001f9a1":                 "animals-nature",
+	"\U0001f43e":                 "animals-nature",
+	"\U0001f983":                 "animals-nature",
+	"\U0001f414":                 "animals-nature",
+	"\U0001f413":                 "animals-nature",
+	"\U0001f423":                 "animals-nature",
+	"\U0001f424":                 "animals-nature",
+	"\U0001f425":                 "animals-nature",
+	"\U0001f426":                 "animals-nature",
+	"\U0001f427":                 "animals-nature",
+	"\U0001f54a":                 "animals-nature",
+	"\U0001f985":                 "animals-nature",
+	"\U0001f986":                 "animals-nature",
+	"\U0001f9a2":                 "animals-nature",
+	"\U0001f989":                 "animals-nature",
+	"\U0001f9a4":                 "animals-nature",
+	"\U0001fab6":                 "animals-nature",
+	"\U0001f9a9":                 "animals-nature",
+	"\U0001f99a":                 "animals-nature",
+	"\U0001f99c":                 "animals-nature",
+	"\U0001f438":                 "animals-nature",
+	"\U0001f40a":                 "animals-nature",
+	"\U0001f422":                 "animals-nature",
+	"\U0001f98e":                 "animals-nature",
+	"\U0001f40d":                 "animals-nature",
+	"\U0001f432":                 "animals-nature",
+	"\U0001f409":                 "animals-nature",
+	"\U0001f995":                 "animals-nature",
+	"\U0001f996":                 "animals-nature",
+	"\U0001f433":                 "animals-nature",
+	"\U0001f40b":                 "animals-nature",
+	"\U0001f42c":                 "animals-nature",
+	"\U0001f9ad":                 "animals-nature",
+	"\U0001f41f":                 "animals-nature",
+	"\U0001f420":                 "animals-nature",
+	"\U0001f421":                 "animals-nature",
+	"\U0001f988":                 "animals-nature",
+	"\U0001f419":                 "animals-nature",
+	"\U0001f41a":                 "animals-nature",
+	"\U0001f40c":                 "animals-nature",
+	"\U0001f98b":                 "animals-nature",
+	"\U0001f41b":                 "animals-nature",
+	"\U0001f41c":                 "animals-nature",
+	"\U0001f41d":                 "animals-nature",
+	"\U0001fab2":                 "animals-nature",
+	"\U0001f41e":                 "animals-nature",
+	"\U0001f997":                 "animals-nature",
+	"\U0001fab3":                 "animals-nature",
+	"\U0001f577":                 "animals-nature",
+	"\U0001f578":                 "animals-nature",
+	"\U0001f982":                 "animals-nature",
+	"\U0001f99f":                 "animals-nature",
+	"\U0001fab0":                 "animals-nature",
+	"\U0001fab1":                 "animals-nature",
+	"\U0001f9a0":                 "animals-nature",
+	"\U0001f490":                 "animals-nature",
+	"\U0001f338":                 "animals-nature",
+	"\U0001f4ae":                 "animals-nature",
+	"\U0001f3f5":                 "animals-nature",
+	"\U0001f339":                 "animals-nature",
+	"\U0001f940":                 "animals-nature",
+	"\U0001f33a":                 "animals-nature",
+	"\U0001f33b":                 "animals-nature",
+	"\U0001f33c":                 "animals-nature",
+	"\U0001f337":                 "animals-nature",
+	"\U0001f331":                 "animals-nature",
+	"\U0001fab4":                 "animals-nature",
+	"\U0001f332":                 "animals-nature",
+	"\U0001f333":                 "animals-nature",
+	"\U0001f334":                 "animals-nature",
+	"\U0001f335":                 "animals-nature",
+	"\U0001f33e":                 "animals-nature",
+	"\U0001f33f":                 "animals-nature",
+	"\u2618":                     "animals-nature",
+	"\U0001f340":                 "animals-nature",
+	"\U0001f341":                 "animals-nature",
+	"\U0001f342":                 "animals-nature",
+	"\U0001f343":                 "animals-nature",
+	"\U0001f347":                 "food-drink",
+	"\U0001f348":                 "food-drink",
+	"\U0001f349":                 "food-drink",
+	"\U0001f34a":                 "food-drink",
+	"\U0001f34b":                 "food-drink",
+	"\U0001f34c":                 "food-drink",
+	"\U0001f34d":                 "food-drink",
+	"\U0001f96d":                 "food-drink",
+	"\U0001f34e":                 "food-drink",
+	"\U0001f34f":                 "food-drink",
+	"\U0001f350":                 "food-drink",
+	"\U0001f351":                 "food-drink",
+	"\U0001f352":                 "food-drink",
+	"\U0001f353":                 "food-drink",
+	"\U0001fad0":                 "food-drink",
+	"\U0001f95d":                 "food-drink",
+	"\U0001f345":                 "food-drink",
+	"\U0001fad2":                 "food-drink",
+	"\U0001f965":                 "food-drink",
+	"\U0001f951":                 "food-drink",
+	"\U0001f346":                 "food-drink",
+	"\U0001f954":                 "food-drink",
+	"\U0001f955":                 "food-drink",
+	"\U0001f33d":                 "food-drink",
+	"\U0001f336":                 "food-drink",
+	"\U0001fad1":                 "food-drink",
+	"\U0001f952":                 "food-drink",
+	"\U0001f96c":                 "food-drink",
+	"\U0001f966":                 "food-drink",
+	"\U0001f9c4":                 "food-drink",
+	"\U0001f9c5":                 "food-drink",
+	"\U0001f344":                 "food-drink",
+	"\U0001f95c":                 "food-drink",
+	"\U0001f330":                 "food-drink",
+	"\U0001f35e":                 "food-drink",
+	"\U0001f950":                 "food-drink",
+	"\U0001f956":                 "food-drink",
+	"\U0001fad3":                 "food-drink",
+	"\U0001f968":                 "food-drink",
+	"\U0001f96f":                 "food-drink",
+	"\U0001f95e":                 "food-drink",
+	"\U0001f9c7":                 "food-drink",
+	"\U0001f9c0":                 "food-drink",
+	"\U0001f356":                 "food-drink",
+	"\U0001f357":                 "food-drink",
+	"\U0001f969":                 "food-drink",
+	"\U0001f953":                 "food-drink",
+	"\U0001f354":                 "food-drink",
+	"\U0001f35f":                 "food-drink",
+	"\U0001f355":                 "food-drink",
+	"\U0001f32d":                 "food-drink",
+	"\U0001f96a":                 "food-drink",
+	"\U0001f32e":                 "food-drink",
+	"\U0001f32f":                 "food-drink",
+	"\U0001fad4":                 "food-drink",
+	"\U0001f959":                 "food-drink",
+	"\U0001f9c6":                 "food-drink",
+	"\U0001f95a":                 "food-drink",
+	"\U0001f373":                 "food-drink",
+	"\U0001f958":                 "food-drink",
+	"\U0001f372":                 "food-drink",
+	"\U0001fad5":                 "food-drink",
+	"\U0001f963":                 "food-drink",
+	"\U0001f957":                 "food-drink",
+	"\U0001f37f":                 "food-drink",
+	"\U0001f9c8":                 "food-drink",
+	"\U0001f9c2":                 "food-drink",
+	"\U0001f96b":                 "food-drink",
+	"\U0001f371":                 "food-drink",
+	"\U0001f358":                 "food-drink",
+	"\U0001f359":                 "food-drink",
+	"\U0001f35a":                 "food-drink",
+	"\U0001f35b":                 "food-drink",
+	"\U0001f35c":                 "food-drink",
+	"\U0001f35d":                 "food-drink",
+	"\U0001f360":                 "food-drink",
+	"\U0001f362":                 "food-drink",
+	"\U0001f363":                 "food-drink",
+	"\U0001f364":                 "food-drink",
+	"\U0001f365":                 "food-drink",
+	"\U0001f96e":                 "food-drink",
+	"\U0001f361":                 "food-drink",
+	"\U0001f95f":                 "food-drink",
+	"\U0001f960":                 "food-drink",
+	"\U0001f961":                 "food-drink",
+	"\U0001f980":                 "food-drink",
+	"\U0001f99e":                 "food-drink",
+	"\U0001f990":                 "food-drink",
+	"\U0001f991":                 "food-drink",
+	"\U0001f9aa":                 "food-drink",
+	"\U0001f366":                 "food-drink",
+	"\U0001f367":                 "food-drink",
+	"\U0001f368":                 "food-drink",
+	"\U0001f369":                 "food-drink",
+	"\U0001f36a":                 "food-drink",
+	"\U0001f382":                 "food-drink",
+	"\U0001f370":                 "food-drink",
+	"\U0001f9c1":                 "food-drink",
+	"\U0001f967":                 "food-drink",
+	"\U0001f36b":                 "food-drink",
+	"\U0001f36c":                 "food-drink",
+	"\U0001f36d":                 "food-drink",
+	"\U0001f36e":                 "food-drink",
+	"\U0001f36f":                 "food-drink",
+	"\U0001f37c":                 "food-drink",
+	"\U0001f95b":                 "food-drink",
+	"\u2615":                     "food-drink",
+	"\U0001fad6":                 "food-drink",
+	"\U0001f375":                 "food-drink",
+	"\U0001f376":                 "food-drink",
+	"\U0001f37e":                 "food-drink",
+	"\U0001f377":                 "food-drink",
+	"\U0001f378":                 "food-drink",
+	"\U0001f379":                 "food-drink",
+	"\U0001f37a":                 "food-drink",
+	"\U0001f37b":                 "food-drink",
+	"\U0001f942":                 "food-drink",
+	"\U0001f943":                 "food-drink",
+	"\U0001f964":                 "food-drink",
+	"\U0001f9cb":                 "food-drink",
+	"\U0001f9c3":                 "food-drink",
+	"\U0001f9c9":                 "food-drink",
+	"\U0001f9ca":                 "food-drink",
+	"\U0001f962":                 "food-drink",
+	"\U0001f37d":                 "food-drink",
+	"\U0001f374":                 "food-drink",
+	"\U0001f944":                 "food-drink",
+	"\U0001f52a":                 "food-drink",
+	"\U0001f3fa":                 "food-drink",
+	"\U0001f30d":                 "travel-places",
+	"\U0001f30e":                 "travel-places",
+	"\U0001f30f":                 "travel-places",
+	"\U0001f310":                 "travel-places",
+	"\U0001f5fa":                 "travel-places",
+	"\U0001f5fe":                 "travel-places",
+	"\U0001f9ed":                 "travel-places",
+	"\U0001f3d4":                 "travel-places",
+	"\u26f0":                     "travel-places",
+	"\U0001f30b":                 "travel-places",
+	"\U0001f5fb":                 "travel-places",
+	"\U0001f3d5":                 "travel-places",
+	"\U0001f3d6":                 "travel-places",
+	"\U0001f3dc":                 "travel-places",
+	"\U0001f3dd":                 "travel-places",
+	"\U0001f3de":                 "travel-places",
+	"\U0001f3df":                 "travel-places",
+	"\U0001f3db":                 "travel-places",
+	"\U0001f3d7":                 "travel-places",
+	"\U0001f9f1":                 "travel-places",
+	"\U0001faa8":                 "travel-places",
+	"\U0001fab5":                 "travel-places",
+	"\U0001f6d6":                 "travel-places",
+	"\U0001f3d8":                 "travel-places",
+	"\U0001f3da":                 "travel-places",
+	"\U0001f3e0":                 "travel-places",
+	"\U0001f3e1":                 "travel-places",
+	"\U0001f3e2":                 "travel-places",
+	"\U0001f3e3":                 "travel-places",
+	"\U0001f3e4":                 "travel-places",
+	"\U0001f3e5":                 "travel-places",
+	"\U0001f3e6":                 "travel-places",
+	"\U0001f3e8":                 "travel-places",
+	"\U0001f3e9":                 "travel-places",
+	"\U0001f3ea":                 "travel-places",
+	"\U0001f3eb":                 "travel-places",
+	"\U0001f3ec":                 "travel-places",
+	"\U0001f3ed":                 "travel-places",
+	"\U0001f3ef":                 "travel-places",
+	"\U0001f3f0":                 "travel-places",
+	"\U0001f492":                 "travel-places",
+	"\U0001f5fc":                 "travel-places",
+	"\U0001f5fd":                 "travel-places",
+	"\u26ea":                     "travel-places",
+	"\U0001f54c":                 "travel-places",
+	"\U0001f6d5":                 "travel-places",
+	"\U0001f54d":                 "travel-places",
+	"\u26e9":                     "travel-places",
+	"\U0001f54b":                 "travel-places",
+	"\u26f2":                     "travel-places",
+	"\u26fa":                     "travel-places",
+	"\U0001f301":                 "travel-places",
+	"\U0001f303":                 "travel-places",
+	"\U0001f3d9":                 "travel-places",
+	"\U0001f304":                 "travel-places",
+	"\U0001f305":                 "travel-places",
+	"\U0001f306":                 "travel-places",
+	"\U0001f307":                 "travel-places",
+	"\U0001f309":                 "travel-places",
+	"\u2668":                     "travel-places",
+	"\U0001f3a0":                 "travel-places",
+	"\U0001f3a1":                 "travel-places",
+	"\U0001f3a2":                 "travel-places",
+	"\U0001f488":                 "travel-places",
+	"\U0001f3aa":                 "travel-places",
+	"\U0001f682":                 "travel-places",
+	"\U0001f683":                 "travel-places",
+	"\U0001f684":                 "travel-places",
+	"\U0001f685":                 "travel-places",
+	"\U0001f686":                 "travel-places",
+	"\U0001f687":                 "travel-places",
+	"\U0001f688":                 "travel-places",
+	"\U0001f689":                 "travel-places",
+	"\U0001f68a":                 "travel-places",
+	"\U0001f69d":                 "travel-places",
+	"\U0001f69e":                 "travel-places",
+	"\U0001f68b":                 "travel-places",
+	"\U0001f68c":                 "travel-places",
+	"\U0001f68d":                 "travel-places",
+	"\U0001f68e":                 "travel-places",
+	"\U0001f690":                 "travel-places",
+	"\U0001f691":                 "travel-places",
+	"\U0001f692":                 "travel-places",
+	"\U0001f693":                 "travel-places",
+	"\U0001f694":                 "travel-places",
+	"\U0001f695":                 "travel-places",
+	"\U0001f696":                 "travel-places",
+	"\U0001f697":                 "travel-places",
+	"\U0001f698":                 "travel-places",
+	"\U0001f699":                 "travel-places",
+	"\U0001f6fb":                 "travel-places",
+	"\U0001f69a":                 "travel-places",
+	"\U0001f69b":                 "travel-places",
+	"\U0001f69c":                 "travel-places",
+	"\U0001f3ce":                 "travel-places",
+	"\U0001f3cd":                 "travel-places",
+	"\U0001f6f5":                 "travel-places",
+	"\U0001f9bd":                 "travel-places",
+	"\U0001f9bc":                 "travel-places",
+	"\U0001f6fa":                 "travel-places",
+	"\U0001f6b2":                 "travel-places",
+	"\U0001f6f4":                 "travel-places",
+	"\U0001f6f9":                 "travel-places",
+	"\U0001f6fc":                 "travel-places",
+	"\U0001f68f":                 "travel-places",
+	"\U0001f6e3":                 "travel-places",
+	"\U0001f6e4":                 "travel-places",
+	"\U0001f6e2":                 "travel-places",
+	"\u26fd":                     "travel-places",
+	"\U0001f6a8":                 "travel-places",
+	"\U0001f6a5":                 "travel-places",
+	"\U0001f6a6":                 "travel-places",
+	"\U0001f6d1":                 "travel-places",
+	"\U0001f6a7":                 "travel-places",
+	"\u2693":                     "travel-places",
+	"\u26f5":                     "travel-places",
+	"\U0001f6f6":                 "travel-places",
+	"\U0001f6a4":                 "travel-places",
+	"\U0001f6f3":                 "travel-places",
+	"\u26f4":                     "travel-places",
+	"\U0001f6e5":                 "travel-places",
+	"\U0001f6a2":                 "travel-places",
+	"\u2708":                     "travel-places",
+	"\U0001f6e9":                 "travel-places",
+	"\U0001f6eb":                 "travel-places",
+	"\U0001f6ec":                 "travel-places",
+	"\U0001fa82":                 "travel-places",
+	"\U0001f4ba":                 "travel-places",
+	"\U0001f681":                 "travel-places",
+	"\U0001f69f":                 "travel-places",
+	"\U0001f6a0":                 "travel-places",
+	"\U0001f6a1":                 "travel-places",
+	"\U0001f6f0":                 "travel-places",
+	"\U0001f680":                 "travel-places",
+	"\U0001f6f8":                 "travel-places",
+	"\U0001f6ce":                 "travel-places",
+	"\U0001f9f3":                 "travel-places",
+	"\u231b":                     "travel-places",
+	"\u23f3":                     "travel-places",
+	"\u231a":                     "travel-places",
+	"\u23f0":                     "travel-places",
+	"\u23f1":                     "travel-places",
+	"\u23f2":                     "travel-places",
+	"\U0001f570":                 "travel-places",
+	"\U0001f55b":                 "travel-places",
+	"\U0001f567":                 "travel-places",
+	"\U0001f550":                 "travel-places",
+	"\U0001f55c":                 "travel-places",
+	"\U0001f551":                 "travel-places",
+	"\U0001f55d":                 "travel-places",
+	"\U0001f552":                 "travel-places",
+	"\U0001f55e":                 "travel-places",
+	"\U0001f553":                 "travel-places",
+	"\U0001f55f":                 "travel-places",
+	"\U0001f554":                 "travel-places",
+	"\U0001f560":                 "travel-places",
+	"\U0001f555":                 "travel-places",
+	"\U0001f561":                 "travel-places",
+	"\U0001f556":                 "travel-places",
+	"\U0001f562":                 "travel-places",
+	"\U0001f557":                 "travel-places",
+	"\U0001f563":                 "travel-places",
+	"\U0001f558":                 "travel-places",
+	"\U0001f564":                 "travel-places",
+	"\U0001f559":                 "travel-places",
+	"\U0001f565":                 "travel-places",
+	"\U0001f55a":                 "travel-places",
+	"\U0001f566":                 "travel-places",
+	"\U0001f311":                 "travel-places",
+	"\U0001f312":                 "travel-places",
+	"\U0001f313":                 "travel-places",
+	"\U0001f314":                 "travel-places",
+	"\U0001f315":                 "travel-places",
+	"\U0001f316":                 "travel-places",
+	"\U0001f317":                 "travel-places",
+	"\U0001f318":                 "travel-places",
+	"\U0001f319":                 "travel-places",
+	"\U0001f31a":                 "travel-places",
+	"\U0001f31b":                 "travel-places",
+	"\U0001f31c":                 "travel-places",
+	"\U0001f321":                 "travel-places",
+	"\u2600":                     "travel-places",
+	"\U0001f31d":                 "travel-places",
+	"\U0001f31e":                 "travel-places",
+	"\U0001fa90":                 "travel-places",
+	"\u2b50":                     "travel-places",
+	"\U0001f31f":                 "travel-places",
+	"\U0001f320":                 "travel-places",
+	"\U0001f30c":                 "travel-places",
+	"\u2601":                     "travel-places",
+	"\u26c5":                     "travel-places",
+	"\u26c8":                     "travel-places",
+	"\U0001f324":                 "travel-places",
+	"\U0001f325":                 "travel-places",
+	"\U0001f326":                 "travel-places",
+	"\U0001f327":                 "travel-places",
+	"\U0001f328":                 "travel-places",
+	"\U0001f329":                 "travel-places",
+	"\U0001f32a":                 "travel-places",
+	"\U0001f32b":                 "travel-places",
+	"\U0001f32c":                 "travel-places",
+	"\U0001f300":                 "travel-places",
+	"\U0001f308":                 "travel-places",
+	"\U0001f302":                 "travel-places",
+	"\u2602":                     "travel-places",
+	"\u2614":                     "travel-places",
+	"\u26f1":                     "travel-places",
+	"\u26a1":                     "travel-places",
+	"\u2744":                     "travel-places",
+	"\u2603":                     "travel-places",
+	"\u26c4":                     "travel-places",
+	"\u2604":                     "travel-places",
+	"\U0001f525":                 "travel-places",
+	"\U0001f4a7":                 "travel-places",
+	"\U0001f30a":                 "travel-places",
+	"\U0001f383":                 "activities",
+	"\U0001f384":                 "activities",
+	"\U0001f386":                 "activities",
+	"\U0001f387":                 "activities",
+	"\U0001f9e8":                 "activities",
+	"\u2728":                     "activities",
+	"\U0001f388":                 "activities",
+	"\U0001f389":                 "activities",
+	"\U0001f38a":                 "activities",
+	"\U0001f38b":                 "activities",
+	"\U0001f38d":                 "activities",
+	"\U0001f38e":                 "activities",
+	"\U0001f38f":                 "activities",
+	"\U0001f390":                 "activities",
+	"\U0001f391":                 "activities",
+	"\U0001f9e7":                 "activities",
+	"\U0001f380":                 "activities",
+	"\U0001f381":                 "activities",
+	"\U0001f397":                 "activities",
+	"\U0001f39f":                 "activities",
+	"\U0001f3ab":                 "activities",
+	"\U0001f396":                 "activities",
+	"\U0001f3c6":                 "activities",
+	"\U0001f3c5":                 "activities",
+	"\U0001f947":                 "activities",
+	"\U0001f948":                 "activities",
+	"\U0001f949":                 "activities",
+	"\u26bd":                     "activities",
+	"\u26be":                     "activities",
+	"\U0001f94e":                 "activities",
+	"\U0001f3c0":                 "activities",
+	"\U0001f3d0":                 "activities",
+	"\U0001f3c8":                 "activities",
+	"\U0001f3c9":                 "activities",
+	"\U0001f3be":                 "activities",
+	"\U0001f94f":                 "activities",
+	"\U0001f3b3":                 "activities",
+	"\U0001f3cf":                 "activities",
+	"\U0001f3d1":                 "activities",
+	"\U0001f3d2":                 "activities",
+	"\U0001f94d":                 "activities",
+	"\U0001f3d3":                 "activities",
+	"\U0001f3f8":                 "activities",
+	"\U0001f94a":                 "activities",
+	"\U0001f94b":                 "activities",
+	"\U0001f945":                 "activities",
+	"\u26f3":                     "activities",
+	"\u26f8":                     "activities",
+	"\U0001f3a3":                 "activities",
+	"\U0001f93f":                 "activities",
+	"\U0001f3bd":                 "activities",
+	"\U0001f3bf":                 "activities",
+	"\U0001f6f7":                 "activities",
+	"\U0001f94c":                 "activities",
+	"\U0001f3af":                 "activities",
+	"\U0001fa80":                 "activities",
+	"\U0001fa81":                 "activities",
+	"\U0001f3b1":                 "activities",
+	"\U0001f52e":                 "activities",
+	"\U0001fa84":                 "activities",
+	"\U0001f9ff":                 "activities",
+	"\U0001f3ae":                 "activities",
+	"\U0001f579":                 "activities",
+	"\U0001f3b0":                 "activities",
+	"\U0001f3b2":                 "activities",
+	"\U0001f9e9":                 "activities",
+	"\U0001f9f8":                 "activities",
+	"\U0001fa85":                 "activities",
+	"\U0001fa86":                 "activities",
+	"\u2660":                     "activities",
+	"\u2665":                     "activities",
+	"\u2666":                     "activities",
+	"\u2663":                     "activities",
+	"\u265f":                     "activities",
+	"\U0001f0cf":                 "activities",
+	"\U0001f004":                 "activities",
+	"\U0001f3b4":                 "activities",
+	"\U0001f3ad":                 "activities",
+	"\U0001f5bc":                 "activities",
+	"\U0001f3a8":                 "activities",
+	"\U0001f9f5":                 "activities",
+	"\U0001faa1":                 "activities",
+	"\U0001f9f6":                 "activities",
+	"\U0001faa2":                 "activities",
+	"\U0001f453":                 "objects",
+	"\U0001f576":                 "objects",
+	"\U0001f97d":                 "objects",
+	"\U0001f97c":                 "objects",
+	"\U0001f9ba":                 "objects",
+	"\U0001f454":                 "objects",
+	"\U0001f455":                 "objects",
+	"\U0001f456":                 "objects",
+	"\U0001f9e3":                 "objects",
+	"\U0001f9e4":                 "objects",
+	"\U0001f9e5":                 "objects",
+	"\U0001f9e6":                 "objects",
+	"\U0001f457":                 "objects",
+	"\U0001f458":                 "objects",
+	"\U0001f97b":                 "objects",
+	"\U0001fa71":                 "objects",
+	"\U0001fa72":                 "objects",
+	"\U0001fa73":                 "objects",
+	"\U0001f459":                 "objects",
+	"\U0001f45a":                 "objects",
+	"\U0001f45b":                 "objects",
+	"\U0001f45c":                 "objects",
+	"\U0001f45d":                 "objects",
+	"\U0001f6cd":                 "objects",
+	"\U0001f392":                 "objects",
+	"\U0001fa74":                 "objects",
+	"\U0001f45e":                 "objects",
+	"\U0001f45f":                 "objects",
+	"\U0001f97e":                 "objects",
+	"\U0001f97f":                 "objects",
+	"\U0001f460":                 "objects",
+	"\U0001f461":                 "objects",
+	"\U0001fa70":                 "objects",
+	"\U0001f462":                 "objects",
+	"\U0001f451":                 "objects",
+	"\U0001f452":                 "objects",
+	"\U0001f3a9":                 "objects",
+	"\U0001f393":                 "objects",
+	"\U0001f9e2":                 "objects",
+	"\U0001fa96":                 "objects",
+	"\u26d1":                     "objects",
+	"\U0001f4ff":                 "objects",
+	"\U0001f484":                 "objects",
+	"\U0001f48d":                 "objects",
+	"\U0001f48e":                 "objects",
+	"\U0001f507":                 "objects",
+	"\U0001f508":                 "objects",
+	"\U0001f509":                 "objects",
+	"\U0001f50a":                 "objects",
+	"\U0001f4e2":                 "objects",
+	"\U0001f4e3":                 "objects",
+	"\U0001f4ef":                 "objects",
+	"\U0001f514":                 "objects",
+	"\U0001f515":                 "objects",
+	"\U0001f3bc":                 "objects",
+	"\U0001f3b5":                 "objects",
+	"\U0001f3b6":                 "objects",
+	"\U0001f399":                 "objects",
+	"\U0001f39a":                 "objects",
+	"\U0001f39b":                 "objects",
+	"\U0001f3a4":                 "objects",
+	"\U0001f3a7":                 "objects",
+	"\U0001f4fb":                 "objects",
+	"\U0001f3b7":                 "objects",
+	"\U0001fa97":                 "objects",
+	"\U0001f3b8":                 "objects",
+	"\U0001f3b9":                 "objects",
+	"\U0001f3ba":                 "objects",
+	"\U0001f3bb":                 "objects",
+	"\U0001fa95":                 "objects",
+	"\U0001f941":                 "objects",
+	"\U0001fa98":                 "objects",
+	"\U0001f4f1":                 "objects",
+	"\U0001f4f2":                 "objects",
+	"\u260e":                     "objects",
+	"\U0001f4de":                 "objects",
+	"\U0001f4df":                 "objects",
+	"\U0001f4e0":                 "objects",
+	"\U0001f50b":                 "objects",
+	"\U0001f50c":                 "objects",
+	"\U0001f4bb":                 "objects",
+	"\U0001f5a5":                 "objects",
+	"\U0001f5a8":                 "objects",
+	"\u2328":                     "objects",
+	"\U0001f5b1":                 "objects",
+	"\U0001f5b2":                 "objects",
+	"\U0001f4bd":                 "objects",
+	"\U0001f4be":                 "objects",
+	"\U0001f4bf":                 "objects",
+	"\U0001f4c0":                 "objects",
+	"\U0001f9ee":                 "objects",
+	"\U0001f3a5":                 "objects",
+	"\U0001f39e":                 "objects",
+	"\U0001f4fd":                 "objects",
+	"\U0001f3ac":                 "objects",
+	"\U0001f4fa":                 "objects",
+	"\U0001f4f7":                 "objects",
+	"\U0001f4f8":                 "objects",
+	"\U0001f4f9":                 "objects",
+	"\U0001f4fc":                 "objects",
+	"\U0001f50d":                 "objects",
+	"\U0001f50e":                 "objects",
+	"\U0001f56f":                 "objects",
+	"\U0001f4a1":                 "objects",
+	"\U0001f526":                 "objects",
+	"\U0001f3ee":                 "objects",
+	"\U0001fa94":                 "objects",
+	"\U0001f4d4":                 "objects",
+	"\U0001f4d5":                 "objects",
+	"\U0001f4d6":                 "objects",
+	"\U0001f4d7":                 "objects",
+	"\U0001f4d8":                 "objects",
+	"\U0001f4d9":                 "objects",
+	"\U0001f4da":                 "objects",
+	"\U0001f4d3":                 "objects",
+	"\U0001f4d2":                 "objects",
+	"\U0001f4c3":                 "objects",
+	"\U0001f4dc":                 "objects",
+	"\U0001f4c4":                 "objects",
+	"\U0001f4f0":                 "objects",
+	"\U0001f5de":                 "objects",
+	"\U0001f4d1":                 "objects",
+	"\U0001f516":                 "objects",
+	"\U0001f3f7":                 "objects",
+	"\U0001f4b0":                 "objects",
+	"\U0001fa99":                 "objects",
+	"\U0001f4b4":                 "objects",
+	"\U0001f4b5":                 "objects",
+	"\U0001f4b6":                 "objects",
+	"\U0001f4b7":                 "objects",
+	"\U0001f4b8":                 "objects",
+	"\U0001f4b3":                 "objects",
+	"\U0001f9fe":                 "objects",
+	"\U0001f4b9":                 "objects",
+	"\u2709":                     "objects",
+	"\U0001f4e7":                 "objects",
+	"\U0001f4e8":                 "objects",
+	"\U0001f4e9":                 "objects",
+	"\U0001f4e4":                 "objects",
+	"\U0001f4e5":                 "objects",
+	"\U0001f4e6":                 "objects",
+	"\U0001f4eb":                 "objects",
+	"\U0001f4ea":                 "objects",
+	"\U0001f4ec":                 "objects",
+	"\U0001f4ed":                 "objects",
+	"\U0001f4ee":                 "objects",
+	"\U0001f5f3":                 "objects",
+	"\u270f":                     "objects",
+	"\u2712":                     "objects",
+	"\U0001f58b":                 "objects",
+	"\U0001f58a":                 "objects",
+	"\U0001f58c":                 "objects",
+	"\U0001f58d":                 "objects",
+	"\U0001f4dd":                 "objects",
+	"\U0001f4bc":                 "objects",
+	"\U0001f4c1":                 "objects",
+	"\U0001f4c2":                 "objects",
+	"\U0001f5c2":                 "objects",
+	"\U0001f4c5":                 "objects",
+	"\U0001f4c6":                 "objects",
+	"\U0001f5d2":                 "objects",
+	"\U0001f5d3":                 "objects",
+	"\U0001f4c7":                 "objects",
+	"\U0001f4c8":                 "objects",
+	"\U0001f4c9":                 "objects",
+	"\U0001f4ca":                 "objects",
+	"\U0001f4cb":                 "objects",
+	"\U0001f4cc":                 "objects",
+	"\U0001f4cd":                 "objects",
+	"\U0001f4ce":                 "objects",
+	"\U0001f587":                 "objects",
+	"\U0001f4cf":                 "objects",
+	"\U0001f4d0":                 "objects",
+	"\u2702":                     "objects",
+	"\U0001f5c3":                 "objects",
+	"\U0001f5c4":                 "objects",
+	"\U0001f5d1":                 "objects",
+	"\U0001f512":                 "objects",
+	"\U0001f513":                 "objects",
+	"\U0001f50f":                 "objects",
+	"\U0001f510":                 "objects",
+	"\U0001f511":                 "objects",
+	"\U0001f5dd":                 "objects",
+	"\U0001f528":                 "objects",
+	"\U0001fa93":                 "objects",
+	"\u26cf":                     "objects",
+	"\u2692":                     "objects",
+	"\U0001f6e0":                 "objects",
+	"\U0001f5e1":                 "objects",
+	"\u2694":                     "objects",
+	"\U0001f52b":                 "objects",
+	"\U0001fa83":                 "objects",
+	"\U0001f3f9":                 "objects",
+	"\U0001f6e1":                 "objects",
+	"\U0001fa9a":                 "objects",
+	"\U0001f527":                 "objects",
+	"\U0001fa9b":                 "objects",
+	"\U0001f529":                 "objects",
+	"\u2699":                     "objects",
+	"\U0001f5dc":                 "objects",
+	"\u2696":                     "objects",
+	"\U0001f9af":                 "objects",
+	"\U0001f517":                 "objects",
+	"\u26d3":                     "objects",
+	"\U0001fa9d":                 "objects",
+	"\U0001f9f0":                 "objects",
+	"\U0001f9f2":                 "objects",
+	"\U0001fa9c":                 "objects",
+	"\u2697":                     "objects",
+	"\U0001f9ea":                 "objects",
+	"\U0001f9eb":                 "objects",
+	"\U0001f9ec":                 "objects",
+	"\U0001f52c":                 "objects",
+	"\U0001f52d":                 "objects",
+	"\U0001f4e1":                 "objects",
+	"\U0001f489":                 "objects",
+	"\U0001fa78":                 "objects",
+	"\U0001f48a":                 "objects",
+	"\U0001fa79":                 "objects",
+	"\U0001fa7a":                 "objects",
+	"\U0001f6aa":                 "objects",
+	"\U0001f6d7":                 "objects",
+	"\U0001fa9e":                 "objects",
+	"\U0001fa9f":                 "objects",
+	"\U0001f6cf":                 "objects",
+	"\U0001f6cb":                 "objects",
+	"\U0001fa91":                 "objects",
+	"\U0001f6bd":                 "objects",
+	"\U0001faa0":                 "objects",
+	"\U0001f6bf":                 "objects",
+	"\U0001f6c1":                 "objects",
+	"\U0001faa4":                 "objects",
+	"\U0001fa92":                 "objects",
+	"\U0001f9f4":                 "objects",
+	"\U0001f9f7":                 "objects",
+	"\U0001f9f9":                 "objects",
+	"\U0001f9fa":                 "objects",
+	"\U0001f9fb":                 "objects",
+	"\U0001faa3":                 "objects",
+	"\U0001f9fc":                 "objects",
+	"\U0001faa5":                 "objects",
+	"\U0001f9fd":                 "objects",
+	"\U0001f9ef":                 "objects",
+	"\U0001f6d2":                 "objects",
+	"\U0001f6ac":                 "objects",
+	"\u26b0":                     "objects",
+	"\U0001faa6":                 "objects",
+	"\u26b1":                     "objects",
+	"\U0001f5ff":                 "objects",
+	"\U0001faa7":                 "objects",
+	"\U0001f3e7":                 "symbols",
+	"\U0001f6ae":                 "symbols",
+	"\U0001f6b0":                 "symbols",
+	"\u267f":                     "symbols",
+	"\U0001f6b9":                 "symbols",
+	"\U0001f6ba":                 "symbols",
+	"\U0001f6bb":                 "symbols",
+	"\U0001f6bc":                 "symbols",
+	"\U0001f6be":                 "symbols",
+	"\U0001f6c2":                 "symbols",
+	"\U0001f6c3":                 "symbols",
+	"\U0001f6c4":                 "symbols",
+	"\U0001f6c5":                 "symbols",
+	"\u26a0":                     "symbols",
+	"\U0001f6b8":                 "symbols",
+	"\u26d4":                     "symbols",
+	"\U0001f6ab":                 "symbols",
+	"\U0001f6b3":                 "symbols",
+	"\U0001f6ad":                 "symbols",
+	"\U0001f6af":                 "symbols",
+	"\U0001f6b1":                 "symbols",
+	"\U0001f6b7":                 "symbols",
+	"\U0001f4f5":                 "symbols",
+	"\U0001f51e":                 "symbols",
+	"\u2622":                     "symbols",
+	"\u2623":                     "symbols",
+	"\u2b06":                     "symbols",
+	"\u2197":                     "symbols",
+	"\u27a1":                     "symbols",
+	"\u2198":                     "symbols",
+	"\u2b07":                     "symbols",
+	"\u2199":                     "symbols",
+	"\u2b05":                     "symbols",
+	"\u2196":                     "symbols",
+	"\u2195":                     "symbols",
+	"\u2194":                     "symbols",
+	"\u21a9":                     "symbols",
+	"\u21aa":                     "symbols",
+	"\u2934":                     "symbols",
+	"\u2935":                     "symbols",
+	"\U0001f503":                 "symbols",
+	"\U0001f504":                 "symbols",
+	"\U0001f519":                 "symbols",
+	"\U0001f51a":                 "symbols",
+	"\U0001f51b":                 "symbols",
+	"\U0001f51c":                 "symbols",
+	"\U0001f51d":                 "symbols",
+	"\U0001f6d0":                 "symbols",
+	"\u269b":                     "symbols",
+	"\U0001f549":                 "symbols",
+	"\u2721":                     "symbols",
+	"\u2638":                     "symbols",
+	"\u262f":                     "symbols",
+	"\u271d":                     "symbols",
+	"\u2626":                     "symbols",
+	"\u262a":                     "symbols",
+	"\u262e":                     "symbols",
+	"\U0001f54e":                 "symbols",
+	"\U0001f52f":                 "symbols",
+	"\u2648":                     "symbols",
+	"\u2649":                     "symbols",
+	"\u264a":                     "symbols",
+	"\u264b":                     "symbols",
+	"\u264c":                     "symbols",
+	"\u264d":                     "symbols",
+	"\u264e":                     "symbols",
+	"\u264f":                     "symbols",
+	"\u2650":                     "symbols",
+	"\u2651":                     "symbols",
+	"\u2652":                     "symbols",
+	"\u2653":                     "symbols",
+	"\u26ce":                     "symbols",
+	"\U0001f500":                 "symbols",
+	"\U0001f501":                 "symbols",
+	"\U0001f502":                 "symbols",
+	"\u25b6":                     "symbols",
+	"\u23e9":                     "symbols",
+	"\u23ed":                     "symbols",
+	"\u23ef":                     "symbols",
+	"\u25c0":                     "symbols",
+	"\u23ea":                     "symbols",
+	"\u23ee":                     "symbols",
+	"\U0001f53c":                 "symbols",
+	"\u23eb":                     "symbols",
+	"\U0001f53d":                 "symbols",
+	"\u23ec":                     "symbols",
+	"\u23f8":                     "symbols",
+	"\u23f9":                     "symbols",
+	"\u23fa":                     "symbols",
+	"\u23cf":                     "symbols",
+	"\U0001f3a6":                 "symbols",
+	"\U0001f505":                 "symbols",
+	"\U0001f506":                 "symbols",
+	"\U0001f4f6":                 "symbols",
+	"\U0001f4f3":                 "symbols",
+	"\U0001f4f4":                 "symbols",
+	"\u2640":                     "symbols",
+	"\u2642":                     "symbols",
+	"\u26a7":                     "symbols",
+	"\u2716":                     "symbols",
+	"\u2795":                     "symbols",
+	"\u2796":                     "symbols",
+	"\u2797":                     "symbols",
+	"\u267e":                     "symbols",
+	"\u203c":                     "symbols",
+	"\u2049":                     "symbols",
+	"\u2753":                     "symbols",
+	"\u2754":                     "symbols",
+	"\u2755":                     "symbols",
+	"\u2757":                     "symbols",
+	"\u3030":                     "symbols",
+	"\U0001f4b1":                 "symbols",
+	"\U0001f4b2":                 "symbols",
+	"\u2695":                     "symbols",
+	"\u267b":                     "symbols",
+	"\u269c":                     "symbols",
+	"\U0001f531":                 "symbols",
+	"\U0001f4db":                 "symbols",
+	"\U0001f530":                 "symbols",
+	"\u2b55":                     "symbols",
+	"\u2705":                     "symbols",
+	"\u2611":                     "symbols",
+	"\u2714":                     "symbols",
+	"\u274c":                     "symbols",
+	"\u274e":                     "symbols",
+	"\u27b0":                     "symbols",
+	"\u27bf":                     "symbols",
+	"\u303d":                     "symbols",
+	"\u2733":                     "symbols",
+	"\u2734":                     "symbols",
+	"\u2747":                     "symbols",
+	"\u00a9":                     "symbols",
+	"\u00ae":                     "symbols",
+	"\u2122":                     "symbols",
+	"#\u20e3":                    "symbols",
+	"*\u20e3":                    "symbols",
+	"0\u20e3":                    "symbols",
+	"1\u20e3":                    "symbols",
+	"2\u20e3":                    "symbols",
+	"3\u20e3":                    "symbols",
+	"4\u20e3":                    "symbols",
+	"5\u20e3":                    "symbols",
+	"6\u20e3":                    "symbols",
+	"7\u20e3":                    "symbols",
+	"8\u20e3":                    "symbols",
+	"9\u20e3":                    "symbols",
+	"\U0001f51f":                 "symbols",
+	"\U0001f520":                 "symbols",
+	"\U0001f521":                 "symbols",
+	"\U0001f522":                 "symbols",
+	"\U0001f523":                 "symbols",
+	"\U0001f524":                 "symbols",
+	"\U0001f170":                 "symbols",
+	"\U0001f18e":                 "symbols",
+	"\U0001f171":                 "symbols",
+	"\U0001f191":                 "symbols",
+	"\U0001f192":                 "symbols",
+	"\U0001f193":                 "symbols",
+	"\u2139":                     "symbols",
+	"\U0001f194":                 "symbols",
+	"\u24c2":                     "symbols",
+	"\U0001f195":                 "symbols",
+	"\U0001f196":                 "symbols",
+	"\U0001f17e":                 "symbols",
+	"\U0001f197":                 "symbols",
+	"\U0001f17f":                 "symbols",
+	"\U0001f198":                 "symbols",
+	"\U0001f199":                 "symbols",
+	"\U0001f19a":                 "symbols",
+	"\U0001f201":                 "symbols",
+	"\U0001f202":                 "symbols",
+	"\U0001f237":                 "symbols",
+	"\U0001f236":                 "symbols",
+	"\U0001f22f":                 "symbols",
+	"\U0001f250":                 "symbols",
+	"\U0001f239":                 "symbols",
+	"\U0001f21a":                 "symbols",
+	"\U0001f232":                 "symbols",
+	"\U0001f251":                 "symbols",
+	"\U0001f238":                 "symbols",
+	"\U0001f234":                 "symbols",
+	"\U0001f233":                 "symbols",
+	"\u3297":                     "symbols",
+	"\u3299":                     "symbols",
+	"\U0001f23a":                 "symbols",
+	"\U0001f235":                 "symbols",
+	"\U0001f534":                 "symbols",
+	"\U0001f7e0":                 "symbols",
+	"\U0001f7e1":                 "symbols",
+	"\U0001f7e2":                 "symbols",
+	"\U0001f535":                 "symbols",
+	"\U0001f7e3":                 "symbols",
+	"\U0001f7e4":                 "symbols",
+	"\u26ab":                     "symbols",
+	"\u26aa":                     "symbols",
+	"\U0001f7e5":                 "symbols",
+	"\U0001f7e7":                 "symbols",
+	"\U0001f7e8":                 "symbols",
+	"\U0001f7e9":                 "symbols",
+	"\U0001f7e6":                 "symbols",
+	"\U0001f7ea":                 "symbols",
+	"\U0001f7eb":                 "symbols",
+	"\u2b1b":                     "symbols",
+	"\u2b1c":                     "symbols",
+	"\u25fc":                     "symbols",
+	"\u25fb":                     "symbols",
+	"\u25fe":                     "symbols",
+	"\u25fd":                     "symbols",
+	"\u25aa":                     "symbols",
+	"\u25ab":                     "symbols",
+	"\U0001f536":                 "symbols",
+	"\U0001f537":                 "symbols",
+	"\U0001f538":                 "symbols",
+	"\U0001f539":                 "symbols",
+	"\U0001f53a":                 "symbols",
+	"\U0001f53b":                 "symbols",
+	"\U0001f4a0":                 "symbols",
+	"\U0001f518":                 "symbols",
+	"\U0001f533":                 "symbols",
+	"\U0001f532":                 "symbols",
+	"\U0001f3c1":                 "flags",
+	"\U0001f6a9":                 "flags",
+	"\U0001f38c":                 "flags",
+	"\U0001f3f4":                 "flags",
+	"\U0001f3f3":                 "flags",
+	"\U0001f3f3\u200d\U0001f308": "flags",
+	"\U0001f3f3\u200d\u26a7":     "flags",
+	"\U0001f3f4\u200d\u2620":     "flags",
+	"\U0001f1e6\U0001f1e8":       "flags",
+	"\U0001f1e6\U0001f1e9":       "flags",
+	"\U0001f1e6\U0001f1ea":       "flags",
+	"\U0001f1e6\U0001f1eb":       "flags",
+	"\U0001f1e6\U0001f1ec":       "flags",
+	"\U0001f1e6\U0001f1ee":       "flags",
+	"\U0001f1e6\U0001f1f1":       "flags",
+	"\U0001f1e6\U0001f1f2":       "flags",
+	"\U0001f1e6\U0001f1f4":       "flags",
+	"\U0001f1e6\U0001f1f6":       "flags",
+	"\U0001f1e6\U0001f1f7":       "flags",
+	"\U0001f1e6\U0001f1f8":       "flags",
+	"\U0001f1e6\U0001f1f9":       "flags",
+	"\U0001f1e6\U0001f1fa":       "flags",
+	"\U0001f1e6\U0001f1fc":       "flags",
+	"\U0001f1e6\U0001f1fd":       "flags",
+	"\U0001f1e6\U0001f1ff":       "flags",
+	"\U0001f1e7\U0001f1e6":       "flags",
+	"\U0001f1e7\U0001f1e7":       "flags",
+	"\U0001f1e7\U0001f1e9":       "flags",
+	"\U0001f1e7\U0001f1ea":       "flags",
+	"\U0001f1e7\U0001f1eb":       "flags",
+	"\U0001f1e7\U0001f1ec":       "flags",
+	"\U0001f1e7\U0001f1ed":       "flags",
+	"\U0001f1e7\U0001f1ee":       "flags",
+	"\U0001f1e7\U0001f1ef":       "flags",
+	"\U0001f1e7\U0001f1f1":       "flags",
+	"\U0001f1e7\U0001f1f2":       "flags",
+	"\U0001f1e7\U0001f1f3":       "flags",
+	"\U0001f1e7\U0001f1f4":       "flags",
+	"\U0001f1e7\U0001f1f6":       "flags",
+	"\U0001f1e7\U0001f1f7":       "flags",
+	"\U0001f1e7\U0001f1f8":       "flags",
+	"\U0001f1e7\U0001f1f9":       "flags",
+	"\U0001f1e7\U0001f1fb":       "flags",
+	"\U0001f1e7\U0001f1fc":       "flags",
+	"\U0001f1e7\U0001f1fe":       "flags",
+	"\U0001f1e7\U0001f1ff":       "flags",
+	"\U0001f1e8\U0001f1e6":       "flags",
+	"\U0001f1e8\U0001f1e8":       "flags",
+	"\U0001f1e8\U0001f1e9":       "flags",
+	"\U0001f1e8\U0001f1eb":       "flags",
+	"\U0001f1e8\U0001f1ec":       "flags",
+	"\U0001f1e8\U0001f1ed":       "flags",
+	"\U0001f1e8\U0001f1ee":       "flags",
+	"\U0001f1e8\U0001f1f0":       "flags",
+	"\U0001f1e8\U0001f1f1":       "flags",
+	"\U0001f1e8\U0001f1f2":       "flags",
+	"\U0001f1e8\U0001f1f3":       "flags",
+	"\U0001f1e8\U0001f1f4":       "flags",
+	"\U0001f1e8\U0001f1f5":       "flags",
+	"\U0001f1e8\U0001f1f7":       "flags",
+	"\U0001f1e8\U0001f1fa":       "flags",
+	"\U0001f1e8\U0001f1fb":       "flags",
+	"\U0001f1e8\U0001f1fc":       "flags",
+	"\U0001f1e8\U0001f1fd":       "flags",
+	"\U0001f1e8\U0001f1fe":       "flags",
+	"\U0001f1e8\U0001f1ff":       "flags",
+	"\U0001f1e9\U0001f1ea":       "flags",
+	"\U0001f1e9\U0001f1ec":       "flags",
+	"\U0001f1e9\U0001f1ef":       "flags",
+	"\U0001f1e9\U0001f1f0":       "flags",
+	"\U0001f1e9\U0001f1f2":       "flags",
+	"\U0001f1e9\U0001f1f4":       "flags",
+	"\U0001f1e9\U0001f1ff":       "flags",
+	"\U0001f1ea\U0001f1e6":       "flags",
+	"\U0001f1ea\U0001f1e8":       "flags",
+	"\U0001f1ea\U0001f1ea":       "flags",
+	"\U0001f1ea\U0001f1ec":       "flags",
+	"\U0001f1ea\U0001f1ed":       "flags",
+	"\U0001f1ea\U0001f1f7":       "flags",
+	"\U0001f1ea\U0001f1f8":       "flags",
+	"\U0001f1ea\U0001f1f9":       "flags",
+	"\U0001f1ea\U0001f1fa":       "flags",
+	"\U0001f1eb\U0001f1ee":       "flags",
+	"\U0001f1eb\U0001f1ef":       "flags",
+	"\U0001f1eb\U0001f1f0":       "flags",
+	"\U0001f1eb\U0001f1f2":       "flags",
+	"\U0001f1eb\U0001f1f4":       "flags",
+	"\U0001f1eb\U0001f1f7":       "flags",
+	"\U0001f1ec\U0001f1e6":       "flags",
+	"\U0001f1ec\U0001f1e7":       "flags",
+	"\U0001f1ec\U0001f1e9":       "flags",
+	"\U0001f1ec\U0001f1ea":       "flags",
+	"\U0001f1ec\U0001f1eb":       "flags",
+	"\U0001f1ec\U0001f1ec":       "flags",
+	"\U0001f1ec\U0001f1ed":       "flags",
+	"\U0001f1ec\U0001f1ee":       "flags",
+	"\U0001f1ec\U0001f1f1":       "flags",
+	"\U0001f1ec\U0001f1f2":       "flags",
+	"\U0001f1ec\U0001f1f3":       "flags",
+	"\U0001f1ec\U0001f1f5":       "flags",
+	"\U0001f1ec\U0001f1f6":       "flags",
+	"\U0001f1ec\U0001f1f7":       "flags",
+	"\U0001f1ec\U0001f1f8":       "flags",
+	"\U0001f1ec\U0001f1f9":       "flags",
+	"\U0001f1ec\U0001f1fa":       "flags",
+	"\U0001f1ec\U0001f1fc":       "flags",
+	"\U0001f1ec\U0001f1fe":       "flags",
+	"\U0001f1ed\U0001f1f0":       "flags",
+	"\U0001f1ed\U0001f1f2":       "flags",
+	"\U0001f1ed\U0001f1f3":       "flags",
+	"\U0001f1ed\U0001f1f7":       "flags",
+	"\U0001f1ed\U0001f1f9":       "flags",
+	"\U0001f1ed\U0001f1fa":       "flags",
+	"\U0001f1ee\U0001f1e8":       "flags",
+	"\U0001f1ee\U0001f1e9":       "flags",
+	"\U0001f1ee\U0001f1ea":       "flags",
+	"\U0001f1ee\U0001f1f1":       "flags",
+	"\U0001f1ee\U0001f1f2":       "flags",
+	"\U0001f1ee\U0001f1f3":       "flags",
+	"\U0001f1ee\U0001f1f4":       "flags",
+	"\U0001f1ee\U0001f1f6":       "flags",
+	"\U0001f1ee\U0001f1f7":       "flags",
+	"\U0001f1ee\U0001f1f8":       "flags",
+	"\U0001f1ee\U0001f1f9":       "flags",
+	"\U0001f1ef\U0001f1ea":       "flags",
+	"\U0001f1ef\U0001f1f2":       "flags",
+	"\U0001f1ef\U0001f1f4":       "flags",
+	"\U0001f1ef\U0001f1f5":       "flags",
+	"\U0001f1f0\U0001f1ea":       "flags",
+	"\U0001f1f0\U0001f1ec":       "flags",
+	"\U0001f1f0\U0001f1ed":       "flags",
+	"\U0001f1f0\U0001f1ee":       "flags",
+	"\U0001f1f0\U0001f1f2":       "flags",
+	"\U0001f1f0\U0001f1f3":       "flags",
+	"\U0001f1f0\U0001f1f5":       "flags",
+	"\U0001f1f0\U0001f1f7":       "flags",
+	"\U0001f1f0\U0001f1fc":       "flags",
+	"\U0001f1f0\U0001f1fe":       "flags",
+	"\U0001f1f0\U0001f1ff":       "flags",
+	"\U0001f1f1\U0001f1e6":       "flags",
+	"\U0001f1f1\U0001f1e7":       "flags",
+	"\U0001f1f1\U0001f1e8":       "flags",
+	"\U0001f1f1\U0001f1ee":       "flags",
+	"\U0001f1f1\U0001f1f0":       "flags",
+	"\U0001f1f1\U0001f1f7":       "flags",
+	"\U0001f1f1\U0001f1f8":       "flags",
+	"\U0001f1f1\U0001f1f9":       "flags",
+	"\U0001f1f1\U0001f1fa":       "flags",
+	"\U0001f1f1\U0001f1fb":       "flags",
+	"\U0001f1f1\U0001f1fe":       "flags",
+	"\U0001f1f2\U0001f1e6":       "flags",
+	"\U0001f1f2\U0001f1e8":       "flags",
+	"\U0001f1f2\U0001f1e9":       "flags",
+	"\U0001f1f2\U0001f1ea":       "flags",
+	"\U0001f1f2\U0001f1eb":       "flags",
+	"\U0001f1f2\U0001f1ec":       "flags",
+	"\U0001f1f2\U0001f1ed":       "flags",
+	"\U0001f1f2\U0001f1f0":       "flags",
+	"\U0001f1f2\U0001f1f1":       "flags",
+	"\U0001f1f2\U0001f1f2":       "flags",
+	"\U0001f1f2\U0001f1f3":       "flags",
+	"\U0001f1f2\U0001f1f4":       "flags",
+	"\U0001f1f2\U0001f1f5":       "flags",
+	"\U0001f1f2\U0001f1f6":       "flags",
+	"\U0001f1f2\U0001f1f7":       "flags",
+	"\U0001f1f2\U0001f1f8":       "flags",
+	"\U0001f1f2\U0001f1f9":       "flags",
+	"\U0001f1f2\U0001f1fa":       "flags",
+	"\U0001f1f2\U0001f1fb":       "flags",
+	"\U0001f1f2\U0001f1fc":       "flags",
+	"\U0001f1f2\U0001f1fd":       "flags",
+	"\U0001f1f2\U0001f1fe":       "flags",
+	"\U0001f1f2\U0001f1ff":       "flags",
+	"\U0001f1f3\U0001f1e6":       "flags",
+	"\U0001f1f3\U0001f1e8":       "flags",
+	"\U0001f1f3\U0001f1ea":       "flags",
+	"\U0001f1f3\U0001f1eb":       "flags",
+	"\U0001f1f3\U0001f1ec":       "flags",
+	"\U0001f1f3\U0001f1ee":       "flags",
+	"\U0001f1f3\U0001f1f1":       "flags",
+	"\U0001f1f3\U0001f1f4":       "flags",
+	"\U0001f1f3\U0001f1f5":       "flags",
+	"\U0001f1f3\U0001f1f7":       "flags",
+	"\U0001f1f3\U0001f1fa":       "flags",
+	"\U0001f1f3\U0001f1ff":       "flags",
+	"\U0001f1f4\U0001f1f2":       "flags",
+	"\U0001f1f5\U0001f1e6":       "flags",
+	"\U0001f1f5\U0001f1ea":       "flags",
+	"\U0001f1f5\U0001f1eb":       "flags",
+	"\U0001f1f5\U0001f1ec":       "flags",
+	"\U0001f1f5\U0001f1ed":       "flags",
+	"\U0001f1f5\U0001f1f0":       "flags",
+	"\U0001f1f5\U0001f1f1":       "flags",
+	"\U0001f1f5\U0001f1f2":       "flags",
+	"\U0001f1f5\U0001f1f3":       "flags",
+	"\U0001f1f5\U0001f1f7":       "flags",
+	"\U0001f1f5\U0001f1f8":       "flags",
+	"\U0001f1f5\U0001f1f9":       "flags",
+	"\U0001f1f5\U0001f1fc":       "flags",
+	"\U0001f1f5\U0001f1fe":       "flags",
+	"\U0001f1f6\U0001f1e6":       "flags",
+	"\U0001f1f7\U0001f1ea":       "flags",
+	"\U0001f1f7\U0001f1f4":       "flags",
+	"\U0001f1f7\U0001f1f8":       "flags",
+	"\U0001f1f7\U0001f1fa":       "flags",
+	"\U0001f1f7\U0001f1fc":       "flags",
+	"\U0001f1f8\U0001f1e6":       "flags",
+	"\U0001f1f8\U0001f1e7":       "flags",
+	"\U0001f1f8\U0001f1e8":       "flags",
+	"\U0001f1f8\U0001f1e9":       "flags",
+	"\U0001f1f8\U0001f1ea":       "flags",
+	"\U0001f1f8\U0001f1ec":       "flags",
+	"\U0001f1f8\U0001f1ed":       "flags",
+	"\U0001f1f8\U0001f1ee":       "flags",
+	"\U0001f1f8\U0001f1ef":       "flags",
+	"\U0001f1f8\U0001f1f0":       "flags",
+	"\U0001f1f8\U0001f1f1":       "flags",
+	"\U0001f1f8\U0001f1f2":       "flags",
+	"\U0001f1f8\U0001f1f3":       "flags",
+	"\U0001f1f8\U0001f1f4":       "flags",
+	"\U0001f1f8\U0001f1f7":       "flags",
+	"\U0001f1f8\U0001f1f8":       "flags",
+	"\U0001f1f8\U0001f1f9":       "flags",
+	"\U0001f1f8\U0001f1fb":       "flags",
+	"\U0001f1f8\U0001f1fd":       "flags",
+	"\U0001f1f8\U0001f1fe":       "flags",
+	"\U0001f1f8\U0001f1ff":       "flags",
+	"\U0001f1f9\U0001f1e6":       "flags",
+	"\U0001f1f9\U0001f1e8":       "flags",
+	"\U0001f1f9\U0001f1e9":       "flags",
+	"\U0001f1f9\U0001f1eb":       "flags",
+	"\U0001f1f9\U0001f1ec":       "flags",
+	"\U0001f1f9\U0001f1ed":       "flags",
+	"\U0001f1f9\U0001f1ef":       "flags",
+	"\U0001f1f9\U0001f1f0":       "flags",
+	"\U0001f1f9\U0001f1f1":       "flags",
+	"\U0001f1f9\U0001f1f2":       "flags",
+	"\U0001f1f9\U0001f1f3":       "flags",
+	"\U0001f1f9\U0001f1f4":       "flags",
+	"\U0001f1f9\U0001f1f7":       "flags",
+	"\U0001f1f9\U0001f1f9":       "flags",
+	"\U0001f1f9\U0001f1fb":       "flags",
+	"\U0001f1f9\U0001f1fc":       "flags",
+	"\U0001f1f9\U0001f1ff":       "flags",
+	"\U0001f1fa\U0001f1e6":       "flags",
+	"\U0001f1fa\U0001f1ec":       "flags",
+	"\U0001f1fa\U0001f1f2":       "flags",
+	"\U0001f1fa\U0001f1f3":       "flags",
+	"\U0001f1fa\U0001f1f8":       "flags",
+	"\U0001f1fa\U0001f1fe":       "flags",
+	"\U0001f1fa\U0001f1ff":       "flags",
+	"\U0001f1fb\U0001f1e6":       "flags",
+	"\U0001f1fb\U0001f1e8":       "flags",
+	"\U0001f1fb\U0001f1ea":       "flags",
+	"\U0001f1fb\U0001f1ec":       "flags",
+	"\U0001f1fb\U0001f1ee":       "flags",
+	"\U0001f1fb\U0001f1f3":       "flags",
+	"\U0001f1fb\U0001f1fa":       "flags",
+	"\U0001f1fc\U0001f1eb":       "flags",
+	"\U0001f1fc\U0001f1f8":       "flags",
+	"\U0001f1fd\U0001f1f0":       "flags",
+	"\U0001f1fe\U0001f1ea":       "flags",
+	"\U0001f1fe\U0001f1f9":       "flags",
+	"\U0001f1ff\U0001f1e6":       "flags",
+	"\U0001f1ff\U0001f1f2":       "flags",
+	"\U0001f1ff\U0001f1fc":       "flags",
+	"\U0001f3f4\U000e0067\U000e0062\U000e0065\U000e006e\U000e0067\U000e007f": "flags",
+	"\U0001f3f4\U000e0067\U000e0062\U000e0073\U000e0063\U000e0074\U000e007f": "flags",
+	"\U0001f3f4\U000e0067\U000e0062\U000e0077\U000e006c\U000e0073\U000e007f": "flags",
+}
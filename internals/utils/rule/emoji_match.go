@@ -0,0 +1,124 @@
+package rule
+
+//go:generate go run gen_emoji_categories.go
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/enescakir/emoji"
+)
+
+// MatchKind is how a reaction rule matches emojis. Exact rules match the emoji of EmojiName and EmojiId,
+// the other kinds describe the emojis they match in EmojiName.
+type MatchKind string
+
+const (
+	MatchExact MatchKind = ""
+	// MatchBase matches the unicode emoji of EmojiName with any skin tone and variation selector.
+	MatchBase MatchKind = "base"
+	// MatchCategory matches unicode emojis of the category named by EmojiName, one of EmojiCategories.
+	MatchCategory MatchKind = "category"
+	// MatchPattern matches custom emojis whose name matches the regular expression in EmojiName.
+	MatchPattern MatchKind = "pattern"
+)
+
+// MaxPatternLength limits regular expressions of pattern rules, so the rule fits in custom ids of components.
+const MaxPatternLength = 80
+
+// BaseEmoji returns the unicode emoji without skin tone modifiers and variation selectors.
+func BaseEmoji(e string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 0x1F3FB && r <= 0x1F3FF) || r == 0xFE0E || r == 0xFE0F {
+			return -1
+		}
+
+		return r
+	}, e)
+}
+
+// EmojiCategory returns the category of the unicode emoji, false if it's unknown.
+func EmojiCategory(e string) (string, bool) {
+	c, ok := emojiCategories[BaseEmoji(e)]
+	return c, ok
+}
+
+// IsEmojiCategory reports whether c is one of EmojiCategories.
+func IsEmojiCategory(c string) bool {
+	for _, category := range EmojiCategories {
+		if category == c {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsValidMatcher reports whether EmojiName describes emojis the way the match kind of the rule expects.
+func (a ReactionRule) IsValidMatcher() bool {
+	switch a.Match {
+	case MatchExact:
+		return true
+	case MatchBase:
+		_, ok := EmojiCategory(a.EmojiName)
+		return ok && a.EmojiId == ""
+	case MatchCategory:
+		return IsEmojiCategory(a.EmojiName) && a.EmojiId == ""
+	case MatchPattern:
+		_, err := regexp.Compile(a.EmojiName)
+		return len(a.EmojiName) <= MaxPatternLength && a.EmojiId == "" && err == nil
+	}
+
+	return false
+}
+
+// Compile compiles the regular expression of pattern rules, so it isn't compiled on every reaction.
+// Rules are compiled when they're cached.
+func (a *ReactionRule) Compile() {
+	if a.Match == MatchPattern {
+		a.pattern, _ = regexp.Compile(a.EmojiName)
+	}
+}
+
+// Matches reports whether a reaction with the emoji is matched by the rule. Exact rules are matched by id
+// for custom emojis and by name otherwise, names of rules may be emoji aliases like :smile:.
+func (a ReactionRule) Matches(emojiName, emojiId string) bool {
+	switch a.Match {
+	case MatchExact:
+		if a.EmojiId != "" && emojiId != "" {
+			return a.EmojiId == emojiId
+		}
+
+		return a.EmojiName != "" && emojiName != "" && emoji.Parse(a.EmojiName) == emojiName
+	case MatchBase:
+		return emojiId == "" && BaseEmoji(a.EmojiName) == BaseEmoji(emojiName)
+	case MatchCategory:
+		c, ok := EmojiCategory(emojiName)
+		return emojiId == "" && ok && c == a.EmojiName
+	case MatchPattern:
+		re := a.pattern
+
+		if re == nil {
+			re, _ = regexp.Compile(a.EmojiName)
+		}
+
+		return emojiId != "" && re != nil && re.MatchString(emojiName)
+	}
+
+	return false
+}
+
+// Describe returns how the rule is shown to admins. Exact rules are described by the caller, because
+// custom emojis are rendered by the discord package.
+func (a ReactionRule) Describe() string {
+	switch a.Match {
+	case MatchBase:
+		return a.EmojiName + " with any skin tone"
+	case MatchCategory:
+		return "emojis of category " + a.EmojiName
+	case MatchPattern:
+		return "custom emojis matching `" + a.EmojiName + "`"
+	}
+
+	return a.EmojiName
+}
@@ -0,0 +1,148 @@
+package rule
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBaseEmoji(t *testing.T) {
+	t.Run("SkinTone", testBaseEmojiSkinTone)
+	t.Run("VariationSelector", testBaseEmojiVariationSelector)
+	t.Run("ZWJSequence", testBaseEmojiZWJSequence)
+}
+
+func TestEmojiCategory(t *testing.T) {
+	t.Run("Known", testEmojiCategoryKnown)
+	t.Run("Flag", testEmojiCategoryFlag)
+	t.Run("ZWJSequence", testEmojiCategoryZWJSequence)
+	t.Run("Unknown", testEmojiCategoryUnknown)
+}
+
+func TestMatches(t *testing.T) {
+	t.Run("Exact", testMatchesExact)
+	t.Run("ExactAlias", testMatchesExactAlias)
+	t.Run("Base", testMatchesBase)
+	t.Run("Category", testMatchesCategory)
+	t.Run("Pattern", testMatchesPattern)
+	t.Run("PatternCompiled", testMatchesPatternCompiled)
+}
+
+func testBaseEmojiSkinTone(t *testing.T) {
+	assert.Equal(t, "\U0001f44d", BaseEmoji("\U0001f44d\U0001f3fd"))
+	assert.Equal(t, "\U0001f44d", BaseEmoji("\U0001f44d\U0001f3ff"))
+	assert.Equal(t, "\U0001f44d", BaseEmoji("\U0001f44d"))
+}
+
+func testBaseEmojiVariationSelector(t *testing.T) {
+	assert.Equal(t, "\u2764", BaseEmoji("\u2764\ufe0f"))
+	assert.Equal(t, "\u2764", BaseEmoji("\u2764\ufe0e"))
+}
+
+func testBaseEmojiZWJSequence(t *testing.T) {
+	// only modifiers are stripped, the joined emojis are kept
+	assert.Equal(t, "\U0001f469\u200d\U0001f4bb", BaseEmoji("\U0001f469\U0001f3fd\u200d\U0001f4bb"))
+	assert.Equal(t, "\U0001f3f3\u200d\U0001f308", BaseEmoji("\U0001f3f3\ufe0f\u200d\U0001f308"))
+}
+
+func testEmojiCategoryKnown(t *testing.T) {
+	c, ok := EmojiCategory("\U0001f44d\U0001f3fd")
+
+	assert.True(t, ok)
+	assert.Equal(t, "people-body", c)
+
+	c, ok = EmojiCategory("\u2764\ufe0f")
+
+	assert.True(t, ok)
+	assert.Equal(t, "smileys-emotion", c)
+}
+
+func testEmojiCategoryFlag(t *testing.T) {
+	c, ok := EmojiCategory("\U0001f1fa\U0001f1f8")
+
+	assert.True(t, ok)
+	assert.Equal(t, "flags", c)
+
+	c, ok = EmojiCategory("\U0001f3f3\ufe0f\u200d\U0001f308")
+
+	assert.True(t, ok)
+	assert.Equal(t, "flags", c)
+}
+
+func testEmojiCategoryZWJSequence(t *testing.T) {
+	c, ok := EmojiCategory("\U0001f469\U0001f3fd\u200d\U0001f4bb")
+
+	assert.True(t, ok)
+	assert.Equal(t, "people-body", c)
+}
+
+func testEmojiCategoryUnknown(t *testing.T) {
+	for _, e := range []string{"", "a", "pepe", "\U0001f3fd", "\U0001f1fa"} {
+		_, ok := EmojiCategory(e)
+		assert.False(t, ok, e)
+	}
+}
+
+func testMatchesExact(t *testing.T) {
+	unicode := ReactionRule{EmojiName: "\U0001f44d"}
+
+	assert.True(t, unicode.Matches("\U0001f44d", ""))
+	assert.False(t, unicode.Matches("\U0001f44d\U0001f3fd", ""), "exact rules don't match other skin tones")
+	assert.False(t, unicode.Matches("", ""))
+
+	custom := ReactionRule{EmojiName: "pepe", EmojiId: "1", IsCustom: true}
+
+	assert.True(t, custom.Matches("pepe", "1"))
+	assert.True(t, custom.Matches("renamed", "1"), "custom emojis are matched by id")
+	assert.False(t, custom.Matches("pepe", "2"))
+}
+
+func testMatchesExactAlias(t *testing.T) {
+	r := ReactionRule{EmojiName: ":thumbsup:"}
+
+	assert.True(t, r.Matches("\U0001f44d", ""))
+	assert.False(t, r.Matches(":thumbsup:", "1"))
+}
+
+func testMatchesBase(t *testing.T) {
+	r := ReactionRule{EmojiName: "\U0001f44d", Match: MatchBase}
+
+	assert.True(t, r.Matches("\U0001f44d", ""))
+	assert.True(t, r.Matches("\U0001f44d\U0001f3fd", ""))
+	assert.False(t, r.Matches("\U0001f44e", ""))
+	assert.False(t, r.Matches("\U0001f44d", "1"), "custom emojis aren't matched")
+}
+
+func testMatchesCategory(t *testing.T) {
+	r := ReactionRule{EmojiName: "flags", Match: MatchCategory}
+
+	assert.True(t, r.Matches("\U0001f1fa\U0001f1f8", ""))
+	assert.True(t, r.Matches("\U0001f3f3\ufe0f\u200d\U0001f308", ""))
+	assert.False(t, r.Matches("\U0001f44d", ""))
+	assert.False(t, r.Matches("flag", "1"), "custom emojis aren't matched")
+}
+
+func testMatchesPattern(t *testing.T) {
+	r := ReactionRule{EmojiName: "^pepe", Match: MatchPattern}
+
+	assert.True(t, r.Matches("pepehands", "1"))
+	assert.False(t, r.Matches("sadpepe", "1"))
+	assert.False(t, r.Matches("pepe", ""), "unicode emojis aren't matched")
+
+	invalid := ReactionRule{EmojiName: "(", Match: MatchPattern}
+
+	assert.False(t, invalid.Matches("(", "1"))
+}
+
+func testMatchesPatternCompiled(t *testing.T) {
+	r := ReactionRule{EmojiName: "^pepe", Match: MatchPattern}
+	r.Compile()
+
+	assert.NotNil(t, r.pattern)
+	assert.True(t, r.Matches("pepehands", "1"))
+
+	exact := ReactionRule{EmojiName: "^pepe"}
+	exact.Compile()
+
+	assert.Nil(t, exact.pattern, "only pattern rules are compiled")
+}
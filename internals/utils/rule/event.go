@@ -0,0 +1,37 @@
+package rule
+
+import "github.com/finkabaj/hyde-bot/internals/utils/guild"
+
+type RuleEventOp string
+
+const (
+	RuleEventCreated RuleEventOp = "created"
+	RuleEventUpdated RuleEventOp = "updated"
+	RuleEventDeleted RuleEventOp = "deleted"
+	// RuleEventExemptionsUpdated is sent when guild wide exemptions change.
+	RuleEventExemptionsUpdated RuleEventOp = "exemptionsUpdated"
+	// RuleEventModLogUpdated is sent when the moderation log channel changes.
+	RuleEventModLogUpdated RuleEventOp = "modLogUpdated"
+	// RuleEventReactionRolesUpdated is sent when reaction roles of messages are created or replaced.
+	RuleEventReactionRolesUpdated RuleEventOp = "reactionRolesUpdated"
+	// RuleEventReactionRolesDeleted is sent when reaction roles of messages are deleted.
+	RuleEventReactionRolesDeleted RuleEventOp = "reactionRolesDeleted"
+	// RuleEventReactionFloodUpdated is sent when the reaction flood rule is created or replaced.
+	RuleEventReactionFloodUpdated RuleEventOp = "reactionFloodUpdated"
+	// RuleEventReactionFloodDeleted is sent when the reaction flood rule is deleted.
+	RuleEventReactionFloodDeleted RuleEventOp = "reactionFloodDeleted"
+)
+
+// RuleEvent describes a change of guild rules made through the API.
+type RuleEvent struct {
+	GuildId              string                    `json:"guildId"`
+	Op                   RuleEventOp               `json:"op"`
+	ReactionRules        []ReactionRule            `json:"reactionRules,omitempty"`
+	DeletedReactionRules []DeleteReactionRuleQuery `json:"deletedReactionRules,omitempty"`
+	Exemptions           *guild.GuildExemptions    `json:"exemptions,omitempty"`
+	ModLog               *guild.GuildModLog        `json:"modLog,omitempty"`
+	ReactionRoles        []ReactionRoleMessage     `json:"reactionRoles,omitempty"`
+	// DeletedReactionRoleMessages are ids of messages whose reaction roles are deleted.
+	DeletedReactionRoleMessages []string           `json:"deletedReactionRoleMessages,omitempty"`
+	ReactionFlood               *ReactionFloodRule `json:"reactionFlood,omitempty"`
+}
@@ -0,0 +1,46 @@
+package rule
+
+import "time"
+
+// Reaction flood limits.
+const (
+	MaxFloodReactions = 100
+	MaxFloodWindow    = 10 * time.Minute
+)
+
+// ReactionFloodRule acts on a member who reacts more than MaxReactions times within the window,
+// no matter which emojis or messages. Delete removes reactions of the member made within the window,
+// the other actions are taken on the member. A guild has at most one flood rule.
+type ReactionFloodRule struct {
+	GuildId       string  `json:"guildId"`
+	MaxReactions  int     `json:"maxReactions"`
+	WindowSeconds int     `json:"windowSeconds"`
+	Actions       Actions `json:"actions"`
+}
+
+// ReactionFloodRuleUpdate replaces the flood rule of a guild.
+type ReactionFloodRuleUpdate struct {
+	MaxReactions  int     `json:"maxReactions" validate:"gte=1,lte=100"`
+	WindowSeconds int     `json:"windowSeconds" validate:"gte=1,lte=600"`
+	Actions       Actions `json:"actions" validate:"min=1,max=5,dive"`
+}
+
+// Window returns the duration reactions of a member are counted for.
+func (f ReactionFloodRule) Window() time.Duration {
+	return time.Duration(f.WindowSeconds) * time.Second
+}
+
+// Update returns the update that replaces the flood rule with f.
+func (f ReactionFloodRule) Update() ReactionFloodRuleUpdate {
+	return ReactionFloodRuleUpdate{
+		MaxReactions:  f.MaxReactions,
+		WindowSeconds: f.WindowSeconds,
+		Actions:       f.Actions,
+	}
+}
+
+// IsValid reports whether the limits are within bounds. Actions are checked separately.
+func (u ReactionFloodRuleUpdate) IsValid() bool {
+	return u.MaxReactions >= 1 && u.MaxReactions <= MaxFloodReactions &&
+		u.WindowSeconds > 0 && time.Duration(u.WindowSeconds)*time.Second <= MaxFloodWindow
+}
@@ -0,0 +1,116 @@
+//go:build ignore
+
+// gen_emoji_categories generates emoji_categories.go from the unicode groups
+// in constants.go of github.com/enescakir/emoji.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	groupRegexp = regexp.MustCompile(`^\s*// GROUP: (.+)$`)
+	emojiRegexp = regexp.MustCompile(`^\s*\w+\s+Emoji(?:WithTone)?\s+=\s+(?:newEmojiWithTone\()?("(?:[^"\\]|\\.)*")`)
+	slugRegexp  = regexp.MustCompile(`[^a-z]+`)
+)
+
+func main() {
+	dir, err := exec.Command("go", "list", "-m", "-f", "{{.Dir}}", "github.com/enescakir/emoji").Output()
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	f, err := os.Open(filepath.Join(strings.TrimSpace(string(dir)), "constants.go"))
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	defer f.Close()
+
+	var categories []string
+	seen := make(map[string]bool)
+	var entries bytes.Buffer
+	category := ""
+	scanner := bufio.NewScanner(f)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if m := groupRegexp.FindStringSubmatch(line); m != nil {
+			category = strings.Trim(slugRegexp.ReplaceAllString(strings.ToLower(m[1]), "-"), "-")
+			categories = append(categories, category)
+			continue
+		}
+
+		m := emojiRegexp.FindStringSubmatch(line)
+
+		if m == nil || category == "" {
+			continue
+		}
+
+		code, err := strconv.Unquote(m[1])
+
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		base := baseEmoji(strings.ReplaceAll(code, "@", ""))
+
+		if base == "" || seen[base] {
+			continue
+		}
+
+		seen[base] = true
+		fmt.Fprintf(&entries, "\t%+q: %q,\n", base, category)
+	}
+
+	if err := scanner.Err(); err != nil {
+		log.Fatal(err)
+	}
+
+	var out bytes.Buffer
+
+	fmt.Fprintln(&out, "// Code generated by gen_emoji_categories.go DO NOT EDIT.")
+	fmt.Fprintln(&out)
+	fmt.Fprintln(&out, "package rule")
+	fmt.Fprintln(&out)
+	fmt.Fprintln(&out, "// EmojiCategories are unicode emoji groups in the order of the unicode emoji list.")
+	fmt.Fprintf(&out, "var EmojiCategories = %#v\n\n", categories)
+	fmt.Fprintln(&out, "// emojiCategories maps base emojis to their category.")
+	fmt.Fprintln(&out, "var emojiCategories = map[string]string{")
+	out.Write(entries.Bytes())
+	fmt.Fprintln(&out, "}")
+
+	src, err := format.Source(out.Bytes())
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile("emoji_categories.go", src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// baseEmoji must match BaseEmoji of the rule package.
+func baseEmoji(e string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 0x1F3FB && r <= 0x1F3FF) || r == 0xFE0E || r == 0xFE0F {
+			return -1
+		}
+
+		return r
+	}, e)
+}
@@ -4,7 +4,10 @@ import (
 	"encoding/json"
 	"errors"
 	"net/url"
+	"regexp"
+	"slices"
 	"strconv"
+	"time"
 )
 
 type ReactAction int
@@ -14,17 +17,151 @@ const (
 	Warn
 	Ban
 	Kick
+	Timeout
 )
 
-const ReactActionCount = 4
+// ReactActionCount is the number of actions, a rule has at most one action of each type.
+const ReactActionCount = 5
+
+// MaxTimeout is the longest timeout discord allows.
+const MaxTimeout = 28 * 24 * time.Hour
+
+func (a ReactAction) String() string {
+	switch a {
+	case Delete:
+		return "delete"
+	case Warn:
+		return "warn"
+	case Ban:
+		return "ban"
+	case Kick:
+		return "kick"
+	case Timeout:
+		return "timeout"
+	}
+
+	return "unknown"
+}
+
+// IsValid reports whether a is one of the defined actions. Zero value is not valid.
+func (a ReactAction) IsValid() bool {
+	return a >= Delete && a <= Timeout
+}
+
+// Action is an action of a reaction rule with its parameters.
+type Action struct {
+	Type ReactAction `json:"type" validate:"gte=1,lte=5"`
+	// DurationSeconds is how long the member is timed out, only set for Timeout.
+	DurationSeconds int `json:"durationSeconds,omitempty" validate:"required_if=Type 5,gte=0,lte=2419200"`
+}
+
+// Duration returns the timeout duration.
+func (a Action) Duration() time.Duration {
+	return time.Duration(a.DurationSeconds) * time.Second
+}
+
+// IsValid reports whether the type is defined and only timeouts have a duration, up to MaxTimeout.
+func (a Action) IsValid() bool {
+	if !a.Type.IsValid() {
+		return false
+	}
+
+	if a.Type != Timeout {
+		return a.DurationSeconds == 0
+	}
+
+	return a.DurationSeconds > 0 && a.Duration() <= MaxTimeout
+}
+
+// Threshold limits of reaction rules.
+const (
+	MinThresholdCount  = 2
+	MaxThresholdCount  = 100
+	MaxThresholdWindow = 24 * time.Hour
+)
+
+// ReactionThreshold makes a rule act on the message once Count distinct members reacted within the window,
+// instead of acting on every member who reacts.
+type ReactionThreshold struct {
+	Count         int `json:"count" validate:"gte=2,lte=100"`
+	WindowSeconds int `json:"windowSeconds" validate:"gte=1,lte=86400"`
+}
+
+// Window returns the duration reactions are counted for.
+func (t ReactionThreshold) Window() time.Duration {
+	return time.Duration(t.WindowSeconds) * time.Second
+}
+
+// IsValid reports whether count and window are within the threshold limits.
+func (t ReactionThreshold) IsValid() bool {
+	return t.Count >= MinThresholdCount && t.Count <= MaxThresholdCount && t.WindowSeconds > 0 && t.Window() <= MaxThresholdWindow
+}
+
+// Actions are actions of a reaction rule in the order they are taken.
+type Actions []Action
+
+// Types returns types of the actions in order.
+func (a Actions) Types() []ReactAction {
+	types := make([]ReactAction, 0, len(a))
+
+	for _, action := range a {
+		types = append(types, action.Type)
+	}
+
+	return types
+}
+
+// UnmarshalJSON also accepts the fixed size array of action numbers rules had before actions got parameters,
+// so writes queued by older versions can be replayed. Zeros were unused slots of the array.
+func (a *Actions) UnmarshalJSON(data []byte) error {
+	var actions []Action
+
+	if err := json.Unmarshal(data, &actions); err == nil {
+		*a = actions
+		return nil
+	}
+
+	var legacy []ReactAction
+
+	if err := json.Unmarshal(data, &legacy); err != nil {
+		return err
+	}
+
+	*a = make(Actions, 0, len(legacy))
+
+	for _, t := range legacy {
+		if t != 0 {
+			*a = append(*a, Action{Type: t})
+		}
+	}
+
+	return nil
+}
 
 type ReactionRule struct {
-	EmojiName  string                        `json:"emojiName,omitempty" validate:"required"`
-	EmojiId    string                        `json:"emojiId,omitempty" validate:"omitempty"`
-	IsCustom   bool                          `json:"isCustom" validate:"boolean"`
-	GuildId    string                        `json:"guildId" validate:"required"`
-	RuleAuthor string                        `json:"ruleAuthor" validate:"required"`
-	Actions    [ReactActionCount]ReactAction `json:"actions" validate:"dive"`
+	EmojiName       string   `json:"emojiName,omitempty" validate:"required"`
+	EmojiId         string   `json:"emojiId,omitempty" validate:"omitempty"`
+	IsCustom        bool     `json:"isCustom" validate:"boolean"`
+	GuildId         string   `json:"guildId" validate:"required"`
+	RuleAuthor      string   `json:"ruleAuthor" validate:"required"`
+	Actions         Actions  `json:"actions" validate:"max=5,dive"`
+	IncludeChannels []string `json:"includeChannels,omitempty" validate:"omitempty,dive,required"` // IncludeChannels limits the rule to these channels. Empty means every channel.
+	ExcludeChannels []string `json:"excludeChannels,omitempty" validate:"omitempty,dive,required"` // ExcludeChannels are channels where the rule never applies. Takes precedence over IncludeChannels.
+	ExemptRoles     []string `json:"exemptRoles,omitempty" validate:"omitempty,dive,required"`     // ExemptRoles are roles the rule never applies to, in addition to guild wide exempt roles.
+	// Threshold is nil for rules that act on every reaction. Threshold rules act on the reacted message:
+	// delete removes the message and the other actions are taken on its author. Set only on creation.
+	Threshold *ReactionThreshold `json:"threshold,omitempty" validate:"omitempty"`
+	// Match is how the rule matches emojis, exact by default. Set only on creation.
+	Match MatchKind `json:"match,omitempty" validate:"omitempty,oneof=base category pattern"`
+
+	pattern *regexp.Regexp // pattern is the compiled EmojiName of pattern rules, see Compile.
+}
+
+// ReactionRuleUpdate identifies a reaction rule of a guild by emoji and holds its new values.
+type ReactionRuleUpdate struct {
+	EmojiName string  `json:"emojiName,omitempty" validate:"required"`
+	EmojiId   string  `json:"emojiId,omitempty" validate:"omitempty"`
+	Actions   Actions `json:"actions" validate:"max=5,dive"`
 }
 
 type DeleteReactionRuleQuery struct {
@@ -58,19 +195,52 @@ func (a ReactionRule) Compare(b ReactionRule) int {
 		return -1
 	}
 
-	if len(a.Actions) != len(b.Actions) {
+	if !slices.Equal(a.Actions, b.Actions) {
 		return -1
 	}
 
-	for i, action := range a.Actions {
-		if action != b.Actions[i] {
-			return -1
-		}
+	if !slices.Equal(a.IncludeChannels, b.IncludeChannels) {
+		return -1
+	}
+
+	if !slices.Equal(a.ExcludeChannels, b.ExcludeChannels) {
+		return -1
+	}
+
+	if !slices.Equal(a.ExemptRoles, b.ExemptRoles) {
+		return -1
+	}
+
+	if (a.Threshold == nil) != (b.Threshold == nil) || (a.Threshold != nil && *a.Threshold != *b.Threshold) {
+		return -1
+	}
+
+	if a.Match != b.Match {
+		return -1
 	}
 
 	return 0
 }
 
+// SameEmoji reports whether both rules are for the same emoji.
+func (a ReactionRule) SameEmoji(b ReactionRule) bool {
+	return a.EmojiName == b.EmojiName && a.EmojiId == b.EmojiId
+}
+
+// AppliesToChannel reports whether the rule is enforced in the channel.
+// parentId is the parent channel of a thread and can be empty.
+func (a ReactionRule) AppliesToChannel(channelId, parentId string) bool {
+	matches := func(channels []string) bool {
+		return slices.Contains(channels, channelId) || (parentId != "" && slices.Contains(channels, parentId))
+	}
+
+	if matches(a.ExcludeChannels) {
+		return false
+	}
+
+	return len(a.IncludeChannels) == 0 || matches(a.IncludeChannels)
+}
+
 func (a *ReactAction) UnmarshalJSON(data []byte) error {
 	var intValue int
 	err := json.Unmarshal(data, &intValue)
@@ -0,0 +1,103 @@
+package rule
+
+import (
+	"errors"
+	"slices"
+)
+
+type ReactionRoleMode string
+
+const (
+	// ReactionRoleToggle grants the role when the reaction is added and revokes it when it's removed.
+	ReactionRoleToggle ReactionRoleMode = "toggle"
+	// ReactionRoleUnique keeps at most one role of the message, adding a reaction revokes the other roles
+	// and removes their reactions. Removing the reaction revokes the role.
+	ReactionRoleUnique ReactionRoleMode = "unique"
+	// ReactionRoleVerify only grants the role, removing the reaction keeps it.
+	ReactionRoleVerify ReactionRoleMode = "verify"
+)
+
+// MaxReactionRoles is the number of different reactions discord allows on a message.
+const MaxReactionRoles = 20
+
+// ReactionRole grants the role to members who react with the emoji.
+type ReactionRole struct {
+	EmojiName string `json:"emojiName" validate:"required"`
+	EmojiId   string `json:"emojiId,omitempty" validate:"omitempty,numeric"`
+	RoleId    string `json:"roleId" validate:"required,numeric"`
+}
+
+// SameEmoji reports whether both reaction roles are for the same emoji.
+func (a ReactionRole) SameEmoji(b ReactionRole) bool {
+	return a.EmojiName == b.EmojiName && a.EmojiId == b.EmojiId
+}
+
+// ReactionRoleMessage is a message with reaction roles, emojis of its roles are unique.
+type ReactionRoleMessage struct {
+	GuildId   string           `json:"guildId"`
+	ChannelId string           `json:"channelId"`
+	MessageId string           `json:"messageId"`
+	Mode      ReactionRoleMode `json:"mode"`
+	Roles     []ReactionRole   `json:"roles"`
+}
+
+// ReactionRoleMessageUpdate replaces the channel, mode and roles of a message.
+type ReactionRoleMessageUpdate struct {
+	ChannelId string           `json:"channelId" validate:"required,numeric"`
+	Mode      ReactionRoleMode `json:"mode" validate:"required,oneof=toggle unique verify"`
+	Roles     []ReactionRole   `json:"roles" validate:"required,min=1,max=20,dive"`
+}
+
+// Update returns the update that replaces the message with m.
+func (m ReactionRoleMessage) Update() ReactionRoleMessageUpdate {
+	return ReactionRoleMessageUpdate{
+		ChannelId: m.ChannelId,
+		Mode:      m.Mode,
+		Roles:     m.Roles,
+	}
+}
+
+var (
+	ErrDuplicateReactionRoles = errors.New("reaction roles have duplicate emojis")
+	// ErrReactionRoleConflict is returned when a reaction rule is enforced on the emoji of a reaction role.
+	ErrReactionRoleConflict = errors.New("reaction role conflicts with a reaction rule")
+)
+
+// Role returns the reaction role of the emoji. Custom emojis are compared by id, unicode emojis by name.
+func (m ReactionRoleMessage) Role(emojiName, emojiId string) (ReactionRole, bool) {
+	i := slices.IndexFunc(m.Roles, func(r ReactionRole) bool {
+		if r.EmojiId != "" || emojiId != "" {
+			return r.EmojiId == emojiId
+		}
+
+		return r.EmojiName == emojiName
+	})
+
+	if i == -1 {
+		return ReactionRole{}, false
+	}
+
+	return m.Roles[i], true
+}
+
+// HaveDuplicateReactionRoles reports whether two reaction roles have the same emoji.
+func HaveDuplicateReactionRoles(roles []ReactionRole) bool {
+	for i, r := range roles {
+		if slices.ContainsFunc(roles[i+1:], r.SameEmoji) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ConflictsWith reports whether the reaction rule is enforced on an emoji of the message.
+func (m ReactionRoleMessage) ConflictsWith(r ReactionRule) bool {
+	if !r.AppliesToChannel(m.ChannelId, "") {
+		return false
+	}
+
+	return slices.ContainsFunc(m.Roles, func(rr ReactionRole) bool {
+		return r.Matches(rr.EmojiName, rr.EmojiId)
+	})
+}
@@ -0,0 +1,52 @@
+package user
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+type User struct {
+	UserId string `json:"userId"`
+	Name   string `json:"name"`
+}
+
+// RefreshToken is stored by hash, the token itself is only known to the client.
+type RefreshToken struct {
+	UserId    string
+	TokenHash string
+	Expires   time.Time
+	// DiscordToken is the encrypted Discord refresh token, used to fetch the guilds of the user again on refresh.
+	DiscordToken string
+}
+
+// UserGuild is a guild of the user with the permissions Discord reported at the last login or refresh.
+type UserGuild struct {
+	UserId      string
+	GuildId     string
+	Owner       bool
+	Permissions int64
+}
+
+// CanManage reports whether the user can manage rules of the guild.
+func (g UserGuild) CanManage() bool {
+	return g.Owner || g.Permissions&(discordgo.PermissionAdministrator|discordgo.PermissionManageServer) != 0
+}
+
+// Session is returned to the client after login or refresh.
+type Session struct {
+	AccessToken  string    `json:"accessToken"`
+	RefreshToken string    `json:"refreshToken"`
+	ExpiresAt    time.Time `json:"expiresAt"` // ExpiresAt is the expiry of AccessToken
+	User         User      `json:"user"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refreshToken" validate:"required"`
+}
+
+var (
+	ErrInvalidState        = errors.New("invalid oauth state")
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+)